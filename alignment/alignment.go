@@ -0,0 +1,329 @@
+// Package alignment verifies that a language file is line-aligned with the EN file
+// it was translated from, so that a diff of the EN file applies to the language
+// file by line numbers. The structure of both files - the length of the front matter,
+// headings, code blocks, shortcodes and the number of lines - is compared line by line,
+// and the first line where it diverges is reported.
+package alignment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+	"github.com/dkarczmarski/go-kweb-lang/pagecontent"
+	"github.com/dkarczmarski/go-kweb-lang/proxycache"
+)
+
+const (
+	// ReasonFrontMatter indicates that the front matter has a different number
+	// of lines in both files.
+	ReasonFrontMatter = "front-matter"
+
+	// ReasonHeading indicates that a heading is not on the same line in both files
+	// or has a different level.
+	ReasonHeading = "heading"
+
+	// ReasonCodeBlock indicates that a code block does not start or end
+	// on the same line in both files.
+	ReasonCodeBlock = "code-block"
+
+	// ReasonShortcode indicates that a shortcode is not on the same line in both files.
+	ReasonShortcode = "shortcode"
+
+	// ReasonLineCount indicates that the files have a different number of lines.
+	ReasonLineCount = "line-count"
+)
+
+// Misalignment describes the first line where the structure of the language file
+// diverges from the EN file.
+//
+// The front matter field with the sync marker of the language file is not counted,
+// because it has no EN counterpart. Apart from it, the front matter of both files
+// must have the same number of lines and the values of the fields are not compared.
+type Misalignment struct {
+	Reason string
+
+	// Line is the 1-based number of the first diverging line of the language file.
+	Line int
+
+	EnLineCount   int
+	LangLineCount int
+}
+
+// CachedMisalignment is the result of the check of a language file stored in the cache.
+// It is valid as long as the start point and the last commit of the language file
+// stay the same.
+type CachedMisalignment struct {
+	StartPointCommitID string
+	LangLastCommitID   string
+	Misalignment       *Misalignment
+}
+
+// GitRepo is an interface used to decouple this package from the concrete git implementation.
+type GitRepo interface {
+	ReadFile(path string) (string, error)
+	FileExistsAt(ctx context.Context, revision string, path string) (bool, error)
+	ReadFileAt(ctx context.Context, revision string, path string) (string, error)
+}
+
+// CacheStorage is an interface used to decouple this package from the concrete store implementation.
+type CacheStorage interface {
+	Read(bucket, key string, buff any) (bool, error)
+	Write(bucket, key string, data any) error
+}
+
+const bucketAlignment = "alignment"
+
+// CacheBucket returns the cache bucket name used for storing the results of the check.
+// The keys are the language file paths.
+func CacheBucket() string {
+	return bucketAlignment
+}
+
+type Checker struct {
+	gitRepo GitRepo
+	cache   CacheStorage
+}
+
+func New(gitRepo GitRepo, cache CacheStorage) *Checker {
+	return &Checker{
+		gitRepo: gitRepo,
+		cache:   cache,
+	}
+}
+
+// CheckPair compares the current language file of the pair with the EN file
+// at the start point of the language file, that is the EN revision
+// the translation is synced with.
+//
+// It returns nil if the files are aligned, for files other than Markdown and HTML
+// pages and if the language file or the EN file at the start point does not exist.
+// The result is cached until the start point or the last commit of the language file changes.
+func (c *Checker) CheckPair(
+	ctx context.Context,
+	pair gitseek.Pair,
+	fileInfo gitseek.FileInfo,
+) (*Misalignment, error) {
+	if !pagecontent.IsPagePair(pair, fileInfo) {
+		return nil, nil
+	}
+
+	startPoint := fileInfo.StartPoint().CommitID
+	if startPoint == "" {
+		return nil, nil
+	}
+
+	cached, err := proxycache.Get(
+		ctx,
+		c.cache,
+		CacheBucket(),
+		pair.LangPath,
+		func(cached CachedMisalignment) bool {
+			return cached.StartPointCommitID != startPoint ||
+				cached.LangLastCommitID != fileInfo.LangLastCommit.CommitID
+		},
+		func(ctx context.Context) (CachedMisalignment, error) {
+			misalignment, err := c.checkPairAt(ctx, pair, startPoint)
+			if err != nil {
+				return CachedMisalignment{}, err
+			}
+
+			return CachedMisalignment{
+				StartPointCommitID: startPoint,
+				LangLastCommitID:   fileInfo.LangLastCommit.CommitID,
+				Misalignment:       misalignment,
+			}, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return cached.Misalignment, nil
+}
+
+func (c *Checker) checkPairAt(ctx context.Context, pair gitseek.Pair, startPoint string) (*Misalignment, error) {
+	exists, err := c.gitRepo.FileExistsAt(ctx, startPoint, pair.EnPath)
+	if err != nil {
+		return nil, fmt.Errorf("check whether %s exists at %s: %w", pair.EnPath, startPoint, err)
+	}
+
+	if !exists {
+		return nil, nil
+	}
+
+	enContent, err := c.gitRepo.ReadFileAt(ctx, startPoint, pair.EnPath)
+	if err != nil {
+		return nil, fmt.Errorf("read %s at %s: %w", pair.EnPath, startPoint, err)
+	}
+
+	langContent, err := c.gitRepo.ReadFile(pair.LangPath)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", pair.LangPath, err)
+	}
+
+	return Compare(enContent, langContent), nil
+}
+
+// Compare compares the structure of the EN content and the language content
+// and returns the first misalignment or nil if the contents are aligned.
+func Compare(enContent, langContent string) *Misalignment {
+	enFile := splitFile(enContent)
+	langFile := splitFile(langContent)
+
+	newMisalignment := func(reason string, line int) *Misalignment {
+		return &Misalignment{
+			Reason:        reason,
+			Line:          line,
+			EnLineCount:   len(enFile.lines),
+			LangLineCount: len(langFile.lines),
+		}
+	}
+
+	if enFile.frontMatterLines != langFile.frontMatterLines {
+		return newMisalignment(ReasonFrontMatter, max(langFile.bodyStart, 1))
+	}
+
+	enBody := enFile.lines[enFile.bodyStart:]
+	langBody := langFile.lines[langFile.bodyStart:]
+
+	enScanner := lineScanner{}
+	langScanner := lineScanner{}
+
+	for i := range min(len(enBody), len(langBody)) {
+		enMarker := enScanner.scan(enBody[i])
+		langMarker := langScanner.scan(langBody[i])
+
+		if enMarker == langMarker {
+			continue
+		}
+
+		reason := enMarker.kind
+		if reason == "" {
+			reason = langMarker.kind
+		}
+
+		return newMisalignment(reason, langFile.bodyStart+i+1)
+	}
+
+	if len(enBody) != len(langBody) {
+		return newMisalignment(ReasonLineCount, langFile.bodyStart+min(len(enBody), len(langBody))+1)
+	}
+
+	return nil
+}
+
+// file is the content of a file split into lines.
+type file struct {
+	lines []string
+
+	// bodyStart is the index of the first line after the front matter.
+	bodyStart int
+
+	// frontMatterLines is the number of lines of the front matter including
+	// the delimiters and without the sync marker field.
+	frontMatterLines int
+}
+
+func splitFile(content string) file {
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	page := pagecontent.Split(content)
+
+	frontMatterLines := page.FrontMatterLines
+
+	for _, line := range lines[min(1, page.FrontMatterLines):max(page.FrontMatterLines-1, 0)] {
+		if isSyncMarkerField(line, page.Delimiter) {
+			frontMatterLines--
+		}
+	}
+
+	return file{
+		lines:            lines,
+		bodyStart:        page.FrontMatterLines,
+		frontMatterLines: frontMatterLines,
+	}
+}
+
+// isSyncMarkerField reports whether the front matter line sets the sync marker.
+func isSyncMarkerField(line string, delimiter string) bool {
+	separator := ":"
+	if delimiter == pagecontent.TOMLDelimiter {
+		separator = "="
+	}
+
+	key, _, found := strings.Cut(line, separator)
+
+	return found && strings.TrimSpace(key) == gitseek.SyncMarkerFrontMatterKey
+}
+
+// marker is the structural element of a line. Lines of prose have no kind.
+type marker struct {
+	kind string
+
+	// detail distinguishes markers of the same kind, for example heading levels.
+	detail string
+}
+
+// lineScanner classifies the lines of a file, keeping track of code blocks,
+// in which only the closing fence is a marker.
+type lineScanner struct {
+	fence string
+}
+
+func (s *lineScanner) scan(line string) marker {
+	trimmed := strings.TrimSpace(line)
+
+	if s.fence != "" {
+		if strings.HasPrefix(trimmed, s.fence) {
+			s.fence = ""
+
+			return marker{kind: ReasonCodeBlock, detail: "end"}
+		}
+
+		return marker{}
+	}
+
+	if fence := codeFence(trimmed); fence != "" {
+		s.fence = fence
+
+		return marker{kind: ReasonCodeBlock, detail: "start"}
+	}
+
+	if level := headingLevel(trimmed); level > 0 {
+		return marker{kind: ReasonHeading, detail: strings.Repeat("#", level)}
+	}
+
+	if strings.HasPrefix(trimmed, "{{<") || strings.HasPrefix(trimmed, "{{%") {
+		return marker{kind: ReasonShortcode}
+	}
+
+	return marker{}
+}
+
+func codeFence(trimmed string) string {
+	for _, fence := range []string{"```", "~~~"} {
+		if strings.HasPrefix(trimmed, fence) {
+			return fence
+		}
+	}
+
+	return ""
+}
+
+func headingLevel(trimmed string) int {
+	level := 0
+	for level < len(trimmed) && trimmed[level] == '#' {
+		level++
+	}
+
+	if level == 0 || level > 6 {
+		return 0
+	}
+
+	if level < len(trimmed) && trimmed[level] != ' ' {
+		return 0
+	}
+
+	return level
+}
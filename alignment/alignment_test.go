@@ -0,0 +1,432 @@
+package alignment_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/dkarczmarski/go-kweb-lang/alignment"
+	"github.com/dkarczmarski/go-kweb-lang/git"
+	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+)
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	const en = `---
+title: Pods
+weight: 10
+---
+
+## Overview
+
+Pods are the smallest deployable units.
+
+` + "```yaml" + `
+# a comment, not a heading
+kind: Pod
+` + "```" + `
+
+{{< note >}}
+A note.
+{{< /note >}}
+`
+
+	for _, tc := range []struct {
+		name string
+		lang string
+		want *alignment.Misalignment
+	}{
+		{
+			name: "aligned",
+			lang: `---
+title: Pody
+weight: 10
+synced_with: 1a2b3c4d
+---
+
+## Przegląd
+
+Pody to najmniejsze jednostki.
+
+` + "```yaml" + `
+# komentarz
+kind: Pod
+` + "```" + `
+
+{{< note >}}
+Notatka.
+{{< /note >}}
+`,
+			want: nil,
+		},
+		{
+			name: "heading moved",
+			lang: `---
+title: Pody
+weight: 10
+---
+
+Wstęp.
+## Przegląd
+`,
+			want: &alignment.Misalignment{
+				Reason:        alignment.ReasonHeading,
+				Line:          6,
+				EnLineCount:   17,
+				LangLineCount: 7,
+			},
+		},
+		{
+			name: "heading level changed",
+			lang: `---
+title: Pody
+weight: 10
+---
+
+### Przegląd
+`,
+			want: &alignment.Misalignment{
+				Reason:        alignment.ReasonHeading,
+				Line:          6,
+				EnLineCount:   17,
+				LangLineCount: 6,
+			},
+		},
+		{
+			name: "code block shifted",
+			lang: `---
+title: Pody
+weight: 10
+---
+
+## Przegląd
+
+Pody to najmniejsze jednostki.
+` + "```yaml" + `
+`,
+			want: &alignment.Misalignment{
+				Reason:        alignment.ReasonCodeBlock,
+				Line:          9,
+				EnLineCount:   17,
+				LangLineCount: 9,
+			},
+		},
+		{
+			name: "shortcode shifted",
+			lang: `---
+title: Pody
+weight: 10
+---
+
+## Przegląd
+
+Pody to najmniejsze jednostki.
+
+` + "```yaml" + `
+# komentarz
+kind: Pod
+` + "```" + `
+{{< note >}}
+`,
+			want: &alignment.Misalignment{
+				Reason:        alignment.ReasonShortcode,
+				Line:          14,
+				EnLineCount:   17,
+				LangLineCount: 14,
+			},
+		},
+		{
+			name: "missing lines at the end",
+			lang: `---
+title: Pody
+weight: 10
+---
+
+## Przegląd
+
+Pody to najmniejsze jednostki.
+
+` + "```yaml" + `
+# komentarz
+kind: Pod
+` + "```" + `
+`,
+			want: &alignment.Misalignment{
+				Reason:        alignment.ReasonLineCount,
+				Line:          14,
+				EnLineCount:   17,
+				LangLineCount: 13,
+			},
+		},
+		{
+			name: "front matter has fewer lines",
+			lang: `---
+title: Pody
+---
+
+## Przegląd
+
+Pody to najmniejsze jednostki.
+
+` + "```yaml" + `
+# komentarz
+kind: Pod
+` + "```" + `
+
+{{< note >}}
+Notatka.
+{{< /note >}}
+`,
+			want: &alignment.Misalignment{
+				Reason:        alignment.ReasonFrontMatter,
+				Line:          3,
+				EnLineCount:   17,
+				LangLineCount: 16,
+			},
+		},
+		{
+			name: "no front matter",
+			lang: "## Przegląd\n",
+			want: &alignment.Misalignment{
+				Reason:        alignment.ReasonFrontMatter,
+				Line:          1,
+				EnLineCount:   17,
+				LangLineCount: 1,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := alignment.Compare(en, tc.lang)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("unexpected misalignment:\n got:  %#v\nwant: %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+var errFileNotFound = errors.New("file not found")
+
+type fakeGitRepo struct {
+	files   map[string]string
+	filesAt map[string]map[string]string
+
+	// readsAt counts the calls of ReadFileAt, if set.
+	readsAt *int
+}
+
+func (f fakeGitRepo) ReadFile(path string) (string, error) {
+	content, ok := f.files[path]
+	if !ok {
+		return "", errFileNotFound
+	}
+
+	return content, nil
+}
+
+func (f fakeGitRepo) FileExistsAt(_ context.Context, revision string, path string) (bool, error) {
+	_, ok := f.filesAt[revision][path]
+
+	return ok, nil
+}
+
+func (f fakeGitRepo) ReadFileAt(_ context.Context, revision string, path string) (string, error) {
+	if f.readsAt != nil {
+		*f.readsAt++
+	}
+
+	content, ok := f.filesAt[revision][path]
+	if !ok {
+		return "", errFileNotFound
+	}
+
+	return content, nil
+}
+
+// jsonCache keeps cache entries marshaled, like the file store does.
+type jsonCache map[string][]byte
+
+func (c jsonCache) Read(bucket, key string, buff any) (bool, error) {
+	data, ok := c[bucket+"/"+key]
+	if !ok {
+		return false, nil
+	}
+
+	return true, json.Unmarshal(data, buff)
+}
+
+func (c jsonCache) Write(bucket, key string, data any) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	c[bucket+"/"+key] = encoded
+
+	return nil
+}
+
+func TestChecker_CheckPair(t *testing.T) {
+	t.Parallel()
+
+	pair := gitseek.Pair{
+		EnPath:   "content/en/docs/a.md",
+		LangPath: "content/pl/docs/a.md",
+	}
+
+	gitRepo := fakeGitRepo{
+		files: map[string]string{
+			// the current EN file has a new section the translation does not have yet
+			"content/en/docs/a.md": "# Pods\n\nText.\n\n## New\n",
+			"content/pl/docs/a.md": "# Pody\n\nTekst.\n",
+		},
+		filesAt: map[string]map[string]string{
+			"synced": {"content/en/docs/a.md": "# Pods\n\nText.\n"},
+			"fork":   {"content/en/docs/a.md": "Text.\n# Pods\n"},
+		},
+	}
+
+	fileInfo := gitseek.FileInfo{
+		FileStatus:       gitseek.StatusEnFileUpdated,
+		LangLastCommit:   git.CommitInfo{CommitID: "last"},
+		LangForkCommit:   &git.CommitInfo{CommitID: "fork"},
+		SyncedWithCommit: &git.CommitInfo{CommitID: "synced"},
+	}
+
+	got, err := alignment.New(gitRepo, jsonCache{}).CheckPair(t.Context(), pair, fileInfo)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got != nil {
+		t.Fatalf("expected the file to be aligned with the synced EN revision, got %#v", got)
+	}
+
+	fileInfo.SyncedWithCommit = nil
+
+	got, err = alignment.New(gitRepo, jsonCache{}).CheckPair(t.Context(), pair, fileInfo)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := &alignment.Misalignment{Reason: alignment.ReasonHeading, Line: 1, EnLineCount: 2, LangLineCount: 3}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected misalignment:\n got:  %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestChecker_CheckPair_Skipped(t *testing.T) {
+	t.Parallel()
+
+	gitRepo := fakeGitRepo{
+		files: map[string]string{
+			"content/pl/docs/a.md": "# Pody\n",
+			"i18n/pl/pl.toml":      "a = \"b\"\n",
+		},
+		filesAt: map[string]map[string]string{
+			"fork": {"i18n/en/en.toml": "a = \"b\"\nc = \"d\"\n"},
+		},
+	}
+
+	fileInfo := gitseek.FileInfo{
+		FileStatus:     gitseek.StatusEnFileUpdated,
+		LangLastCommit: git.CommitInfo{CommitID: "last"},
+		LangForkCommit: &git.CommitInfo{CommitID: "fork"},
+	}
+
+	for _, tc := range []struct {
+		name     string
+		pair     gitseek.Pair
+		fileInfo gitseek.FileInfo
+	}{
+		{
+			name:     "not a page",
+			pair:     gitseek.Pair{EnPath: "i18n/en/en.toml", LangPath: "i18n/pl/pl.toml"},
+			fileInfo: fileInfo,
+		},
+		{
+			name:     "EN file did not exist at the start point",
+			pair:     gitseek.Pair{EnPath: "content/en/docs/a.md", LangPath: "content/pl/docs/a.md"},
+			fileInfo: fileInfo,
+		},
+		{
+			name:     "missing lang file",
+			pair:     gitseek.Pair{EnPath: "content/en/docs/a.md", LangPath: "content/pl/docs/a.md"},
+			fileInfo: gitseek.FileInfo{FileStatus: gitseek.StatusLangFileMissing},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := alignment.New(gitRepo, jsonCache{}).CheckPair(t.Context(), tc.pair, tc.fileInfo)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != nil {
+				t.Fatalf("expected nil, got %#v", got)
+			}
+		})
+	}
+}
+
+func TestChecker_CheckPair_CachesResultPerCommit(t *testing.T) {
+	t.Parallel()
+
+	pair := gitseek.Pair{
+		EnPath:   "content/en/docs/a.md",
+		LangPath: "content/pl/docs/a.md",
+	}
+
+	readsAt := 0
+	gitRepo := fakeGitRepo{
+		files: map[string]string{
+			"content/pl/docs/a.md": "# Pody\n\nTekst.\n",
+		},
+		filesAt: map[string]map[string]string{
+			"fork":  {"content/en/docs/a.md": "# Pods\n\nText.\n"},
+			"fork2": {"content/en/docs/a.md": "Text.\n# Pods\n"},
+		},
+		readsAt: &readsAt,
+	}
+	checker := alignment.New(gitRepo, jsonCache{})
+
+	fileInfo := gitseek.FileInfo{
+		FileStatus:     gitseek.StatusLangFileUpToDate,
+		LangLastCommit: git.CommitInfo{CommitID: "last"},
+		LangForkCommit: &git.CommitInfo{CommitID: "fork"},
+	}
+
+	for _, tc := range []struct {
+		name         string
+		forkCommitID string
+		lastCommitID string
+		wantReadsAt  int
+		wantAligned  bool
+	}{
+		{name: "first check", forkCommitID: "fork", lastCommitID: "last", wantReadsAt: 1, wantAligned: true},
+		{name: "same commits", forkCommitID: "fork", lastCommitID: "last", wantReadsAt: 1, wantAligned: true},
+		{name: "new lang commit", forkCommitID: "fork", lastCommitID: "last2", wantReadsAt: 2, wantAligned: true},
+		{name: "new start point", forkCommitID: "fork2", lastCommitID: "last2", wantReadsAt: 3, wantAligned: false},
+	} {
+		fileInfo.LangForkCommit = &git.CommitInfo{CommitID: tc.forkCommitID}
+		fileInfo.LangLastCommit = git.CommitInfo{CommitID: tc.lastCommitID}
+
+		got, err := checker.CheckPair(t.Context(), pair, fileInfo)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+
+		if (got == nil) != tc.wantAligned {
+			t.Fatalf("%s: unexpected misalignment: %#v", tc.name, got)
+		}
+
+		if readsAt != tc.wantReadsAt {
+			t.Fatalf("%s: unexpected number of EN file reads: got %d, want %d", tc.name, readsAt, tc.wantReadsAt)
+		}
+	}
+}
@@ -11,6 +11,9 @@ import (
 
 func NewApp(
 	flagRepoDir *string,
+	flagRepoURL *string,
+	flagRepoBranch *string,
+	flagSourceLangCode *string,
 	flagCacheDir *string,
 	flagLangCodes *string,
 	flagOnce *bool,
@@ -21,6 +24,10 @@ func NewApp(
 	flagSkipPR *bool,
 	flagNoWeb *bool,
 	flagWebHTTPAddr *string,
+	flagPairRulesFile *string,
+	flagExcludeFile *string,
+	flagReposFile *string,
+	flagGitHubAPI *string,
 ) (*bootstrap.App, error) {
 	cfg := config.Default()
 
@@ -31,6 +38,9 @@ func NewApp(
 	config.ApplyFlags(
 		&cfg,
 		flagRepoDir,
+		flagRepoURL,
+		flagRepoBranch,
+		flagSourceLangCode,
 		flagCacheDir,
 		flagLangCodes,
 		flagOnce,
@@ -41,10 +51,18 @@ func NewApp(
 		flagSkipPR,
 		flagNoWeb,
 		flagWebHTTPAddr,
+		flagPairRulesFile,
+		flagExcludeFile,
+		flagReposFile,
+		flagGitHubAPI,
 	)
 
 	config.Show(cfg, true)
 
+	if err := config.ReadReposFile(&cfg); err != nil {
+		return nil, fmt.Errorf("read repos file: %w", err)
+	}
+
 	if err := config.ReadGitHubTokenFile(&cfg, true, true); err != nil {
 		return nil, fmt.Errorf("read github token file: %w", err)
 	}
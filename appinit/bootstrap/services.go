@@ -4,43 +4,72 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/dkarczmarski/go-kweb-lang/alignment"
 	"github.com/dkarczmarski/go-kweb-lang/appinit/config"
 	"github.com/dkarczmarski/go-kweb-lang/dashboard"
 	"github.com/dkarczmarski/go-kweb-lang/filepairs"
+	"github.com/dkarczmarski/go-kweb-lang/frontmatter"
 	"github.com/dkarczmarski/go-kweb-lang/git"
 	"github.com/dkarczmarski/go-kweb-lang/githist"
 	"github.com/dkarczmarski/go-kweb-lang/github"
 	"github.com/dkarczmarski/go-kweb-lang/githubmon"
 	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+	"github.com/dkarczmarski/go-kweb-lang/histindex"
+	"github.com/dkarczmarski/go-kweb-lang/i18nkeys"
 	"github.com/dkarczmarski/go-kweb-lang/langcnt"
+	"github.com/dkarczmarski/go-kweb-lang/prpreview"
 	"github.com/dkarczmarski/go-kweb-lang/pullreq"
 	"github.com/dkarczmarski/go-kweb-lang/store"
 	"github.com/dkarczmarski/go-kweb-lang/tasks"
+	"github.com/dkarczmarski/go-kweb-lang/untranslated"
 	"github.com/dkarczmarski/go-kweb-lang/web"
 )
 
 const (
-	githubThrottleDelay = 3 * time.Second
-	githubPerPage       = 100
+	githubThrottleDelay        = 3 * time.Second
+	githubGraphQLThrottleDelay = time.Second
+	githubPerPage              = 100
 )
 
+// Services are the services shared by all tracked repositories.
 type Services struct {
+	CacheStore *store.JSONFileStore
+	GitHub     *github.GitHub
+	// GraphQLGitHub is nil unless the GraphQL API is configured.
+	GraphQLGitHub *github.GitHub
+	Repos         []*RepoServices
+	GitHubMonitor *githubmon.Monitor
+	// GitHubWebhook is nil if no webhook secret is configured.
+	GitHubWebhook *githubmon.WebhookReceiver
+	Server        *web.Server
+}
+
+// RepoServices are the services of a single tracked repository.
+type RepoServices struct {
+	Repo                 config.Repo
 	LangCodesProvider    *langcnt.LangCodesProvider
 	GitRepo              *git.Git
-	CacheStore           *store.JSONFileStore
+	CacheStore           *store.NamespacedStore
 	DashboardStore       *dashboard.Store
+	AckStore             *dashboard.AckStore
+	HistoryIndex         *histindex.Index
 	GitRepoHist          *githist.GitHist
 	FilePaths            *filepairs.FilePaths
 	PairProviders        *filepairs.PairProviders
+	Exclusions           *filepairs.Exclusions
 	GitSeek              *gitseek.GitSeek
+	I18NKeys             *i18nkeys.Comparator
+	FrontMatter          *frontmatter.Checker
+	Alignment            *alignment.Checker
+	Untranslated         *untranslated.Detector
 	GitHub               *github.GitHub
+	GitHubGraphQL        *github.GraphQL
 	FilePRIndex          *pullreq.FilePRIndex
+	PRPreviewer          *prpreview.Previewer
 	RefreshRepoTask      *tasks.RefreshRepoTask
 	RefreshPRTask        *tasks.RefreshPRTask
 	OnGitHubUpdateTask   *tasks.OnGitHubUpdateTask
 	RefreshDashboardTask *tasks.RefreshDashboardTask
-	GitHubMonitor        *githubmon.Monitor
-	Server               *web.Server
 }
 
 func BuildServices(cfg config.Config) (*Services, error) {
@@ -51,8 +80,38 @@ func BuildServices(cfg config.Config) (*Services, error) {
 	//nolint:exhaustruct
 	services := &Services{}
 
-	buildCoreServices(cfg, services)
-	buildTaskServices(cfg, services)
+	services.CacheStore = store.NewFileStore(cfg.CacheDir)
+	services.GitHub = github.NewGitHub(
+		github.WithDefaults(),
+		github.WithAuthorization(cfg.GitHubToken, cfg.GitHubUserAgent),
+		// with authorization github allows at most 30 calls per minute, so
+		// for safety we use a 3-second delay between requests, shared by all repositories
+		github.WithThrottle(githubThrottleDelay),
+	)
+
+	if cfg.GitHubAPI == config.GitHubAPIGraphQL {
+		// the GraphQL API has its own rate limit, separate from the limits of the REST API
+		services.GraphQLGitHub = github.NewGitHub(
+			github.WithDefaults(),
+			github.WithAuthorization(cfg.GitHubToken, cfg.GitHubUserAgent),
+			github.WithThrottle(githubGraphQLThrottleDelay),
+		)
+	}
+
+	for _, repo := range cfg.TrackedRepos() {
+		repoServices, err := buildRepoServices(repo, services)
+		if err != nil {
+			return nil, fmt.Errorf("repository %s: %w", repo.Name, err)
+		}
+
+		services.Repos = append(services.Repos, repoServices)
+	}
+
+	buildMonitor(cfg, services)
+
+	if err := buildWebhookReceiver(cfg, services); err != nil {
+		return nil, err
+	}
 
 	if err := buildOptionalServices(cfg, services); err != nil {
 		return nil, err
@@ -61,53 +120,183 @@ func BuildServices(cfg config.Config) (*Services, error) {
 	return services, nil
 }
 
-func buildCoreServices(cfg config.Config, services *Services) {
-	langCodesProvider := &langcnt.LangCodesProvider{RepoDir: cfg.RepoDir}
-	langCodesProvider.SetLangCodesFilter(cfg.LangCodes)
+func buildRepoServices(repo config.Repo, shared *Services) (*RepoServices, error) {
+	//nolint:exhaustruct
+	services := &RepoServices{Repo: repo}
+
+	if err := buildCoreServices(repo, shared, services); err != nil {
+		return nil, err
+	}
+
+	buildTaskServices(services)
+
+	return services, nil
+}
+
+func buildCoreServices(repo config.Repo, shared *Services, services *RepoServices) error {
+	langCodesProvider := &langcnt.LangCodesProvider{RepoDir: repo.Dir, SourceLangCode: repo.SourceLangCode}
+	langCodesProvider.SetLangCodesFilter(repo.LangCodes)
 	services.LangCodesProvider = langCodesProvider
 
-	services.GitRepo = git.NewRepo(cfg.RepoDir)
-	services.CacheStore = store.NewFileStore(cfg.CacheDir)
+	services.GitRepo = git.NewRepo(repo.Dir, func(config *git.NewRepoConfig) {
+		config.Branch = repo.Branch
+	})
+	services.CacheStore = store.NewNamespacedStore(shared.CacheStore, repo.CacheNamespace)
 	services.DashboardStore = dashboard.NewStore(services.CacheStore)
-	services.GitRepoHist = githist.New(services.GitRepo, services.CacheStore)
-	services.FilePaths = filepairs.New()
+	services.AckStore = dashboard.NewAckStore(services.CacheStore)
+	services.HistoryIndex = histindex.New(services.GitRepo, services.CacheStore)
+	services.GitRepoHist = githist.New(services.GitRepo, services.CacheStore, func(config *githist.NewConfig) {
+		config.HistoryIndex = services.HistoryIndex
+	})
 
-	services.PairProviders = filepairs.NewPairProviders(
-		filepairs.NewContentPairProvider(services.GitRepo),
-		filepairs.NewI18NPairProvider(services.GitRepo),
+	if err := buildPairServices(repo, services); err != nil {
+		return err
+	}
+
+	services.GitSeek = gitseek.New(
+		histindex.NewRepo(services.GitRepo, services.HistoryIndex),
+		services.GitRepoHist,
+		services.CacheStore,
+		func(config *gitseek.NewConfig) {
+			config.LangPaths = services.FilePaths
+		},
 	)
+	services.I18NKeys = i18nkeys.New(services.GitRepo, func(config *i18nkeys.ComparatorConfig) {
+		config.FilePaths = services.FilePaths
+	})
+	services.FrontMatter = frontmatter.New(services.GitRepo)
+	services.Alignment = alignment.New(services.GitRepo, services.CacheStore)
+	services.Untranslated = untranslated.New(services.GitRepo)
 
-	services.GitSeek = gitseek.New(services.GitRepo, services.GitRepoHist, services.CacheStore)
+	repository, err := config.GitHubRepository(repo.URL)
+	if err != nil {
+		return fmt.Errorf("github repository: %w", err)
+	}
 
-	services.GitHub = github.NewGitHub(
-		github.WithDefaults(),
-		github.WithAuthorization(cfg.GitHubToken, cfg.GitHubUserAgent),
-		// with authorization github allows at most 30 calls per minute, so
-		// for safety we use a 3-second delay between requests
-		github.WithThrottle(githubThrottleDelay),
+	services.GitHub = shared.GitHub.ForRepository(repository, repo.Branch)
+
+	var prGitHub pullreq.GitHub = services.GitHub
+	if shared.GraphQLGitHub != nil {
+		services.GitHubGraphQL = github.NewGraphQL(
+			shared.GraphQLGitHub.ForRepository(repository, repo.Branch),
+			func(config *github.GraphQLConfig) {
+				config.REST = services.GitHub
+			},
+		)
+		prGitHub = services.GitHubGraphQL
+	}
+
+	services.FilePRIndex = pullreq.NewFilePRIndex(
+		prGitHub,
+		services.CacheStore,
+		githubPerPage,
+		func(config *pullreq.FilePRIndexConfig) {
+			config.FilePaths = services.FilePaths
+		},
 	)
 
-	services.FilePRIndex = pullreq.NewFilePRIndex(services.GitHub, services.CacheStore, githubPerPage)
+	return nil
 }
 
-func buildTaskServices(cfg config.Config, services *Services) {
+// buildPairServices builds the file pair services from the pair rules file
+// and the exclusion file or from the built-in rules if the files are not set.
+func buildPairServices(repo config.Repo, services *RepoServices) error {
+	rules := filepairs.DefaultPairRules()
+
+	if repo.PairRulesFile != "" {
+		loadedRules, err := filepairs.LoadPairRules(repo.PairRulesFile)
+		if err != nil {
+			return fmt.Errorf("load pair rules: %w", err)
+		}
+
+		rules = loadedRules
+	}
+
+	matchers, err := filepairs.CompilePairRules(rules, func(config *filepairs.RulePairMatcherConfig) {
+		config.ContentDirs = services.LangCodesProvider
+	})
+	if err != nil {
+		return fmt.Errorf("compile pair rules: %w", err)
+	}
+
+	pairMatchers := make([]filepairs.PairMatcher, 0, len(matchers))
+	pairProviders := make([]filepairs.PairProvider, 0, len(matchers))
+
+	for _, matcher := range matchers {
+		pairMatchers = append(pairMatchers, matcher)
+		pairProviders = append(pairProviders, filepairs.NewRulePairProvider(
+			matcher,
+			services.GitRepo,
+			func(config *filepairs.RulePairProviderConfig) {
+				config.RenameFinder = services.GitRepo
+				config.SourceLangCode = repo.SourceLangCode
+			},
+		))
+	}
+
+	services.FilePaths = filepairs.New(func(config *filepairs.FilePathsConfig) {
+		config.PairMatchers = pairMatchers
+		config.SourceLangCode = repo.SourceLangCode
+	})
+	services.PairProviders = filepairs.NewPairProviders(pairProviders...)
+
+	exclusionRules := filepairs.DefaultExclusionRules()
+
+	if repo.ExcludeFile != "" {
+		loadedRules, err := filepairs.LoadExclusionRules(repo.ExcludeFile)
+		if err != nil {
+			return fmt.Errorf("load exclusion rules: %w", err)
+		}
+
+		exclusionRules = loadedRules
+	}
+
+	exclusions, err := filepairs.NewExclusions(exclusionRules)
+	if err != nil {
+		return fmt.Errorf("compile exclusion rules: %w", err)
+	}
+
+	services.Exclusions = exclusions
+	services.PairProviders.SetExclusions(exclusions)
+
+	return nil
+}
+
+func buildTaskServices(services *RepoServices) {
 	services.RefreshRepoTask = tasks.NewRefreshRepoTask(
 		services.GitRepoHist,
 		services.FilePaths,
 		services.LangCodesProvider,
 		services.GitSeek,
+		services.Exclusions,
+	)
+
+	services.PRPreviewer = prpreview.New(
+		services.GitRepo,
+		services.GitSeek,
+		services.FilePaths,
+		services.CacheStore,
 	)
 
 	services.RefreshDashboardTask = tasks.NewRefreshDashboardTask(
 		services.LangCodesProvider,
 		services.PairProviders,
 		services.GitSeek,
+		tasks.ContentCheckers{
+			I18NKeys:     services.I18NKeys,
+			FrontMatter:  services.FrontMatter,
+			Alignment:    services.Alignment,
+			Untranslated: services.Untranslated,
+		},
 		services.FilePRIndex,
+		services.PRPreviewer,
+		services.AckStore,
 		services.DashboardStore,
 	)
 
 	services.RefreshPRTask = tasks.NewRefreshPRTask(
 		services.FilePRIndex,
+		services.PRPreviewer,
 		services.LangCodesProvider,
 	)
 
@@ -116,16 +305,53 @@ func buildTaskServices(cfg config.Config, services *Services) {
 		services.RefreshPRTask,
 		services.RefreshDashboardTask,
 	)
+}
+
+func buildMonitor(cfg config.Config, services *Services) {
+	repos := make([]githubmon.Repository, 0, len(services.Repos))
+	for _, repoServices := range services.Repos {
+		repos = append(repos, githubmon.Repository{
+			Name:         repoServices.Repo.Name,
+			GitHub:       repoServices.GitHub,
+			LangProvider: repoServices.LangCodesProvider,
+			Storage:      githubmon.NewMonitorFileStorage(repoServices.CacheStore),
+			OnUpdateTask: repoServices.OnGitHubUpdateTask,
+		})
+	}
 
 	services.GitHubMonitor = githubmon.NewMonitor(
-		services.GitHub,
-		services.LangCodesProvider,
-		githubmon.NewMonitorFileStorage(services.CacheStore),
+		repos,
 		cfg.SkipGitChecking,
 		cfg.SkipPRChecking,
 	)
 }
 
+func buildWebhookReceiver(cfg config.Config, services *Services) error {
+	if cfg.GitHubWebhookSecret == "" {
+		return nil
+	}
+
+	repos := make([]githubmon.WebhookRepository, 0, len(services.Repos))
+	for _, repoServices := range services.Repos {
+		repository, err := config.GitHubRepository(repoServices.Repo.URL)
+		if err != nil {
+			return fmt.Errorf("repository %s: github repository: %w", repoServices.Repo.Name, err)
+		}
+
+		repos = append(repos, githubmon.WebhookRepository{
+			Name:         repoServices.Repo.Name,
+			FullName:     repository,
+			Branch:       repoServices.Repo.Branch,
+			LangProvider: repoServices.LangCodesProvider,
+			OnUpdateTask: repoServices.OnGitHubUpdateTask,
+		})
+	}
+
+	services.GitHubWebhook = githubmon.NewWebhookReceiver(cfg.GitHubWebhookSecret, repos)
+
+	return nil
+}
+
 func buildOptionalServices(cfg config.Config, services *Services) error {
 	if cfg.NoWeb {
 		return nil
@@ -135,7 +361,22 @@ func buildOptionalServices(cfg config.Config, services *Services) error {
 		return fmt.Errorf("param WebHTTPAddr is not set: %w", config.ErrBadConfiguration)
 	}
 
-	services.Server = web.NewServer(cfg.WebHTTPAddr, services.DashboardStore)
+	repos := make([]web.Repository, 0, len(services.Repos))
+	for _, repoServices := range services.Repos {
+		repos = append(repos, web.Repository{
+			Name:           repoServices.Repo.Name,
+			DashboardStore: repoServices.DashboardStore,
+			AckStore:       repoServices.AckStore,
+			Links:          web.NewGitHubLinks(repoServices.Repo.URL, repoServices.Repo.Branch),
+			GitRepo:        repoServices.GitRepo,
+		})
+	}
+
+	services.Server = web.NewServer(cfg.WebHTTPAddr, repos, func(config *web.NewServerConfig) {
+		if services.GitHubWebhook != nil {
+			config.GitHubWebhook = services.GitHubWebhook
+		}
+	})
 
 	return nil
 }
@@ -1,7 +1,16 @@
 package config
 
+// The GitHub APIs used to fetch pull requests.
+const (
+	GitHubAPIREST    = "rest"
+	GitHubAPIGraphQL = "graphql"
+)
+
 type Config struct {
 	RepoDir         string
+	RepoURL         string
+	RepoBranch      string
+	SourceLangCode  string
 	CacheDir        string
 	LangCodes       []string
 	RunOnce         bool
@@ -13,14 +22,29 @@ type Config struct {
 	SkipPRChecking  bool
 	NoWeb           bool
 	WebHTTPAddr     string
+	PairRulesFile   string
+	ExcludeFile     string
+	ReposFile       string
+	// Repos are the repositories loaded from ReposFile.
+	Repos []Repo
+
+	// GitHubWebhookSecret is the secret of the GitHub webhook; the webhook endpoint is enabled when set.
+	GitHubWebhookSecret string
+
+	// GitHubAPI is the API used to fetch pull requests, GitHubAPIREST or GitHubAPIGraphQL.
+	GitHubAPI string
 }
 
 func Default() Config {
 	//nolint:exhaustruct
 	return Config{
 		RepoDir:         "./.appdata/kubernetes-website",
+		RepoURL:         "https://github.com/kubernetes/website",
+		RepoBranch:      "main",
+		SourceLangCode:  "en",
 		CacheDir:        "./.appdata/cache",
 		GitHubTokenFile: ".github-token.txt",
 		WebHTTPAddr:     ":8080",
+		GitHubAPI:       GitHubAPIREST,
 	}
 }
@@ -27,6 +27,10 @@ func TestDefault(t *testing.T) {
 	if cfg.WebHTTPAddr != ":8080" {
 		t.Fatalf("unexpected WebHTTPAddr: %q", cfg.WebHTTPAddr)
 	}
+
+	if cfg.GitHubAPI != config.GitHubAPIREST {
+		t.Fatalf("unexpected GitHubAPI: %q", cfg.GitHubAPI)
+	}
 }
 
 func TestParseLangCodes_Empty(t *testing.T) {
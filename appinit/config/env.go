@@ -14,6 +14,18 @@ func FromEnv(cfg *Config) error {
 		cfg.RepoDir = v
 	}
 
+	if v, ok := env("REPO_URL"); ok {
+		cfg.RepoURL = v
+	}
+
+	if v, ok := env("REPO_BRANCH"); ok {
+		cfg.RepoBranch = v
+	}
+
+	if v, ok := env("SOURCE_LANG_CODE"); ok {
+		cfg.SourceLangCode = v
+	}
+
 	if v, ok := env("CACHE_DIR"); ok {
 		cfg.CacheDir = v
 	}
@@ -33,12 +45,32 @@ func FromEnv(cfg *Config) error {
 		cfg.GitHubTokenFile = v
 	}
 
+	if v, ok := env("GITHUB_WEBHOOK_SECRET"); ok {
+		cfg.GitHubWebhookSecret = v
+	}
+
+	if v, ok := env("GITHUB_API"); ok {
+		cfg.GitHubAPI = v
+	}
+
 	errs = parseEnvBool("NO_WEB", &cfg.NoWeb, errs)
 
 	if v, ok := env("WEB_HTTP_ADDR"); ok {
 		cfg.WebHTTPAddr = v
 	}
 
+	if v, ok := env("PAIR_RULES_FILE"); ok {
+		cfg.PairRulesFile = v
+	}
+
+	if v, ok := env("EXCLUDE_FILE"); ok {
+		cfg.ExcludeFile = v
+	}
+
+	if v, ok := env("REPOS_FILE"); ok {
+		cfg.ReposFile = v
+	}
+
 	if len(errs) > 0 {
 		return fmt.Errorf(
 			"%w:\n - %s",
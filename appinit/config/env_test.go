@@ -11,14 +11,22 @@ import (
 
 func TestFromEnv_LoadsValues(t *testing.T) {
 	t.Setenv("REPO_DIR", "/tmp/repo")
+	t.Setenv("REPO_URL", "https://github.com/example/docs")
+	t.Setenv("REPO_BRANCH", "develop")
+	t.Setenv("SOURCE_LANG_CODE", "de")
 	t.Setenv("CACHE_DIR", "/tmp/cache")
 	t.Setenv("LANG_CODES", "pl, de ,pl,,fr")
 	t.Setenv("RUN_ONCE", "true")
 	t.Setenv("RUN_INTERVAL", "15")
 	t.Setenv("GITHUB_TOKEN", "secret-token")
 	t.Setenv("GITHUB_TOKEN_FILE", "/tmp/token.txt")
+	t.Setenv("GITHUB_WEBHOOK_SECRET", "webhook-secret")
+	t.Setenv("GITHUB_API", "graphql")
 	t.Setenv("NO_WEB", "true")
 	t.Setenv("WEB_HTTP_ADDR", ":9090")
+	t.Setenv("PAIR_RULES_FILE", "/tmp/pairs.json")
+	t.Setenv("EXCLUDE_FILE", "/tmp/exclude.json")
+	t.Setenv("REPOS_FILE", "/tmp/repos.json")
 
 	var cfg config.Config
 
@@ -31,6 +39,18 @@ func TestFromEnv_LoadsValues(t *testing.T) {
 		t.Fatalf("unexpected RepoDir: %q", cfg.RepoDir)
 	}
 
+	if cfg.RepoURL != "https://github.com/example/docs" {
+		t.Fatalf("unexpected RepoURL: %q", cfg.RepoURL)
+	}
+
+	if cfg.RepoBranch != "develop" {
+		t.Fatalf("unexpected RepoBranch: %q", cfg.RepoBranch)
+	}
+
+	if cfg.SourceLangCode != "de" {
+		t.Fatalf("unexpected SourceLangCode: %q", cfg.SourceLangCode)
+	}
+
 	if cfg.CacheDir != "/tmp/cache" {
 		t.Fatalf("unexpected CacheDir: %q", cfg.CacheDir)
 	}
@@ -56,6 +76,14 @@ func TestFromEnv_LoadsValues(t *testing.T) {
 		t.Fatalf("unexpected GitHubTokenFile: %q", cfg.GitHubTokenFile)
 	}
 
+	if cfg.GitHubWebhookSecret != "webhook-secret" {
+		t.Fatalf("unexpected GitHubWebhookSecret: %q", cfg.GitHubWebhookSecret)
+	}
+
+	if cfg.GitHubAPI != "graphql" {
+		t.Fatalf("unexpected GitHubAPI: %q", cfg.GitHubAPI)
+	}
+
 	if !cfg.NoWeb {
 		t.Fatalf("expected NoWeb=true")
 	}
@@ -63,6 +91,18 @@ func TestFromEnv_LoadsValues(t *testing.T) {
 	if cfg.WebHTTPAddr != ":9090" {
 		t.Fatalf("unexpected WebHTTPAddr: %q", cfg.WebHTTPAddr)
 	}
+
+	if cfg.PairRulesFile != "/tmp/pairs.json" {
+		t.Fatalf("unexpected PairRulesFile: %q", cfg.PairRulesFile)
+	}
+
+	if cfg.ExcludeFile != "/tmp/exclude.json" {
+		t.Fatalf("unexpected ExcludeFile: %q", cfg.ExcludeFile)
+	}
+
+	if cfg.ReposFile != "/tmp/repos.json" {
+		t.Fatalf("unexpected ReposFile: %q", cfg.ReposFile)
+	}
 }
 
 func TestFromEnv_InvalidBool(t *testing.T) {
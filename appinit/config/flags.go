@@ -8,6 +8,9 @@ import (
 func ApplyFlags(
 	cfg *Config,
 	flagRepoDir *string,
+	flagRepoURL *string,
+	flagRepoBranch *string,
+	flagSourceLangCode *string,
 	flagCacheDir *string,
 	flagLangCodes *string,
 	flagOnce *bool,
@@ -18,9 +21,16 @@ func ApplyFlags(
 	flagSkipPR *bool,
 	flagNoWeb *bool,
 	flagWebHTTPAddr *string,
+	flagPairRulesFile *string,
+	flagExcludeFile *string,
+	flagReposFile *string,
+	flagGitHubAPI *string,
 ) {
 	applyFlagLangCodes(flagLangCodes, &cfg.LangCodes)
 	applyFlagString(flagRepoDir, &cfg.RepoDir)
+	applyFlagString(flagRepoURL, &cfg.RepoURL)
+	applyFlagString(flagRepoBranch, &cfg.RepoBranch)
+	applyFlagString(flagSourceLangCode, &cfg.SourceLangCode)
 	applyFlagString(flagCacheDir, &cfg.CacheDir)
 	applyFlagBoolTrue(flagOnce, &cfg.RunOnce)
 	applyFlagIntPositive(flagInterval, &cfg.RunInterval)
@@ -30,6 +40,10 @@ func ApplyFlags(
 	applyFlagBoolTrue(flagSkipPR, &cfg.SkipPRChecking)
 	applyFlagBoolTrue(flagNoWeb, &cfg.NoWeb)
 	applyFlagString(flagWebHTTPAddr, &cfg.WebHTTPAddr)
+	applyFlagString(flagPairRulesFile, &cfg.PairRulesFile)
+	applyFlagString(flagExcludeFile, &cfg.ExcludeFile)
+	applyFlagString(flagReposFile, &cfg.ReposFile)
+	applyFlagString(flagGitHubAPI, &cfg.GitHubAPI)
 }
 
 func Show(cfg Config, withPrint bool) {
@@ -39,6 +53,9 @@ func Show(cfg Config, withPrint bool) {
 
 	log.Printf("LANG_CODES: %s", strings.Join(cfg.LangCodes, ","))
 	log.Printf("REPO_DIR: %s", cfg.RepoDir)
+	log.Printf("REPO_URL: %s", cfg.RepoURL)
+	log.Printf("REPO_BRANCH: %s", cfg.RepoBranch)
+	log.Printf("SOURCE_LANG_CODE: %s", cfg.SourceLangCode)
 	log.Printf("CACHE_DIR: %s", cfg.CacheDir)
 	log.Printf("RUN_ONCE: %v", cfg.RunOnce)
 	log.Printf("RUN_INTERVAL: %v", cfg.RunInterval)
@@ -50,10 +67,21 @@ func Show(cfg Config, withPrint bool) {
 	}
 
 	log.Printf("GITHUB_TOKEN_FILE: %s", cfg.GitHubTokenFile)
+
+	if cfg.GitHubWebhookSecret != "" {
+		log.Printf("GITHUB_WEBHOOK_SECRET: (set)")
+	} else {
+		log.Printf("GITHUB_WEBHOOK_SECRET: (empty)")
+	}
+
 	log.Printf("SKIP_GIT: %v", cfg.SkipGitChecking)
 	log.Printf("SKIP_PR: %v", cfg.SkipPRChecking)
 	log.Printf("NO_WEB: %v", cfg.NoWeb)
 	log.Printf("WEB_HTTP_ADDR: %s", cfg.WebHTTPAddr)
+	log.Printf("PAIR_RULES_FILE: %s", cfg.PairRulesFile)
+	log.Printf("EXCLUDE_FILE: %s", cfg.ExcludeFile)
+	log.Printf("REPOS_FILE: %s", cfg.ReposFile)
+	log.Printf("GITHUB_API: %s", cfg.GitHubAPI)
 }
 
 func applyFlagString(flag *string, target *string) {
@@ -19,16 +19,26 @@ func TestApplyFlags_AppliesValues(t *testing.T) {
 	}
 
 	repoDir := "new-repo"
+	repoURL := "https://github.com/example/docs"
+	repoBranch := "develop"
+	sourceLangCode := "de"
 	cacheDir := "new-cache"
 	langCodes := "pl, de ,pl,,fr"
 	runOnce := true
 	runInterval := 30
 	noWeb := true
 	webHTTPAddr := ":9090"
+	pairRulesFile := "pairs.json"
+	excludeFile := "exclude.json"
+	reposFile := "repos.json"
+	gitHubAPI := "graphql"
 
 	config.ApplyFlags(
 		&cfg,
 		&repoDir,
+		&repoURL,
+		&repoBranch,
+		&sourceLangCode,
 		&cacheDir,
 		&langCodes,
 		&runOnce,
@@ -39,12 +49,28 @@ func TestApplyFlags_AppliesValues(t *testing.T) {
 		nil,
 		&noWeb,
 		&webHTTPAddr,
+		&pairRulesFile,
+		&excludeFile,
+		&reposFile,
+		&gitHubAPI,
 	)
 
 	if cfg.RepoDir != "new-repo" {
 		t.Fatalf("unexpected RepoDir: %q", cfg.RepoDir)
 	}
 
+	if cfg.RepoURL != "https://github.com/example/docs" {
+		t.Fatalf("unexpected RepoURL: %q", cfg.RepoURL)
+	}
+
+	if cfg.RepoBranch != "develop" {
+		t.Fatalf("unexpected RepoBranch: %q", cfg.RepoBranch)
+	}
+
+	if cfg.SourceLangCode != "de" {
+		t.Fatalf("unexpected SourceLangCode: %q", cfg.SourceLangCode)
+	}
+
 	if cfg.CacheDir != "new-cache" {
 		t.Fatalf("unexpected CacheDir: %q", cfg.CacheDir)
 	}
@@ -69,6 +95,22 @@ func TestApplyFlags_AppliesValues(t *testing.T) {
 	if cfg.WebHTTPAddr != ":9090" {
 		t.Fatalf("unexpected WebHTTPAddr: %q", cfg.WebHTTPAddr)
 	}
+
+	if cfg.PairRulesFile != "pairs.json" {
+		t.Fatalf("unexpected PairRulesFile: %q", cfg.PairRulesFile)
+	}
+
+	if cfg.ExcludeFile != "exclude.json" {
+		t.Fatalf("unexpected ExcludeFile: %q", cfg.ExcludeFile)
+	}
+
+	if cfg.ReposFile != "repos.json" {
+		t.Fatalf("unexpected ReposFile: %q", cfg.ReposFile)
+	}
+
+	if cfg.GitHubAPI != "graphql" {
+		t.Fatalf("unexpected GitHubAPI: %q", cfg.GitHubAPI)
+	}
 }
 
 func TestApplyFlags_NilPointersIgnored(t *testing.T) {
@@ -83,7 +125,8 @@ func TestApplyFlags_NilPointersIgnored(t *testing.T) {
 
 	config.ApplyFlags(
 		&cfg,
-		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil,
 	)
 
 	want := config.Config{
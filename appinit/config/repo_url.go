@@ -0,0 +1,34 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	gitHubHost           = "github.com"
+	gitHubRepoPathLength = 2
+)
+
+// GitHubRepository returns the repository in the owner/name form for a GitHub
+// repository URL like https://github.com/kubernetes/website.
+func GitHubRepository(repoURL string) (string, error) {
+	parsedURL, err := url.Parse(repoURL)
+	if err != nil {
+		return "", fmt.Errorf("param RepoURL %q: %w: %w", repoURL, ErrBadConfiguration, err)
+	}
+
+	if parsedURL.Scheme != "https" || parsedURL.Host != gitHubHost {
+		return "", fmt.Errorf("param RepoURL %q is not a GitHub https URL: %w", repoURL, ErrBadConfiguration)
+	}
+
+	repoPath := strings.TrimSuffix(strings.Trim(parsedURL.Path, "/"), ".git")
+
+	parts := strings.Split(repoPath, "/")
+	if len(parts) != gitHubRepoPathLength || parts[0] == "" || parts[1] == "" {
+		return "", fmt.Errorf("param RepoURL %q has no owner/name path: %w", repoURL, ErrBadConfiguration)
+	}
+
+	return repoPath, nil
+}
@@ -0,0 +1,186 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	reposCacheNamespace = "repos"
+	defaultRepoName     = "default"
+)
+
+var repoNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Repo is the configuration of a single tracked repository.
+type Repo struct {
+	// Name identifies the repository in the web UI, the API and the cache.
+	Name           string   `json:"name"`
+	URL            string   `json:"url"`
+	Branch         string   `json:"branch"`
+	SourceLangCode string   `json:"sourceLangCode"`
+	Dir            string   `json:"repoDir"`
+	LangCodes      []string `json:"langCodes"`
+	PairRulesFile  string   `json:"pairRulesFile"`
+	ExcludeFile    string   `json:"excludeFile"`
+
+	// CacheNamespace is the prefix of the cache buckets of the repository.
+	CacheNamespace string `json:"-"`
+}
+
+// ReposFile is the JSON document with the tracked repositories.
+type ReposFile struct {
+	Repos []Repo `json:"repos"`
+}
+
+// ReadReposFile loads the repositories from the file set in ReposFile.
+// The parameters not set for a repository are taken from the top-level
+// parameters, and the repository directory defaults to a directory named
+// after the repository next to RepoDir.
+func ReadReposFile(cfg *Config) error {
+	if len(cfg.ReposFile) == 0 {
+		return nil
+	}
+
+	data, err := os.ReadFile(cfg.ReposFile)
+	if err != nil {
+		return fmt.Errorf("read repos file %s: %w", cfg.ReposFile, err)
+	}
+
+	var reposFile ReposFile
+	if err := json.Unmarshal(data, &reposFile); err != nil {
+		return fmt.Errorf("parse repos file %s: %w", cfg.ReposFile, err)
+	}
+
+	if len(reposFile.Repos) == 0 {
+		return fmt.Errorf("no repositories in %s: %w", cfg.ReposFile, ErrBadConfiguration)
+	}
+
+	repos := make([]Repo, 0, len(reposFile.Repos))
+	for _, repo := range reposFile.Repos {
+		repos = append(repos, withRepoDefaults(*cfg, repo))
+	}
+
+	cfg.Repos = repos
+
+	return nil
+}
+
+func withRepoDefaults(cfg Config, repo Repo) Repo {
+	repo.Name = strings.TrimSpace(repo.Name)
+
+	if repo.Branch == "" {
+		repo.Branch = cfg.RepoBranch
+	}
+
+	if repo.SourceLangCode == "" {
+		repo.SourceLangCode = cfg.SourceLangCode
+	}
+
+	if repo.Dir == "" && repo.Name != "" {
+		repo.Dir = filepath.Join(filepath.Dir(cfg.RepoDir), repo.Name)
+	}
+
+	if repo.LangCodes == nil {
+		repo.LangCodes = cfg.LangCodes
+	}
+
+	if repo.PairRulesFile == "" {
+		repo.PairRulesFile = cfg.PairRulesFile
+	}
+
+	if repo.ExcludeFile == "" {
+		repo.ExcludeFile = cfg.ExcludeFile
+	}
+
+	repo.CacheNamespace = reposCacheNamespace + "/" + repo.Name
+
+	return repo
+}
+
+// TrackedRepos returns the tracked repositories.
+//
+// Without a repositories file, the only repository is built from the top-level
+// parameters. Its cache buckets are not namespaced, so that the cache of an
+// instance tracking a single repository stays valid.
+func (cfg Config) TrackedRepos() []Repo {
+	if len(cfg.Repos) > 0 {
+		return cfg.Repos
+	}
+
+	return []Repo{{
+		Name:           repoNameFromURL(cfg.RepoURL),
+		URL:            cfg.RepoURL,
+		Branch:         cfg.RepoBranch,
+		SourceLangCode: cfg.SourceLangCode,
+		Dir:            cfg.RepoDir,
+		LangCodes:      cfg.LangCodes,
+		PairRulesFile:  cfg.PairRulesFile,
+		ExcludeFile:    cfg.ExcludeFile,
+		CacheNamespace: "",
+	}}
+}
+
+// repoNameFromURL returns a name like kubernetes-website for the repository URL.
+func repoNameFromURL(repoURL string) string {
+	repository, err := GitHubRepository(repoURL)
+	if err != nil {
+		return defaultRepoName
+	}
+
+	return strings.ReplaceAll(repository, "/", "-")
+}
+
+func validateRepos(repos []Repo) error {
+	names := make(map[string]struct{}, len(repos))
+	dirs := make(map[string]struct{}, len(repos))
+
+	for _, repo := range repos {
+		if err := validateRepo(repo); err != nil {
+			return err
+		}
+
+		if _, ok := names[repo.Name]; ok {
+			return fmt.Errorf("repository name %q is not unique: %w", repo.Name, ErrBadConfiguration)
+		}
+
+		names[repo.Name] = struct{}{}
+
+		dir := filepath.Clean(repo.Dir)
+		if _, ok := dirs[dir]; ok {
+			return fmt.Errorf("repository %s: directory %q is not unique: %w", repo.Name, repo.Dir, ErrBadConfiguration)
+		}
+
+		dirs[dir] = struct{}{}
+	}
+
+	return nil
+}
+
+func validateRepo(repo Repo) error {
+	if !repoNamePattern.MatchString(repo.Name) {
+		return fmt.Errorf("repository name %q is not valid: %w", repo.Name, ErrBadConfiguration)
+	}
+
+	if _, err := GitHubRepository(repo.URL); err != nil {
+		return fmt.Errorf("repository %s: %w", repo.Name, err)
+	}
+
+	if len(repo.Branch) == 0 {
+		return fmt.Errorf("repository %s: param RepoBranch is not set: %w", repo.Name, ErrBadConfiguration)
+	}
+
+	if len(repo.SourceLangCode) == 0 {
+		return fmt.Errorf("repository %s: param SourceLangCode is not set: %w", repo.Name, ErrBadConfiguration)
+	}
+
+	if len(repo.Dir) == 0 {
+		return fmt.Errorf("repository %s: param RepoDir is not set: %w", repo.Name, ErrBadConfiguration)
+	}
+
+	return nil
+}
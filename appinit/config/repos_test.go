@@ -0,0 +1,159 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/dkarczmarski/go-kweb-lang/appinit/config"
+)
+
+func TestTrackedRepos_WithoutReposFile(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Default()
+	cfg.LangCodes = []string{"pl"}
+
+	got := cfg.TrackedRepos()
+	want := []config.Repo{{
+		Name:           "kubernetes-website",
+		URL:            "https://github.com/kubernetes/website",
+		Branch:         "main",
+		SourceLangCode: "en",
+		Dir:            "./.appdata/kubernetes-website",
+		LangCodes:      []string{"pl"},
+		CacheNamespace: "",
+	}}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected repos:\ngot  %#v\nwant %#v", got, want)
+	}
+}
+
+func TestReadReposFile(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), "repos.json")
+
+	err := os.WriteFile(file, []byte(`{
+		"repos": [
+			{"name": "kubernetes-website", "url": "https://github.com/kubernetes/website"},
+			{
+				"name": "docs",
+				"url": "https://github.com/example/docs",
+				"branch": "develop",
+				"sourceLangCode": "de",
+				"repoDir": "/data/docs",
+				"langCodes": ["en"]
+			}
+		]
+	}`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.Default()
+	cfg.ReposFile = file
+	cfg.LangCodes = []string{"pl"}
+	cfg.ExcludeFile = "exclude.json"
+
+	if err := config.ReadReposFile(&cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []config.Repo{
+		{
+			Name:           "kubernetes-website",
+			URL:            "https://github.com/kubernetes/website",
+			Branch:         "main",
+			SourceLangCode: "en",
+			Dir:            ".appdata/kubernetes-website",
+			LangCodes:      []string{"pl"},
+			ExcludeFile:    "exclude.json",
+			CacheNamespace: "repos/kubernetes-website",
+		},
+		{
+			Name:           "docs",
+			URL:            "https://github.com/example/docs",
+			Branch:         "develop",
+			SourceLangCode: "de",
+			Dir:            "/data/docs",
+			LangCodes:      []string{"en"},
+			ExcludeFile:    "exclude.json",
+			CacheNamespace: "repos/docs",
+		},
+	}
+
+	if got := cfg.TrackedRepos(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected repos:\ngot  %#v\nwant %#v", got, want)
+	}
+
+	if err := config.Validate(cfg); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+}
+
+func TestReadReposFile_Empty(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), "repos.json")
+	if err := os.WriteFile(file, []byte(`{"repos": []}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.Default()
+	cfg.ReposFile = file
+
+	if err := config.ReadReposFile(&cfg); !errors.Is(err, config.ErrBadConfiguration) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestValidate_Repos(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name  string
+		repos []config.Repo
+	}{
+		{
+			name: "duplicated name",
+			repos: []config.Repo{
+				{Name: "docs", URL: "https://github.com/example/docs", Branch: "main", SourceLangCode: "en", Dir: "/a"},
+				{Name: "docs", URL: "https://github.com/example/site", Branch: "main", SourceLangCode: "en", Dir: "/b"},
+			},
+		},
+		{
+			name: "duplicated directory",
+			repos: []config.Repo{
+				{Name: "docs", URL: "https://github.com/example/docs", Branch: "main", SourceLangCode: "en", Dir: "/a"},
+				{Name: "site", URL: "https://github.com/example/site", Branch: "main", SourceLangCode: "en", Dir: "/a/"},
+			},
+		},
+		{
+			name: "invalid name",
+			repos: []config.Repo{
+				{Name: "a/b", URL: "https://github.com/example/docs", Branch: "main", SourceLangCode: "en", Dir: "/a"},
+			},
+		},
+		{
+			name: "invalid url",
+			repos: []config.Repo{
+				{Name: "docs", URL: "https://gitlab.com/example/docs", Branch: "main", SourceLangCode: "en", Dir: "/a"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := config.Default()
+			cfg.Repos = tc.repos
+
+			if err := config.Validate(cfg); !errors.Is(err, config.ErrBadConfiguration) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
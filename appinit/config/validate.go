@@ -18,6 +18,10 @@ func Validate(cfg Config) error {
 		return fmt.Errorf("param RepoDir is not set: %w", ErrBadConfiguration)
 	}
 
+	if err := validateRepos(cfg.TrackedRepos()); err != nil {
+		return err
+	}
+
 	if len(cfg.CacheDir) == 0 {
 		return fmt.Errorf("param CacheDir is not set: %w", ErrBadConfiguration)
 	}
@@ -26,9 +30,34 @@ func Validate(cfg Config) error {
 		return fmt.Errorf("param WebHTTPAddr is not set: %w", ErrBadConfiguration)
 	}
 
+	if err := validateGitHubAPI(cfg); err != nil {
+		return err
+	}
+
+	if cfg.NoWeb && len(cfg.GitHubWebhookSecret) != 0 {
+		return fmt.Errorf("param GitHubWebhookSecret requires the web server: %w", ErrBadConfiguration)
+	}
+
 	return nil
 }
 
+func validateGitHubAPI(cfg Config) error {
+	switch cfg.GitHubAPI {
+	case "", GitHubAPIREST:
+		return nil
+	case GitHubAPIGraphQL:
+		// the GraphQL API does not allow anonymous access
+		if len(cfg.GitHubToken) == 0 && !cfg.SkipPRChecking {
+			return fmt.Errorf("param GitHubAPI %q requires a GitHub token: %w", cfg.GitHubAPI, ErrBadConfiguration)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("param GitHubAPI %q is not one of %q, %q: %w",
+			cfg.GitHubAPI, GitHubAPIREST, GitHubAPIGraphQL, ErrBadConfiguration)
+	}
+}
+
 func ReadGitHubTokenFile(cfg *Config, skipFileNotExist, skipEmptyFile bool) error {
 	if len(cfg.GitHubTokenFile) == 0 {
 		return nil
@@ -13,9 +13,12 @@ func TestValidate_OK(t *testing.T) {
 	t.Parallel()
 
 	cfg := config.Config{
-		RepoDir:     "/tmp/repo",
-		CacheDir:    "/tmp/cache",
-		WebHTTPAddr: ":8080",
+		RepoDir:        "/tmp/repo",
+		RepoURL:        "https://github.com/kubernetes/website",
+		RepoBranch:     "main",
+		SourceLangCode: "en",
+		CacheDir:       "/tmp/cache",
+		WebHTTPAddr:    ":8080",
 	}
 
 	err := config.Validate(cfg)
@@ -24,6 +27,49 @@ func TestValidate_OK(t *testing.T) {
 	}
 }
 
+func TestValidate_InvalidRepoURL(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Default()
+	cfg.RepoURL = "https://gitlab.com/example/docs"
+
+	err := config.Validate(cfg)
+	if !errors.Is(err, config.ErrBadConfiguration) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestGitHubRepository(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		repoURL string
+		want    string
+		wantErr bool
+	}{
+		{repoURL: "https://github.com/kubernetes/website", want: "kubernetes/website"},
+		{repoURL: "https://github.com/example/docs.git", want: "example/docs"},
+		{repoURL: "https://github.com/example/docs/", want: "example/docs"},
+		{repoURL: "http://github.com/example/docs", wantErr: true},
+		{repoURL: "https://github.com/example", wantErr: true},
+		{repoURL: "https://github.com/example/docs/tree/main", wantErr: true},
+		{repoURL: "", wantErr: true},
+	} {
+		got, err := config.GitHubRepository(tc.repoURL)
+		if tc.wantErr {
+			if !errors.Is(err, config.ErrBadConfiguration) {
+				t.Fatalf("GitHubRepository(%q): expected ErrBadConfiguration, got %v", tc.repoURL, err)
+			}
+
+			continue
+		}
+
+		if err != nil || got != tc.want {
+			t.Fatalf("GitHubRepository(%q) = (%q, %v), want %q", tc.repoURL, got, err, tc.want)
+		}
+	}
+}
+
 func TestValidate_MissingRepoDir(t *testing.T) {
 	t.Parallel()
 
@@ -47,9 +93,12 @@ func TestValidate_NoWebAllowsEmptyAddr(t *testing.T) {
 	t.Parallel()
 
 	cfg := config.Config{
-		RepoDir:  "/tmp/repo",
-		CacheDir: "/tmp/cache",
-		NoWeb:    true,
+		RepoDir:        "/tmp/repo",
+		RepoURL:        "https://github.com/kubernetes/website",
+		RepoBranch:     "main",
+		SourceLangCode: "en",
+		CacheDir:       "/tmp/cache",
+		NoWeb:          true,
 	}
 
 	err := config.Validate(cfg)
@@ -58,6 +107,51 @@ func TestValidate_NoWebAllowsEmptyAddr(t *testing.T) {
 	}
 }
 
+func TestValidate_WebhookSecretRequiresWeb(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Default()
+	cfg.NoWeb = true
+	cfg.GitHubWebhookSecret = "secret"
+
+	err := config.Validate(cfg)
+	if !errors.Is(err, config.ErrBadConfiguration) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestValidate_GitHubAPI(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name    string
+		api     string
+		token   string
+		skipPR  bool
+		wantErr bool
+	}{
+		{name: "rest", api: config.GitHubAPIREST},
+		{name: "graphql", api: config.GitHubAPIGraphQL, token: "token"},
+		{name: "graphql without token", api: config.GitHubAPIGraphQL, wantErr: true},
+		{name: "graphql without token and PR checking", api: config.GitHubAPIGraphQL, skipPR: true},
+		{name: "unknown", api: "soap", token: "token", wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := config.Default()
+			cfg.GitHubAPI = tc.api
+			cfg.GitHubToken = tc.token
+			cfg.SkipPRChecking = tc.skipPR
+
+			err := config.Validate(cfg)
+			if tc.wantErr != errors.Is(err, config.ErrBadConfiguration) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
 func TestReadGitHubTokenFile_TwoLines(t *testing.T) {
 	t.Parallel()
 
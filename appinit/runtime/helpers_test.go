@@ -4,8 +4,11 @@ package runtime
 import (
 	"context"
 	"time"
+)
 
-	"github.com/dkarczmarski/go-kweb-lang/githubmon"
+const (
+	testRepoURL      = "https://github.com/example/docs"
+	testOtherRepoURL = "https://github.com/example/site"
 )
 
 type fakeRepoCreator struct {
@@ -27,30 +30,26 @@ type fakeRetryChecker struct {
 	retryCalls    int
 	retryCtx      context.Context
 	retryDelay    time.Duration
-	retryTask     githubmon.OnUpdateTask
 	retryErr      error
 	intervalCalls int
 	intervalCtx   context.Context
 	intervalDelay time.Duration
-	intervalTask  githubmon.OnUpdateTask
 	intervalErr   error
 	intervalCh    chan struct{}
 }
 
-func (f *fakeRetryChecker) RetryCheck(ctx context.Context, delay time.Duration, task githubmon.OnUpdateTask) error {
+func (f *fakeRetryChecker) RetryCheck(ctx context.Context, delay time.Duration) error {
 	f.retryCalls++
 	f.retryCtx = ctx
 	f.retryDelay = delay
-	f.retryTask = task
 
 	return f.retryErr
 }
 
-func (f *fakeRetryChecker) IntervalCheck(ctx context.Context, delay time.Duration, task githubmon.OnUpdateTask) error {
+func (f *fakeRetryChecker) IntervalCheck(ctx context.Context, delay time.Duration) error {
 	f.intervalCalls++
 	f.intervalCtx = ctx
 	f.intervalDelay = delay
-	f.intervalTask = task
 
 	if f.intervalCh != nil {
 		close(f.intervalCh)
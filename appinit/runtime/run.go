@@ -13,14 +13,12 @@ import (
 	"time"
 
 	"github.com/dkarczmarski/go-kweb-lang/appinit/bootstrap"
-	"github.com/dkarczmarski/go-kweb-lang/githubmon"
 )
 
 const (
 	defaultDirPerm      = 0o755
 	defaultRetryDelay   = 15 * time.Second
 	shutdownGracePeriod = 10 * time.Second
-	repoURL             = "https://github.com/kubernetes/website"
 )
 
 type repoCreator interface {
@@ -28,8 +26,15 @@ type repoCreator interface {
 }
 
 type retryChecker interface {
-	RetryCheck(ctx context.Context, retryDelay time.Duration, onUpdateTask githubmon.OnUpdateTask) error
-	IntervalCheck(ctx context.Context, intervalDelay time.Duration, onUpdateTask githubmon.OnUpdateTask) error
+	RetryCheck(ctx context.Context, retryDelay time.Duration) error
+	IntervalCheck(ctx context.Context, intervalDelay time.Duration) error
+}
+
+// repoSource describes where a tracked repository is cloned from and to.
+type repoSource struct {
+	dir     string
+	url     string
+	creator repoCreator
 }
 
 type httpServer interface {
@@ -41,40 +46,51 @@ func Run(ctx context.Context, app *bootstrap.App) error {
 	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
+	repos := make([]repoSource, 0, len(app.Services.Repos))
+	for _, repoServices := range app.Services.Repos {
+		repos = append(repos, repoSource{
+			dir:     repoServices.Repo.Dir,
+			url:     repoServices.Repo.URL,
+			creator: repoServices.GitRepo,
+		})
+	}
+
 	if err := runCheckAndRefresh(
 		ctx,
-		app.Config.RepoDir,
+		repos,
 		app.Config.SkipGitChecking,
 		app.Config.RunOnce,
 		app.Config.RunInterval,
-		app.Services.GitRepo,
 		app.Services.GitHubMonitor,
-		app.Services.OnGitHubUpdateTask,
 	); err != nil {
 		return err
 	}
 
+	if app.Services.GitHubWebhook != nil {
+		go app.Services.GitHubWebhook.Run(ctx)
+	}
+
 	return runWebServer(ctx, app.Services.Server)
 }
 
 func runCheckAndRefresh(
 	ctx context.Context,
-	repoDirPath string,
+	repos []repoSource,
 	skipGitChecking bool,
 	runOnce bool,
 	runInterval int,
-	gitRepo repoCreator,
 	gitHubMonitor retryChecker,
-	refreshTask githubmon.OnUpdateTask,
 ) error {
 	if !skipGitChecking {
-		if err := createRepoIfNotExists(ctx, repoDirPath, gitRepo); err != nil {
-			return err
+		for _, repo := range repos {
+			if err := createRepoIfNotExists(ctx, repo.dir, repo.url, repo.creator); err != nil {
+				return err
+			}
 		}
 	}
 
 	if runOnce {
-		if err := gitHubMonitor.RetryCheck(ctx, defaultRetryDelay, refreshTask); err != nil {
+		if err := gitHubMonitor.RetryCheck(ctx, defaultRetryDelay); err != nil {
 			return fmt.Errorf("github monitor retry check failed: %w", err)
 		}
 
@@ -85,7 +101,7 @@ func runCheckAndRefresh(
 		go func() {
 			intervalDelay := time.Minute * time.Duration(runInterval)
 
-			if err := gitHubMonitor.IntervalCheck(ctx, intervalDelay, refreshTask); err != nil {
+			if err := gitHubMonitor.IntervalCheck(ctx, intervalDelay); err != nil {
 				if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
 					log.Printf("context cancelled or deadline exceeded: %v", err)
 
@@ -143,7 +159,7 @@ func runWebServer(ctx context.Context, server httpServer) error {
 	}
 }
 
-func createRepoIfNotExists(ctx context.Context, repoDirPath string, gitRepo repoCreator) error {
+func createRepoIfNotExists(ctx context.Context, repoDirPath string, repoURL string, gitRepo repoCreator) error {
 	exists, err := fileExists(filepath.Join(repoDirPath, ".git"))
 	if err != nil {
 		return fmt.Errorf("error while checking if a git repository exists: %w", err)
@@ -153,14 +169,14 @@ func createRepoIfNotExists(ctx context.Context, repoDirPath string, gitRepo repo
 		return nil
 	}
 
-	log.Println("repository does not exist yet. creating a new one...")
+	log.Printf("repository %s does not exist yet. creating a new one...", repoURL)
 
 	if err := os.MkdirAll(repoDirPath, defaultDirPerm); err != nil {
 		return fmt.Errorf("error while creating directory %s: %w", repoDirPath, err)
 	}
 
 	if err := gitRepo.Create(ctx, repoURL); err != nil {
-		return fmt.Errorf("error while creating kubernetes repository: %w", err)
+		return fmt.Errorf("error while creating repository from %s: %w", repoURL, err)
 	}
 
 	log.Println("repository was created")
@@ -9,8 +9,6 @@ import (
 	"path/filepath"
 	"testing"
 	"time"
-
-	"github.com/dkarczmarski/go-kweb-lang/githubmon"
 )
 
 func TestRunCheckAndRefresh_RunOnce_CallsRetryCheck(t *testing.T) {
@@ -24,17 +22,13 @@ func TestRunCheckAndRefresh_RunOnce_CallsRetryCheck(t *testing.T) {
 	repo := &fakeRepoCreator{}
 	monitor := &fakeRetryChecker{}
 
-	var refreshTask githubmon.OnUpdateTask
-
 	err := runCheckAndRefresh(
 		t.Context(),
-		repoDir,
+		[]repoSource{{dir: repoDir, url: testRepoURL, creator: repo}},
 		false,
 		true,
 		0,
-		repo,
 		monitor,
-		refreshTask,
 	)
 	if err != nil {
 		t.Fatalf("runCheckAndRefresh returned error: %v", err)
@@ -52,10 +46,6 @@ func TestRunCheckAndRefresh_RunOnce_CallsRetryCheck(t *testing.T) {
 		t.Fatalf("unexpected retry delay: got %v, want %v", monitor.retryDelay, defaultRetryDelay)
 	}
 
-	if monitor.retryTask != nil {
-		t.Fatal("expected nil task passed to RetryCheck")
-	}
-
 	if monitor.intervalCalls != 0 {
 		t.Fatalf("expected IntervalCheck not to be called, got %d", monitor.intervalCalls)
 	}
@@ -72,17 +62,13 @@ func TestRunCheckAndRefresh_RunOnce_ReturnsRetryError(t *testing.T) {
 	wantErr := errors.New("retry failed")
 	monitor := &fakeRetryChecker{retryErr: wantErr}
 
-	var refreshTask githubmon.OnUpdateTask
-
 	err := runCheckAndRefresh(
 		t.Context(),
-		repoDir,
+		[]repoSource{{dir: repoDir, url: testRepoURL, creator: &fakeRepoCreator{}}},
 		false,
 		true,
 		0,
-		&fakeRepoCreator{},
 		monitor,
-		refreshTask,
 	)
 	if err == nil {
 		t.Fatal("expected error, got nil")
@@ -104,17 +90,13 @@ func TestRunCheckAndRefresh_IntervalMode_CallsIntervalCheck(t *testing.T) {
 	ch := make(chan struct{})
 	monitor := &fakeRetryChecker{intervalCh: ch}
 
-	var refreshTask githubmon.OnUpdateTask
-
 	err := runCheckAndRefresh(
 		t.Context(),
-		repoDir,
+		[]repoSource{{dir: repoDir, url: testRepoURL, creator: &fakeRepoCreator{}}},
 		false,
 		false,
 		7,
-		&fakeRepoCreator{},
 		monitor,
-		refreshTask,
 	)
 	if err != nil {
 		t.Fatalf("runCheckAndRefresh returned error: %v", err)
@@ -134,10 +116,6 @@ func TestRunCheckAndRefresh_IntervalMode_CallsIntervalCheck(t *testing.T) {
 	if monitor.intervalDelay != wantDelay {
 		t.Fatalf("unexpected interval delay: got %v, want %v", monitor.intervalDelay, wantDelay)
 	}
-
-	if monitor.intervalTask != nil {
-		t.Fatal("expected nil task passed to IntervalCheck")
-	}
 }
 
 func TestRunCheckAndRefresh_CreatesRepoWhenMissing(t *testing.T) {
@@ -147,17 +125,13 @@ func TestRunCheckAndRefresh_CreatesRepoWhenMissing(t *testing.T) {
 	repo := &fakeRepoCreator{}
 	monitor := &fakeRetryChecker{}
 
-	var refreshTask githubmon.OnUpdateTask
-
 	err := runCheckAndRefresh(
 		t.Context(),
-		repoDir,
+		[]repoSource{{dir: repoDir, url: testRepoURL, creator: repo}},
 		false,
 		false,
 		0,
-		repo,
 		monitor,
-		refreshTask,
 	)
 	if err != nil {
 		t.Fatalf("runCheckAndRefresh returned error: %v", err)
@@ -167,8 +141,49 @@ func TestRunCheckAndRefresh_CreatesRepoWhenMissing(t *testing.T) {
 		t.Fatalf("expected repo.Create to be called once, got %d", repo.createCalls)
 	}
 
-	if repo.createURL != repoURL {
-		t.Fatalf("unexpected repo URL: got %q, want %q", repo.createURL, repoURL)
+	if repo.createURL != testRepoURL {
+		t.Fatalf("unexpected repo URL: got %q, want %q", repo.createURL, testRepoURL)
+	}
+}
+
+func TestRunCheckAndRefresh_CreatesAllMissingRepos(t *testing.T) {
+	t.Parallel()
+
+	existingRepoDir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(existingRepoDir, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	existingRepo := &fakeRepoCreator{}
+	missingRepo := &fakeRepoCreator{}
+	monitor := &fakeRetryChecker{}
+
+	err := runCheckAndRefresh(
+		t.Context(),
+		[]repoSource{
+			{dir: existingRepoDir, url: testRepoURL, creator: existingRepo},
+			{dir: t.TempDir(), url: testOtherRepoURL, creator: missingRepo},
+		},
+		false,
+		true,
+		0,
+		monitor,
+	)
+	if err != nil {
+		t.Fatalf("runCheckAndRefresh returned error: %v", err)
+	}
+
+	if existingRepo.createCalls != 0 {
+		t.Fatalf("expected existing repo not to be created, got %d calls", existingRepo.createCalls)
+	}
+
+	if missingRepo.createCalls != 1 || missingRepo.createURL != testOtherRepoURL {
+		t.Fatalf("expected missing repo to be created from %q, got %d calls with %q",
+			testOtherRepoURL, missingRepo.createCalls, missingRepo.createURL)
+	}
+
+	if monitor.retryCalls != 1 {
+		t.Fatalf("expected RetryCheck to be called once, got %d", monitor.retryCalls)
 	}
 }
 
@@ -178,17 +193,13 @@ func TestRunCheckAndRefresh_SkipGitChecking_DoesNotCreateRepo(t *testing.T) {
 	repo := &fakeRepoCreator{}
 	monitor := &fakeRetryChecker{}
 
-	var refreshTask githubmon.OnUpdateTask
-
 	err := runCheckAndRefresh(
 		t.Context(),
-		t.TempDir(),
+		[]repoSource{{dir: t.TempDir(), url: testRepoURL, creator: repo}},
 		true,
 		false,
 		0,
-		repo,
 		monitor,
-		refreshTask,
 	)
 	if err != nil {
 		t.Fatalf("runCheckAndRefresh returned error: %v", err)
@@ -205,7 +216,7 @@ func TestCreateRepoIfNotExists_CreatesRepo(t *testing.T) {
 	repoDir := filepath.Join(t.TempDir(), "repo")
 	repo := &fakeRepoCreator{}
 
-	err := createRepoIfNotExists(t.Context(), repoDir, repo)
+	err := createRepoIfNotExists(t.Context(), repoDir, testRepoURL, repo)
 	if err != nil {
 		t.Fatalf("createRepoIfNotExists returned error: %v", err)
 	}
@@ -229,7 +240,7 @@ func TestCreateRepoIfNotExists_DoesNothingWhenGitDirExists(t *testing.T) {
 
 	repo := &fakeRepoCreator{}
 
-	err := createRepoIfNotExists(t.Context(), repoDir, repo)
+	err := createRepoIfNotExists(t.Context(), repoDir, testRepoURL, repo)
 	if err != nil {
 		t.Fatalf("createRepoIfNotExists returned error: %v", err)
 	}
@@ -10,7 +10,10 @@ import (
 
 //nolint:gochecknoglobals
 var (
-	flagRepoDir         = flag.String("repo-dir", "", "kubernetes website repository directory path")
+	flagRepoDir         = flag.String("repo-dir", "", "repository directory path")
+	flagRepoURL         = flag.String("repo-url", "", "github repository url to clone")
+	flagRepoBranch      = flag.String("repo-branch", "", "tracked branch of the repository")
+	flagSourceLangCode  = flag.String("source-lang-code", "", "lang code of the source files that are translated")
 	flagCacheDir        = flag.String("cache-dir", "", "cache directory path")
 	flagLangCodes       = flag.String("lang-codes", "", "allowed lang codes")
 	flagRunOnce         = flag.Bool("run-once", false, "run synchronization once at startup")
@@ -21,6 +24,10 @@ var (
 	flagSkipPR          = flag.Bool("skip-pr", false, "skip pull request checking")
 	flagNoWeb           = flag.Bool("no-web", false, "disable web server")
 	flagWebHTTPAddr     = flag.String("web-http-addr", "", "TCP address for the server to listen on")
+	flagPairRulesFile   = flag.String("pair-rules-file", "", "JSON file with file pair rules")
+	flagExcludeFile     = flag.String("exclude-file", "", "JSON file with file exclusion patterns")
+	flagReposFile       = flag.String("repos-file", "", "JSON file with repositories to track")
+	flagGitHubAPI       = flag.String("github-api", "", "github api used to fetch pull requests: rest or graphql")
 )
 
 func main() {
@@ -30,6 +37,9 @@ func main() {
 
 	app, err := appinit.NewApp(
 		flagRepoDir,
+		flagRepoURL,
+		flagRepoBranch,
+		flagSourceLangCode,
 		flagCacheDir,
 		flagLangCodes,
 		flagRunOnce,
@@ -40,6 +50,10 @@ func main() {
 		flagSkipPR,
 		flagNoWeb,
 		flagWebHTTPAddr,
+		flagPairRulesFile,
+		flagExcludeFile,
+		flagReposFile,
+		flagGitHubAPI,
 	)
 	if err != nil {
 		log.Fatal(err)
@@ -0,0 +1,171 @@
+package dashboard
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+)
+
+// AckedUpdates holds the IDs of EN commits that were acknowledged as not requiring
+// a translation update, keyed by the lang path of the translated file.
+type AckedUpdates map[string][]string
+
+// IsAcked checks whether the EN commit was acknowledged for the given lang path.
+func (a AckedUpdates) IsAcked(langPath, commitID string) bool {
+	return slices.Contains(a[langPath], commitID)
+}
+
+func LangAckedUpdatesBucket(langCode string) string {
+	return fmt.Sprintf("lang/%s/acked-updates", langCode)
+}
+
+func LangAckedUpdatesKey() string {
+	return singleCacheKey
+}
+
+// AckStore persists acknowledged EN updates per language.
+type AckStore struct {
+	mu           sync.Mutex
+	cacheStorage CacheStorage
+}
+
+func NewAckStore(cacheStorage CacheStorage) *AckStore {
+	//nolint:exhaustruct
+	return &AckStore{
+		cacheStorage: cacheStorage,
+	}
+}
+
+func (s *AckStore) ReadAckedUpdates(langCode string) (AckedUpdates, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.read(langCode)
+}
+
+// Ack marks the EN commit as not requiring an update of the lang file.
+func (s *AckStore) Ack(langCode, langPath, commitID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ackedUpdates, err := s.read(langCode)
+	if err != nil {
+		return err
+	}
+
+	if ackedUpdates.IsAcked(langPath, commitID) {
+		return nil
+	}
+
+	ackedUpdates[langPath] = append(ackedUpdates[langPath], commitID)
+
+	return s.write(langCode, ackedUpdates)
+}
+
+// Unack reverts Ack.
+func (s *AckStore) Unack(langCode, langPath, commitID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ackedUpdates, err := s.read(langCode)
+	if err != nil {
+		return err
+	}
+
+	if !ackedUpdates.IsAcked(langPath, commitID) {
+		return nil
+	}
+
+	commitIDs := slices.DeleteFunc(ackedUpdates[langPath], func(ackedCommitID string) bool {
+		return ackedCommitID == commitID
+	})
+	if len(commitIDs) == 0 {
+		delete(ackedUpdates, langPath)
+	} else {
+		ackedUpdates[langPath] = commitIDs
+	}
+
+	return s.write(langCode, ackedUpdates)
+}
+
+func (s *AckStore) read(langCode string) (AckedUpdates, error) {
+	bucket := LangAckedUpdatesBucket(langCode)
+	key := LangAckedUpdatesKey()
+
+	var ackedUpdates AckedUpdates
+
+	found, err := s.cacheStorage.Read(bucket, key, &ackedUpdates)
+	if err != nil {
+		return nil, fmt.Errorf("read acked updates from cache store bucket=%q key=%q: %w", bucket, key, err)
+	}
+
+	if !found || ackedUpdates == nil {
+		return AckedUpdates{}, nil
+	}
+
+	return ackedUpdates, nil
+}
+
+func (s *AckStore) write(langCode string, ackedUpdates AckedUpdates) error {
+	bucket := LangAckedUpdatesBucket(langCode)
+	key := LangAckedUpdatesKey()
+
+	if err := s.cacheStorage.Write(bucket, key, ackedUpdates); err != nil {
+		return fmt.Errorf("write acked updates to cache store bucket=%q key=%q: %w", bucket, key, err)
+	}
+
+	return nil
+}
+
+// ApplyAckedUpdates moves acknowledged EN updates of every item to the AckedEnUpdates
+// group and moves no longer acknowledged ones back, so it can be applied again
+// to an already processed dashboard.
+//
+// A file whose all EN updates are acknowledged is reported as up to date.
+func ApplyAckedUpdates(dashboard Dashboard, ackedUpdates AckedUpdates) Dashboard {
+	items := make([]Item, 0, len(dashboard.Items))
+	for _, item := range dashboard.Items {
+		items = append(items, applyItemAckedUpdates(item, ackedUpdates))
+	}
+
+	return Dashboard{
+		LangCode:       dashboard.LangCode,
+		Items:          items,
+		ExcludedCounts: dashboard.ExcludedCounts,
+	}
+}
+
+func applyItemAckedUpdates(item Item, ackedUpdates AckedUpdates) Item {
+	allUpdates := item.EnUpdates
+	if len(item.AckedEnUpdates) > 0 {
+		allUpdates = append(slices.Clone(item.EnUpdates), item.AckedEnUpdates...)
+		slices.SortStableFunc(allUpdates, func(a, b gitseek.EnUpdate) int {
+			return strings.Compare(b.Commit.DateTime, a.Commit.DateTime)
+		})
+	}
+
+	var enUpdates, ackedEnUpdates []gitseek.EnUpdate
+
+	for _, enUpdate := range allUpdates {
+		if ackedUpdates.IsAcked(item.LangPath, enUpdate.Commit.CommitID) {
+			ackedEnUpdates = append(ackedEnUpdates, enUpdate)
+		} else {
+			enUpdates = append(enUpdates, enUpdate)
+		}
+	}
+
+	item.EnUpdates = enUpdates
+	item.AckedEnUpdates = ackedEnUpdates
+
+	switch {
+	case item.FileStatus == gitseek.StatusEnFileUpdated && len(enUpdates) == 0 && len(ackedEnUpdates) > 0:
+		item.FileStatus = gitseek.StatusLangFileUpToDate
+	case item.FileStatus == gitseek.StatusLangFileUpToDate && len(enUpdates) > 0:
+		item.FileStatus = gitseek.StatusEnFileUpdated
+	}
+
+	return item
+}
@@ -0,0 +1,139 @@
+package dashboard_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dkarczmarski/go-kweb-lang/dashboard"
+	"github.com/dkarczmarski/go-kweb-lang/git"
+	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+)
+
+func TestAckStore_AckUnack(t *testing.T) {
+	t.Parallel()
+
+	store := dashboard.NewAckStore(newFakeCacheStorage())
+
+	for _, commitID := range []string{"en1", "en2", "en1"} {
+		if err := store.Ack("pl", "content/pl/a.md", commitID); err != nil {
+			t.Fatalf("Ack returned error: %v", err)
+		}
+	}
+
+	got, err := store.ReadAckedUpdates("pl")
+	if err != nil {
+		t.Fatalf("ReadAckedUpdates returned error: %v", err)
+	}
+
+	want := dashboard.AckedUpdates{"content/pl/a.md": {"en1", "en2"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %#v, got %#v", want, got)
+	}
+
+	for _, commitID := range []string{"en1", "en2"} {
+		if err := store.Unack("pl", "content/pl/a.md", commitID); err != nil {
+			t.Fatalf("Unack returned error: %v", err)
+		}
+	}
+
+	got, err = store.ReadAckedUpdates("pl")
+	if err != nil {
+		t.Fatalf("ReadAckedUpdates returned error: %v", err)
+	}
+
+	if len(got) != 0 {
+		t.Fatalf("expected no acked updates, got %#v", got)
+	}
+}
+
+func TestAckStore_ReadAckedUpdates_NotFound(t *testing.T) {
+	t.Parallel()
+
+	store := dashboard.NewAckStore(newFakeCacheStorage())
+
+	got, err := store.ReadAckedUpdates("pl")
+	if err != nil {
+		t.Fatalf("ReadAckedUpdates returned error: %v", err)
+	}
+
+	if got == nil || len(got) != 0 {
+		t.Fatalf("expected empty acked updates, got %#v", got)
+	}
+}
+
+func TestApplyAckedUpdates(t *testing.T) {
+	t.Parallel()
+
+	enUpdate := func(commitID, dateTime string) gitseek.EnUpdate {
+		//nolint:exhaustruct
+		return gitseek.EnUpdate{Commit: git.CommitInfo{CommitID: commitID, DateTime: dateTime}}
+	}
+
+	original := dashboard.Dashboard{
+		LangCode: "pl",
+		Items: []dashboard.Item{
+			{
+				FileInfo: gitseek.FileInfo{
+					LangPath:   "content/pl/a.md",
+					FileStatus: gitseek.StatusEnFileUpdated,
+					EnUpdates: []gitseek.EnUpdate{
+						enUpdate("en3", "2023-01-03T10:00:00+00:00"),
+						enUpdate("en2", "2023-01-02T10:00:00+00:00"),
+						enUpdate("en1", "2023-01-01T10:00:00+00:00"),
+					},
+				},
+			},
+			{
+				FileInfo: gitseek.FileInfo{
+					LangPath:   "content/pl/b.md",
+					FileStatus: gitseek.StatusEnFileUpdated,
+					EnUpdates: []gitseek.EnUpdate{
+						enUpdate("en4", "2023-01-04T10:00:00+00:00"),
+					},
+				},
+			},
+		},
+		ExcludedCounts: map[string]int{"content": 2},
+	}
+
+	ackedUpdates := dashboard.AckedUpdates{
+		"content/pl/a.md": {"en1", "en3"},
+		"content/pl/b.md": {"en4"},
+	}
+
+	acked := dashboard.ApplyAckedUpdates(original, ackedUpdates)
+
+	if !reflect.DeepEqual(acked.ExcludedCounts, original.ExcludedCounts) {
+		t.Fatalf("expected excluded counts %#v, got %#v", original.ExcludedCounts, acked.ExcludedCounts)
+	}
+
+	itemA := acked.Items[0]
+	if itemA.FileStatus != gitseek.StatusEnFileUpdated {
+		t.Fatalf("expected status %q, got %q", gitseek.StatusEnFileUpdated, itemA.FileStatus)
+	}
+
+	if len(itemA.EnUpdates) != 1 || itemA.EnUpdates[0].Commit.CommitID != "en2" {
+		t.Fatalf("unexpected EN updates: %#v", itemA.EnUpdates)
+	}
+
+	if len(itemA.AckedEnUpdates) != 2 ||
+		itemA.AckedEnUpdates[0].Commit.CommitID != "en3" ||
+		itemA.AckedEnUpdates[1].Commit.CommitID != "en1" {
+		t.Fatalf("unexpected acked EN updates: %#v", itemA.AckedEnUpdates)
+	}
+
+	itemB := acked.Items[1]
+	if itemB.FileStatus != gitseek.StatusLangFileUpToDate {
+		t.Fatalf("expected status %q, got %q", gitseek.StatusLangFileUpToDate, itemB.FileStatus)
+	}
+
+	if len(itemB.EnUpdates) != 0 || len(itemB.AckedEnUpdates) != 1 {
+		t.Fatalf("unexpected updates: %#v / %#v", itemB.EnUpdates, itemB.AckedEnUpdates)
+	}
+
+	restored := dashboard.ApplyAckedUpdates(acked, dashboard.AckedUpdates{})
+
+	if !reflect.DeepEqual(restored, original) {
+		t.Fatalf("expected unacking to restore the dashboard:\n got:  %#v\nwant: %#v", restored, original)
+	}
+}
@@ -1,13 +1,74 @@
 package dashboard
 
-import "github.com/dkarczmarski/go-kweb-lang/gitseek"
+import (
+	"slices"
+
+	"github.com/dkarczmarski/go-kweb-lang/alignment"
+	"github.com/dkarczmarski/go-kweb-lang/frontmatter"
+	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+	"github.com/dkarczmarski/go-kweb-lang/i18nkeys"
+	"github.com/dkarczmarski/go-kweb-lang/prpreview"
+	"github.com/dkarczmarski/go-kweb-lang/pullreq"
+	"github.com/dkarczmarski/go-kweb-lang/untranslated"
+)
 
 type Dashboard struct {
 	LangCode string
 	Items    []Item
+
+	// ExcludedCounts holds the number of file pairs excluded from the dashboard, by pair provider name.
+	ExcludedCounts map[string]int
 }
 
 type Item struct {
 	gitseek.FileInfo
 	PRs []int
+
+	// PRInfos describes the pull requests of PRs whose details are known.
+	PRInfos []pullreq.PRInfo
+
+	// EnPath is the path to the EN file the language file is paired with.
+	EnPath string
+
+	// AckedEnUpdates holds EN updates acknowledged as not requiring a translation update.
+	AckedEnUpdates []gitseek.EnUpdate
+
+	// PRPreviews describes the file at the heads of its pull requests, as if they were merged.
+	PRPreviews []prpreview.FilePreview
+
+	// I18NKeyChanges lists the keys that need attention if the file is an i18n file.
+	I18NKeyChanges []i18nkeys.KeyChange
+
+	// FrontMatterMismatches lists the non-translatable front matter fields
+	// whose values differ from the EN file.
+	FrontMatterMismatches []frontmatter.Mismatch
+
+	// Misalignment is the first line where the file is not line-aligned
+	// with the EN file it was translated from, nil if it is aligned.
+	Misalignment *alignment.Misalignment
+
+	// UntranslatedContent is the share of the file that is identical to the EN file,
+	// nil if it is below the reported minimum.
+	UntranslatedContent *untranslated.Content
+}
+
+// HasApprovedPR reports whether any pull request of the file is approved. As only open
+// pull requests are indexed, such a pull request is approved but not merged yet.
+func (item Item) HasApprovedPR() bool {
+	return slices.ContainsFunc(item.PRInfos, func(prInfo pullreq.PRInfo) bool {
+		return prInfo.Approved
+	})
+}
+
+// HasDraftPR reports whether any pull request of the file is a draft.
+func (item Item) HasDraftPR() bool {
+	return slices.ContainsFunc(item.PRInfos, func(prInfo pullreq.PRInfo) bool {
+		return prInfo.Draft
+	})
+}
+
+// HasFindings reports whether the content checks found problems in the file.
+func (item Item) HasFindings() bool {
+	return len(item.I18NKeyChanges) > 0 || len(item.FrontMatterMismatches) > 0 || item.Misalignment != nil ||
+		item.UntranslatedContent != nil
 }
@@ -3,6 +3,7 @@ package dashboard
 import (
 	"github.com/dkarczmarski/go-kweb-lang/git"
 	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+	"github.com/dkarczmarski/go-kweb-lang/prpreview"
 	"github.com/dkarczmarski/go-kweb-lang/pullreq"
 )
 
@@ -14,22 +15,30 @@ func BuildDashboard(
 	langCode string,
 	seekerFileInfos []gitseek.FileInfo,
 	prIndex pullreq.FilePRIndexData,
+	prInfos pullreq.PRInfoIndexData,
+	prPreviews prpreview.LangPreviews,
+	ackedUpdates AckedUpdates,
 ) Dashboard {
 	items := make([]Item, 0, len(seekerFileInfos))
 
 	for _, seekerFileInfo := range seekerFileInfos {
-		prs := prIndex[seekerFileInfo.LangPath]
+		prs := prIndex.PRNumbers(seekerFileInfo.LangPath)
 
 		item := Item{
-			FileInfo: seekerFileInfo,
-			PRs:      prs,
+			FileInfo:       seekerFileInfo,
+			PRs:            prs,
+			PRInfos:        findPRInfos(prInfos, prs),
+			AckedEnUpdates: nil,
+			PRPreviews:     prPreviews[seekerFileInfo.LangPath],
 		}
 
 		items = append(items, item)
 	}
 
-	for prFilePath, prs := range prIndex {
+	for prFilePath := range prIndex {
 		if !containsItem(items, prFilePath) {
+			prs := prIndex.PRNumbers(prFilePath)
+
 			items = append(items, Item{
 				FileInfo: gitseek.FileInfo{
 					LangPath:        prFilePath,
@@ -39,15 +48,19 @@ func BuildDashboard(
 					LangForkCommit:  nil,
 					EnUpdates:       nil,
 				},
-				PRs: prs,
+				PRs:            prs,
+				PRInfos:        findPRInfos(prInfos, prs),
+				AckedEnUpdates: nil,
+				PRPreviews:     prPreviews[prFilePath],
 			})
 		}
 	}
 
-	return Dashboard{
-		LangCode: langCode,
-		Items:    items,
-	}
+	return ApplyAckedUpdates(Dashboard{
+		LangCode:       langCode,
+		Items:          items,
+		ExcludedCounts: nil,
+	}, ackedUpdates)
 }
 
 func containsItem(items []Item, fileRelPath string) bool {
@@ -59,3 +72,17 @@ func containsItem(items []Item, fileRelPath string) bool {
 
 	return false
 }
+
+// findPRInfos returns the details of the pull requests in the order of prs,
+// skipping the pull requests whose details are not known.
+func findPRInfos(prInfos pullreq.PRInfoIndexData, prs []int) []pullreq.PRInfo {
+	var result []pullreq.PRInfo
+
+	for _, prNumber := range prs {
+		if prInfo, ok := prInfos[prNumber]; ok {
+			result = append(result, prInfo)
+		}
+	}
+
+	return result
+}
@@ -2,9 +2,11 @@
 package dashboard
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+	"github.com/dkarczmarski/go-kweb-lang/prpreview"
 	"github.com/dkarczmarski/go-kweb-lang/pullreq"
 )
 
@@ -26,10 +28,10 @@ func TestBuildDashboard(t *testing.T) {
 		}
 
 		prIndex := pullreq.FilePRIndexData{
-			"content/pl/b.md": {101, 102},
+			"content/pl/b.md": {{Number: 101}, {Number: 102}},
 		}
 
-		got := BuildDashboard("pl", seekerFileInfos, prIndex)
+		got := BuildDashboard("pl", seekerFileInfos, prIndex, nil, nil, nil)
 
 		if got.LangCode != "pl" {
 			t.Fatalf("expected lang code pl, got %q", got.LangCode)
@@ -71,10 +73,10 @@ func TestBuildDashboard(t *testing.T) {
 		}
 
 		prIndex := pullreq.FilePRIndexData{
-			"content/pl/missing.md": {555},
+			"content/pl/missing.md": {{Number: 555}},
 		}
 
-		got := BuildDashboard("pl", seekerFileInfos, prIndex)
+		got := BuildDashboard("pl", seekerFileInfos, prIndex, nil, nil, nil)
 
 		if len(got.Items) != 2 {
 			t.Fatalf("expected 2 items, got %d", len(got.Items))
@@ -106,10 +108,10 @@ func TestBuildDashboard(t *testing.T) {
 		}
 
 		prIndex := pullreq.FilePRIndexData{
-			"content/pl/a.md": {123},
+			"content/pl/a.md": {{Number: 123}},
 		}
 
-		got := BuildDashboard("pl", seekerFileInfos, prIndex)
+		got := BuildDashboard("pl", seekerFileInfos, prIndex, nil, nil, nil)
 
 		if len(got.Items) != 1 {
 			t.Fatalf("expected 1 item, got %d", len(got.Items))
@@ -123,6 +125,70 @@ func TestBuildDashboard(t *testing.T) {
 			t.Fatalf("unexpected PRs: %#v", got.Items[0].PRs)
 		}
 	})
+
+	t.Run("adds pr previews to items matched by lang path", func(t *testing.T) {
+		t.Parallel()
+
+		seekerFileInfos := []gitseek.FileInfo{
+			{
+				LangPath:   "content/pl/a.md",
+				FileStatus: "up-to-date",
+			},
+		}
+
+		prIndex := pullreq.FilePRIndexData{
+			"content/pl/a.md":       {{Number: 123}},
+			"content/pl/missing.md": {{Number: 555}},
+		}
+
+		prPreviews := prpreview.LangPreviews{
+			"content/pl/a.md": {
+				{PRNumber: 123, FileInfo: gitseek.FileInfo{FileStatus: gitseek.StatusLangFileUpToDate}},
+			},
+			"content/pl/missing.md": {
+				{PRNumber: 555, FileInfo: gitseek.FileInfo{FileStatus: gitseek.StatusEnFileUpdated}},
+			},
+		}
+
+		got := BuildDashboard("pl", seekerFileInfos, prIndex, nil, prPreviews, nil)
+
+		if len(got.Items) != 2 {
+			t.Fatalf("expected 2 items, got %d", len(got.Items))
+		}
+
+		for _, item := range got.Items {
+			if len(item.PRPreviews) != 1 || item.PRPreviews[0].PRNumber != prIndex[item.LangPath][0].Number {
+				t.Fatalf("unexpected PR previews of %s: %#v", item.LangPath, item.PRPreviews)
+			}
+		}
+	})
+
+	t.Run("adds known pr details in the order of prs", func(t *testing.T) {
+		t.Parallel()
+
+		seekerFileInfos := []gitseek.FileInfo{
+			{
+				LangPath:   "content/pl/a.md",
+				FileStatus: "up-to-date",
+			},
+		}
+
+		prIndex := pullreq.FilePRIndexData{
+			"content/pl/a.md": {{Number: 125}, {Number: 124}, {Number: 123}},
+		}
+
+		prInfos := pullreq.PRInfoIndexData{
+			123: {Number: 123, Title: "[pl] a", Approved: true},
+			125: {Number: 125, Title: "[pl] a again", Draft: true},
+		}
+
+		got := BuildDashboard("pl", seekerFileInfos, prIndex, prInfos, nil, nil)
+
+		want := []pullreq.PRInfo{prInfos[125], prInfos[123]}
+		if !reflect.DeepEqual(got.Items[0].PRInfos, want) {
+			t.Fatalf("unexpected PR details: %#v", got.Items[0].PRInfos)
+		}
+	})
 }
 
 func TestContainsItem(t *testing.T) {
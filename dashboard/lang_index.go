@@ -6,4 +6,8 @@ type LangIndex struct {
 
 type LangIndexItem struct {
 	LangCode string
+	// LangName is the display name of the language, empty if unknown.
+	LangName   string
+	ContentDir string
+	Weight     int
 }
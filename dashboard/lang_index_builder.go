@@ -1,21 +1,28 @@
 package dashboard
 
-import "fmt"
+import (
+	"fmt"
 
-type LangCodesProvider interface {
-	LangCodes() ([]string, error)
+	"github.com/dkarczmarski/go-kweb-lang/langcnt"
+)
+
+type LanguagesProvider interface {
+	Languages() ([]langcnt.Language, error)
 }
 
-func BuildLangIndex(langCodesProvider LangCodesProvider) (LangIndex, error) {
-	langCodes, err := langCodesProvider.LangCodes()
+func BuildLangIndex(languagesProvider LanguagesProvider) (LangIndex, error) {
+	languages, err := languagesProvider.Languages()
 	if err != nil {
 		return LangIndex{}, fmt.Errorf("failed to get available languages: %w", err)
 	}
 
-	items := make([]LangIndexItem, 0, len(langCodes))
-	for _, langCode := range langCodes {
+	items := make([]LangIndexItem, 0, len(languages))
+	for _, language := range languages {
 		items = append(items, LangIndexItem{
-			LangCode: langCode,
+			LangCode:   language.Code,
+			LangName:   language.Name,
+			ContentDir: language.ContentDir,
+			Weight:     language.Weight,
 		})
 	}
 
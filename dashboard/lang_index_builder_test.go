@@ -3,21 +3,24 @@ package dashboard
 
 import (
 	"errors"
+	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/dkarczmarski/go-kweb-lang/langcnt"
 )
 
-type stubLangCodesProvider struct {
-	langCodes []string
+type stubLanguagesProvider struct {
+	languages []langcnt.Language
 	err       error
 }
 
-func (s stubLangCodesProvider) LangCodes() ([]string, error) {
+func (s stubLanguagesProvider) Languages() ([]langcnt.Language, error) {
 	if s.err != nil {
 		return nil, s.err
 	}
 
-	return s.langCodes, nil
+	return s.languages, nil
 }
 
 func TestBuildLangIndex(t *testing.T) {
@@ -26,8 +29,8 @@ func TestBuildLangIndex(t *testing.T) {
 	t.Run("builds lang index from provider", func(t *testing.T) {
 		t.Parallel()
 
-		provider := stubLangCodesProvider{
-			langCodes: []string{"pl", "de", "fr"},
+		provider := stubLanguagesProvider{
+			languages: []langcnt.Language{{Code: "pl"}, {Code: "de"}, {Code: "fr"}},
 		}
 
 		got, err := BuildLangIndex(provider)
@@ -52,11 +55,33 @@ func TestBuildLangIndex(t *testing.T) {
 		}
 	})
 
+	t.Run("copies language metadata", func(t *testing.T) {
+		t.Parallel()
+
+		provider := stubLanguagesProvider{
+			languages: []langcnt.Language{
+				{Code: "pl", Name: "Polski (Polish)", ContentDir: "content/pl", Weight: 7},
+			},
+		}
+
+		got, err := BuildLangIndex(provider)
+		if err != nil {
+			t.Fatalf("BuildLangIndex returned error: %v", err)
+		}
+
+		want := []LangIndexItem{
+			{LangCode: "pl", LangName: "Polski (Polish)", ContentDir: "content/pl", Weight: 7},
+		}
+		if !reflect.DeepEqual(got.Items, want) {
+			t.Fatalf("unexpected items\nactual:   %+v\nexpected: %+v", got.Items, want)
+		}
+	})
+
 	t.Run("returns wrapped error when provider fails", func(t *testing.T) {
 		t.Parallel()
 
 		providerErr := errors.New("boom")
-		provider := stubLangCodesProvider{
+		provider := stubLanguagesProvider{
 			err: providerErr,
 		}
 
@@ -81,8 +106,8 @@ func TestBuildLangIndex(t *testing.T) {
 	t.Run("returns empty index for empty provider result", func(t *testing.T) {
 		t.Parallel()
 
-		provider := stubLangCodesProvider{
-			langCodes: []string{},
+		provider := stubLanguagesProvider{
+			languages: []langcnt.Language{},
 		}
 
 		got, err := BuildLangIndex(provider)
@@ -1,6 +1,7 @@
 package dashboard_test
 
 import (
+	"maps"
 	"testing"
 
 	"github.com/dkarczmarski/go-kweb-lang/dashboard"
@@ -121,6 +122,8 @@ func (s *fakeCacheStorage) Read(bucket, key string, buff any) (bool, error) {
 		*out = *(value.(*dashboard.Dashboard))
 	case *dashboard.LangIndex:
 		*out = *(value.(*dashboard.LangIndex))
+	case *dashboard.AckedUpdates:
+		*out = maps.Clone(value.(dashboard.AckedUpdates))
 	default:
 		panic("unsupported buffer type")
 	}
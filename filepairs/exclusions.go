@@ -0,0 +1,232 @@
+package filepairs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+)
+
+var ErrInvalidExclusionPattern = errors.New("invalid exclusion pattern")
+
+// ExclusionRules holds gitignore-style patterns of files excluded from pairing.
+// Patterns apply to all languages, LangPatterns only to the given language and
+// are evaluated after Patterns, so they can re-include files with "!".
+type ExclusionRules struct {
+	Patterns     []string            `json:"patterns"`
+	LangPatterns map[string][]string `json:"langs,omitempty"`
+}
+
+// DefaultExclusionRules returns the exclusion rules used when no exclusion file is set.
+func DefaultExclusionRules() ExclusionRules {
+	//nolint:exhaustruct
+	return ExclusionRules{
+		Patterns: []string{"OWNERS"},
+	}
+}
+
+// LoadExclusionRules reads exclusion rules from a JSON file.
+func LoadExclusionRules(path string) (ExclusionRules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ExclusionRules{}, fmt.Errorf("read exclusion file %s: %w", path, err)
+	}
+
+	var rules ExclusionRules
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return ExclusionRules{}, fmt.Errorf("parse exclusion file %s: %w", path, err)
+	}
+
+	return rules, nil
+}
+
+// Exclusions decides which files are excluded from pairing.
+//
+// Patterns follow the .gitignore syntax and are matched against paths relative
+// to the repository root:
+//   - blank patterns and patterns starting with # are ignored,
+//   - a leading ! negates the pattern, so that a previously excluded file is included again,
+//   - a trailing / matches only directories (and so all files below them),
+//   - a pattern without a slash matches a file or a directory name at any depth,
+//   - a pattern with a slash is anchored to the repository root,
+//   - *, ? and [...] match within a path segment and ** matches any number of segments.
+//
+// As in .gitignore, the last matching pattern decides.
+type Exclusions struct {
+	patterns     []ignorePattern
+	langPatterns map[string][]ignorePattern
+}
+
+func NewExclusions(rules ExclusionRules) (*Exclusions, error) {
+	patterns, err := parseIgnorePatterns(rules.Patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	langPatterns := make(map[string][]ignorePattern, len(rules.LangPatterns))
+
+	for langCode, lines := range rules.LangPatterns {
+		parsed, err := parseIgnorePatterns(lines)
+		if err != nil {
+			return nil, fmt.Errorf("lang %s: %w", langCode, err)
+		}
+
+		langPatterns[langCode] = parsed
+	}
+
+	return &Exclusions{
+		patterns:     patterns,
+		langPatterns: langPatterns,
+	}, nil
+}
+
+// IsExcluded reports whether the file is excluded for the language.
+// A nil Exclusions excludes nothing.
+func (e *Exclusions) IsExcluded(filePath string, langCode string) bool {
+	if e == nil {
+		return false
+	}
+
+	segments := splitPath(filePath)
+	excluded := false
+
+	for _, pattern := range e.patterns {
+		if pattern.match(segments) {
+			excluded = !pattern.negate
+		}
+	}
+
+	for _, pattern := range e.langPatterns[langCode] {
+		if pattern.match(segments) {
+			excluded = !pattern.negate
+		}
+	}
+
+	return excluded
+}
+
+// IsPairExcluded reports whether the EN file or the language file of the pair is excluded.
+func (e *Exclusions) IsPairExcluded(pair Pair, langCode string) bool {
+	return e.IsExcluded(pair.EnPath, langCode) || e.IsExcluded(pair.LangPath, langCode)
+}
+
+type ignorePattern struct {
+	negate   bool
+	dirOnly  bool
+	anchored bool
+	segments []string
+}
+
+func parseIgnorePatterns(lines []string) ([]ignorePattern, error) {
+	patterns := make([]ignorePattern, 0, len(lines))
+
+	for _, line := range lines {
+		pattern, ok, err := parseIgnorePattern(line)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			patterns = append(patterns, pattern)
+		}
+	}
+
+	return patterns, nil
+}
+
+func parseIgnorePattern(line string) (ignorePattern, bool, error) {
+	//nolint:exhaustruct
+	pattern := ignorePattern{}
+
+	text := strings.TrimSpace(line)
+	if text == "" || strings.HasPrefix(text, "#") {
+		return pattern, false, nil
+	}
+
+	switch {
+	case strings.HasPrefix(text, "!"):
+		pattern.negate = true
+		text = text[1:]
+	case strings.HasPrefix(text, `\!`), strings.HasPrefix(text, `\#`):
+		text = text[1:]
+	}
+
+	if strings.HasSuffix(text, "/") {
+		pattern.dirOnly = true
+		text = strings.TrimRight(text, "/")
+	}
+
+	if strings.HasPrefix(text, "/") {
+		pattern.anchored = true
+		text = strings.TrimLeft(text, "/")
+	}
+
+	if text == "" {
+		return pattern, false, fmt.Errorf("%w: %q", ErrInvalidExclusionPattern, line)
+	}
+
+	if strings.Contains(text, "/") {
+		pattern.anchored = true
+	}
+
+	pattern.segments = strings.Split(text, "/")
+
+	for _, segment := range pattern.segments {
+		if _, err := path.Match(segment, ""); err != nil {
+			return pattern, false, fmt.Errorf("%w: %q: %w", ErrInvalidExclusionPattern, line, err)
+		}
+	}
+
+	return pattern, true, nil
+}
+
+// match reports whether the pattern matches the file or one of its parent directories.
+func (p ignorePattern) match(segments []string) bool {
+	if !p.anchored {
+		for idx, segment := range segments {
+			isFile := idx == len(segments)-1
+			if p.dirOnly && isFile {
+				continue
+			}
+
+			if ok, _ := path.Match(p.segments[0], segment); ok {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return matchSegmentsPrefix(p.segments, segments, !p.dirOnly)
+}
+
+// matchSegmentsPrefix reports whether the pattern segments match a leading part of the
+// path segments. The leading part is a directory, unless it is the whole path, which
+// is allowed only if matchWhole is true.
+func matchSegmentsPrefix(pattern []string, segments []string, matchWhole bool) bool {
+	if len(pattern) == 0 {
+		return len(segments) > 0 || matchWhole
+	}
+
+	if pattern[0] == "**" {
+		for skip := 0; skip <= len(segments); skip++ {
+			if matchSegmentsPrefix(pattern[1:], segments[skip:], matchWhole) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if len(segments) == 0 {
+		return false
+	}
+
+	if ok, _ := path.Match(pattern[0], segments[0]); !ok {
+		return false
+	}
+
+	return matchSegmentsPrefix(pattern[1:], segments[1:], matchWhole)
+}
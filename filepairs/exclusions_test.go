@@ -0,0 +1,142 @@
+package filepairs_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/dkarczmarski/go-kweb-lang/filepairs"
+)
+
+func TestExclusions_IsExcluded(t *testing.T) {
+	t.Parallel()
+
+	exclusions, err := filepairs.NewExclusions(filepairs.ExclusionRules{
+		Patterns: []string{
+			"# comment",
+			"",
+			"OWNERS",
+			"_redirects",
+			"*.png",
+			"vendor/",
+			"/static/",
+			"content/*/docs/generated/**/*.md",
+			"!content/*/docs/generated/keep.md",
+			`\!important.md`,
+		},
+		LangPatterns: map[string][]string{
+			"pl": {
+				"/content/pl/blog/",
+				"!content/**/logo.png",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewExclusions() error = %v", err)
+	}
+
+	tests := []struct {
+		path     string
+		langCode string
+		want     bool
+	}{
+		{path: "content/pl/OWNERS", langCode: "pl", want: true},
+		{path: "content/pl/docs/concepts/OWNERS", langCode: "pl", want: true},
+		{path: "content/pl/_redirects", langCode: "pl", want: true},
+		{path: "content/pl/docs/images/diagram.png", langCode: "pl", want: true},
+		{path: "content/pl/docs/vendor/lib/page.md", langCode: "pl", want: true},
+		{path: "content/pl/docs/vendor", langCode: "pl", want: false},
+		{path: "static/images/logo.svg", langCode: "pl", want: true},
+		{path: "content/pl/static/page.md", langCode: "pl", want: false},
+		{path: "content/pl/docs/generated/page.md", langCode: "pl", want: true},
+		{path: "content/pl/docs/generated/a/b/page.md", langCode: "pl", want: true},
+		{path: "content/pl/docs/generated/keep.md", langCode: "pl", want: false},
+		{path: "content/pl/docs/!important.md", langCode: "pl", want: true},
+		{path: "content/pl/blog/post.md", langCode: "pl", want: true},
+		{path: "content/de/blog/post.md", langCode: "de", want: false},
+		{path: "content/pl/docs/logo.png", langCode: "pl", want: false},
+		{path: "content/de/docs/logo.png", langCode: "de", want: true},
+		{path: "content/pl/docs/page.md", langCode: "pl", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := exclusions.IsExcluded(tt.path, tt.langCode); got != tt.want {
+			t.Errorf("IsExcluded(%q, %q) = %v, want %v", tt.path, tt.langCode, got, tt.want)
+		}
+	}
+}
+
+func TestExclusions_IsPairExcluded(t *testing.T) {
+	t.Parallel()
+
+	exclusions, err := filepairs.NewExclusions(filepairs.ExclusionRules{
+		LangPatterns: map[string][]string{
+			"pl": {"/content/pl/blog/"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewExclusions() error = %v", err)
+	}
+
+	pair := filepairs.Pair{EnPath: "content/en/blog/post.md", LangPath: "content/pl/blog/post.md"}
+
+	if !exclusions.IsPairExcluded(pair, "pl") {
+		t.Fatal("expected pair with excluded lang path to be excluded")
+	}
+
+	if exclusions.IsPairExcluded(filepairs.Pair{EnPath: pair.EnPath, LangPath: "content/de/blog/post.md"}, "de") {
+		t.Fatal("expected pair of another language not to be excluded")
+	}
+}
+
+func TestExclusions_Nil(t *testing.T) {
+	t.Parallel()
+
+	var exclusions *filepairs.Exclusions
+
+	if exclusions.IsExcluded("content/pl/OWNERS", "pl") {
+		t.Fatal("expected nil exclusions to exclude nothing")
+	}
+}
+
+func TestNewExclusions_InvalidPattern(t *testing.T) {
+	t.Parallel()
+
+	for _, rules := range []filepairs.ExclusionRules{
+		{Patterns: []string{"content/[pl"}},
+		{Patterns: []string{"/"}},
+		{LangPatterns: map[string][]string{"pl": {"!"}}},
+	} {
+		_, err := filepairs.NewExclusions(rules)
+		if !errors.Is(err, filepairs.ErrInvalidExclusionPattern) {
+			t.Fatalf("NewExclusions(%#v) error = %v, want %v", rules, err, filepairs.ErrInvalidExclusionPattern)
+		}
+	}
+}
+
+func TestLoadExclusionRules(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "exclusions.json")
+
+	data := `{"patterns": ["OWNERS", "*.png"], "langs": {"pl": ["/content/pl/blog/"]}}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	got, err := filepairs.LoadExclusionRules(path)
+	if err != nil {
+		t.Fatalf("LoadExclusionRules() error = %v", err)
+	}
+
+	want := filepairs.ExclusionRules{
+		Patterns:     []string{"OWNERS", "*.png"},
+		LangPatterns: map[string][]string{"pl": {"/content/pl/blog/"}},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("LoadExclusionRules() = %#v, want %#v", got, want)
+	}
+}
@@ -10,15 +10,33 @@ import (
 var ErrPairMatcherNotFound = errors.New("pair matcher not found")
 
 type FilePaths struct {
-	pairMatchers []PairMatcher
+	pairMatchers   []PairMatcher
+	sourceLangCode string
 }
 
-func New() *FilePaths {
-	return &FilePaths{
-		pairMatchers: []PairMatcher{
+type FilePathsConfig struct {
+	// PairMatchers are checked in order and the first matching one is used.
+	// By default the built-in content and i18n matchers are used.
+	PairMatchers []PairMatcher
+	// SourceLangCode is the language code of the files that are translated. The default is "en".
+	SourceLangCode string
+}
+
+func New(opts ...func(config *FilePathsConfig)) *FilePaths {
+	config := FilePathsConfig{
+		PairMatchers: []PairMatcher{
 			ContentPairMatcher{},
 			I18NPairMatcher{},
 		},
+		SourceLangCode: defaultSourceLangCode,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return &FilePaths{
+		pairMatchers:   config.PairMatchers,
+		sourceLangCode: config.SourceLangCode,
 	}
 }
 
@@ -37,6 +55,7 @@ func (fp *FilePaths) CheckPath(path string) (*PathInfo, error) {
 				PairMatcherName: pairMatcher.Name(),
 				LangCode:        langCode,
 				pairMatcher:     pairMatcher,
+				sourceLangCode:  fp.sourceLangCode,
 			}, nil
 		}
 	}
@@ -44,6 +63,31 @@ func (fp *FilePaths) CheckPath(path string) (*PathInfo, error) {
 	return nil, fmt.Errorf("%w: %s", ErrPairMatcherNotFound, cleanPath)
 }
 
+// LangPath returns the language file path corresponding to the EN file path.
+func (fp *FilePaths) LangPath(enPath string, langCode string) (string, error) {
+	pathInfo, err := fp.CheckPath(enPath)
+	if err != nil {
+		return "", err
+	}
+
+	return pathInfo.LangPath(langCode)
+}
+
+// EnPath returns the source (EN) file path corresponding to the language file path.
+func (fp *FilePaths) EnPath(langPath string) (string, error) {
+	pathInfo, err := fp.CheckPath(langPath)
+	if err != nil {
+		return "", err
+	}
+
+	enPath, err := pathInfo.pairMatcher.LangPath(pathInfo.Path, fp.sourceLangCode)
+	if err != nil {
+		return "", fmt.Errorf("pair matcher %s: %w", pathInfo.PairMatcherName, err)
+	}
+
+	return enPath, nil
+}
+
 func splitPath(path string) []string {
 	return strings.Split(filepath.ToSlash(filepath.Clean(path)), "/")
 }
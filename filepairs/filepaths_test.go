@@ -120,3 +120,87 @@ func TestFilePaths_CheckPath_NotFound(t *testing.T) {
 		t.Fatalf("CheckPath() error = %v, want ErrPairMatcherNotFound", err)
 	}
 }
+
+func TestFilePaths_LangPath(t *testing.T) {
+	t.Parallel()
+
+	fp := filepairs.New()
+
+	got, err := fp.LangPath("content/en/docs/b/page.md", "pl")
+	if err != nil {
+		t.Fatalf("LangPath() error = %v", err)
+	}
+
+	if got != "content/pl/docs/b/page.md" {
+		t.Fatalf("LangPath() = %q, want %q", got, "content/pl/docs/b/page.md")
+	}
+}
+
+func TestFilePaths_LangPath_RequiresEnPath(t *testing.T) {
+	t.Parallel()
+
+	fp := filepairs.New()
+
+	_, err := fp.LangPath("content/de/docs/page.md", "pl")
+	if !errors.Is(err, filepairs.ErrLangPathRequiresEnPath) {
+		t.Fatalf("LangPath() error = %v, want %v", err, filepairs.ErrLangPathRequiresEnPath)
+	}
+}
+
+func TestFilePaths_EnPath(t *testing.T) {
+	t.Parallel()
+
+	fp := filepairs.New()
+
+	for _, tc := range []struct {
+		langPath string
+		want     string
+	}{
+		{langPath: "content/pl/docs/page.md", want: "content/en/docs/page.md"},
+		{langPath: "i18n/pl/pl.toml", want: "i18n/en/en.toml"},
+	} {
+		got, err := fp.EnPath(tc.langPath)
+		if err != nil {
+			t.Fatalf("EnPath(%q) error = %v", tc.langPath, err)
+		}
+
+		if got != tc.want {
+			t.Fatalf("EnPath(%q) = %q, want %q", tc.langPath, got, tc.want)
+		}
+	}
+}
+
+func TestFilePaths_SourceLangCode(t *testing.T) {
+	t.Parallel()
+
+	fp := filepairs.New(func(config *filepairs.FilePathsConfig) {
+		config.SourceLangCode = "de"
+	})
+
+	info, err := fp.CheckPath("content/de/docs/page.md")
+	if err != nil {
+		t.Fatalf("CheckPath() error = %v", err)
+	}
+
+	if !info.IsEnPath() {
+		t.Fatal("IsEnPath() = false, want true for the source language")
+	}
+
+	langPath, err := info.LangPath("en")
+	if err != nil {
+		t.Fatalf("LangPath() error = %v", err)
+	}
+
+	if langPath != "content/en/docs/page.md" {
+		t.Fatalf("LangPath() = %q, want %q", langPath, "content/en/docs/page.md")
+	}
+
+	sourcePath, err := fp.EnPath("content/en/docs/page.md")
+	if err != nil {
+		t.Fatalf("EnPath() error = %v", err)
+	}
+
+	if sourcePath != "content/de/docs/page.md" {
+		t.Fatalf("EnPath() = %q, want %q", sourcePath, "content/de/docs/page.md")
+	}
+}
@@ -11,12 +11,15 @@ const (
 	i18nPathPartsLen = 3
 )
 
+// I18NPairRuleName is the name of the built-in pair rule of the Hugo i18n files.
+const I18NPairRuleName = i18nDirPrefix
+
 var ErrInvalidI18NPath = errors.New("invalid i18n path")
 
 type I18NPairMatcher struct{}
 
 func (m I18NPairMatcher) Name() string {
-	return i18nDirPrefix
+	return I18NPairRuleName
 }
 
 func (m I18NPairMatcher) CheckPath(path string) (bool, string, error) {
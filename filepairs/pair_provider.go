@@ -1,5 +1,7 @@
 package filepairs
 
+import "context"
+
 // PairProvider lists file pairs for a given language.
 //
 // A pair connects an English (EN) file with its corresponding
@@ -13,5 +15,5 @@ type PairProvider interface {
 	Name() string
 
 	// ListPairs returns all file pairs for the given language code.
-	ListPairs(langCode string) ([]Pair, error)
+	ListPairs(ctx context.Context, langCode string) ([]Pair, error)
 }
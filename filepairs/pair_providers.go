@@ -1,27 +1,67 @@
 package filepairs
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 type PairProviders struct {
-	providers []PairProvider
+	providers  []PairProvider
+	exclusions *Exclusions
+}
+
+// LangPairs holds the pairs of a language listed by all pair providers.
+type LangPairs struct {
+	Pairs []Pair
+
+	// ExcludedCounts holds the number of pairs removed by the exclusions, by pair provider name.
+	ExcludedCounts map[string]int
 }
 
 func NewPairProviders(providers ...PairProvider) *PairProviders {
 	return &PairProviders{
-		providers: providers,
+		providers:  providers,
+		exclusions: nil,
 	}
 }
 
-func (p *PairProviders) ListPairs(langCode string) ([]Pair, error) {
-	var out []Pair
+// SetExclusions sets the exclusions applied to the pairs of every provider.
+func (p *PairProviders) SetExclusions(exclusions *Exclusions) {
+	p.exclusions = exclusions
+}
+
+func (p *PairProviders) ListPairs(ctx context.Context, langCode string) ([]Pair, error) {
+	langPairs, err := p.ListLangPairs(ctx, langCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return langPairs.Pairs, nil
+}
+
+// ListLangPairs lists the pairs of all providers that are not excluded and counts
+// the excluded ones.
+func (p *PairProviders) ListLangPairs(ctx context.Context, langCode string) (LangPairs, error) {
+	out := LangPairs{
+		Pairs:          nil,
+		ExcludedCounts: make(map[string]int),
+	}
 
 	for _, provider := range p.providers {
-		pairs, err := provider.ListPairs(langCode)
+		pairs, err := provider.ListPairs(ctx, langCode)
 		if err != nil {
-			return nil, fmt.Errorf("pair provider %s: %w", provider.Name(), err)
+			return LangPairs{}, fmt.Errorf("pair provider %s: %w", provider.Name(), err)
 		}
 
-		out = append(out, pairs...)
+		for _, pair := range pairs {
+			if p.exclusions.IsPairExcluded(pair, langCode) {
+				out.ExcludedCounts[provider.Name()]++
+
+				continue
+			}
+
+			out.Pairs = append(out.Pairs, pair)
+		}
 	}
 
 	return out, nil
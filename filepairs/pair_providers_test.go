@@ -1,7 +1,9 @@
 package filepairs_test
 
 import (
+	"context"
 	"errors"
+	"reflect"
 	"testing"
 
 	"github.com/dkarczmarski/go-kweb-lang/filepairs"
@@ -16,7 +18,7 @@ func (f fakePairProvider) Name() string {
 	return f.name
 }
 
-func (f fakePairProvider) ListPairs(langCode string) ([]filepairs.Pair, error) {
+func (f fakePairProvider) ListPairs(_ context.Context, langCode string) ([]filepairs.Pair, error) {
 	return f.listPairsFunc(langCode)
 }
 
@@ -46,7 +48,7 @@ func TestPairProviders_ListPairs(t *testing.T) {
 		},
 	)
 
-	got, err := providers.ListPairs("pl")
+	got, err := providers.ListPairs(t.Context(), "pl")
 	if err != nil {
 		t.Fatalf("ListPairs() error = %v", err)
 	}
@@ -81,8 +83,62 @@ func TestPairProviders_ListPairs_ProviderError(t *testing.T) {
 		},
 	)
 
-	_, err := providers.ListPairs("pl")
+	_, err := providers.ListPairs(t.Context(), "pl")
 	if err == nil {
 		t.Fatal("ListPairs() error = nil, want error")
 	}
 }
+
+func TestPairProviders_ListLangPairs_Exclusions(t *testing.T) {
+	t.Parallel()
+
+	exclusions, err := filepairs.NewExclusions(filepairs.ExclusionRules{
+		Patterns: []string{"OWNERS", "*.png"},
+		LangPatterns: map[string][]string{
+			"pl": {"/content/pl/docs/reference/"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewExclusions() error = %v", err)
+	}
+
+	providers := filepairs.NewPairProviders(
+		fakePairProvider{
+			name: "content",
+			listPairsFunc: func(_ string) ([]filepairs.Pair, error) {
+				return []filepairs.Pair{
+					{EnPath: "content/en/docs/a.md", LangPath: "content/pl/docs/a.md"},
+					{EnPath: "content/en/docs/OWNERS", LangPath: "content/pl/docs/OWNERS"},
+					{EnPath: "content/en/docs/img.png", LangPath: "content/pl/docs/img.png"},
+					{EnPath: "content/en/docs/reference/b.md", LangPath: "content/pl/docs/reference/b.md"},
+				}, nil
+			},
+		},
+		fakePairProvider{
+			name: "i18n",
+			listPairsFunc: func(_ string) ([]filepairs.Pair, error) {
+				return []filepairs.Pair{
+					{EnPath: "i18n/en/en.toml", LangPath: "i18n/pl/pl.toml"},
+				}, nil
+			},
+		},
+	)
+	providers.SetExclusions(exclusions)
+
+	got, err := providers.ListLangPairs(t.Context(), "pl")
+	if err != nil {
+		t.Fatalf("ListLangPairs() error = %v", err)
+	}
+
+	want := filepairs.LangPairs{
+		Pairs: []filepairs.Pair{
+			{EnPath: "content/en/docs/a.md", LangPath: "content/pl/docs/a.md"},
+			{EnPath: "i18n/en/en.toml", LangPath: "i18n/pl/pl.toml"},
+		},
+		ExcludedCounts: map[string]int{"content": 3},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ListLangPairs() = %#v, want %#v", got, want)
+	}
+}
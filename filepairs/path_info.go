@@ -5,7 +5,8 @@ import (
 	"fmt"
 )
 
-const enLangCode = "en"
+// defaultSourceLangCode is the language code of the source (EN) files if not configured otherwise.
+const defaultSourceLangCode = "en"
 
 var (
 	ErrLangPathRequiresEnPath = errors.New("lang path can be resolved only for EN path")
@@ -17,11 +18,13 @@ type PathInfo struct {
 	PairMatcherName string
 	LangCode        string
 
-	pairMatcher PairMatcher
+	pairMatcher    PairMatcher
+	sourceLangCode string
 }
 
+// IsEnPath reports whether the path is a file of the source language, which is EN by default.
 func (pi *PathInfo) IsEnPath() bool {
-	return pi != nil && pi.LangCode == enLangCode
+	return pi != nil && pi.LangCode == pi.sourceLangCode
 }
 
 func (pi *PathInfo) LangPath(langCode string) (string, error) {
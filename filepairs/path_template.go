@@ -0,0 +1,233 @@
+package filepairs
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+const (
+	langPlaceholder       = "{lang}"
+	contentDirPlaceholder = "{contentDir}"
+)
+
+var ErrInvalidPathTemplate = errors.New("invalid path template")
+
+type templateTokenKind int
+
+const (
+	tokenLiteral templateTokenKind = iota
+	tokenLang
+	tokenStar
+	tokenDoubleStar
+	tokenDoubleStarSlash
+)
+
+type templateToken struct {
+	kind templateTokenKind
+	text string
+}
+
+// pathTemplate is a compiled path pattern. A template consists of literal text and:
+//   - {lang} which matches a language code (a single path segment or a part of it),
+//   - * which matches any characters within a single path segment,
+//   - ** which matches any characters including path separators; when followed
+//     by a slash, it matches zero or more whole directories.
+//
+// All {lang} placeholders of a path must match the same language code.
+type pathTemplate struct {
+	raw    string
+	tokens []templateToken
+	re     *regexp.Regexp
+}
+
+func compilePathTemplate(template string) (*pathTemplate, error) {
+	if template == "" {
+		return nil, fmt.Errorf("%w: empty template", ErrInvalidPathTemplate)
+	}
+
+	if strings.HasPrefix(template, "/") {
+		return nil, fmt.Errorf("%w: template must be relative: %s", ErrInvalidPathTemplate, template)
+	}
+
+	tokens := parsePathTemplate(template)
+
+	var expr strings.Builder
+
+	expr.WriteString("^")
+
+	for _, token := range tokens {
+		switch token.kind {
+		case tokenLiteral:
+			expr.WriteString(regexp.QuoteMeta(token.text))
+		case tokenLang:
+			expr.WriteString(`([^/]+)`)
+		case tokenStar:
+			expr.WriteString(`([^/]*)`)
+		case tokenDoubleStar:
+			expr.WriteString(`(.*)`)
+		case tokenDoubleStarSlash:
+			expr.WriteString(`((?:[^/]+/)*)`)
+		}
+	}
+
+	expr.WriteString("$")
+
+	re, err := regexp.Compile(expr.String())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidPathTemplate, template, err)
+	}
+
+	return &pathTemplate{
+		raw:    template,
+		tokens: tokens,
+		re:     re,
+	}, nil
+}
+
+// compileRuleTemplate compiles a template of a pair rule. The {contentDir} placeholder
+// is replaced with the default content directory, content/{lang}.
+func compileRuleTemplate(template string) (*pathTemplate, error) {
+	rest, found := strings.CutPrefix(template, contentDirPlaceholder)
+	if strings.Contains(rest, contentDirPlaceholder) {
+		return nil, fmt.Errorf("%w: %s must be at the beginning of the template: %s",
+			ErrInvalidPathTemplate, contentDirPlaceholder, template)
+	}
+
+	if found {
+		template = contentDirPrefix + "/" + langPlaceholder + rest
+	}
+
+	return compilePathTemplate(template)
+}
+
+func parsePathTemplate(template string) []templateToken {
+	var (
+		tokens  []templateToken
+		literal strings.Builder
+	)
+
+	flushLiteral := func() {
+		if literal.Len() > 0 {
+			tokens = append(tokens, templateToken{kind: tokenLiteral, text: literal.String()})
+			literal.Reset()
+		}
+	}
+
+	for i := 0; i < len(template); {
+		rest := template[i:]
+
+		switch {
+		case strings.HasPrefix(rest, langPlaceholder):
+			flushLiteral()
+			tokens = append(tokens, templateToken{kind: tokenLang, text: langPlaceholder})
+			i += len(langPlaceholder)
+		case strings.HasPrefix(rest, "**/"):
+			flushLiteral()
+			tokens = append(tokens, templateToken{kind: tokenDoubleStarSlash, text: "**/"})
+			i += len("**/")
+		case strings.HasPrefix(rest, "**"):
+			flushLiteral()
+			tokens = append(tokens, templateToken{kind: tokenDoubleStar, text: "**"})
+			i += len("**")
+		case rest[0] == '*':
+			flushLiteral()
+			tokens = append(tokens, templateToken{kind: tokenStar, text: "*"})
+			i++
+		default:
+			literal.WriteByte(rest[0])
+			i++
+		}
+	}
+
+	flushLiteral()
+
+	return tokens
+}
+
+// match matches the path against the template. It returns the values matched by
+// the non-literal tokens and the language code.
+func (t *pathTemplate) match(filePath string) ([]string, string, bool) {
+	groups := t.re.FindStringSubmatch(filePath)
+	if groups == nil {
+		return nil, "", false
+	}
+
+	values := groups[1:]
+	langCode := ""
+	valueIdx := 0
+
+	for _, token := range t.tokens {
+		if token.kind == tokenLiteral {
+			continue
+		}
+
+		if token.kind == tokenLang {
+			value := values[valueIdx]
+			if langCode != "" && langCode != value {
+				return nil, "", false
+			}
+
+			langCode = value
+		}
+
+		valueIdx++
+	}
+
+	return values, langCode, true
+}
+
+// expand builds a path from the template, the values matched by a previous call
+// to match and the language code.
+func (t *pathTemplate) expand(values []string, langCode string) string {
+	var out strings.Builder
+
+	valueIdx := 0
+
+	for _, token := range t.tokens {
+		switch token.kind {
+		case tokenLiteral:
+			out.WriteString(token.text)
+
+			continue
+		case tokenLang:
+			out.WriteString(langCode)
+		case tokenStar, tokenDoubleStar, tokenDoubleStarSlash:
+			out.WriteString(values[valueIdx])
+		}
+
+		valueIdx++
+	}
+
+	return out.String()
+}
+
+// baseDir returns the deepest directory containing all files the template can match
+// for the language code. It is an empty string when that directory is the root.
+func (t *pathTemplate) baseDir(langCode string) string {
+	var prefix strings.Builder
+
+	for _, token := range t.tokens {
+		switch token.kind {
+		case tokenLiteral:
+			prefix.WriteString(token.text)
+		case tokenLang:
+			prefix.WriteString(langCode)
+		case tokenStar, tokenDoubleStar, tokenDoubleStarSlash:
+			return dirOfPrefix(prefix.String())
+		}
+	}
+
+	return dirOfPrefix(prefix.String())
+}
+
+func dirOfPrefix(prefix string) string {
+	idx := strings.LastIndex(prefix, "/")
+	if idx < 0 {
+		return ""
+	}
+
+	return path.Clean(prefix[:idx])
+}
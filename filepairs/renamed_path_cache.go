@@ -0,0 +1,64 @@
+package filepairs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// renamedPathCache remembers the paths that EN files were renamed to, so that
+// the history of a missing EN file is looked up again only after HEAD moves.
+type renamedPathCache struct {
+	finder RenameFinder
+
+	mu    sync.Mutex
+	head  string
+	paths map[string]string
+}
+
+func newRenamedPathCache(finder RenameFinder) *renamedPathCache {
+	//nolint:exhaustruct
+	return &renamedPathCache{
+		finder: finder,
+		paths:  make(map[string]string),
+	}
+}
+
+// findRenamedPaths returns the current paths of the given paths keyed by the old path,
+// with an empty string for a path that was not renamed.
+func (c *renamedPathCache) findRenamedPaths(ctx context.Context, paths []string) (map[string]string, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	head, err := c.finder.HeadCommitID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("find head commit: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if head != c.head {
+		c.head = head
+		c.paths = make(map[string]string)
+	}
+
+	renamedPaths := make(map[string]string, len(paths))
+
+	for _, path := range paths {
+		renamedPath, found := c.paths[path]
+		if !found {
+			renamedPath, err = c.finder.FindRenamedPath(ctx, path)
+			if err != nil {
+				return nil, fmt.Errorf("find renamed path of %s: %w", path, err)
+			}
+
+			c.paths[path] = renamedPath
+		}
+
+		renamedPaths[path] = renamedPath
+	}
+
+	return renamedPaths, nil
+}
@@ -0,0 +1,305 @@
+package filepairs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var (
+	ErrInvalidPairRule = errors.New("invalid pair rule")
+	ErrPathNotMatched  = errors.New("path does not match pair rule")
+)
+
+// PairRule is a declarative description of a pair pattern.
+//
+// Path is a path template of the EN and language files, for example
+// "{contentDir}/**" or "i18n/{lang}/{lang}.toml". The {lang} placeholder
+// matches a language code, * matches any characters within a path segment
+// and ** matches any characters including path separators.
+//
+// The {contentDir} placeholder matches the content directory of a language,
+// which is content/{lang} unless configured otherwise by the ContentDirs of
+// RulePairMatcherConfig. It can only be used at the beginning of a template.
+//
+// Include and Exclude are patterns in the same syntax that further restrict
+// the files matched by Path. A file is matched if it matches Path, at least
+// one of the Include patterns (if any) and none of the Exclude patterns.
+type PairRule struct {
+	Name    string   `json:"name"`
+	Path    string   `json:"path"`
+	Include []string `json:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// PairRulesFile is the format of the file with pair rules.
+type PairRulesFile struct {
+	Pairs []PairRule `json:"pairs"`
+}
+
+// DefaultPairRules returns the rules of the built-in pair patterns.
+func DefaultPairRules() []PairRule {
+	//nolint:exhaustruct
+	return []PairRule{
+		{
+			Name: contentDirPrefix,
+			Path: contentDirPlaceholder + "/**",
+		},
+		{
+			Name: I18NPairRuleName,
+			Path: i18nDirPrefix + "/{lang}/{lang}.toml",
+		},
+	}
+}
+
+// LoadPairRules reads pair rules from a JSON file.
+func LoadPairRules(path string) ([]PairRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read pair rules file %s: %w", path, err)
+	}
+
+	var rulesFile PairRulesFile
+	if err := json.Unmarshal(data, &rulesFile); err != nil {
+		return nil, fmt.Errorf("parse pair rules file %s: %w", path, err)
+	}
+
+	if len(rulesFile.Pairs) == 0 {
+		return nil, fmt.Errorf("%w: no pair rules in %s", ErrInvalidPairRule, path)
+	}
+
+	return rulesFile.Pairs, nil
+}
+
+// ContentDirProvider provides the content directories of the languages.
+type ContentDirProvider interface {
+	// ContentDirs returns the content directories by language code. The directories
+	// are relative to the repository directory and slash-separated.
+	ContentDirs() (map[string]string, error)
+}
+
+// RulePairMatcher is a PairMatcher compiled from a PairRule.
+type RulePairMatcher struct {
+	name    string
+	path    *pathTemplate
+	include []*pathTemplate
+	exclude []*pathTemplate
+	// usesContentDir reports whether the rule path has the {contentDir} placeholder.
+	usesContentDir bool
+	contentDirs    ContentDirProvider
+}
+
+type RulePairMatcherConfig struct {
+	// ContentDirs provides the directories matched by the {contentDir} placeholder.
+	// By default, the content directory of a language is content/{lang}.
+	ContentDirs ContentDirProvider
+}
+
+func NewRulePairMatcher(rule PairRule, opts ...func(config *RulePairMatcherConfig)) (*RulePairMatcher, error) {
+	//nolint:exhaustruct
+	config := RulePairMatcherConfig{}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	if strings.TrimSpace(rule.Name) == "" {
+		return nil, fmt.Errorf("%w: name is not set for path %s", ErrInvalidPairRule, rule.Path)
+	}
+
+	pathTmpl, err := compileRuleTemplate(rule.Path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidPairRule, rule.Name, err)
+	}
+
+	if !strings.Contains(rule.Path, langPlaceholder) && !strings.Contains(rule.Path, contentDirPlaceholder) {
+		return nil, fmt.Errorf("%w: %s: path %s has no %s or %s placeholder",
+			ErrInvalidPairRule, rule.Name, rule.Path, langPlaceholder, contentDirPlaceholder)
+	}
+
+	include, err := compilePathTemplates(rule.Include)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: include: %w", ErrInvalidPairRule, rule.Name, err)
+	}
+
+	exclude, err := compilePathTemplates(rule.Exclude)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: exclude: %w", ErrInvalidPairRule, rule.Name, err)
+	}
+
+	return &RulePairMatcher{
+		name:           rule.Name,
+		path:           pathTmpl,
+		include:        include,
+		exclude:        exclude,
+		usesContentDir: strings.HasPrefix(rule.Path, contentDirPlaceholder),
+		contentDirs:    config.ContentDirs,
+	}, nil
+}
+
+// CompilePairRules compiles the rules into pair matchers. Rule names must be unique.
+func CompilePairRules(rules []PairRule, opts ...func(config *RulePairMatcherConfig)) ([]*RulePairMatcher, error) {
+	matchers := make([]*RulePairMatcher, 0, len(rules))
+	names := make(map[string]struct{}, len(rules))
+
+	for _, rule := range rules {
+		if _, exists := names[rule.Name]; exists {
+			return nil, fmt.Errorf("%w: duplicate name %s", ErrInvalidPairRule, rule.Name)
+		}
+
+		names[rule.Name] = struct{}{}
+
+		matcher, err := NewRulePairMatcher(rule, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		matchers = append(matchers, matcher)
+	}
+
+	return matchers, nil
+}
+
+func compilePathTemplates(templates []string) ([]*pathTemplate, error) {
+	compiled := make([]*pathTemplate, 0, len(templates))
+
+	for _, template := range templates {
+		pathTmpl, err := compileRuleTemplate(template)
+		if err != nil {
+			return nil, err
+		}
+
+		compiled = append(compiled, pathTmpl)
+	}
+
+	return compiled, nil
+}
+
+func (m *RulePairMatcher) Name() string {
+	return m.name
+}
+
+func (m *RulePairMatcher) CheckPath(path string) (bool, string, error) {
+	defaultPath, ok, err := m.toDefaultContentDir(path)
+	if err != nil || !ok {
+		return false, "", err
+	}
+
+	_, langCode, ok := m.path.match(defaultPath)
+	if !ok {
+		return false, "", nil
+	}
+
+	if !m.accept(defaultPath) {
+		return false, "", nil
+	}
+
+	return true, langCode, nil
+}
+
+func (m *RulePairMatcher) LangPath(path string, langCode string) (string, error) {
+	defaultPath, ok, err := m.toDefaultContentDir(path)
+	if err != nil {
+		return "", err
+	}
+
+	var values []string
+	if ok {
+		values, _, ok = m.path.match(defaultPath)
+	}
+
+	if !ok {
+		return "", fmt.Errorf("%w: %s: %s", ErrPathNotMatched, m.name, path)
+	}
+
+	return m.fromDefaultContentDir(m.path.expand(values, langCode), langCode)
+}
+
+// baseDir returns the deepest directory containing all files the rule can match
+// for the language code. It is an empty string when that directory is the root.
+func (m *RulePairMatcher) baseDir(langCode string) (string, error) {
+	return m.fromDefaultContentDir(m.path.baseDir(langCode), langCode)
+}
+
+// toDefaultContentDir replaces the configured content directory of a language at the
+// beginning of the path with the default one, which the compiled templates match.
+// It returns false if the path is in the default content directory of a language
+// that has another content directory configured.
+func (m *RulePairMatcher) toDefaultContentDir(path string) (string, bool, error) {
+	if !m.usesContentDir || m.contentDirs == nil {
+		return path, true, nil
+	}
+
+	contentDirs, err := m.contentDirs.ContentDirs()
+	if err != nil {
+		return "", false, fmt.Errorf("content directories: %w", err)
+	}
+
+	matchedLangCode, matchedDir := "", ""
+
+	for langCode, contentDir := range contentDirs {
+		if hasDirPrefix(path, contentDir) && len(contentDir) > len(matchedDir) {
+			matchedLangCode, matchedDir = langCode, contentDir
+		}
+	}
+
+	if matchedDir != "" {
+		return defaultContentDir(matchedLangCode) + path[len(matchedDir):], true, nil
+	}
+
+	if rest, ok := strings.CutPrefix(path, contentDirPrefix+"/"); ok {
+		langCode, _, _ := strings.Cut(rest, "/")
+		if contentDir, ok := contentDirs[langCode]; ok && contentDir != defaultContentDir(langCode) {
+			return "", false, nil
+		}
+	}
+
+	return path, true, nil
+}
+
+// fromDefaultContentDir replaces the default content directory of the language at the
+// beginning of the path with the configured one.
+func (m *RulePairMatcher) fromDefaultContentDir(path string, langCode string) (string, error) {
+	if !m.usesContentDir || m.contentDirs == nil {
+		return path, nil
+	}
+
+	contentDirs, err := m.contentDirs.ContentDirs()
+	if err != nil {
+		return "", fmt.Errorf("content directories: %w", err)
+	}
+
+	contentDir, ok := contentDirs[langCode]
+	if !ok || !hasDirPrefix(path, defaultContentDir(langCode)) {
+		return path, nil
+	}
+
+	return contentDir + path[len(defaultContentDir(langCode)):], nil
+}
+
+func defaultContentDir(langCode string) string {
+	return contentDirPrefix + "/" + langCode
+}
+
+func hasDirPrefix(path string, dir string) bool {
+	return path == dir || strings.HasPrefix(path, dir+"/")
+}
+
+func (m *RulePairMatcher) accept(path string) bool {
+	if len(m.include) > 0 && !matchAnyTemplate(m.include, path) {
+		return false
+	}
+
+	return !matchAnyTemplate(m.exclude, path)
+}
+
+func matchAnyTemplate(templates []*pathTemplate, path string) bool {
+	for _, template := range templates {
+		if _, _, ok := template.match(path); ok {
+			return true
+		}
+	}
+
+	return false
+}
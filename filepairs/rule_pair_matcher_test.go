@@ -0,0 +1,420 @@
+//nolint:goconst
+package filepairs_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/dkarczmarski/go-kweb-lang/filepairs"
+)
+
+func mustRulePairMatcher(t *testing.T, rule filepairs.PairRule) *filepairs.RulePairMatcher {
+	t.Helper()
+
+	matcher, err := filepairs.NewRulePairMatcher(rule)
+	if err != nil {
+		t.Fatalf("NewRulePairMatcher() error = %v", err)
+	}
+
+	return matcher
+}
+
+func TestRulePairMatcher_CheckPath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		rule         filepairs.PairRule
+		path         string
+		wantMatch    bool
+		wantLangCode string
+	}{
+		{
+			name:         "directory tree",
+			rule:         filepairs.PairRule{Name: "content", Path: "content/{lang}/**"},
+			path:         "content/pl/docs/page.md",
+			wantMatch:    true,
+			wantLangCode: "pl",
+		},
+		{
+			name:      "directory tree does not match the directory itself",
+			rule:      filepairs.PairRule{Name: "content", Path: "content/{lang}/**"},
+			path:      "content/pl",
+			wantMatch: false,
+		},
+		{
+			name:         "repeated placeholder",
+			rule:         filepairs.PairRule{Name: "i18n", Path: "i18n/{lang}/{lang}.toml"},
+			path:         "i18n/de/de.toml",
+			wantMatch:    true,
+			wantLangCode: "de",
+		},
+		{
+			name:      "repeated placeholder with different language codes",
+			rule:      filepairs.PairRule{Name: "i18n", Path: "i18n/{lang}/{lang}.toml"},
+			path:      "i18n/de/pl.toml",
+			wantMatch: false,
+		},
+		{
+			name:         "placeholder inside a segment",
+			rule:         filepairs.PairRule{Name: "data", Path: "data/i18n/{lang}.yaml"},
+			path:         "data/i18n/pt-br.yaml",
+			wantMatch:    true,
+			wantLangCode: "pt-br",
+		},
+		{
+			name:      "star does not cross directories",
+			rule:      filepairs.PairRule{Name: "layouts", Path: "layouts/{lang}/*.html"},
+			path:      "layouts/pl/partials/head.html",
+			wantMatch: false,
+		},
+		{
+			name:         "double star with slash matches zero directories",
+			rule:         filepairs.PairRule{Name: "layouts", Path: "layouts/{lang}/**/*.html"},
+			path:         "layouts/pl/index.html",
+			wantMatch:    true,
+			wantLangCode: "pl",
+		},
+		{
+			name: "included path",
+			rule: filepairs.PairRule{
+				Name:    "content",
+				Path:    "content/{lang}/**",
+				Include: []string{"**/*.md"},
+			},
+			path:         "content/pl/docs/page.md",
+			wantMatch:    true,
+			wantLangCode: "pl",
+		},
+		{
+			name: "path not included",
+			rule: filepairs.PairRule{
+				Name:    "content",
+				Path:    "content/{lang}/**",
+				Include: []string{"**/*.md"},
+			},
+			path:      "content/pl/docs/image.png",
+			wantMatch: false,
+		},
+		{
+			name: "excluded path",
+			rule: filepairs.PairRule{
+				Name:    "content",
+				Path:    "content/{lang}/**",
+				Exclude: []string{"content/{lang}/OWNERS"},
+			},
+			path:      "content/pl/OWNERS",
+			wantMatch: false,
+		},
+		{
+			name: "exclusion of a top-level file does not apply to subdirectories",
+			rule: filepairs.PairRule{
+				Name:    "content",
+				Path:    "content/{lang}/**",
+				Exclude: []string{"content/{lang}/OWNERS"},
+			},
+			path:         "content/pl/docs/OWNERS",
+			wantMatch:    true,
+			wantLangCode: "pl",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			matcher := mustRulePairMatcher(t, tt.rule)
+
+			gotMatch, gotLangCode, err := matcher.CheckPath(tt.path)
+			if err != nil {
+				t.Fatalf("CheckPath() error = %v", err)
+			}
+
+			if gotMatch != tt.wantMatch || gotLangCode != tt.wantLangCode {
+				t.Fatalf("CheckPath() = (%v, %q), want (%v, %q)", gotMatch, gotLangCode, tt.wantMatch, tt.wantLangCode)
+			}
+		})
+	}
+}
+
+func TestRulePairMatcher_LangPath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		template string
+		path     string
+		langCode string
+		want     string
+	}{
+		{
+			name:     "directory tree",
+			template: "content/{lang}/**",
+			path:     "content/en/docs/page.md",
+			langCode: "pl",
+			want:     "content/pl/docs/page.md",
+		},
+		{
+			name:     "repeated placeholder",
+			template: "i18n/{lang}/{lang}.toml",
+			path:     "i18n/en/en.toml",
+			langCode: "pl",
+			want:     "i18n/pl/pl.toml",
+		},
+		{
+			name:     "wildcards keep matched values",
+			template: "layouts/{lang}/**/*.html",
+			path:     "layouts/pl/partials/head.html",
+			langCode: "en",
+			want:     "layouts/en/partials/head.html",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			matcher := mustRulePairMatcher(t, filepairs.PairRule{Name: "test", Path: tt.template})
+
+			got, err := matcher.LangPath(tt.path, tt.langCode)
+			if err != nil {
+				t.Fatalf("LangPath() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Fatalf("LangPath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRulePairMatcher_LangPath_NotMatched(t *testing.T) {
+	t.Parallel()
+
+	matcher := mustRulePairMatcher(t, filepairs.PairRule{Name: "i18n", Path: "i18n/{lang}/{lang}.toml"})
+
+	_, err := matcher.LangPath("content/en/docs/page.md", "pl")
+	if !errors.Is(err, filepairs.ErrPathNotMatched) {
+		t.Fatalf("LangPath() error = %v, want %v", err, filepairs.ErrPathNotMatched)
+	}
+}
+
+func TestNewRulePairMatcher_InvalidRule(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		rule filepairs.PairRule
+	}{
+		{name: "missing name", rule: filepairs.PairRule{Path: "content/{lang}/**"}},
+		{name: "empty path", rule: filepairs.PairRule{Name: "content"}},
+		{name: "absolute path", rule: filepairs.PairRule{Name: "content", Path: "/content/{lang}/**"}},
+		{name: "missing placeholder", rule: filepairs.PairRule{Name: "content", Path: "content/en/**"}},
+		{name: "content directory placeholder not at the beginning", rule: filepairs.PairRule{
+			Name: "content",
+			Path: "site/{contentDir}/**",
+		}},
+		{name: "empty exclude pattern", rule: filepairs.PairRule{
+			Name:    "content",
+			Path:    "content/{lang}/**",
+			Exclude: []string{""},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := filepairs.NewRulePairMatcher(tt.rule)
+			if !errors.Is(err, filepairs.ErrInvalidPairRule) {
+				t.Fatalf("NewRulePairMatcher() error = %v, want %v", err, filepairs.ErrInvalidPairRule)
+			}
+		})
+	}
+}
+
+func TestCompilePairRules_DuplicateName(t *testing.T) {
+	t.Parallel()
+
+	_, err := filepairs.CompilePairRules([]filepairs.PairRule{
+		{Name: "content", Path: "content/{lang}/**"},
+		{Name: "content", Path: "i18n/{lang}/{lang}.toml"},
+	})
+	if !errors.Is(err, filepairs.ErrInvalidPairRule) {
+		t.Fatalf("CompilePairRules() error = %v, want %v", err, filepairs.ErrInvalidPairRule)
+	}
+}
+
+// The default rules must recognize paths in the same way as the built-in matchers.
+func TestDefaultPairRules_MatchBuiltInMatchers(t *testing.T) {
+	t.Parallel()
+
+	matchers, err := filepairs.CompilePairRules(filepairs.DefaultPairRules())
+	if err != nil {
+		t.Fatalf("CompilePairRules() error = %v", err)
+	}
+
+	pairMatchers := make([]filepairs.PairMatcher, 0, len(matchers))
+	for _, matcher := range matchers {
+		pairMatchers = append(pairMatchers, matcher)
+	}
+
+	fromRules := filepairs.New(func(config *filepairs.FilePathsConfig) {
+		config.PairMatchers = pairMatchers
+	})
+	builtIn := filepairs.New()
+
+	for _, path := range []string{
+		"content/en/docs/page.md",
+		"content/pl/docs/page.md",
+		"content/pl/docs/OWNERS",
+		"i18n/en/en.toml",
+		"i18n/pl/pl.toml",
+		"i18n/pl/en.toml",
+		"static/logo.svg",
+		"content/en",
+	} {
+		gotInfo, gotErr := fromRules.CheckPath(path)
+		wantInfo, wantErr := builtIn.CheckPath(path)
+
+		if (gotErr == nil) != (wantErr == nil) {
+			t.Fatalf("CheckPath(%q) error = %v, want %v", path, gotErr, wantErr)
+		}
+
+		if gotErr != nil {
+			continue
+		}
+
+		if gotInfo.PairMatcherName != wantInfo.PairMatcherName || gotInfo.LangCode != wantInfo.LangCode {
+			t.Fatalf("CheckPath(%q) = (%q, %q), want (%q, %q)", path,
+				gotInfo.PairMatcherName, gotInfo.LangCode, wantInfo.PairMatcherName, wantInfo.LangCode)
+		}
+
+		if !gotInfo.IsEnPath() {
+			continue
+		}
+
+		gotLangPath, _ := gotInfo.LangPath("pl")
+		wantLangPath, _ := wantInfo.LangPath("pl")
+
+		if gotLangPath != wantLangPath {
+			t.Fatalf("LangPath(%q) = %q, want %q", path, gotLangPath, wantLangPath)
+		}
+	}
+}
+
+func TestLoadPairRules(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "pairs.json")
+
+	data := `{
+  "pairs": [
+    {"name": "content", "path": "content/{lang}/**", "exclude": ["content/{lang}/OWNERS"]},
+    {"name": "data", "path": "data/i18n/{lang}/{lang}.toml"}
+  ]
+}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	got, err := filepairs.LoadPairRules(path)
+	if err != nil {
+		t.Fatalf("LoadPairRules() error = %v", err)
+	}
+
+	want := []filepairs.PairRule{
+		{Name: "content", Path: "content/{lang}/**", Exclude: []string{"content/{lang}/OWNERS"}},
+		{Name: "data", Path: "data/i18n/{lang}/{lang}.toml"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("LoadPairRules() = %#v, want %#v", got, want)
+	}
+}
+
+func TestLoadPairRules_NoRules(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "pairs.json")
+
+	if err := os.WriteFile(path, []byte(`{"pairs": []}`), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	_, err := filepairs.LoadPairRules(path)
+	if !errors.Is(err, filepairs.ErrInvalidPairRule) {
+		t.Fatalf("LoadPairRules() error = %v, want %v", err, filepairs.ErrInvalidPairRule)
+	}
+}
+
+type fakeContentDirs map[string]string
+
+func (f fakeContentDirs) ContentDirs() (map[string]string, error) {
+	return f, nil
+}
+
+func TestRulePairMatcher_ContentDirs(t *testing.T) {
+	t.Parallel()
+
+	matchers, err := filepairs.CompilePairRules(
+		[]filepairs.PairRule{{
+			Name:    "content",
+			Path:    "{contentDir}/**",
+			Exclude: []string{"{contentDir}/OWNERS"},
+		}},
+		func(config *filepairs.RulePairMatcherConfig) {
+			config.ContentDirs = fakeContentDirs{
+				"en":    "content/en",
+				"pt-br": "translations/pt",
+			}
+		},
+	)
+	if err != nil {
+		t.Fatalf("CompilePairRules() error = %v", err)
+	}
+
+	matcher := matchers[0]
+
+	for _, tt := range []struct {
+		path         string
+		wantMatch    bool
+		wantLangCode string
+	}{
+		{path: "content/en/docs/page.md", wantMatch: true, wantLangCode: "en"},
+		{path: "translations/pt/docs/page.md", wantMatch: true, wantLangCode: "pt-br"},
+		{path: "translations/pt/OWNERS", wantMatch: false},
+		{path: "content/pt-br/docs/page.md", wantMatch: false},
+		{path: "content/pl/docs/page.md", wantMatch: true, wantLangCode: "pl"},
+	} {
+		match, langCode, err := matcher.CheckPath(tt.path)
+		if err != nil {
+			t.Fatalf("CheckPath(%q) error = %v", tt.path, err)
+		}
+
+		if match != tt.wantMatch || langCode != tt.wantLangCode {
+			t.Fatalf("CheckPath(%q) = (%v, %q), want (%v, %q)", tt.path, match, langCode, tt.wantMatch, tt.wantLangCode)
+		}
+	}
+
+	langPath, err := matcher.LangPath("content/en/docs/page.md", "pt-br")
+	if err != nil {
+		t.Fatalf("LangPath() error = %v", err)
+	}
+
+	if langPath != "translations/pt/docs/page.md" {
+		t.Fatalf("LangPath() = %q, want %q", langPath, "translations/pt/docs/page.md")
+	}
+
+	enPath, err := matcher.LangPath("translations/pt/docs/page.md", "en")
+	if err != nil {
+		t.Fatalf("LangPath() error = %v", err)
+	}
+
+	if enPath != "content/en/docs/page.md" {
+		t.Fatalf("LangPath() = %q, want %q", enPath, "content/en/docs/page.md")
+	}
+}
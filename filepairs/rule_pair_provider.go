@@ -0,0 +1,211 @@
+package filepairs
+
+import (
+	"context"
+	"fmt"
+)
+
+type RuleFilesLister interface {
+	FileExists(path string) (bool, error)
+	ListFiles(path string) ([]string, error)
+}
+
+// RenameFinder finds the current path of a file that was renamed (moved).
+// The results are cached until the HEAD commit changes.
+type RenameFinder interface {
+	HeadCommitID(ctx context.Context) (string, error)
+	FindRenamedPath(ctx context.Context, path string) (string, error)
+}
+
+// RulePairProvider lists pairs of the files matched by a RulePairMatcher.
+type RulePairProvider struct {
+	matcher        *RulePairMatcher
+	files          RuleFilesLister
+	renamedPaths   *renamedPathCache
+	sourceLangCode string
+}
+
+type RulePairProviderConfig struct {
+	// RenameFinder is used to pair language files with EN files that have been moved.
+	// Without it, moved EN files are listed as missing translations.
+	RenameFinder RenameFinder
+	// SourceLangCode is the language code of the files that are translated. The default is "en".
+	SourceLangCode string
+}
+
+func NewRulePairProvider(
+	matcher *RulePairMatcher,
+	files RuleFilesLister,
+	opts ...func(config *RulePairProviderConfig),
+) *RulePairProvider {
+	//nolint:exhaustruct
+	config := RulePairProviderConfig{
+		SourceLangCode: defaultSourceLangCode,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	var renamedPaths *renamedPathCache
+	if config.RenameFinder != nil {
+		renamedPaths = newRenamedPathCache(config.RenameFinder)
+	}
+
+	return &RulePairProvider{
+		matcher:        matcher,
+		files:          files,
+		renamedPaths:   renamedPaths,
+		sourceLangCode: config.SourceLangCode,
+	}
+}
+
+func (p *RulePairProvider) Name() string {
+	return p.matcher.Name()
+}
+
+func (p *RulePairProvider) ListPairs(ctx context.Context, langCode string) ([]Pair, error) {
+	langPaths, err := p.listMatchingFiles(langCode)
+	if err != nil {
+		return nil, fmt.Errorf("list files for lang %s: %w", langCode, err)
+	}
+
+	enPaths, err := p.listMatchingFiles(p.sourceLangCode)
+	if err != nil {
+		return nil, fmt.Errorf("list files for EN: %w", err)
+	}
+
+	pairs := make([]Pair, 0, len(langPaths)+len(enPaths))
+	addedLangPaths := make(map[string]struct{}, len(langPaths)+len(enPaths))
+
+	for _, langPath := range langPaths {
+		enPath, err := p.matcher.LangPath(langPath, p.sourceLangCode)
+		if err != nil {
+			return nil, fmt.Errorf("resolve EN path for %s: %w", langPath, err)
+		}
+
+		pairs = append(pairs, Pair{
+			EnPath:   enPath,
+			LangPath: langPath,
+		})
+
+		addedLangPaths[langPath] = struct{}{}
+	}
+
+	if err := p.markMovedEnPaths(ctx, pairs, addedLangPaths, enPaths, langCode); err != nil {
+		return nil, err
+	}
+
+	for _, enPath := range enPaths {
+		langPath, err := p.matcher.LangPath(enPath, langCode)
+		if err != nil {
+			return nil, fmt.Errorf("resolve lang path for %s: %w", enPath, err)
+		}
+
+		if _, exists := addedLangPaths[langPath]; exists {
+			continue
+		}
+
+		pairs = append(pairs, Pair{
+			EnPath:   enPath,
+			LangPath: langPath,
+		})
+
+		addedLangPaths[langPath] = struct{}{}
+	}
+
+	return pairs, nil
+}
+
+// listMatchingFiles lists the files of the language that are matched by the rule.
+// Only the base directory of the rule path is walked.
+func (p *RulePairProvider) listMatchingFiles(langCode string) ([]string, error) {
+	baseDir, err := p.matcher.baseDir(langCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if baseDir != "" {
+		exists, err := p.files.FileExists(baseDir)
+		if err != nil {
+			return nil, fmt.Errorf("check directory %s: %w", baseDir, err)
+		}
+
+		if !exists {
+			return nil, nil
+		}
+	}
+
+	relPaths, err := p.files.ListFiles(baseDir)
+	if err != nil {
+		return nil, fmt.Errorf("list files in %s: %w", baseDir, err)
+	}
+
+	var paths []string
+
+	for _, relPath := range relPaths {
+		path := relPath
+		if baseDir != "" {
+			path = baseDir + "/" + relPath
+		}
+
+		match, matchedLangCode, err := p.matcher.CheckPath(path)
+		if err != nil {
+			return nil, fmt.Errorf("check path %s: %w", path, err)
+		}
+
+		if match && matchedLangCode == langCode {
+			paths = append(paths, path)
+		}
+	}
+
+	return paths, nil
+}
+
+// markMovedEnPaths looks for EN files that have been moved while their translation
+// stayed at the old path. The translation is already paired with the old EN path and
+// gitseek reports it as moved, so the lang path for the new EN path is marked as added
+// to avoid listing the moved EN file as a missing translation.
+func (p *RulePairProvider) markMovedEnPaths(
+	ctx context.Context,
+	pairs []Pair,
+	addedLangPaths map[string]struct{},
+	enPaths []string,
+	langCode string,
+) error {
+	if p.renamedPaths == nil {
+		return nil
+	}
+
+	existingEnPaths := make(map[string]struct{}, len(enPaths))
+	for _, enPath := range enPaths {
+		existingEnPaths[enPath] = struct{}{}
+	}
+
+	var missingEnPaths []string
+
+	for _, pair := range pairs {
+		if _, exists := existingEnPaths[pair.EnPath]; !exists {
+			missingEnPaths = append(missingEnPaths, pair.EnPath)
+		}
+	}
+
+	renamedEnPaths, err := p.renamedPaths.findRenamedPaths(ctx, missingEnPaths)
+	if err != nil {
+		return err
+	}
+
+	for _, movedToEnPath := range renamedEnPaths {
+		if _, exists := existingEnPaths[movedToEnPath]; !exists {
+			continue
+		}
+
+		langPath, err := p.matcher.LangPath(movedToEnPath, langCode)
+		if err != nil {
+			return fmt.Errorf("resolve lang path for %s: %w", movedToEnPath, err)
+		}
+
+		addedLangPaths[langPath] = struct{}{}
+	}
+
+	return nil
+}
@@ -0,0 +1,284 @@
+//nolint:goconst
+package filepairs_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/dkarczmarski/go-kweb-lang/filepairs"
+)
+
+// fakeRuleFiles is an in-memory file tree. ListFiles returns paths relative
+// to the given directory, like git.Git.ListFiles.
+type fakeRuleFiles struct {
+	dirs         map[string][]string
+	listFilesErr error
+}
+
+func (f fakeRuleFiles) FileExists(path string) (bool, error) {
+	_, ok := f.dirs[path]
+
+	return ok, nil
+}
+
+func (f fakeRuleFiles) ListFiles(path string) ([]string, error) {
+	if f.listFilesErr != nil {
+		return nil, f.listFilesErr
+	}
+
+	return f.dirs[path], nil
+}
+
+func TestRulePairProvider_ListPairs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		rule  filepairs.PairRule
+		files fakeRuleFiles
+		want  []filepairs.Pair
+	}{
+		{
+			name: "directory tree with exclusion",
+			rule: filepairs.PairRule{
+				Name:    "content",
+				Path:    "content/{lang}/**",
+				Exclude: []string{"content/{lang}/OWNERS"},
+			},
+			files: fakeRuleFiles{dirs: map[string][]string{
+				"content/en": {"docs/page1.md", "OWNERS", "docs/page2.md"},
+				"content/pl": {"docs/page1.md", "docs/page3.md", "OWNERS"},
+			}},
+			want: []filepairs.Pair{
+				{EnPath: "content/en/docs/page1.md", LangPath: "content/pl/docs/page1.md"},
+				{EnPath: "content/en/docs/page3.md", LangPath: "content/pl/docs/page3.md"},
+				{EnPath: "content/en/docs/page2.md", LangPath: "content/pl/docs/page2.md"},
+			},
+		},
+		{
+			name: "single file pattern lists only matching files",
+			rule: filepairs.DefaultPairRules()[1],
+			files: fakeRuleFiles{dirs: map[string][]string{
+				"i18n/en": {"en.toml", "README.md"},
+				"i18n/pl": {"pl.toml", "en.toml"},
+			}},
+			want: []filepairs.Pair{
+				{EnPath: "i18n/en/en.toml", LangPath: "i18n/pl/pl.toml"},
+			},
+		},
+		{
+			name: "placeholder inside a file name",
+			rule: filepairs.PairRule{Name: "data", Path: "data/i18n/{lang}.yaml"},
+			files: fakeRuleFiles{dirs: map[string][]string{
+				"data/i18n": {"en.yaml", "de.yaml"},
+			}},
+			want: []filepairs.Pair{
+				{EnPath: "data/i18n/en.yaml", LangPath: "data/i18n/pl.yaml"},
+			},
+		},
+		{
+			name:  "no files",
+			rule:  filepairs.PairRule{Name: "layouts", Path: "layouts/{lang}/**/*.html"},
+			files: fakeRuleFiles{dirs: map[string][]string{}},
+			want:  []filepairs.Pair{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			provider := filepairs.NewRulePairProvider(mustRulePairMatcher(t, tt.rule), tt.files)
+
+			if got := provider.Name(); got != tt.rule.Name {
+				t.Fatalf("Name() = %q, want %q", got, tt.rule.Name)
+			}
+
+			got, err := provider.ListPairs(t.Context(), "pl")
+			if err != nil {
+				t.Fatalf("ListPairs() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("ListPairs() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+type fakeRenameFinder struct {
+	head                string
+	findRenamedPathFunc func(ctx context.Context, path string) (string, error)
+}
+
+func (f fakeRenameFinder) HeadCommitID(_ context.Context) (string, error) {
+	return f.head, nil
+}
+
+func (f fakeRenameFinder) FindRenamedPath(ctx context.Context, path string) (string, error) {
+	return f.findRenamedPathFunc(ctx, path)
+}
+
+func TestRulePairProvider_ListPairs_MovedEnFile(t *testing.T) {
+	t.Parallel()
+
+	files := fakeRuleFiles{dirs: map[string][]string{
+		"content/en": {"docs/b/page1.md", "docs/page2.md"},
+		"content/pl": {"docs/page1.md"},
+	}}
+
+	matcher := mustRulePairMatcher(t, filepairs.DefaultPairRules()[0])
+
+	provider := filepairs.NewRulePairProvider(matcher, files, func(config *filepairs.RulePairProviderConfig) {
+		config.RenameFinder = fakeRenameFinder{
+			findRenamedPathFunc: func(_ context.Context, path string) (string, error) {
+				if path == "content/en/docs/page1.md" {
+					return "content/en/docs/b/page1.md", nil
+				}
+
+				return "", nil
+			},
+		}
+	})
+
+	got, err := provider.ListPairs(t.Context(), "pl")
+	if err != nil {
+		t.Fatalf("ListPairs() error = %v", err)
+	}
+
+	want := []filepairs.Pair{
+		{EnPath: "content/en/docs/page1.md", LangPath: "content/pl/docs/page1.md"},
+		{EnPath: "content/en/docs/page2.md", LangPath: "content/pl/docs/page2.md"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ListPairs() = %#v, want %#v", got, want)
+	}
+}
+
+type countingRenameFinder struct {
+	head  string
+	calls int
+}
+
+func (f *countingRenameFinder) HeadCommitID(_ context.Context) (string, error) {
+	return f.head, nil
+}
+
+func (f *countingRenameFinder) FindRenamedPath(_ context.Context, _ string) (string, error) {
+	f.calls++
+
+	return "", nil
+}
+
+func TestRulePairProvider_ListPairs_CachesRenamedPathsPerHead(t *testing.T) {
+	t.Parallel()
+
+	files := fakeRuleFiles{dirs: map[string][]string{
+		"content/en": {"docs/page2.md"},
+		"content/pl": {"docs/page1.md"},
+	}}
+
+	finder := &countingRenameFinder{head: "head-1"}
+
+	provider := filepairs.NewRulePairProvider(
+		mustRulePairMatcher(t, filepairs.DefaultPairRules()[0]),
+		files,
+		func(config *filepairs.RulePairProviderConfig) {
+			config.RenameFinder = finder
+		},
+	)
+
+	for _, tc := range []struct {
+		head      string
+		wantCalls int
+	}{
+		{head: "head-1", wantCalls: 1},
+		{head: "head-1", wantCalls: 1},
+		{head: "head-2", wantCalls: 2},
+	} {
+		finder.head = tc.head
+
+		if _, err := provider.ListPairs(t.Context(), "pl"); err != nil {
+			t.Fatalf("ListPairs() error = %v", err)
+		}
+
+		if finder.calls != tc.wantCalls {
+			t.Fatalf("FindRenamedPath() calls at %s = %d, want %d", tc.head, finder.calls, tc.wantCalls)
+		}
+	}
+}
+
+func TestRulePairProvider_ListPairs_RenameFinderError(t *testing.T) {
+	t.Parallel()
+
+	wantErr := errors.New("boom")
+
+	files := fakeRuleFiles{dirs: map[string][]string{
+		"content/pl": {"docs/page1.md"},
+	}}
+
+	matcher := mustRulePairMatcher(t, filepairs.DefaultPairRules()[0])
+
+	provider := filepairs.NewRulePairProvider(matcher, files, func(config *filepairs.RulePairProviderConfig) {
+		config.RenameFinder = fakeRenameFinder{
+			findRenamedPathFunc: func(_ context.Context, _ string) (string, error) {
+				return "", wantErr
+			},
+		}
+	})
+
+	_, err := provider.ListPairs(t.Context(), "pl")
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("ListPairs() error = %v, want %v", err, wantErr)
+	}
+}
+
+func TestRulePairProvider_ListPairs_SourceLangCode(t *testing.T) {
+	t.Parallel()
+
+	files := fakeRuleFiles{dirs: map[string][]string{
+		"content/de": {"docs/page1.md", "docs/page2.md"},
+		"content/en": {"docs/page1.md"},
+	}}
+
+	matcher := mustRulePairMatcher(t, filepairs.DefaultPairRules()[0])
+
+	provider := filepairs.NewRulePairProvider(matcher, files, func(config *filepairs.RulePairProviderConfig) {
+		config.SourceLangCode = "de"
+	})
+
+	got, err := provider.ListPairs(t.Context(), "en")
+	if err != nil {
+		t.Fatalf("ListPairs() error = %v", err)
+	}
+
+	want := []filepairs.Pair{
+		{EnPath: "content/de/docs/page1.md", LangPath: "content/en/docs/page1.md"},
+		{EnPath: "content/de/docs/page2.md", LangPath: "content/en/docs/page2.md"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ListPairs() = %#v, want %#v", got, want)
+	}
+}
+
+func TestRulePairProvider_ListPairs_ListFilesError(t *testing.T) {
+	t.Parallel()
+
+	wantErr := errors.New("boom")
+
+	files := fakeRuleFiles{
+		dirs:         map[string][]string{"content/pl": nil},
+		listFilesErr: wantErr,
+	}
+
+	provider := filepairs.NewRulePairProvider(mustRulePairMatcher(t, filepairs.DefaultPairRules()[0]), files)
+
+	_, err := provider.ListPairs(t.Context(), "pl")
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("ListPairs() error = %v, want %v", err, wantErr)
+	}
+}
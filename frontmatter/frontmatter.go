@@ -0,0 +1,202 @@
+// Package frontmatter checks that the front matter fields that are not translated,
+// such as weight or aliases, have the same values in the language file as in the
+// EN file. A drift of these fields breaks, for example, the order of pages in the
+// navigation of the translated site.
+package frontmatter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+	"github.com/dkarczmarski/go-kweb-lang/pagecontent"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// MismatchKindMissing indicates that the field is set in the EN file
+	// but not in the language file.
+	MismatchKindMissing = "missing"
+
+	// MismatchKindUnexpected indicates that the field is set in the language file
+	// but not in the EN file.
+	MismatchKindUnexpected = "unexpected"
+
+	// MismatchKindDifferent indicates that the field has different values
+	// in the EN file and in the language file.
+	MismatchKindDifferent = "different"
+)
+
+// DefaultFields returns the front matter fields that are checked by default.
+func DefaultFields() []string {
+	return []string{"weight", "content_type", "reviewers", "aliases"}
+}
+
+// Mismatch describes a non-translatable front matter field whose value
+// in the language file differs from the EN file.
+type Mismatch struct {
+	Field string
+	Kind  string
+
+	// EnValue and LangValue are the values of the field, empty if not set.
+	EnValue   string
+	LangValue string
+}
+
+// GitRepo is an interface used to decouple this package from the concrete git implementation.
+type GitRepo interface {
+	ReadFile(path string) (string, error)
+}
+
+type Checker struct {
+	gitRepo GitRepo
+	fields  []string
+}
+
+type NewConfig struct {
+	// Fields are the names of the checked front matter fields.
+	// The default is DefaultFields.
+	Fields []string
+}
+
+func New(gitRepo GitRepo, opts ...func(config *NewConfig)) *Checker {
+	config := NewConfig{
+		Fields: DefaultFields(),
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return &Checker{
+		gitRepo: gitRepo,
+		fields:  config.Fields,
+	}
+}
+
+// CheckPair compares the checked front matter fields of the current EN file
+// and the current language file of the pair.
+//
+// It returns nil for files other than Markdown and HTML pages, if the language
+// file or the EN file does not exist or if one of them has no front matter.
+func (c *Checker) CheckPair(_ context.Context, pair gitseek.Pair, fileInfo gitseek.FileInfo) ([]Mismatch, error) {
+	if !pagecontent.IsPagePair(pair, fileInfo) {
+		return nil, nil
+	}
+
+	enFields, err := c.readFrontMatter(pair.EnPath)
+	if err != nil || enFields == nil {
+		return nil, err
+	}
+
+	langFields, err := c.readFrontMatter(pair.LangPath)
+	if err != nil || langFields == nil {
+		return nil, err
+	}
+
+	return c.compare(enFields, langFields)
+}
+
+func (c *Checker) readFrontMatter(path string) (map[string]any, error) {
+	content, err := c.gitRepo.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	fields, err := Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("parse front matter of %s: %w", path, err)
+	}
+
+	return fields, nil
+}
+
+func (c *Checker) compare(enFields, langFields map[string]any) ([]Mismatch, error) {
+	var mismatches []Mismatch
+
+	for _, field := range c.fields {
+		enValue, enOK := enFields[field]
+		langValue, langOK := langFields[field]
+
+		if !enOK && !langOK {
+			continue
+		}
+
+		enText, err := formatValue(enValue, enOK)
+		if err != nil {
+			return nil, fmt.Errorf("format EN value of %s: %w", field, err)
+		}
+
+		langText, err := formatValue(langValue, langOK)
+		if err != nil {
+			return nil, fmt.Errorf("format lang value of %s: %w", field, err)
+		}
+
+		var kind string
+
+		switch {
+		case !langOK:
+			kind = MismatchKindMissing
+		case !enOK:
+			kind = MismatchKindUnexpected
+		case enText != langText:
+			kind = MismatchKindDifferent
+		default:
+			continue
+		}
+
+		mismatches = append(mismatches, Mismatch{
+			Field:     field,
+			Kind:      kind,
+			EnValue:   enText,
+			LangValue: langText,
+		})
+	}
+
+	return mismatches, nil
+}
+
+// formatValue returns the value as text: strings as they are and other values
+// as JSON, so that values decoded from YAML and TOML compare equal.
+func formatValue(value any, ok bool) (string, error) {
+	if !ok {
+		return "", nil
+	}
+
+	if text, isString := value.(string); isString {
+		return text, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("marshal %v: %w", value, err)
+	}
+
+	return string(data), nil
+}
+
+// Parse returns the fields of the YAML (---) or TOML (+++) front matter
+// of the content, or nil if the content has no front matter.
+func Parse(content string) (map[string]any, error) {
+	page := pagecontent.Split(content)
+	if page.Delimiter == "" {
+		return nil, nil
+	}
+
+	fields := make(map[string]any)
+
+	if page.Delimiter == pagecontent.TOMLDelimiter {
+		if _, err := toml.Decode(page.FrontMatter, &fields); err != nil {
+			return nil, fmt.Errorf("decode TOML front matter: %w", err)
+		}
+
+		return fields, nil
+	}
+
+	if err := yaml.Unmarshal([]byte(page.FrontMatter), &fields); err != nil {
+		return nil, fmt.Errorf("decode YAML front matter: %w", err)
+	}
+
+	return fields, nil
+}
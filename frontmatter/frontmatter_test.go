@@ -0,0 +1,212 @@
+package frontmatter_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/dkarczmarski/go-kweb-lang/frontmatter"
+	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+)
+
+var errFileNotFound = errors.New("file not found")
+
+type fakeGitRepo map[string]string
+
+func (f fakeGitRepo) ReadFile(path string) (string, error) {
+	content, ok := f[path]
+	if !ok {
+		return "", errFileNotFound
+	}
+
+	return content, nil
+}
+
+var pagePair = gitseek.Pair{
+	EnPath:   "content/en/docs/a.md",
+	LangPath: "content/pl/docs/a.md",
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name    string
+		content string
+		want    map[string]any
+	}{
+		{
+			name:    "YAML front matter",
+			content: "---\ntitle: Pods\nweight: 10\naliases:\n- /docs/pods/\n---\n\n# Pods\n",
+			want: map[string]any{
+				"title":   "Pods",
+				"weight":  10,
+				"aliases": []any{"/docs/pods/"},
+			},
+		},
+		{
+			name:    "TOML front matter",
+			content: "+++\ntitle = \"Pods\"\nweight = 10\n+++\n\n# Pods\n",
+			want: map[string]any{
+				"title":  "Pods",
+				"weight": int64(10),
+			},
+		},
+		{
+			name:    "no front matter",
+			content: "# Pods\n",
+			want:    nil,
+		},
+		{
+			name:    "unterminated front matter",
+			content: "---\ntitle: Pods\n",
+			want:    nil,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := frontmatter.Parse(tc.content)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("unexpected fields:\n got:  %#v\nwant: %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestChecker_CheckPair(t *testing.T) {
+	t.Parallel()
+
+	gitRepo := fakeGitRepo{
+		"content/en/docs/a.md": `---
+title: Pods
+content_type: concept
+weight: 10
+reviewers:
+- alice
+- bob
+---
+
+Pods are the smallest deployable units.
+`,
+		"content/pl/docs/a.md": `---
+title: Pody
+weight: 20
+reviewers:
+- alice
+- bob
+aliases:
+- /pl/docs/pody/
+---
+
+Pody to najmniejsze jednostki.
+`,
+	}
+
+	fileInfo := gitseek.FileInfo{FileStatus: gitseek.StatusLangFileUpToDate}
+
+	got, err := frontmatter.New(gitRepo).CheckPair(t.Context(), pagePair, fileInfo)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []frontmatter.Mismatch{
+		{Field: "weight", Kind: frontmatter.MismatchKindDifferent, EnValue: "10", LangValue: "20"},
+		{Field: "content_type", Kind: frontmatter.MismatchKindMissing, EnValue: "concept"},
+		{Field: "aliases", Kind: frontmatter.MismatchKindUnexpected, LangValue: `["/pl/docs/pody/"]`},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected mismatches:\n got:  %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestChecker_CheckPair_ConfiguredFields(t *testing.T) {
+	t.Parallel()
+
+	gitRepo := fakeGitRepo{
+		"content/en/docs/a.md": "+++\ntitle = \"Pods\"\nweight = 10\nlayout = \"docs\"\n+++\n",
+		"content/pl/docs/a.md": "+++\ntitle = \"Pody\"\nweight = 20\nlayout = \"blog\"\n+++\n",
+	}
+
+	checker := frontmatter.New(gitRepo, func(config *frontmatter.NewConfig) {
+		config.Fields = []string{"layout"}
+	})
+
+	got, err := checker.CheckPair(t.Context(), pagePair, gitseek.FileInfo{FileStatus: gitseek.StatusEnFileUpdated})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []frontmatter.Mismatch{
+		{Field: "layout", Kind: frontmatter.MismatchKindDifferent, EnValue: "docs", LangValue: "blog"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected mismatches:\n got:  %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestChecker_CheckPair_Skipped(t *testing.T) {
+	t.Parallel()
+
+	gitRepo := fakeGitRepo{
+		"content/en/docs/a.md":   "---\nweight: 10\n---\n",
+		"content/pl/docs/a.md":   "Pody\n",
+		"data/en/a.yaml":         "---\nweight: 10\n---\n",
+		"data/pl/a.yaml":         "---\nweight: 20\n---\n",
+		"content/en/docs/b.md":   "---\nweight: 10\n---\n",
+		"content/pl/docs/b.md":   "---\nweight: 20\n---\n",
+		"content/en/docs/bad.md": "---\nweight: [\n---\n",
+	}
+
+	for _, tc := range []struct {
+		name     string
+		pair     gitseek.Pair
+		fileInfo gitseek.FileInfo
+	}{
+		{
+			name:     "lang file without front matter",
+			pair:     pagePair,
+			fileInfo: gitseek.FileInfo{FileStatus: gitseek.StatusEnFileUpdated},
+		},
+		{
+			name:     "not a page",
+			pair:     gitseek.Pair{EnPath: "data/en/a.yaml", LangPath: "data/pl/a.yaml"},
+			fileInfo: gitseek.FileInfo{FileStatus: gitseek.StatusEnFileUpdated},
+		},
+		{
+			name:     "missing lang file",
+			pair:     gitseek.Pair{EnPath: "content/en/docs/b.md", LangPath: "content/pl/docs/b.md"},
+			fileInfo: gitseek.FileInfo{FileStatus: gitseek.StatusLangFileMissing},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := frontmatter.New(gitRepo).CheckPair(t.Context(), tc.pair, tc.fileInfo)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != nil {
+				t.Fatalf("expected nil, got %#v", got)
+			}
+		})
+	}
+
+	t.Run("invalid front matter", func(t *testing.T) {
+		t.Parallel()
+
+		pair := gitseek.Pair{EnPath: "content/en/docs/bad.md", LangPath: "content/pl/docs/b.md"}
+
+		_, err := frontmatter.New(gitRepo).CheckPair(t.Context(), pair, gitseek.FileInfo{FileStatus: gitseek.StatusEnFileUpdated})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
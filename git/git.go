@@ -7,15 +7,28 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/dkarczmarski/go-kweb-lang/git/internal/process"
 )
 
-var ErrInvalidCommitInfoLine = errors.New("invalid commit info line")
+var (
+	ErrInvalidCommitInfoLine = errors.New("invalid commit info line")
+	ErrInvalidCommitFilesLog = errors.New("invalid commit files log")
+)
 
-const commitInfoSegmentCount = 3
+const (
+	commitInfoSegmentCount = 3
+	fullFileDiffContext    = 1_000_000
+	commitFilesHeader      = "commit "
+	maxFileRenames         = 16
+	pullRequestRefsPrefix  = "refs/pull/"
+	fullSimilarity         = 100
+	defaultBranch          = "main"
+)
 
 //nolint:gochecknoglobals
 var emptyCommitInfo CommitInfo
@@ -30,8 +43,37 @@ type CommitInfo struct {
 	Comment string
 }
 
+// CommitFiles represents a commit together with its parents and the files it changed.
+type CommitFiles struct {
+	CommitInfo
+
+	// ParentIDs are hashes of the parent commits
+	ParentIDs []string
+	// Files are paths of the files added, modified or deleted by the commit
+	Files []string
+}
+
+// FileRename represents a commit that renamed (moved) a file.
+type FileRename struct {
+	// Commit is the commit that renamed the file
+	Commit CommitInfo
+	// OldPath is the path of the file before the rename
+	OldPath string
+	// NewPath is the path of the file after the rename
+	NewPath string
+	// Similarity is the similarity index in percent reported by git rename detection
+	Similarity int
+}
+
+// IsPure reports whether the file was moved without changing its content.
+func (r FileRename) IsPure() bool {
+	return r.Similarity >= fullSimilarity
+}
+
 type NewRepoConfig struct {
 	Runner Runner
+	// Branch is the tracked branch of the repository. The default is "main".
+	Branch string
 }
 
 type Runner interface {
@@ -41,11 +83,13 @@ type Runner interface {
 type Git struct {
 	path   string
 	runner Runner
+	branch string
 }
 
 func NewRepo(path string, opts ...func(config *NewRepoConfig)) *Git {
 	config := NewRepoConfig{
 		Runner: &process.StdCommandRunner{},
+		Branch: defaultBranch,
 	}
 
 	for _, opt := range opts {
@@ -55,14 +99,17 @@ func NewRepo(path string, opts ...func(config *NewRepoConfig)) *Git {
 	return &Git{
 		path:   path,
 		runner: config.Runner,
+		branch: config.Branch,
 	}
 }
 
-// Create performs a git clone using the given url.
+// Create performs a git clone of the tracked branch using the given url.
 func (g *Git) Create(ctx context.Context, url string) error {
 	return execToErr(g.exec(ctx, g.path,
 		"git",
 		"clone",
+		"--branch",
+		g.branch,
 		url,
 		".",
 	))
@@ -77,19 +124,48 @@ func (g *Git) Checkout(ctx context.Context, commitID string) error {
 	))
 }
 
-// ListMainBranchCommits lists all commits in the main branch.
+// ListMainBranchCommits lists all commits in the tracked branch.
 func (g *Git) ListMainBranchCommits(ctx context.Context) ([]CommitInfo, error) {
 	return execToCommitInfoSlice(g.exec(ctx, g.path,
 		"git",
 		"--no-pager",
 		"log",
-		"main",
+		g.branch,
 		"--pretty=format:%H %cd %s",
 		"--date=iso-strict",
 		"--first-parent",
 	))
 }
 
+// ListMainBranchCommitParents lists the parents of the commits in the tracked branch,
+// keyed by commit ID.
+func (g *Git) ListMainBranchCommitParents(ctx context.Context) (map[string][]string, error) {
+	lines, err := execToLines(g.exec(ctx, g.path,
+		"git",
+		"--no-pager",
+		"log",
+		g.branch,
+		"--pretty=format:%H %P",
+		"--first-parent",
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	parents := make(map[string][]string, len(lines))
+
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		parents[fields[0]] = fields[1:]
+	}
+
+	return parents, nil
+}
+
 // FileExists checks whether the file exists in a repository.
 func (g *Git) FileExists(path string) (bool, error) {
 	_, err := os.Stat(filepath.Join(g.path, path))
@@ -151,6 +227,48 @@ func (g *Git) FindFileLastCommit(ctx context.Context, path string) (CommitInfo,
 	))
 }
 
+// FindFileLastCommitAt provides information about the last commit for the given file
+// in the history of the given revision.
+func (g *Git) FindFileLastCommitAt(ctx context.Context, revision string, path string) (CommitInfo, error) {
+	return execToCommitInfo(g.exec(ctx, g.path,
+		"git",
+		"log",
+		"-1",
+		"--format=%H %cd %s",
+		"--date=iso-strict",
+		revision,
+		"--",
+		path,
+	))
+}
+
+// FileExistsAt checks whether the file exists in the given revision.
+func (g *Git) FileExistsAt(ctx context.Context, revision string, path string) (bool, error) {
+	lines, err := execToLines(g.exec(ctx, g.path,
+		"git",
+		"ls-tree",
+		"--name-only",
+		revision,
+		"--",
+		path,
+	))
+	if err != nil {
+		return false, err
+	}
+
+	return len(lines) > 0, nil
+}
+
+// ReadFileAt returns the content of the file in the given revision.
+func (g *Git) ReadFileAt(ctx context.Context, revision string, path string) (string, error) {
+	return g.exec(ctx, g.path,
+		"git",
+		"--no-pager",
+		"show",
+		revision+":"+path,
+	)
+}
+
 // FindFileCommitsAfter lists commits affecting the file after commitIDFrom.
 func (g *Git) FindFileCommitsAfter(ctx context.Context, path string, commitIDFrom string) ([]CommitInfo, error) {
 	return execToCommitInfoSlice(g.exec(ctx, g.path,
@@ -175,7 +293,7 @@ func (g *Git) ListMergePoints(ctx context.Context, commitID string) ([]CommitInf
 		"--pretty=format:%H %cd %s",
 		"--date=iso-strict",
 		"--reverse",
-		commitID+"..main",
+		commitID+".."+g.branch,
 	))
 }
 
@@ -187,7 +305,7 @@ func (g *Git) Fetch(ctx context.Context) error {
 	))
 }
 
-// ListFreshCommits lists commits present on origin/main but not yet on main.
+// ListFreshCommits lists commits present on the origin branch but not yet on the local branch.
 func (g *Git) ListFreshCommits(ctx context.Context) ([]CommitInfo, error) {
 	return execToCommitInfoSlice(g.exec(ctx, g.path,
 		"git",
@@ -195,10 +313,91 @@ func (g *Git) ListFreshCommits(ctx context.Context) ([]CommitInfo, error) {
 		"log",
 		"--pretty=format:%H %cd %s",
 		"--date=iso-strict",
-		"main..origin/main",
+		g.branch+".."+g.originBranch(),
+	))
+}
+
+// ListDroppedCommits lists commits present on the local branch but no longer on the origin
+// branch, for example after the history of the origin branch was rewritten.
+func (g *Git) ListDroppedCommits(ctx context.Context) ([]CommitInfo, error) {
+	return execToCommitInfoSlice(g.exec(ctx, g.path,
+		"git",
+		"--no-pager",
+		"log",
+		"--pretty=format:%H %cd %s",
+		"--date=iso-strict",
+		g.originBranch()+".."+g.branch,
 	))
 }
 
+// ResetToOrigin resets the checked out branch to the origin branch, discarding local commits.
+func (g *Git) ResetToOrigin(ctx context.Context) error {
+	return execToErr(g.exec(ctx, g.path,
+		"git",
+		"reset",
+		"--hard",
+		g.originBranch(),
+	))
+}
+
+func (g *Git) originBranch() string {
+	return "origin/" + g.branch
+}
+
+// PullRequestHeadRef returns the ref under which the head of the pull request is fetched.
+func PullRequestHeadRef(prNumber int) string {
+	return pullRequestRefsPrefix + strconv.Itoa(prNumber) + "/head"
+}
+
+// FetchPullRequestHeads fetches the heads of the given pull requests from origin
+// into the local refs returned by PullRequestHeadRef. Force-pushed heads are overwritten.
+func (g *Git) FetchPullRequestHeads(ctx context.Context, prNumbers []int) error {
+	if len(prNumbers) == 0 {
+		return nil
+	}
+
+	args := make([]string, 0, len(prNumbers)+2)
+	args = append(args, "fetch", "origin")
+
+	for _, prNumber := range prNumbers {
+		ref := PullRequestHeadRef(prNumber)
+		args = append(args, "+"+ref+":"+ref)
+	}
+
+	return execToErr(g.exec(ctx, g.path, "git", args...))
+}
+
+// PrunePullRequestHeads deletes the local refs returned by PullRequestHeadRef of pull requests
+// other than the given open ones and returns the numbers of the pruned pull requests.
+func (g *Git) PrunePullRequestHeads(ctx context.Context, openPRNumbers []int) ([]int, error) {
+	refs, err := execToLines(g.exec(ctx, g.path,
+		"git",
+		"for-each-ref",
+		"--format=%(refname)",
+		pullRequestRefsPrefix,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	var prunedPRNumbers []int
+
+	for _, ref := range refs {
+		prNumber, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(ref, pullRequestRefsPrefix), "/head"))
+		if err != nil || ref != PullRequestHeadRef(prNumber) || slices.Contains(openPRNumbers, prNumber) {
+			continue
+		}
+
+		if err := execToErr(g.exec(ctx, g.path, "git", "update-ref", "-d", ref)); err != nil {
+			return prunedPRNumbers, err
+		}
+
+		prunedPRNumbers = append(prunedPRNumbers, prNumber)
+	}
+
+	return prunedPRNumbers, nil
+}
+
 // Pull runs git pull.
 func (g *Git) Pull(ctx context.Context) error {
 	return execToErr(g.exec(ctx, g.path,
@@ -253,6 +452,274 @@ func (g *Git) ListFilesBetweenCommits(ctx context.Context, forkCommitID, branchL
 	))
 }
 
+// ListCommitsWithFiles lists commits reachable from revisionRange (for example "HEAD"
+// or "a1b2c3..HEAD") together with the files changed by each of them, walking
+// the history only once. Children are always listed before their parents.
+//
+// Like FindFileCommitsAfter, it does not list files for merge commits
+// and does not detect renames.
+func (g *Git) ListCommitsWithFiles(ctx context.Context, revisionRange string) ([]CommitFiles, error) {
+	out, err := g.exec(ctx, g.path,
+		"git",
+		"-c",
+		"core.quotePath=false",
+		"--no-pager",
+		"log",
+		"--topo-order",
+		"--name-status",
+		"--no-renames",
+		"--format="+commitFilesHeader+"%H %P%n%H %cd %s",
+		"--date=iso-strict",
+		revisionRange,
+		"--",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseCommitFilesLog(out)
+}
+
+// FindFileRenames follows the renames of a file that no longer exists at the given path,
+// using git rename detection on the commits that removed it.
+// It returns the renames ordered from the oldest, the last one leads to the current path
+// of the file. It returns nil if the file exists or if it was deleted rather than renamed.
+func (g *Git) FindFileRenames(ctx context.Context, path string) ([]FileRename, error) {
+	var renames []FileRename
+
+	currentPath := path
+
+	for range maxFileRenames {
+		exists, err := g.FileExists(currentPath)
+		if err != nil {
+			return nil, err
+		}
+
+		if exists {
+			return renames, nil
+		}
+
+		rename, found, err := g.findFileRename(ctx, currentPath)
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			return nil, nil
+		}
+
+		renames = append(renames, rename)
+		currentPath = rename.NewPath
+	}
+
+	return nil, nil
+}
+
+// HeadCommitID returns the ID of the HEAD commit of the local clone.
+func (g *Git) HeadCommitID(ctx context.Context) (string, error) {
+	out, err := g.exec(ctx, g.path,
+		"git",
+		"rev-parse",
+		"HEAD",
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(out), nil
+}
+
+// FindRenamedPath returns the current path of a file that was renamed (moved)
+// from the given path or an empty string if there is no such file.
+func (g *Git) FindRenamedPath(ctx context.Context, path string) (string, error) {
+	renames, err := g.FindFileRenames(ctx, path)
+	if err != nil {
+		return "", err
+	}
+
+	if len(renames) == 0 {
+		return "", nil
+	}
+
+	return renames[len(renames)-1].NewPath, nil
+}
+
+// findFileRename checks whether the last commit affecting the removed file renamed it.
+func (g *Git) findFileRename(ctx context.Context, path string) (FileRename, bool, error) {
+	commit, err := g.FindFileLastCommit(ctx, path)
+	if err != nil {
+		return FileRename{}, false, err
+	}
+
+	if commit.CommitID == "" {
+		return FileRename{}, false, nil
+	}
+
+	lines, err := execToLines(g.exec(ctx, g.path,
+		"git",
+		"-c",
+		"core.quotePath=false",
+		"--no-pager",
+		"show",
+		"--format=",
+		"--name-status",
+		"--find-renames",
+		"--diff-filter=R",
+		commit.CommitID,
+	))
+	if err != nil {
+		return FileRename{}, false, err
+	}
+
+	for _, line := range lines {
+		segs := strings.Split(line, "\t")
+		if len(segs) != 3 || !strings.HasPrefix(segs[0], "R") || segs[1] != path {
+			continue
+		}
+
+		similarity, err := strconv.Atoi(strings.TrimPrefix(segs[0], "R"))
+		if err != nil {
+			return FileRename{}, false, fmt.Errorf("%w: unexpected rename status %q", ErrInvalidCommitFilesLog, segs[0])
+		}
+
+		return FileRename{
+			Commit:     commit,
+			OldPath:    segs[1],
+			NewPath:    segs[2],
+			Similarity: similarity,
+		}, true, nil
+	}
+
+	return FileRename{}, false, nil
+}
+
+// FindCommit provides information about the given commit.
+// It returns an empty CommitInfo if the commit does not exist.
+func (g *Git) FindCommit(ctx context.Context, commitID string) (CommitInfo, error) {
+	return execToCommitInfo(g.exec(ctx, g.path,
+		"git",
+		"--no-pager",
+		"log",
+		"-1",
+		"--ignore-missing",
+		"--format=%H %cd %s",
+		"--date=iso-strict",
+		commitID,
+		"--",
+	))
+}
+
+// ListCommitTrailerValues lists values of the given trailer (for example "Signed-off-by")
+// in the message of the commit.
+func (g *Git) ListCommitTrailerValues(ctx context.Context, commitID string, key string) ([]string, error) {
+	lines, err := execToLines(g.exec(ctx, g.path,
+		"git",
+		"--no-pager",
+		"log",
+		"-1",
+		"--format=%(trailers:key="+key+",valueonly)",
+		commitID,
+		"--",
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	var values []string
+
+	for _, line := range lines {
+		if value := strings.TrimSpace(line); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values, nil
+}
+
+// ReadFile returns the content of the file in the working tree.
+func (g *Git) ReadFile(path string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(g.path, path))
+	if err != nil {
+		return "", fmt.Errorf("read file: %w", err)
+	}
+
+	return string(content), nil
+}
+
+// FileDiff returns the unified diff introduced by commitID for the given file.
+// The whole file is included as context so that callers can tell which part
+// of the file (for example front matter or a code block) each change belongs to.
+func (g *Git) FileDiff(ctx context.Context, commitID string, path string) (string, error) {
+	return g.exec(ctx, g.path,
+		"git",
+		"--no-pager",
+		"show",
+		"--format=",
+		"--no-color",
+		"--no-ext-diff",
+		"--unified="+strconv.Itoa(fullFileDiffContext),
+		commitID,
+		"--",
+		path,
+	)
+}
+
+// FileRangeDiff returns the unified diff of the given files between two revisions.
+// Renames between the given paths are detected, so passing the old and the new
+// path of a moved file shows its changes instead of a deletion and an addition.
+func (g *Git) FileRangeDiff(ctx context.Context, fromRevision string, toRevision string, paths ...string) (string, error) {
+	return g.fileRangeDiff(ctx, fromRevision, toRevision, nil, paths)
+}
+
+// FullFileRangeDiff is FileRangeDiff with the whole files as context,
+// so that every line of both revisions is part of the diff.
+func (g *Git) FullFileRangeDiff(
+	ctx context.Context,
+	fromRevision string,
+	toRevision string,
+	paths ...string,
+) (string, error) {
+	return g.fileRangeDiff(ctx, fromRevision, toRevision, []string{"--unified=" + strconv.Itoa(fullFileDiffContext)}, paths)
+}
+
+func (g *Git) fileRangeDiff(
+	ctx context.Context,
+	fromRevision string,
+	toRevision string,
+	options []string,
+	paths []string,
+) (string, error) {
+	args := []string{
+		"--no-pager",
+		"diff",
+		"--no-color",
+		"--no-ext-diff",
+		"--find-renames",
+	}
+	args = append(args, options...)
+	args = append(args, fromRevision, toRevision, "--")
+
+	return g.exec(ctx, g.path, "git", append(args, paths...)...)
+}
+
+// FilePatch returns the changes of the given files introduced by commitID
+// as an email patch (git format-patch), which can be applied with git am.
+func (g *Git) FilePatch(ctx context.Context, commitID string, paths ...string) (string, error) {
+	args := []string{
+		"--no-pager",
+		"format-patch",
+		"-1",
+		"--stdout",
+		"--no-signature",
+		"--no-color",
+		"--find-renames",
+		commitID,
+		"--",
+	}
+
+	return g.exec(ctx, g.path, "git", append(args, paths...)...)
+}
+
 //nolint:unparam
 func (g *Git) exec(ctx context.Context, workingDir string, cmd string, args ...string) (string, error) {
 	out, err := g.runner.Exec(ctx, workingDir, cmd, args...)
@@ -301,6 +768,57 @@ func execToCommitInfoSlice(out string, err error) ([]CommitInfo, error) {
 	return commits, nil
 }
 
+func parseCommitFilesLog(out string) ([]CommitFiles, error) {
+	if len(strings.TrimSpace(out)) == 0 {
+		return nil, nil
+	}
+
+	var commits []CommitFiles
+
+	lines := outputToLines(out)
+
+	for idx := 0; idx < len(lines); idx++ {
+		line := lines[idx]
+
+		switch {
+		case strings.HasPrefix(line, commitFilesHeader):
+			if idx+1 >= len(lines) {
+				return nil, fmt.Errorf("%w: missing commit info after %q", ErrInvalidCommitFilesLog, line)
+			}
+
+			idx++
+
+			commitInfo, err := lineToCommitInfo(lines[idx])
+			if err != nil {
+				return nil, err
+			}
+
+			ids := strings.Fields(strings.TrimPrefix(line, commitFilesHeader))
+			if len(ids) == 0 || ids[0] != commitInfo.CommitID {
+				return nil, fmt.Errorf("%w: unexpected header %q", ErrInvalidCommitFilesLog, line)
+			}
+
+			commits = append(commits, CommitFiles{
+				CommitInfo: commitInfo,
+				ParentIDs:  ids[1:],
+				Files:      nil,
+			})
+		case len(strings.TrimSpace(line)) == 0:
+			continue
+		default:
+			_, path, found := strings.Cut(line, "\t")
+			if !found || len(commits) == 0 {
+				return nil, fmt.Errorf("%w: unexpected line %q", ErrInvalidCommitFilesLog, line)
+			}
+
+			last := &commits[len(commits)-1]
+			last.Files = append(last.Files, path)
+		}
+	}
+
+	return commits, nil
+}
+
 func execToSeparatedLines(out string, err error) ([]string, error) {
 	lines, err := execToLines(out, err)
 	if err != nil {
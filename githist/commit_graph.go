@@ -0,0 +1,168 @@
+package githist
+
+import (
+	"github.com/dkarczmarski/go-kweb-lang/git"
+)
+
+// commitGraph indexes the first-parent history of the main branch, so that
+// membership tests and position lookups take constant time.
+//
+// Position 0 is the HEAD of the main branch and the first parent of the commit
+// at position i is the commit at position i+1.
+type commitGraph struct {
+	commits []git.CommitInfo
+	// parents holds the parents of the commit at the same position,
+	// nil if they are not known, for example for fresh commits.
+	parents   [][]string
+	positions map[string]int
+}
+
+// commitGraphData is the compact persisted form of commitGraph: one column per
+// commit attribute, indexed by the first-parent position.
+type commitGraphData struct {
+	IDs      []string   `json:"ids"`
+	Dates    []string   `json:"dates"`
+	Comments []string   `json:"comments"`
+	Parents  [][]string `json:"parents,omitempty"`
+}
+
+// newCommitGraph builds the graph from main branch commits ordered from the newest
+// and their parents keyed by commit ID.
+func newCommitGraph(commits []git.CommitInfo, parentsByID map[string][]string) *commitGraph {
+	parents := make([][]string, len(commits))
+
+	for position := range commits {
+		parents[position] = parentsByID[commits[position].CommitID]
+	}
+
+	return newCommitGraphWithParents(commits, parents)
+}
+
+func newCommitGraphWithParents(commits []git.CommitInfo, parents [][]string) *commitGraph {
+	positions := make(map[string]int, len(commits))
+
+	for position := range commits {
+		if _, exists := positions[commits[position].CommitID]; !exists {
+			positions[commits[position].CommitID] = position
+		}
+	}
+
+	return &commitGraph{
+		commits:   commits,
+		parents:   parents,
+		positions: positions,
+	}
+}
+
+func newCommitGraphFromData(data commitGraphData) *commitGraph {
+	commits := make([]git.CommitInfo, len(data.IDs))
+	parents := make([][]string, len(data.IDs))
+
+	for position := range data.IDs {
+		commits[position] = git.CommitInfo{
+			CommitID: data.IDs[position],
+			DateTime: valueAt(data.Dates, position),
+			Comment:  valueAt(data.Comments, position),
+		}
+
+		if position < len(data.Parents) {
+			parents[position] = data.Parents[position]
+		}
+	}
+
+	return newCommitGraphWithParents(commits, parents)
+}
+
+func (g *commitGraph) data() commitGraphData {
+	data := commitGraphData{
+		IDs:      make([]string, len(g.commits)),
+		Dates:    make([]string, len(g.commits)),
+		Comments: make([]string, len(g.commits)),
+		Parents:  make([][]string, len(g.commits)),
+	}
+
+	for position, commit := range g.commits {
+		data.IDs[position] = commit.CommitID
+		data.Dates[position] = commit.DateTime
+		data.Comments[position] = commit.Comment
+		data.Parents[position] = g.parents[position]
+	}
+
+	return data
+}
+
+// contains checks whether the commit is on the first-parent history of the main branch.
+func (g *commitGraph) contains(commitID string) bool {
+	_, ok := g.positions[commitID]
+
+	return ok
+}
+
+// parentsOf returns the parents of a main branch commit, if they are known.
+func (g *commitGraph) parentsOf(commitID string) ([]string, bool) {
+	position, ok := g.positions[commitID]
+	if !ok || g.parents[position] == nil {
+		return nil, false
+	}
+
+	return g.parents[position], true
+}
+
+// head returns the newest commit of the main branch.
+func (g *commitGraph) head() (git.CommitInfo, bool) {
+	if len(g.commits) == 0 {
+		return zeroCommitInfo(), false
+	}
+
+	return g.commits[0], true
+}
+
+// withFreshCommits returns a graph extended with fresh commits ordered from the newest,
+// whose oldest commit is a child of the current HEAD.
+func (g *commitGraph) withFreshCommits(freshCommits []git.CommitInfo) *commitGraph {
+	if len(freshCommits) == 0 {
+		return g
+	}
+
+	commits := make([]git.CommitInfo, 0, len(freshCommits)+len(g.commits))
+	commits = append(commits, freshCommits...)
+	commits = append(commits, g.commits...)
+
+	parents := make([][]string, len(freshCommits), len(commits))
+	parents = append(parents, g.parents...)
+
+	return newCommitGraphWithParents(commits, parents)
+}
+
+// withoutCommits returns a graph without the given commits, for example commits
+// dropped from the main branch by a force-push.
+func (g *commitGraph) withoutCommits(droppedCommits []git.CommitInfo) *commitGraph {
+	if len(droppedCommits) == 0 {
+		return g
+	}
+
+	dropped := make(map[string]bool, len(droppedCommits))
+	for _, commit := range droppedCommits {
+		dropped[commit.CommitID] = true
+	}
+
+	commits := make([]git.CommitInfo, 0, len(g.commits))
+	parents := make([][]string, 0, len(g.commits))
+
+	for position, commit := range g.commits {
+		if !dropped[commit.CommitID] {
+			commits = append(commits, commit)
+			parents = append(parents, g.parents[position])
+		}
+	}
+
+	return newCommitGraphWithParents(commits, parents)
+}
+
+func valueAt(values []string, position int) string {
+	if position >= len(values) {
+		return ""
+	}
+
+	return values[position]
+}
@@ -0,0 +1,126 @@
+package githist
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/dkarczmarski/go-kweb-lang/git"
+)
+
+func TestCommitGraph(t *testing.T) {
+	t.Parallel()
+
+	commits := []git.CommitInfo{
+		{CommitID: "C-ID-30", DateTime: "DT-30", Comment: "TEXT-30"},
+		{CommitID: "C-ID-20", DateTime: "DT-20", Comment: "TEXT-20"},
+		{CommitID: "C-ID-10", DateTime: "DT-10", Comment: "TEXT-10"},
+	}
+
+	parents := map[string][]string{
+		"C-ID-30": {"C-ID-20", "C-ID-25"},
+		"C-ID-20": {"C-ID-10"},
+		"C-ID-10": {},
+	}
+
+	t.Run("contains main branch commits only", func(t *testing.T) {
+		t.Parallel()
+
+		graph := newCommitGraph(commits, parents)
+
+		if !graph.contains("C-ID-20") {
+			t.Error("expected C-ID-20 to be on the main branch")
+		}
+
+		if graph.contains("C-ID-25") {
+			t.Error("expected C-ID-25 not to be on the main branch")
+		}
+
+		head, ok := graph.head()
+		if !ok || head != commits[0] {
+			t.Errorf("unexpected head: %+v", head)
+		}
+	})
+
+	t.Run("survives the persisted form", func(t *testing.T) {
+		t.Parallel()
+
+		encoded, err := json.Marshal(newCommitGraph(commits, parents).data())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var data commitGraphData
+		if err := json.Unmarshal(encoded, &data); err != nil {
+			t.Fatal(err)
+		}
+
+		graph := newCommitGraphFromData(data)
+
+		if !reflect.DeepEqual(commits, graph.commits) {
+			t.Errorf("unexpected commits: %+v", graph.commits)
+		}
+
+		if !graph.contains("C-ID-10") {
+			t.Error("expected C-ID-10 to be on the main branch")
+		}
+
+		if got, ok := graph.parentsOf("C-ID-30"); !ok || !reflect.DeepEqual(got, parents["C-ID-30"]) {
+			t.Errorf("unexpected parents of C-ID-30: %v", got)
+		}
+	})
+
+	t.Run("returns parents of main branch commits", func(t *testing.T) {
+		t.Parallel()
+
+		graph := newCommitGraph(commits, parents).withFreshCommits([]git.CommitInfo{
+			{CommitID: "C-ID-40", DateTime: "DT-40", Comment: "TEXT-40"},
+		})
+
+		if got, ok := graph.parentsOf("C-ID-30"); !ok || !reflect.DeepEqual(got, parents["C-ID-30"]) {
+			t.Errorf("unexpected parents of C-ID-30: %v", got)
+		}
+
+		if got, ok := graph.parentsOf("C-ID-10"); !ok || len(got) != 0 {
+			t.Errorf("unexpected parents of root commit C-ID-10: %v", got)
+		}
+
+		if _, ok := graph.parentsOf("C-ID-40"); ok {
+			t.Error("expected parents of fresh commit C-ID-40 not to be known")
+		}
+
+		if _, ok := graph.parentsOf("C-ID-25"); ok {
+			t.Error("expected parents of C-ID-25 not to be known")
+		}
+	})
+
+	t.Run("is extended with fresh commits", func(t *testing.T) {
+		t.Parallel()
+
+		graph := newCommitGraph(commits, parents)
+		fresh := graph.withFreshCommits([]git.CommitInfo{
+			{CommitID: "C-ID-40", DateTime: "DT-40", Comment: "TEXT-40"},
+		})
+
+		if !fresh.contains("C-ID-40") || !fresh.contains("C-ID-10") {
+			t.Error("expected both fresh and old commits to be on the main branch")
+		}
+
+		if graph.contains("C-ID-40") {
+			t.Error("expected the original graph to be left unchanged")
+		}
+
+		head, _ := fresh.head()
+		if head.CommitID != "C-ID-40" {
+			t.Errorf("unexpected head: %+v", head)
+		}
+	})
+
+	t.Run("empty graph has no head", func(t *testing.T) {
+		t.Parallel()
+
+		if _, ok := newCommitGraph(nil, nil).head(); ok {
+			t.Error("expected no head")
+		}
+	})
+}
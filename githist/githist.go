@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"sync"
 
 	"github.com/dkarczmarski/go-kweb-lang/git"
 )
@@ -23,10 +24,13 @@ var ErrCommitOnMainBranch = errors.New("commit is on main branch")
 // GitRepo is an interface used to decouple this package from the concrete git implementation.
 type GitRepo interface {
 	ListMainBranchCommits(ctx context.Context) ([]git.CommitInfo, error)
+	ListMainBranchCommitParents(ctx context.Context) (map[string][]string, error)
 	ListMergePoints(ctx context.Context, commitID string) ([]git.CommitInfo, error)
 	Fetch(ctx context.Context) error
 	ListFreshCommits(ctx context.Context) ([]git.CommitInfo, error)
+	ListDroppedCommits(ctx context.Context) ([]git.CommitInfo, error)
 	Pull(ctx context.Context) error
+	ResetToOrigin(ctx context.Context) error
 	ListFilesInCommit(ctx context.Context, commitID string) ([]string, error)
 	ListAncestorCommits(ctx context.Context, commitID string) ([]git.CommitInfo, error)
 	ListCommitParents(ctx context.Context, commitID string) ([]string, error)
@@ -48,31 +52,57 @@ type Invalidator interface {
 	InvalidateFile(langCode, path string) error
 }
 
+// HistoryIndex is an index of the git history that has to be extended
+// with every pulled commit and rebuilt when the history is rewritten.
+type HistoryIndex interface {
+	Extend(ctx context.Context) error
+	Invalidate() error
+}
+
+type NewConfig struct {
+	// HistoryIndex is extended after fresh commits are pulled. It is optional.
+	HistoryIndex HistoryIndex
+}
+
 type GitHist struct {
-	gitRepo GitRepo
-	cache   CacheStorage
+	gitRepo      GitRepo
+	cache        CacheStorage
+	historyIndex HistoryIndex
+
+	mu    sync.Mutex
+	graph *commitGraph
 }
 
-func New(gitRepo GitRepo, cache CacheStorage) *GitHist {
+func New(gitRepo GitRepo, cache CacheStorage, opts ...func(config *NewConfig)) *GitHist {
+	config := NewConfig{
+		HistoryIndex: nil,
+	}
+
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	//nolint:exhaustruct
 	return &GitHist{
-		gitRepo: gitRepo,
-		cache:   cache,
+		gitRepo:      gitRepo,
+		cache:        cache,
+		historyIndex: config.HistoryIndex,
 	}
 }
 
 const (
-	bucketMainBranchCommits = "git-main-branch-commits"
+	bucketMainBranchCommits = "git-main-branch-graph"
 	keyMainBranchCommits    = ""
 )
 
 // MainBranchCommitsCacheBucket returns the cache bucket name used for storing
-// main branch commits.
+// the main branch commit graph.
 func MainBranchCommitsCacheBucket() string {
 	return bucketMainBranchCommits
 }
 
 // MainBranchCommitsCacheKey returns the cache key used for storing
-// main branch commits inside the main branch commits bucket.
+// the main branch commit graph inside the main branch commits bucket.
 func MainBranchCommitsCacheKey() string {
 	return keyMainBranchCommits
 }
@@ -82,20 +112,20 @@ func MainBranchCommitsCacheKey() string {
 // ErrCommitOnMainBranch is returned when commitID already exists on the main
 // branch. In that case there is no fork commit to find.
 func (gh *GitHist) FindForkCommit(ctx context.Context, commitID string) (*git.CommitInfo, error) {
-	mainBranchCommits, err := gh.listMainBranchCommits(ctx)
+	mainBranch, err := gh.mainBranchGraph(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return gh.findForkCommit(ctx, commitID, mainBranchCommits)
+	return gh.findForkCommit(ctx, commitID, mainBranch)
 }
 
 func (gh *GitHist) findForkCommit(
 	ctx context.Context,
 	commitID string,
-	mainBranchCommits []git.CommitInfo,
+	mainBranch *commitGraph,
 ) (*git.CommitInfo, error) {
-	return findFirstIntersectionWithMainBranch(ctx, mainBranchCommits, commitID, gh.gitRepo.ListAncestorCommits)
+	return findFirstIntersectionWithMainBranch(ctx, mainBranch, commitID, gh.gitRepo.ListAncestorCommits)
 }
 
 // FindMergeCommit returns the merge commit with the main branch for the given commitID.
@@ -103,19 +133,28 @@ func (gh *GitHist) findForkCommit(
 // ErrCommitOnMainBranch is returned when commitID already exists on the main
 // branch. In that case there is no merge commit to find.
 func (gh *GitHist) FindMergeCommit(ctx context.Context, commitID string) (*git.CommitInfo, error) {
-	mainBranchCommits, err := gh.listMainBranchCommits(ctx)
+	mainBranch, err := gh.mainBranchGraph(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return findFirstIntersectionWithMainBranch(ctx, mainBranchCommits, commitID, gh.gitRepo.ListMergePoints)
+	return findFirstIntersectionWithMainBranch(ctx, mainBranch, commitID, gh.gitRepo.ListMergePoints)
 }
 
-func (gh *GitHist) listMainBranchCommits(ctx context.Context) ([]git.CommitInfo, error) {
+// mainBranchGraph returns the main branch commit graph held in memory. It is loaded
+// from the cache or, if it is not cached yet, built from the git repository.
+func (gh *GitHist) mainBranchGraph(ctx context.Context) (*commitGraph, error) {
+	gh.mu.Lock()
+	defer gh.mu.Unlock()
+
+	if gh.graph != nil {
+		return gh.graph, nil
+	}
+
 	bucket := MainBranchCommitsCacheBucket()
 	key := MainBranchCommitsCacheKey()
 
-	var cached []git.CommitInfo
+	var cached commitGraphData
 
 	exists, err := gh.cache.Read(bucket, key, &cached)
 	if err != nil {
@@ -123,7 +162,9 @@ func (gh *GitHist) listMainBranchCommits(ctx context.Context) ([]git.CommitInfo,
 	}
 
 	if exists {
-		return cached, nil
+		gh.graph = newCommitGraphFromData(cached)
+
+		return gh.graph, nil
 	}
 
 	mainBranchCommits, err := gh.gitRepo.ListMainBranchCommits(ctx)
@@ -131,26 +172,33 @@ func (gh *GitHist) listMainBranchCommits(ctx context.Context) ([]git.CommitInfo,
 		return nil, fmt.Errorf("list main branch commits: %w", err)
 	}
 
-	if err := gh.cache.Write(bucket, key, mainBranchCommits); err != nil {
+	mainBranchParents, err := gh.gitRepo.ListMainBranchCommitParents(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list main branch commit parents: %w", err)
+	}
+
+	graph := newCommitGraph(mainBranchCommits, mainBranchParents)
+
+	if err := gh.cache.Write(bucket, key, graph.data()); err != nil {
 		return nil, fmt.Errorf("write main branch commits cache: %w", err)
 	}
 
-	return mainBranchCommits, nil
+	gh.graph = graph
+
+	return gh.graph, nil
 }
 
 // findFirstIntersectionWithMainBranch finds the first commit that exists both
 // on the main branch and on the commit path produced for the given commitID.
 //
-// mainBranchCommits must contain commits from the main branch ordered from
-// the oldest to the newest commit. The element at index 0 must be the oldest
-// commit in the list.
+// mainBranch is the commit graph of the main branch.
 //
 // pathFunc is used to generate a commit path for the given commitID.
 // The order of commits in the returned slice is important. Commits are checked
 // sequentially starting from index 0.
 //
 // The function returns the first commit from the generated path that also
-// exists in mainBranchCommits.
+// exists on the main branch.
 //
 // ErrCommitOnMainBranch is returned when commitID itself already exists on the
 // main branch.
@@ -159,14 +207,14 @@ func (gh *GitHist) listMainBranchCommits(ctx context.Context) ([]git.CommitInfo,
 //
 // ErrCommitPathsNotConnected is returned when the path returned by pathFunc
 // does not intersect with the main branch. In that case none of the commits
-// from the generated path exist in mainBranchCommits.
+// from the generated path exist on the main branch.
 func findFirstIntersectionWithMainBranch(
 	ctx context.Context,
-	mainBranchCommits []git.CommitInfo,
+	mainBranch *commitGraph,
 	commitID string,
 	pathFunc func(ctx context.Context, commitID string) ([]git.CommitInfo, error),
 ) (*git.CommitInfo, error) {
-	if containsCommit(mainBranchCommits, commitID) {
+	if mainBranch.contains(commitID) {
 		return nil, ErrCommitOnMainBranch
 	}
 
@@ -175,23 +223,13 @@ func findFirstIntersectionWithMainBranch(
 		return nil, err
 	}
 
-	return findFirstCommit(mainBranchCommits, commitPath)
-}
-
-func containsCommit(list []git.CommitInfo, commitID string) bool {
-	for i := range list {
-		if list[i].CommitID == commitID {
-			return true
-		}
-	}
-
-	return false
+	return findFirstCommit(mainBranch, commitPath)
 }
 
 // findFirstCommit returns the first commit from commitPath that also exists
-// on mainBranchPath.
+// on the main branch.
 //
-// Both paths must be ordered from the top of the history towards older commits.
+// commitPath must be ordered from the top of the history towards older commits.
 // The first element is the newest commit in the path.
 // The last element is the oldest commit in the path.
 //
@@ -201,7 +239,7 @@ func containsCommit(list []git.CommitInfo, commitID string) bool {
 // It returns ErrCommitPathsNotConnected when the two paths do not share any
 // common commit.
 func findFirstCommit(
-	mainBranchPathToRoot []git.CommitInfo,
+	mainBranch *commitGraph,
 	commitPath []git.CommitInfo,
 ) (*git.CommitInfo, error) {
 	if len(commitPath) == 0 {
@@ -210,7 +248,7 @@ func findFirstCommit(
 
 	for i := range commitPath {
 		commit := commitPath[i]
-		if containsCommit(mainBranchPathToRoot, commit.CommitID) {
+		if mainBranch.contains(commit.CommitID) {
 			clonedCommitInfo := cloneCommitInfo(commit)
 
 			return &clonedCommitInfo, nil
@@ -222,13 +260,17 @@ func findFirstCommit(
 
 // PullRefresh performs a git fetch to retrieve fresh data, detects any changes, runs git pull
 // and returns the list of changed files.
+//
+// If the history of the origin main branch was rewritten (for example by a force-push), the
+// commits dropped from the main branch are detected as well, files changed by them are also
+// returned and the local clone is reset to the origin branch instead of being pulled.
 func (gh *GitHist) PullRefresh(ctx context.Context) ([]string, error) {
-	mainBranchCommits, err := gh.listMainBranchCommits(ctx)
+	mainBranch, err := gh.mainBranchGraph(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("list main branch commits: %w", err)
 	}
 
-	lastMainCommit := gh.getLastMainBranchCommit(mainBranchCommits)
+	lastMainCommit := gh.getLastMainBranchCommit(mainBranch)
 
 	log.Printf("[githist] last main branch commit: %v", lastMainCommit)
 
@@ -241,29 +283,75 @@ func (gh *GitHist) PullRefresh(ctx context.Context) ([]string, error) {
 		return nil, fmt.Errorf("list fresh commits: %w", err)
 	}
 
-	if len(freshCommits) > 0 {
+	droppedCommits, err := gh.gitRepo.ListDroppedCommits(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list dropped commits: %w", err)
+	}
+
+	if len(freshCommits) > 0 || len(droppedCommits) > 0 {
 		if err := gh.InvalidateMainBranchCommits(); err != nil {
 			return nil, fmt.Errorf("invalidate main branch commits cache: %w", err)
 		}
 	}
 
-	freshMainBranchCommits := make([]git.CommitInfo, 0, len(freshCommits)+len(mainBranchCommits))
-	freshMainBranchCommits = append(freshMainBranchCommits, freshCommits...)
-	freshMainBranchCommits = append(freshMainBranchCommits, mainBranchCommits...)
+	if len(droppedCommits) > 0 {
+		log.Printf("[githist] main branch diverged from origin: %d dropped commits", len(droppedCommits))
+	}
 
-	changedFiles, err := gh.processFreshCommits(ctx, freshCommits, freshMainBranchCommits)
+	droppedFiles, err := gh.processCommits(ctx, "dropped", droppedCommits, mainBranch)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := gh.gitRepo.Pull(ctx); err != nil {
-		return nil, fmt.Errorf("git pull: %w", err)
+	freshMainBranch := mainBranch.withoutCommits(droppedCommits).withFreshCommits(freshCommits)
+
+	freshFiles, err := gh.processCommits(ctx, "fresh", freshCommits, freshMainBranch)
+	if err != nil {
+		return nil, err
 	}
 
-	return changedFiles, nil
+	if err := gh.updateLocalClone(ctx, len(freshCommits) > 0, len(droppedCommits) > 0); err != nil {
+		return nil, err
+	}
+
+	return append(droppedFiles, freshFiles...), nil
+}
+
+// updateLocalClone moves the local main branch to the origin branch and updates the history index.
+func (gh *GitHist) updateLocalClone(ctx context.Context, hasFreshCommits, diverged bool) error {
+	if !diverged {
+		if err := gh.gitRepo.Pull(ctx); err != nil {
+			return fmt.Errorf("git pull: %w", err)
+		}
+
+		if hasFreshCommits && gh.historyIndex != nil {
+			if err := gh.historyIndex.Extend(ctx); err != nil {
+				return fmt.Errorf("extend history index: %w", err)
+			}
+		}
+
+		return nil
+	}
+
+	if err := gh.gitRepo.ResetToOrigin(ctx); err != nil {
+		return fmt.Errorf("git reset to origin: %w", err)
+	}
+
+	if gh.historyIndex != nil {
+		if err := gh.historyIndex.Invalidate(); err != nil {
+			return fmt.Errorf("invalidate history index: %w", err)
+		}
+	}
+
+	return nil
 }
 
 func (gh *GitHist) InvalidateMainBranchCommits() error {
+	gh.mu.Lock()
+	defer gh.mu.Unlock()
+
+	gh.graph = nil
+
 	if err := gh.cache.Delete(MainBranchCommitsCacheBucket(), MainBranchCommitsCacheKey()); err != nil {
 		return fmt.Errorf("delete main branch commits cache: %w", err)
 	}
@@ -271,36 +359,40 @@ func (gh *GitHist) InvalidateMainBranchCommits() error {
 	return nil
 }
 
-func (gh *GitHist) processFreshCommits(
+// processCommits lists files changed by the commits (ordered from the newest).
+// Files of merge commits are determined using the given main branch graph.
+func (gh *GitHist) processCommits(
 	ctx context.Context,
-	freshCommits []git.CommitInfo,
-	freshMainBranchCommits []git.CommitInfo,
+	kind string,
+	commits []git.CommitInfo,
+	mainBranch *commitGraph,
 ) ([]string, error) {
 	changedFiles := make([]string, 0)
 
-	for idx := range freshCommits {
-		freshCommit := freshCommits[len(freshCommits)-1-idx]
+	for idx := range commits {
+		commit := commits[len(commits)-1-idx]
 
 		log.Printf(
-			"[githist][%d/%d] processing fresh commit: %s",
+			"[githist][%d/%d] processing %s commit: %s",
 			idx+1,
-			len(freshCommits),
-			&freshCommit,
+			len(commits),
+			kind,
+			&commit,
 		)
 
-		commitFiles, err := gh.gitRepo.ListFilesInCommit(ctx, freshCommit.CommitID)
+		commitFiles, err := gh.gitRepo.ListFilesInCommit(ctx, commit.CommitID)
 		if err != nil {
-			return nil, fmt.Errorf("list files in commit %s: %w", freshCommit.CommitID, err)
+			return nil, fmt.Errorf("list files in commit %s: %w", commit.CommitID, err)
 		}
 
 		if len(commitFiles) == 0 {
 			mergeCommitFiles, err := gh.mergeCommitFiles(
 				ctx,
-				freshCommit.CommitID,
-				freshMainBranchCommits,
+				commit.CommitID,
+				mainBranch,
 			)
 			if err != nil {
-				return nil, fmt.Errorf("list files in merge commit %s: %w", freshCommit.CommitID, err)
+				return nil, fmt.Errorf("list files in merge commit %s: %w", commit.CommitID, err)
 			}
 
 			changedFiles = append(changedFiles, mergeCommitFiles...)
@@ -308,7 +400,7 @@ func (gh *GitHist) processFreshCommits(
 			log.Printf(
 				"[githist][%d/%d] merge commit files: %s",
 				idx+1,
-				len(freshCommits),
+				len(commits),
 				mergeCommitFiles,
 			)
 		} else {
@@ -317,7 +409,7 @@ func (gh *GitHist) processFreshCommits(
 			log.Printf(
 				"[githist][%d/%d] commit files: %s",
 				idx+1,
-				len(freshCommits),
+				len(commits),
 				commitFiles,
 			)
 		}
@@ -328,32 +420,51 @@ func (gh *GitHist) processFreshCommits(
 
 // IsMainBranchCommit checks whether the given commit ID is part of the main branch.
 func (gh *GitHist) IsMainBranchCommit(ctx context.Context, commitID string) (bool, error) {
-	mainBranchCommits, err := gh.listMainBranchCommits(ctx)
+	mainBranch, err := gh.mainBranchGraph(ctx)
 	if err != nil {
 		return false, err
 	}
 
-	return containsCommit(mainBranchCommits, commitID), nil
+	return mainBranch.contains(commitID), nil
 }
 
 // MergeCommitFiles lists all files from the branch that was merged in the merge commit specified by mergeCommitID.
 func (gh *GitHist) MergeCommitFiles(ctx context.Context, mergeCommitID string) ([]string, error) {
-	mainBranchCommits, err := gh.listMainBranchCommits(ctx)
+	mainBranch, err := gh.mainBranchGraph(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return gh.mergeCommitFiles(ctx, mergeCommitID, mainBranchCommits)
+	return gh.mergeCommitFiles(ctx, mergeCommitID, mainBranch)
+}
+
+// listCommitParents returns the parents of a commit from the main branch graph
+// and falls back to git for commits whose parents are not indexed.
+func (gh *GitHist) listCommitParents(
+	ctx context.Context,
+	commitID string,
+	mainBranch *commitGraph,
+) ([]string, error) {
+	if parents, ok := mainBranch.parentsOf(commitID); ok {
+		return parents, nil
+	}
+
+	parents, err := gh.gitRepo.ListCommitParents(ctx, commitID)
+	if err != nil {
+		return nil, fmt.Errorf("list parents of merge commit %s: %w", commitID, err)
+	}
+
+	return parents, nil
 }
 
 func (gh *GitHist) mergeCommitFiles(
 	ctx context.Context,
 	mergeCommitID string,
-	mainBranchCommits []git.CommitInfo,
+	mainBranch *commitGraph,
 ) ([]string, error) {
-	parents, err := gh.gitRepo.ListCommitParents(ctx, mergeCommitID)
+	parents, err := gh.listCommitParents(ctx, mergeCommitID, mainBranch)
 	if err != nil {
-		return nil, fmt.Errorf("list parents of merge commit %s: %w", mergeCommitID, err)
+		return nil, err
 	}
 
 	if len(parents) == 1 {
@@ -366,7 +477,7 @@ func (gh *GitHist) mergeCommitFiles(
 	for i := range parents {
 		branchParentCommitID := parents[i]
 
-		forkCommit, err := gh.findForkCommit(ctx, branchParentCommitID, mainBranchCommits)
+		forkCommit, err := gh.findForkCommit(ctx, branchParentCommitID, mainBranch)
 		if err != nil {
 			if errors.Is(err, ErrCommitOnMainBranch) {
 				continue
@@ -398,20 +509,21 @@ func (gh *GitHist) mergeCommitFiles(
 }
 
 func (gh *GitHist) GetLastMainBranchCommit(ctx context.Context) (git.CommitInfo, error) {
-	commits, err := gh.listMainBranchCommits(ctx)
+	mainBranch, err := gh.mainBranchGraph(ctx)
 	if err != nil {
 		return zeroCommitInfo(), err
 	}
 
-	return gh.getLastMainBranchCommit(commits), nil
+	return gh.getLastMainBranchCommit(mainBranch), nil
 }
 
-func (gh *GitHist) getLastMainBranchCommit(mainBranchCommits []git.CommitInfo) git.CommitInfo {
-	if len(mainBranchCommits) == 0 {
+func (gh *GitHist) getLastMainBranchCommit(mainBranch *commitGraph) git.CommitInfo {
+	head, ok := mainBranch.head()
+	if !ok {
 		return zeroCommitInfo()
 	}
 
-	return cloneCommitInfo(mainBranchCommits[0])
+	return cloneCommitInfo(head)
 }
 
 // cloneCommitInfo clones a single commit info to avoid retaining references to large underlying data
@@ -43,6 +43,11 @@ func TestGitHist_FindForkCommit(t *testing.T) {
 					Return(mainBranchCommits, nil).
 					Times(1)
 
+				m.EXPECT().
+					ListMainBranchCommitParents(ctx).
+					Return(nil, nil).
+					Times(1)
+
 				m.EXPECT().
 					ListAncestorCommits(ctx, commitID).
 					Return([]git.CommitInfo{
@@ -62,6 +67,11 @@ func TestGitHist_FindForkCommit(t *testing.T) {
 					ListMainBranchCommits(ctx).
 					Return(mainBranchCommits, nil).
 					Times(1)
+
+				m.EXPECT().
+					ListMainBranchCommitParents(ctx).
+					Return(nil, nil).
+					Times(1)
 			},
 		},
 	} {
@@ -127,6 +137,11 @@ func TestGitHist_FindMergeCommit(t *testing.T) {
 					Return(mainBranchCommits, nil).
 					Times(1)
 
+				m.EXPECT().
+					ListMainBranchCommitParents(ctx).
+					Return(nil, nil).
+					Times(1)
+
 				m.EXPECT().
 					ListMergePoints(ctx, commitID).
 					Return([]git.CommitInfo{
@@ -146,6 +161,11 @@ func TestGitHist_FindMergeCommit(t *testing.T) {
 					ListMainBranchCommits(ctx).
 					Return(mainBranchCommits, nil).
 					Times(1)
+
+				m.EXPECT().
+					ListMainBranchCommitParents(ctx).
+					Return(nil, nil).
+					Times(1)
 			},
 		},
 	} {
@@ -182,6 +202,112 @@ func TestGitHist_FindMergeCommit(t *testing.T) {
 	}
 }
 
+func TestGitHist_KeepsMainBranchGraphInMemory(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	ctrl := gomock.NewController(t)
+	gitRepo := mocks.NewMockGitRepo(ctrl)
+	cache := mocks.NewMockCacheStorage(ctrl)
+
+	cache.EXPECT().
+		Read(githist.MainBranchCommitsCacheBucket(), githist.MainBranchCommitsCacheKey(), gomock.Any()).
+		DoAndReturn(storetests.MockReadNotFound()).
+		Times(1)
+
+	cache.EXPECT().
+		Write(githist.MainBranchCommitsCacheBucket(), githist.MainBranchCommitsCacheKey(), gomock.Any()).
+		Return(nil).
+		Times(1)
+
+	gitRepo.EXPECT().
+		ListMainBranchCommits(ctx).
+		Return([]git.CommitInfo{
+			{CommitID: "C-ID-20", DateTime: "DT-20", Comment: "TEXT-20"},
+			{CommitID: "C-ID-10", DateTime: "DT-10", Comment: "TEXT-10"},
+		}, nil).
+		Times(1)
+
+	gitRepo.EXPECT().
+		ListMainBranchCommitParents(ctx).
+		Return(nil, nil).
+		Times(1)
+
+	gitRepoHist := githist.New(gitRepo, cache)
+
+	for _, tc := range []struct {
+		commitID string
+		expected bool
+	}{
+		{commitID: "C-ID-20", expected: true},
+		{commitID: "C-ID-10", expected: true},
+		{commitID: "C-ID-15", expected: false},
+	} {
+		isMain, err := gitRepoHist.IsMainBranchCommit(ctx, tc.commitID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if isMain != tc.expected {
+			t.Errorf("IsMainBranchCommit(%s) = %v, want %v", tc.commitID, isMain, tc.expected)
+		}
+	}
+}
+
+func TestGitHist_MergeCommitFiles_UsesIndexedParents(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	ctrl := gomock.NewController(t)
+	gitRepo := mocks.NewMockGitRepo(ctrl)
+	cache := mocks.NewMockCacheStorage(ctrl)
+
+	cache.EXPECT().
+		Read(githist.MainBranchCommitsCacheBucket(), githist.MainBranchCommitsCacheKey(), gomock.Any()).
+		DoAndReturn(storetests.MockReadNotFound())
+
+	cache.EXPECT().
+		Write(githist.MainBranchCommitsCacheBucket(), githist.MainBranchCommitsCacheKey(), gomock.Any()).
+		Return(nil)
+
+	gitRepo.EXPECT().
+		ListMainBranchCommits(ctx).
+		Return([]git.CommitInfo{
+			{CommitID: "C-ID-30", DateTime: "DT-30", Comment: "TEXT-30"},
+			{CommitID: "C-ID-10", DateTime: "DT-10", Comment: "TEXT-10"},
+		}, nil)
+
+	gitRepo.EXPECT().
+		ListMainBranchCommitParents(ctx).
+		Return(map[string][]string{
+			"C-ID-30": {"C-ID-10", "C-ID-25"},
+			"C-ID-10": {},
+		}, nil)
+
+	gitRepo.EXPECT().
+		ListAncestorCommits(ctx, "C-ID-25").
+		Return([]git.CommitInfo{
+			{CommitID: "C-ID-25", DateTime: "DT-25", Comment: "TEXT-25"},
+			{CommitID: "C-ID-10", DateTime: "DT-10", Comment: "TEXT-10"},
+		}, nil)
+
+	gitRepo.EXPECT().
+		ListFilesBetweenCommits(ctx, "C-ID-10", "C-ID-25").
+		Return([]string{"content/en/a.md"}, nil)
+
+	// ListCommitParents is not expected, the parents are taken from the main branch graph
+	files, err := githist.New(gitRepo, cache).MergeCommitFiles(ctx, "C-ID-30")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(files, []string{"content/en/a.md"}) {
+		t.Errorf("unexpected files: %v", files)
+	}
+}
+
 func TestGitHist_PullRefresh(t *testing.T) {
 	t.Parallel()
 
@@ -202,6 +328,10 @@ func TestGitHist_PullRefresh(t *testing.T) {
 						{CommitID: "C-ID-1", DateTime: "DT-1", Comment: "Comment-1"},
 					}, nil)
 
+				gitRepo.EXPECT().
+					ListMainBranchCommitParents(ctx).
+					Return(nil, nil)
+
 				cache.EXPECT().
 					Read(githist.MainBranchCommitsCacheBucket(), githist.MainBranchCommitsCacheKey(), gomock.Any()).
 					DoAndReturn(storetests.MockReadNotFound())
@@ -213,6 +343,10 @@ func TestGitHist_PullRefresh(t *testing.T) {
 				gitRepo.EXPECT().
 					ListFreshCommits(ctx).
 					Return(nil, nil)
+
+				gitRepo.EXPECT().
+					ListDroppedCommits(ctx).
+					Return(nil, nil)
 			},
 		},
 	} {
@@ -242,6 +376,138 @@ func TestGitHist_PullRefresh(t *testing.T) {
 	}
 }
 
+func TestGitHist_PullRefresh_ExtendsHistoryIndex(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	ctrl := gomock.NewController(t)
+	gitRepo := mocks.NewMockGitRepo(ctrl)
+	cache := mocks.NewMockCacheStorage(ctrl)
+	historyIndex := mocks.NewMockHistoryIndex(ctrl)
+
+	cache.EXPECT().
+		Read(githist.MainBranchCommitsCacheBucket(), githist.MainBranchCommitsCacheKey(), gomock.Any()).
+		DoAndReturn(storetests.MockReadNotFound())
+
+	cache.EXPECT().
+		Write(githist.MainBranchCommitsCacheBucket(), githist.MainBranchCommitsCacheKey(), gomock.Any()).
+		Return(nil)
+
+	cache.EXPECT().
+		Delete(githist.MainBranchCommitsCacheBucket(), githist.MainBranchCommitsCacheKey()).
+		Return(nil)
+
+	gitRepo.EXPECT().
+		ListMainBranchCommits(ctx).
+		Return([]git.CommitInfo{
+			{CommitID: "C-ID-1", DateTime: "DT-1", Comment: "Comment-1"},
+		}, nil)
+
+	gitRepo.EXPECT().
+		ListMainBranchCommitParents(ctx).
+		Return(nil, nil)
+
+	gomock.InOrder(
+		gitRepo.EXPECT().Fetch(ctx).Return(nil),
+		gitRepo.EXPECT().
+			ListFreshCommits(ctx).
+			Return([]git.CommitInfo{
+				{CommitID: "C-ID-2", DateTime: "DT-2", Comment: "Comment-2"},
+			}, nil),
+		gitRepo.EXPECT().
+			ListDroppedCommits(ctx).
+			Return(nil, nil),
+		gitRepo.EXPECT().
+			ListFilesInCommit(ctx, "C-ID-2").
+			Return([]string{"file-2"}, nil),
+		gitRepo.EXPECT().Pull(ctx).Return(nil),
+		historyIndex.EXPECT().Extend(ctx).Return(nil),
+	)
+
+	gitRepoHist := githist.New(gitRepo, cache, func(config *githist.NewConfig) {
+		config.HistoryIndex = historyIndex
+	})
+
+	files, err := gitRepoHist.PullRefresh(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(files, []string{"file-2"}) {
+		t.Errorf("unexpected files %v", files)
+	}
+}
+
+func TestGitHist_PullRefresh_ResetsDivergedMainBranch(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	ctrl := gomock.NewController(t)
+	gitRepo := mocks.NewMockGitRepo(ctrl)
+	cache := mocks.NewMockCacheStorage(ctrl)
+	historyIndex := mocks.NewMockHistoryIndex(ctrl)
+
+	cache.EXPECT().
+		Read(githist.MainBranchCommitsCacheBucket(), githist.MainBranchCommitsCacheKey(), gomock.Any()).
+		DoAndReturn(storetests.MockReadNotFound())
+
+	cache.EXPECT().
+		Write(githist.MainBranchCommitsCacheBucket(), githist.MainBranchCommitsCacheKey(), gomock.Any()).
+		Return(nil)
+
+	cache.EXPECT().
+		Delete(githist.MainBranchCommitsCacheBucket(), githist.MainBranchCommitsCacheKey()).
+		Return(nil)
+
+	gitRepo.EXPECT().
+		ListMainBranchCommits(ctx).
+		Return([]git.CommitInfo{
+			{CommitID: "C-ID-2", DateTime: "DT-2", Comment: "Comment-2"},
+			{CommitID: "C-ID-1", DateTime: "DT-1", Comment: "Comment-1"},
+		}, nil)
+
+	gitRepo.EXPECT().
+		ListMainBranchCommitParents(ctx).
+		Return(nil, nil)
+
+	gomock.InOrder(
+		gitRepo.EXPECT().Fetch(ctx).Return(nil),
+		gitRepo.EXPECT().
+			ListFreshCommits(ctx).
+			Return([]git.CommitInfo{
+				{CommitID: "C-ID-2B", DateTime: "DT-3", Comment: "Comment-2B"},
+			}, nil),
+		gitRepo.EXPECT().
+			ListDroppedCommits(ctx).
+			Return([]git.CommitInfo{
+				{CommitID: "C-ID-2", DateTime: "DT-2", Comment: "Comment-2"},
+			}, nil),
+		gitRepo.EXPECT().
+			ListFilesInCommit(ctx, "C-ID-2").
+			Return([]string{"file-dropped"}, nil),
+		gitRepo.EXPECT().
+			ListFilesInCommit(ctx, "C-ID-2B").
+			Return([]string{"file-fresh"}, nil),
+		gitRepo.EXPECT().ResetToOrigin(ctx).Return(nil),
+		historyIndex.EXPECT().Invalidate().Return(nil),
+	)
+
+	gitRepoHist := githist.New(gitRepo, cache, func(config *githist.NewConfig) {
+		config.HistoryIndex = historyIndex
+	})
+
+	files, err := gitRepoHist.PullRefresh(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(files, []string{"file-dropped", "file-fresh"}) {
+		t.Errorf("unexpected files %v", files)
+	}
+}
+
 func TestGitHist_GetLastMainBranchCommit(t *testing.T) {
 	t.Parallel()
 
@@ -292,6 +558,10 @@ func TestGitHist_GetLastMainBranchCommit(t *testing.T) {
 				ListMainBranchCommits(ctx).
 				Return(tc.mainBranch, nil)
 
+			gitRepo.EXPECT().
+				ListMainBranchCommitParents(ctx).
+				Return(nil, nil)
+
 			commit, err := gitRepoHist.GetLastMainBranchCommit(ctx)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
@@ -157,6 +157,45 @@ func (c *MockGitRepoListCommitParentsCall) DoAndReturn(f func(context.Context, s
 	return c
 }
 
+// ListDroppedCommits mocks base method.
+func (m *MockGitRepo) ListDroppedCommits(ctx context.Context) ([]git.CommitInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDroppedCommits", ctx)
+	ret0, _ := ret[0].([]git.CommitInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDroppedCommits indicates an expected call of ListDroppedCommits.
+func (mr *MockGitRepoMockRecorder) ListDroppedCommits(ctx any) *MockGitRepoListDroppedCommitsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDroppedCommits", reflect.TypeOf((*MockGitRepo)(nil).ListDroppedCommits), ctx)
+	return &MockGitRepoListDroppedCommitsCall{Call: call}
+}
+
+// MockGitRepoListDroppedCommitsCall wrap *gomock.Call
+type MockGitRepoListDroppedCommitsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockGitRepoListDroppedCommitsCall) Return(arg0 []git.CommitInfo, arg1 error) *MockGitRepoListDroppedCommitsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockGitRepoListDroppedCommitsCall) Do(f func(context.Context) ([]git.CommitInfo, error)) *MockGitRepoListDroppedCommitsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockGitRepoListDroppedCommitsCall) DoAndReturn(f func(context.Context) ([]git.CommitInfo, error)) *MockGitRepoListDroppedCommitsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // ListFilesBetweenCommits mocks base method.
 func (m *MockGitRepo) ListFilesBetweenCommits(ctx context.Context, forkCommitID, branchLastCommitID string) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -274,6 +313,45 @@ func (c *MockGitRepoListFreshCommitsCall) DoAndReturn(f func(context.Context) ([
 	return c
 }
 
+// ListMainBranchCommitParents mocks base method.
+func (m *MockGitRepo) ListMainBranchCommitParents(ctx context.Context) (map[string][]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMainBranchCommitParents", ctx)
+	ret0, _ := ret[0].(map[string][]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMainBranchCommitParents indicates an expected call of ListMainBranchCommitParents.
+func (mr *MockGitRepoMockRecorder) ListMainBranchCommitParents(ctx any) *MockGitRepoListMainBranchCommitParentsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMainBranchCommitParents", reflect.TypeOf((*MockGitRepo)(nil).ListMainBranchCommitParents), ctx)
+	return &MockGitRepoListMainBranchCommitParentsCall{Call: call}
+}
+
+// MockGitRepoListMainBranchCommitParentsCall wrap *gomock.Call
+type MockGitRepoListMainBranchCommitParentsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockGitRepoListMainBranchCommitParentsCall) Return(arg0 map[string][]string, arg1 error) *MockGitRepoListMainBranchCommitParentsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockGitRepoListMainBranchCommitParentsCall) Do(f func(context.Context) (map[string][]string, error)) *MockGitRepoListMainBranchCommitParentsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockGitRepoListMainBranchCommitParentsCall) DoAndReturn(f func(context.Context) (map[string][]string, error)) *MockGitRepoListMainBranchCommitParentsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // ListMainBranchCommits mocks base method.
 func (m *MockGitRepo) ListMainBranchCommits(ctx context.Context) ([]git.CommitInfo, error) {
 	m.ctrl.T.Helper()
@@ -390,6 +468,44 @@ func (c *MockGitRepoPullCall) DoAndReturn(f func(context.Context) error) *MockGi
 	return c
 }
 
+// ResetToOrigin mocks base method.
+func (m *MockGitRepo) ResetToOrigin(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetToOrigin", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetToOrigin indicates an expected call of ResetToOrigin.
+func (mr *MockGitRepoMockRecorder) ResetToOrigin(ctx any) *MockGitRepoResetToOriginCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetToOrigin", reflect.TypeOf((*MockGitRepo)(nil).ResetToOrigin), ctx)
+	return &MockGitRepoResetToOriginCall{Call: call}
+}
+
+// MockGitRepoResetToOriginCall wrap *gomock.Call
+type MockGitRepoResetToOriginCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockGitRepoResetToOriginCall) Return(arg0 error) *MockGitRepoResetToOriginCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockGitRepoResetToOriginCall) Do(f func(context.Context) error) *MockGitRepoResetToOriginCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockGitRepoResetToOriginCall) DoAndReturn(f func(context.Context) error) *MockGitRepoResetToOriginCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // MockCacheStorage is a mock of CacheStorage interface.
 type MockCacheStorage struct {
 	ctrl     *gomock.Controller
@@ -590,3 +706,103 @@ func (c *MockInvalidatorInvalidateFileCall) DoAndReturn(f func(string, string) e
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// MockHistoryIndex is a mock of HistoryIndex interface.
+type MockHistoryIndex struct {
+	ctrl     *gomock.Controller
+	recorder *MockHistoryIndexMockRecorder
+	isgomock struct{}
+}
+
+// MockHistoryIndexMockRecorder is the mock recorder for MockHistoryIndex.
+type MockHistoryIndexMockRecorder struct {
+	mock *MockHistoryIndex
+}
+
+// NewMockHistoryIndex creates a new mock instance.
+func NewMockHistoryIndex(ctrl *gomock.Controller) *MockHistoryIndex {
+	mock := &MockHistoryIndex{ctrl: ctrl}
+	mock.recorder = &MockHistoryIndexMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHistoryIndex) EXPECT() *MockHistoryIndexMockRecorder {
+	return m.recorder
+}
+
+// Extend mocks base method.
+func (m *MockHistoryIndex) Extend(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Extend", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Extend indicates an expected call of Extend.
+func (mr *MockHistoryIndexMockRecorder) Extend(ctx any) *MockHistoryIndexExtendCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Extend", reflect.TypeOf((*MockHistoryIndex)(nil).Extend), ctx)
+	return &MockHistoryIndexExtendCall{Call: call}
+}
+
+// MockHistoryIndexExtendCall wrap *gomock.Call
+type MockHistoryIndexExtendCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockHistoryIndexExtendCall) Return(arg0 error) *MockHistoryIndexExtendCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockHistoryIndexExtendCall) Do(f func(context.Context) error) *MockHistoryIndexExtendCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockHistoryIndexExtendCall) DoAndReturn(f func(context.Context) error) *MockHistoryIndexExtendCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Invalidate mocks base method.
+func (m *MockHistoryIndex) Invalidate() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invalidate")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Invalidate indicates an expected call of Invalidate.
+func (mr *MockHistoryIndexMockRecorder) Invalidate() *MockHistoryIndexInvalidateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invalidate", reflect.TypeOf((*MockHistoryIndex)(nil).Invalidate))
+	return &MockHistoryIndexInvalidateCall{Call: call}
+}
+
+// MockHistoryIndexInvalidateCall wrap *gomock.Call
+type MockHistoryIndexInvalidateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockHistoryIndexInvalidateCall) Return(arg0 error) *MockHistoryIndexInvalidateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockHistoryIndexInvalidateCall) Do(f func() error) *MockHistoryIndexInvalidateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockHistoryIndexInvalidateCall) DoAndReturn(f func() error) *MockHistoryIndexInvalidateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -1,4 +1,4 @@
-// Package github provides information about the Kubernetes GitHub repository.
+// Package github provides information about a GitHub repository, by default kubernetes/website.
 package github
 
 import (
@@ -24,6 +24,18 @@ const (
 	maxHTTPRetries        = 10
 	defaultRetryWait      = time.Minute
 	retryResetSafetyDelay = 3 * time.Second
+	defaultRepository     = "kubernetes/website"
+	defaultBranch         = "main"
+	prFilesPerPage        = 100
+	maxPRFilesPages       = 30 // GitHub lists at most 3000 files of a pull request
+)
+
+// The statuses of the files changed by a pull request.
+const (
+	PRFileStatusAdded    = "added"
+	PRFileStatusRemoved  = "removed"
+	PRFileStatusModified = "modified"
+	PRFileStatusRenamed  = "renamed"
 )
 
 var (
@@ -40,12 +52,18 @@ type Config struct {
 	Token            string
 	UserAgent        string
 	ThrottleInterval time.Duration
+	// Repository is the repository in the owner/name form. The default is kubernetes/website.
+	Repository string
+	// Branch is the tracked branch of the repository. The default is main.
+	Branch string
 }
 
 type GitHub struct {
 	baseURL    string
 	httpClient *http.Client
 	throttler  *throttle.Throttler
+	repository string
+	branch     string
 }
 
 type CommitInfo struct {
@@ -74,8 +92,45 @@ type PRSearchResult struct {
 
 //nolint:tagliatelle
 type PRItem struct {
-	Number    int    `json:"number"`
-	UpdatedAt string `json:"updated_at"`
+	Number    int     `json:"number"`
+	Title     string  `json:"title"`
+	User      User    `json:"user"`
+	Draft     bool    `json:"draft"`
+	CreatedAt string  `json:"created_at"`
+	UpdatedAt string  `json:"updated_at"`
+	Labels    []Label `json:"labels"`
+
+	// HeadSHA is the commit ID of the head of the pull request.
+	// It is set only by the GraphQL client.
+	HeadSHA string `json:"head_sha,omitempty"`
+
+	// ReviewDecision is APPROVED, CHANGES_REQUESTED, REVIEW_REQUIRED or empty if no review is required.
+	// It is set only by the GraphQL client.
+	ReviewDecision string `json:"review_decision,omitempty"`
+
+	// Files are the files changed by the pull request, nil if they are not known.
+	// They are set only by the GraphQL client, for pull requests whose files
+	// fit in one page and which do not rename files.
+	Files []PRFile `json:"-"`
+
+	// RequestedReviewers are the requested reviewers of the pull request, nil if they
+	// are not known. They are set only by the GraphQL client.
+	RequestedReviewers []string `json:"-"`
+}
+
+// The review decisions of a pull request.
+const (
+	ReviewDecisionApproved         = "APPROVED"
+	ReviewDecisionChangesRequested = "CHANGES_REQUESTED"
+	ReviewDecisionReviewRequired   = "REVIEW_REQUIRED"
+)
+
+type Label struct {
+	Name string `json:"name"`
+}
+
+type User struct {
+	Login string `json:"login"`
 }
 
 type CommitFiles struct {
@@ -83,6 +138,17 @@ type CommitFiles struct {
 	Files    []string
 }
 
+// PRFile is a file changed by a pull request compared with its base branch.
+//
+//nolint:tagliatelle
+type PRFile struct {
+	Filename string `json:"filename"`
+	// Status is one of the PRFileStatus constants or copied, changed or unchanged.
+	Status string `json:"status"`
+	// PreviousFilename is the path of a renamed file before the rename.
+	PreviousFilename string `json:"previous_filename,omitempty"`
+}
+
 func WithDefaults() func(*Config) {
 	return func(config *Config) {
 		config.BaseURL = defaultBaseURL
@@ -114,6 +180,14 @@ func WithThrottle(interval time.Duration) func(*Config) {
 	}
 }
 
+// WithRepository sets the repository (in the owner/name form) and its tracked branch.
+func WithRepository(repository, branch string) func(*Config) {
+	return func(config *Config) {
+		config.Repository = repository
+		config.Branch = branch
+	}
+}
+
 func NewGitHub(opts ...func(*Config)) *GitHub {
 	var config Config
 
@@ -121,6 +195,14 @@ func NewGitHub(opts ...func(*Config)) *GitHub {
 		opt(&config)
 	}
 
+	if config.Repository == "" {
+		config.Repository = defaultRepository
+	}
+
+	if config.Branch == "" {
+		config.Branch = defaultBranch
+	}
+
 	var throttlerInstance *throttle.Throttler
 	if config.ThrottleInterval > 0 {
 		throttlerInstance = throttle.NewThrottler(config.ThrottleInterval)
@@ -130,11 +212,34 @@ func NewGitHub(opts ...func(*Config)) *GitHub {
 		baseURL:    config.BaseURL,
 		httpClient: config.HTTPClient,
 		throttler:  throttlerInstance,
+		repository: config.Repository,
+		branch:     config.Branch,
+	}
+}
+
+// ForRepository returns a client of another repository which shares the HTTP client
+// and the throttler with gh, so that all repositories together respect the rate limits.
+func (gh *GitHub) ForRepository(repository, branch string) *GitHub {
+	if repository == "" {
+		repository = defaultRepository
+	}
+
+	if branch == "" {
+		branch = defaultBranch
+	}
+
+	return &GitHub{
+		baseURL:    gh.baseURL,
+		httpClient: gh.httpClient,
+		throttler:  gh.throttler,
+		repository: repository,
+		branch:     branch,
 	}
 }
 
 func (gh *GitHub) GetLatestCommit(ctx context.Context) (*CommitInfo, error) {
-	urlStr := fmt.Sprintf("%v/repos/kubernetes/website/commits?per_page=1", gh.baseURL)
+	urlStr := fmt.Sprintf("%v/repos/%s/commits?sha=%s&per_page=1",
+		gh.baseURL, gh.repository, url.QueryEscape(gh.branch))
 
 	resp, err := gh.httpGetWithRetry(ctx, urlStr)
 	if err != nil {
@@ -213,24 +318,7 @@ func toShortLangCode(langCode string) string {
 func (gh *GitHub) buildPRSearchURL(filter PRSearchFilter, page PageRequest) (string, error) {
 	baseURL := fmt.Sprintf("%v/search/issues", gh.baseURL)
 
-	queryParts := []string{
-		"repo:kubernetes/website",
-		"is:pr",
-	}
-
-	if filter.OnlyOpen {
-		queryParts = append(queryParts, "state:open")
-	}
-
-	if len(filter.LangCode) > 0 {
-		queryParts = append(queryParts, "label:language/"+filter.LangCode)
-	}
-
-	if len(filter.UpdatedFrom) > 0 {
-		queryParts = append(queryParts, "updated:>"+filter.UpdatedFrom)
-	}
-
-	queryText := strings.Join(queryParts, "+")
+	queryText := strings.Join(gh.prSearchQualifiers(filter), "+")
 
 	queryValues := url.Values{}
 
@@ -258,8 +346,30 @@ func (gh *GitHub) buildPRSearchURL(filter PRSearchFilter, page PageRequest) (str
 	return parsedURL.String(), nil
 }
 
+// prSearchQualifiers returns the search qualifiers of the pull requests matching the filter.
+func (gh *GitHub) prSearchQualifiers(filter PRSearchFilter) []string {
+	queryParts := []string{
+		"repo:" + gh.repository,
+		"is:pr",
+	}
+
+	if filter.OnlyOpen {
+		queryParts = append(queryParts, "state:open")
+	}
+
+	if len(filter.LangCode) > 0 {
+		queryParts = append(queryParts, "label:language/"+filter.LangCode)
+	}
+
+	if len(filter.UpdatedFrom) > 0 {
+		queryParts = append(queryParts, "updated:>"+filter.UpdatedFrom)
+	}
+
+	return queryParts
+}
+
 func (gh *GitHub) GetPRCommits(ctx context.Context, prNumber int) ([]string, error) {
-	urlStr := fmt.Sprintf("%v/repos/kubernetes/website/pulls/%d/commits", gh.baseURL, prNumber)
+	urlStr := fmt.Sprintf("%v/repos/%s/pulls/%d/commits", gh.baseURL, gh.repository, prNumber)
 
 	resp, err := gh.httpGetWithRetry(ctx, urlStr)
 	if err != nil {
@@ -281,12 +391,50 @@ func (gh *GitHub) GetPRCommits(ctx context.Context, prNumber int) ([]string, err
 	return commitIDs, nil
 }
 
+// GetPRRequestedReviewers returns the logins of the users and the owner/slug names
+// of the teams whose review of the pull request is requested.
+func (gh *GitHub) GetPRRequestedReviewers(ctx context.Context, prNumber int) ([]string, error) {
+	urlStr := fmt.Sprintf("%v/repos/%s/pulls/%d/requested_reviewers", gh.baseURL, gh.repository, prNumber)
+
+	resp, err := gh.httpGetWithRetry(ctx, urlStr)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	var requested requestedReviewersModel
+	if err := json.NewDecoder(resp.Body).Decode(&requested); err != nil {
+		return nil, fmt.Errorf("decode PR requested reviewers JSON: %w", err)
+	}
+
+	owner, _, _ := strings.Cut(gh.repository, "/")
+
+	reviewers := make([]string, 0, len(requested.Users)+len(requested.Teams))
+	for _, user := range requested.Users {
+		reviewers = append(reviewers, user.Login)
+	}
+
+	for _, team := range requested.Teams {
+		reviewers = append(reviewers, owner+"/"+team.Slug)
+	}
+
+	return reviewers, nil
+}
+
+type requestedReviewersModel struct {
+	Users []User `json:"users"`
+	Teams []struct {
+		Slug string `json:"slug"`
+	} `json:"teams"`
+}
+
 type commitItem struct {
 	SHA string `json:"sha"`
 }
 
 func (gh *GitHub) GetCommitFiles(ctx context.Context, commitID string) (*CommitFiles, error) {
-	urlStr := fmt.Sprintf("%v/repos/kubernetes/website/commits/%s", gh.baseURL, commitID)
+	urlStr := fmt.Sprintf("%v/repos/%s/commits/%s", gh.baseURL, gh.repository, commitID)
 
 	resp, err := gh.httpGetWithRetry(ctx, urlStr)
 	if err != nil {
@@ -318,7 +466,78 @@ type commitModel struct {
 	} `json:"files"`
 }
 
+// GetPRFiles returns the files changed by the pull request, that is the net changes
+// of all its commits. Files changed by one commit and reverted by another are not listed.
+func (gh *GitHub) GetPRFiles(ctx context.Context, prNumber int) ([]PRFile, error) {
+	urlStr := fmt.Sprintf("%v/repos/%s/pulls/%d/files?per_page=%d",
+		gh.baseURL, gh.repository, prNumber, prFilesPerPage)
+
+	var files []PRFile
+
+	for page := 0; len(urlStr) > 0; page++ {
+		if page == maxPRFilesPages {
+			log.Printf("PR #%d has more than %d pages of files, the remaining files are skipped", prNumber, maxPRFilesPages)
+
+			break
+		}
+
+		pageFiles, nextURL, err := gh.getPRFilesPage(ctx, urlStr)
+		if err != nil {
+			return nil, fmt.Errorf("get files of PR #%d: %w", prNumber, err)
+		}
+
+		files = append(files, pageFiles...)
+		urlStr = nextURL
+	}
+
+	return files, nil
+}
+
+// getPRFilesPage returns the files of the page and the URL of the next page, if any.
+func (gh *GitHub) getPRFilesPage(ctx context.Context, urlStr string) ([]PRFile, string, error) {
+	resp, err := gh.httpGetWithRetry(ctx, urlStr)
+	if err != nil {
+		return nil, "", err
+	}
+
+	defer resp.Body.Close()
+
+	var files []PRFile
+	if err := json.NewDecoder(resp.Body).Decode(&files); err != nil {
+		return nil, "", fmt.Errorf("decode PR files JSON: %w", err)
+	}
+
+	return files, nextPageURL(resp.Header.Get("Link")), nil
+}
+
+// nextPageURL returns the URL of the next page from a Link header like
+// `<https://api.github.com/...&page=2>; rel="next", <https://api.github.com/...&page=5>; rel="last"`.
+func nextPageURL(linkHeader string) string {
+	for link := range strings.SplitSeq(linkHeader, ",") {
+		target, params, ok := strings.Cut(link, ";")
+		if !ok {
+			continue
+		}
+
+		for param := range strings.SplitSeq(params, ";") {
+			if strings.TrimSpace(param) == `rel="next"` {
+				return strings.Trim(strings.TrimSpace(target), "<>")
+			}
+		}
+	}
+
+	return ""
+}
+
 func (gh *GitHub) httpGetWithRetry(ctx context.Context, urlStr string) (*http.Response, error) {
+	return gh.httpDoWithRetry(ctx, http.MethodGet, urlStr, nil)
+}
+
+func (gh *GitHub) httpPostWithRetry(ctx context.Context, urlStr string, body []byte) (*http.Response, error) {
+	return gh.httpDoWithRetry(ctx, http.MethodPost, urlStr, body)
+}
+
+func (gh *GitHub) httpDoWithRetry(ctx context.Context, method string, urlStr string, body []byte) (*http.Response, error) {
 	var (
 		resp *http.Response
 		err  error
@@ -326,10 +545,10 @@ func (gh *GitHub) httpGetWithRetry(ctx context.Context, urlStr string) (*http.Re
 
 	for i := range maxHTTPRetries {
 		if i > 0 {
-			log.Printf("[%d/%d] retry http GET %s", i, maxHTTPRetries, urlStr)
+			log.Printf("[%d/%d] retry http %s %s", i, maxHTTPRetries, method, urlStr)
 		}
 
-		resp, err = gh.httpGet(ctx, urlStr)
+		resp, err = gh.httpDo(ctx, method, urlStr, body)
 		if err == nil {
 			break
 		}
@@ -416,12 +635,21 @@ func sleepCtx(ctx context.Context, d time.Duration) error {
 	}
 }
 
-func (gh *GitHub) httpGet(ctx context.Context, urlStr string) (*http.Response, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, urlStr, nil)
+func (gh *GitHub) httpDo(ctx context.Context, method string, urlStr string, body []byte) (*http.Response, error) {
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, urlStr, bodyReader)
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
 
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
 	if gh.throttler != nil {
 		if err := gh.throttler.Throttle(ctx); err != nil {
 			return nil, fmt.Errorf("github throttling failed: %w", err)
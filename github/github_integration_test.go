@@ -71,6 +71,8 @@ func TestGitHub_GetPRCommits_Integration(t *testing.T) {
 	for _, tc := range []struct {
 		name           string
 		prNumber       int
+		repository     string
+		forRepository  string
 		response       []byte
 		expectedURL    string
 		expectedResult []string
@@ -81,6 +83,22 @@ func TestGitHub_GetPRCommits_Integration(t *testing.T) {
 			expectedURL:    "/repos/kubernetes/website/pulls/42/commits",
 			expectedResult: []string{"5bac466fc45325e2f5cfa63d06b9f2032ecba712"},
 		},
+		{
+			name:           "custom repository",
+			prNumber:       42,
+			repository:     "example/docs",
+			response:       GetPRCommits,
+			expectedURL:    "/repos/example/docs/pulls/42/commits",
+			expectedResult: []string{"5bac466fc45325e2f5cfa63d06b9f2032ecba712"},
+		},
+		{
+			name:           "client derived for another repository",
+			prNumber:       42,
+			forRepository:  "example/other-docs",
+			response:       GetPRCommits,
+			expectedURL:    "/repos/example/other-docs/pulls/42/commits",
+			expectedResult: []string{"5bac466fc45325e2f5cfa63d06b9f2032ecba712"},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
@@ -93,7 +111,11 @@ func TestGitHub_GetPRCommits_Integration(t *testing.T) {
 			gh := github.NewGitHub(func(config *github.Config) {
 				config.HTTPClient = mockServer.Client()
 				config.BaseURL = mockServer.URL
-			})
+			}, github.WithRepository(tc.repository, ""))
+
+			if tc.forRepository != "" {
+				gh = gh.ForRepository(tc.forRepository, "")
+			}
 
 			actualResult, err := gh.GetPRCommits(ctx, tc.prNumber)
 			if err != nil {
@@ -144,10 +166,38 @@ func TestGitHub_PRSearch_Integration(t *testing.T) {
 			},
 			expectedResult: &github.PRSearchResult{
 				Items: []github.PRItem{
-					{49640, "2025-02-04T14:53:37Z"},
-					{49669, "2025-02-07T07:18:42Z"},
-					{49639, "2025-02-10T07:40:28Z"},
-					{49633, "2025-02-10T07:47:50Z"},
+					{
+						Number:    49640,
+						Title:     "[pl] docs/contribute/analytics.md",
+						User:      github.User{Login: "dkarczmarski"},
+						CreatedAt: "2025-02-04T14:43:47Z",
+						UpdatedAt: "2025-02-04T14:53:37Z",
+						Labels:    prSearchLabels("size/S"),
+					},
+					{
+						Number:    49669,
+						Title:     "[pl] docs/contribute/style/content-organization.md",
+						User:      github.User{Login: "dkarczmarski"},
+						CreatedAt: "2025-02-06T17:47:26Z",
+						UpdatedAt: "2025-02-07T07:18:42Z",
+						Labels:    prSearchLabels("size/L"),
+					},
+					{
+						Number:    49639,
+						Title:     "[pl] docs/contribute/advanced.md",
+						User:      github.User{Login: "dkarczmarski"},
+						CreatedAt: "2025-02-04T14:24:24Z",
+						UpdatedAt: "2025-02-10T07:40:28Z",
+						Labels:    prSearchLabels("size/L"),
+					},
+					{
+						Number:    49633,
+						Title:     "[pl] docs/contribute/docs.md",
+						User:      github.User{Login: "dkarczmarski"},
+						CreatedAt: "2025-02-04T12:45:19Z",
+						UpdatedAt: "2025-02-10T07:47:50Z",
+						Labels:    prSearchLabels("size/L"),
+					},
 				},
 				TotalCount: 49,
 			},
@@ -178,6 +228,118 @@ func TestGitHub_PRSearch_Integration(t *testing.T) {
 	}
 }
 
+//go:embed testdata/TestGitHub_GetPRRequestedReviewers.txt
+var GetPRRequestedReviewers []byte
+
+func TestGitHub_GetPRRequestedReviewers_Integration(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	mockServer := newMockServer(
+		t,
+		"/repos/kubernetes/website/pulls/42/requested_reviewers",
+		url.Values{},
+		GetPRRequestedReviewers,
+	)
+	defer mockServer.Close()
+
+	gh := github.NewGitHub(func(config *github.Config) {
+		config.HTTPClient = mockServer.Client()
+		config.BaseURL = mockServer.URL
+	})
+
+	actualResult, err := gh.GetPRRequestedReviewers(ctx, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedResult := []string{"pl-reviewer", "kubernetes/sig-docs-pl-reviews"}
+
+	if !reflect.DeepEqual(expectedResult, actualResult) {
+		t.Errorf("result error\nexpected : %+v\nactual   : %+v", expectedResult, actualResult)
+	}
+}
+
+//go:embed testdata/TestGitHub_GetPRFiles_page1.txt
+var GetPRFilesPage1 []byte
+
+//go:embed testdata/TestGitHub_GetPRFiles_page2.txt
+var GetPRFilesPage2 []byte
+
+func TestGitHub_GetPRFiles_Integration(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	var mockServer *httptest.Server
+
+	mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/kubernetes/website/pulls/42/files" {
+			t.Errorf("unexpected URL: %s", r.URL)
+		}
+
+		if r.URL.Query().Get("per_page") != "100" {
+			t.Errorf("unexpected per_page: %s", r.URL)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Query().Get("page") {
+		case "":
+			nextURL := mockServer.URL + r.URL.Path + "?per_page=100&page=2"
+			w.Header().Set("Link", "<"+nextURL+`>; rel="next", <`+nextURL+`>; rel="last"`)
+			_, _ = w.Write(GetPRFilesPage1)
+		case "2":
+			_, _ = w.Write(GetPRFilesPage2)
+		default:
+			t.Errorf("unexpected page: %s", r.URL)
+		}
+	}))
+	defer mockServer.Close()
+
+	gh := github.NewGitHub(func(config *github.Config) {
+		config.HTTPClient = mockServer.Client()
+		config.BaseURL = mockServer.URL
+	})
+
+	actualResult, err := gh.GetPRFiles(ctx, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedResult := []github.PRFile{
+		{
+			Filename: "content/pl/docs/concepts/overview/_index.md",
+			Status:   github.PRFileStatusModified,
+		},
+		{
+			Filename: "content/pl/docs/concepts/overview/components.md",
+			Status:   github.PRFileStatusAdded,
+		},
+		{
+			Filename:         "content/pl/docs/concepts/overview/working-with-objects/_index.md",
+			Status:           github.PRFileStatusRenamed,
+			PreviousFilename: "content/pl/docs/concepts/overview/objects/_index.md",
+		},
+	}
+
+	if !reflect.DeepEqual(expectedResult, actualResult) {
+		t.Errorf("result error\nexpected : %+v\nactual   : %+v", expectedResult, actualResult)
+	}
+}
+
+// prSearchLabels returns the labels of the pull requests of TestGitHub_PRSearch.txt with the size label.
+func prSearchLabels(sizeLabel string) []github.Label {
+	return []github.Label{
+		{Name: "cncf-cla: yes"},
+		{Name: sizeLabel},
+		{Name: "sig/docs"},
+		{Name: "language/pl"},
+		{Name: "area/localization"},
+	}
+}
+
 func newMockServer(
 	t *testing.T,
 	expectedURL string,
@@ -10,7 +10,8 @@ func TestGitHubBuildPRSearchURL(t *testing.T) {
 	t.Parallel()
 
 	gh := &GitHub{
-		baseURL: "https://api.github.com",
+		baseURL:    "https://api.github.com",
+		repository: "kubernetes/website",
 	}
 
 	got, err := gh.buildPRSearchURL(
@@ -42,7 +43,8 @@ func TestGitHubBuildPRSearchURL_InvalidBaseURL(t *testing.T) {
 	t.Parallel()
 
 	gh := &GitHub{
-		baseURL: "https://api.github.com\nbad",
+		baseURL:    "https://api.github.com\nbad",
+		repository: "kubernetes/website",
 	}
 
 	_, err := gh.buildPRSearchURL(
@@ -0,0 +1,513 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+)
+
+const (
+	graphQLPath          = "/graphql"
+	graphQLMaxPerPage    = 100
+	graphQLLabelsPerPage = 50
+	graphQLReviewersMax  = 50
+	graphQLRateLimited   = "RATE_LIMITED"
+)
+
+var ErrGraphQLQuery = errors.New("graphql query failed")
+
+// prSearchQuery fetches the pull requests found by the search together with their labels,
+// review decision, requested reviewers, head commit and the first page of their files.
+const prSearchQuery = `query($q: String!, $first: Int!, $after: String, $labels: Int!, $reviewers: Int!, $files: Int!) {
+  search(query: $q, type: ISSUE, first: $first, after: $after) {
+    issueCount
+    nodes {
+      ... on PullRequest {
+        number
+        title
+        author { login }
+        isDraft
+        createdAt
+        updatedAt
+        headRefOid
+        reviewDecision
+        labels(first: $labels) { nodes { name } }
+        reviewRequests(first: $reviewers) {
+          nodes { requestedReviewer { ... on User { login } ... on Team { combinedSlug } } }
+        }
+        files(first: $files) { nodes { path changeType } pageInfo { hasNextPage endCursor } }
+      }
+    }
+  }
+}`
+
+// prSearchCursorQuery fetches only the cursor of a page of the pull requests found by the search.
+const prSearchCursorQuery = `query($q: String!, $first: Int!, $after: String) {
+  search(query: $q, type: ISSUE, first: $first, after: $after) {
+    pageInfo { hasNextPage endCursor }
+  }
+}`
+
+// prRequestedReviewersQuery fetches the requested reviewers of a pull request.
+const prRequestedReviewersQuery = `query($owner: String!, $name: String!, $number: Int!, $reviewers: Int!) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      reviewRequests(first: $reviewers) {
+        nodes { requestedReviewer { ... on User { login } ... on Team { combinedSlug } } }
+      }
+    }
+  }
+}`
+
+// prFilesQuery fetches a page of the files of a pull request.
+const prFilesQuery = `query($owner: String!, $name: String!, $number: Int!, $files: Int!, $after: String) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      files(first: $files, after: $after) { nodes { path changeType } pageInfo { hasNextPage endCursor } }
+    }
+  }
+}`
+
+// GraphQL fetches pull requests with the GitHub GraphQL API. A search returns the pull
+// requests together with their labels, review decision, requested reviewers, head commit
+// and files in a single query (see PRItem.Files and PRItem.RequestedReviewers).
+//
+// The GraphQL API does not return the previous paths of renamed files, so the files
+// of pull requests which rename files are fetched from the REST API.
+type GraphQL struct {
+	gitHub *GitHub
+	rest   *GitHub
+}
+
+type GraphQLConfig struct {
+	// REST is the client of the REST API used for the files of pull requests which
+	// rename files. The default is the client the GraphQL client is created from.
+	REST *GitHub
+}
+
+// NewGraphQL creates a GraphQL client of the repository of gitHub,
+// which shares its HTTP client and throttler.
+func NewGraphQL(gitHub *GitHub, opts ...func(config *GraphQLConfig)) *GraphQL {
+	config := GraphQLConfig{
+		REST: gitHub,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return &GraphQL{
+		gitHub: gitHub,
+		rest:   config.REST,
+	}
+}
+
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []graphQLError  `json:"errors"`
+}
+
+type graphQLError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+type graphQLPageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+type graphQLFiles struct {
+	Nodes []struct {
+		Path       string `json:"path"`
+		ChangeType string `json:"changeType"`
+	} `json:"nodes"`
+	PageInfo graphQLPageInfo `json:"pageInfo"`
+}
+
+type graphQLReviewRequests struct {
+	Nodes []struct {
+		RequestedReviewer *struct {
+			Login        string `json:"login"`
+			CombinedSlug string `json:"combinedSlug"`
+		} `json:"requestedReviewer"`
+	} `json:"nodes"`
+}
+
+type graphQLPullRequest struct {
+	Number         int    `json:"number"`
+	Title          string `json:"title"`
+	Author         *User  `json:"author"`
+	IsDraft        bool   `json:"isDraft"`
+	CreatedAt      string `json:"createdAt"`
+	UpdatedAt      string `json:"updatedAt"`
+	HeadRefOid     string `json:"headRefOid"`
+	ReviewDecision string `json:"reviewDecision"`
+	Labels         struct {
+		Nodes []Label `json:"nodes"`
+	} `json:"labels"`
+	ReviewRequests graphQLReviewRequests `json:"reviewRequests"`
+	Files          graphQLFiles          `json:"files"`
+}
+
+type graphQLSearchData struct {
+	Search struct {
+		IssueCount int                  `json:"issueCount"`
+		Nodes      []graphQLPullRequest `json:"nodes"`
+	} `json:"search"`
+}
+
+type graphQLSearchCursorData struct {
+	Search struct {
+		PageInfo graphQLPageInfo `json:"pageInfo"`
+	} `json:"search"`
+}
+
+type graphQLPRRequestedReviewersData struct {
+	Repository struct {
+		PullRequest *struct {
+			ReviewRequests graphQLReviewRequests `json:"reviewRequests"`
+		} `json:"pullRequest"`
+	} `json:"repository"`
+}
+
+type graphQLPRFilesData struct {
+	Repository struct {
+		PullRequest *struct {
+			Files graphQLFiles `json:"files"`
+		} `json:"pullRequest"`
+	} `json:"repository"`
+}
+
+// PRSearch searches the pull requests like GitHub.PRSearch. The pages before the requested
+// one are walked with queries fetching only their cursors.
+func (gql *GraphQL) PRSearch(ctx context.Context, filter PRSearchFilter, page PageRequest) (*PRSearchResult, error) {
+	filter.LangCode = toShortLangCode(filter.LangCode)
+
+	queryParts := gql.gitHub.prSearchQualifiers(filter)
+	if len(page.Sort) > 0 {
+		order := page.Order
+		if len(order) == 0 {
+			order = "desc"
+		}
+
+		queryParts = append(queryParts, "sort:"+page.Sort+"-"+order)
+	}
+
+	searchQuery := strings.Join(queryParts, " ")
+
+	perPage := page.PerPage
+	if perPage <= 0 || perPage > graphQLMaxPerPage {
+		perPage = graphQLMaxPerPage
+	}
+
+	after, found, err := gql.searchPageCursor(ctx, searchQuery, perPage, page.Page)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return &PRSearchResult{Items: []PRItem{}}, nil
+	}
+
+	var data graphQLSearchData
+	if err := gql.query(ctx, prSearchQuery, map[string]any{
+		"q":         searchQuery,
+		"first":     perPage,
+		"after":     after,
+		"labels":    graphQLLabelsPerPage,
+		"reviewers": graphQLReviewersMax,
+		"files":     prFilesPerPage,
+	}, &data); err != nil {
+		return nil, fmt.Errorf("search PRs: %w", err)
+	}
+
+	result := PRSearchResult{
+		Items:      make([]PRItem, 0, len(data.Search.Nodes)),
+		TotalCount: data.Search.IssueCount,
+	}
+
+	for _, pullRequest := range data.Search.Nodes {
+		var author User
+		if pullRequest.Author != nil {
+			author = *pullRequest.Author
+		}
+
+		var files []PRFile
+		if !pullRequest.Files.PageInfo.HasNextPage {
+			if pageFiles, hasRenames := toPRFiles(pullRequest.Files); !hasRenames {
+				files = pageFiles
+			}
+		}
+
+		result.Items = append(result.Items, PRItem{
+			Number:             pullRequest.Number,
+			Title:              pullRequest.Title,
+			User:               author,
+			Draft:              pullRequest.IsDraft,
+			CreatedAt:          pullRequest.CreatedAt,
+			UpdatedAt:          pullRequest.UpdatedAt,
+			Labels:             pullRequest.Labels.Nodes,
+			HeadSHA:            pullRequest.HeadRefOid,
+			ReviewDecision:     pullRequest.ReviewDecision,
+			Files:              files,
+			RequestedReviewers: toRequestedReviewers(pullRequest.ReviewRequests),
+		})
+	}
+
+	return &result, nil
+}
+
+// searchPageCursor returns the cursor after which the page of the search starts, nil for
+// the first page. It returns false if the search has fewer pages.
+func (gql *GraphQL) searchPageCursor(
+	ctx context.Context,
+	searchQuery string,
+	perPage int,
+	pageNumber int,
+) (*string, bool, error) {
+	var after *string
+
+	for range pageNumber - 1 {
+		var data graphQLSearchCursorData
+		if err := gql.query(ctx, prSearchCursorQuery, map[string]any{
+			"q":     searchQuery,
+			"first": perPage,
+			"after": after,
+		}, &data); err != nil {
+			return nil, false, fmt.Errorf("search PRs page cursor: %w", err)
+		}
+
+		if !data.Search.PageInfo.HasNextPage {
+			return nil, false, nil
+		}
+
+		after = &data.Search.PageInfo.EndCursor
+	}
+
+	return after, true, nil
+}
+
+// GetPRFiles returns the files changed by the pull request like GitHub.GetPRFiles.
+// The files of pull requests which rename files are fetched from the REST API.
+func (gql *GraphQL) GetPRFiles(ctx context.Context, prNumber int) ([]PRFile, error) {
+	files, hasRenames, err := gql.fetchPRFiles(ctx, prNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasRenames {
+		return gql.rest.GetPRFiles(ctx, prNumber)
+	}
+
+	return files, nil
+}
+
+// GetPRRequestedReviewers returns the requested reviewers of the pull request
+// like GitHub.GetPRRequestedReviewers.
+func (gql *GraphQL) GetPRRequestedReviewers(ctx context.Context, prNumber int) ([]string, error) {
+	owner, name, _ := strings.Cut(gql.gitHub.repository, "/")
+
+	var data graphQLPRRequestedReviewersData
+	if err := gql.query(ctx, prRequestedReviewersQuery, map[string]any{
+		"owner":     owner,
+		"name":      name,
+		"number":    prNumber,
+		"reviewers": graphQLReviewersMax,
+	}, &data); err != nil {
+		return nil, fmt.Errorf("get requested reviewers of PR #%d: %w", prNumber, err)
+	}
+
+	if data.Repository.PullRequest == nil {
+		return nil, fmt.Errorf("%w: PR #%d not found", ErrGraphQLQuery, prNumber)
+	}
+
+	return toRequestedReviewers(data.Repository.PullRequest.ReviewRequests), nil
+}
+
+// fetchPRFiles fetches the files of the pull request. It stops at the first page with
+// a renamed file, because the files of such pull requests are fetched from the REST API.
+func (gql *GraphQL) fetchPRFiles(ctx context.Context, prNumber int) ([]PRFile, bool, error) {
+	owner, name, _ := strings.Cut(gql.gitHub.repository, "/")
+
+	var (
+		files      []PRFile
+		hasRenames bool
+		after      *string
+	)
+
+	for range maxPRFilesPages {
+		var data graphQLPRFilesData
+		if err := gql.query(ctx, prFilesQuery, map[string]any{
+			"owner":  owner,
+			"name":   name,
+			"number": prNumber,
+			"files":  prFilesPerPage,
+			"after":  after,
+		}, &data); err != nil {
+			return nil, false, fmt.Errorf("get files of PR #%d: %w", prNumber, err)
+		}
+
+		if data.Repository.PullRequest == nil {
+			return nil, false, fmt.Errorf("%w: PR #%d not found", ErrGraphQLQuery, prNumber)
+		}
+
+		pageFiles := data.Repository.PullRequest.Files
+		pageResult, pageHasRenames := toPRFiles(pageFiles)
+
+		files = append(files, pageResult...)
+		hasRenames = hasRenames || pageHasRenames
+
+		if hasRenames || !pageFiles.PageInfo.HasNextPage {
+			break
+		}
+
+		after = &pageFiles.PageInfo.EndCursor
+	}
+
+	return files, hasRenames, nil
+}
+
+// toPRFiles converts the files and reports whether any of them was renamed.
+func toPRFiles(graphQLFiles graphQLFiles) ([]PRFile, bool) {
+	files := make([]PRFile, 0, len(graphQLFiles.Nodes))
+	hasRenames := false
+
+	for _, node := range graphQLFiles.Nodes {
+		status := toPRFileStatus(node.ChangeType)
+		hasRenames = hasRenames || status == PRFileStatusRenamed
+
+		//nolint:exhaustruct
+		files = append(files, PRFile{
+			Filename: node.Path,
+			Status:   status,
+		})
+	}
+
+	return files, hasRenames
+}
+
+// toRequestedReviewers returns the logins of the requested users and the owner/slug names
+// of the requested teams.
+func toRequestedReviewers(reviewRequests graphQLReviewRequests) []string {
+	reviewers := make([]string, 0, len(reviewRequests.Nodes))
+
+	for _, node := range reviewRequests.Nodes {
+		switch {
+		case node.RequestedReviewer == nil:
+			continue
+		case node.RequestedReviewer.Login != "":
+			reviewers = append(reviewers, node.RequestedReviewer.Login)
+		case node.RequestedReviewer.CombinedSlug != "":
+			reviewers = append(reviewers, node.RequestedReviewer.CombinedSlug)
+		}
+	}
+
+	return reviewers
+}
+
+// toPRFileStatus maps a GraphQL PatchStatus to the status returned by the REST API.
+func toPRFileStatus(changeType string) string {
+	switch changeType {
+	case "ADDED":
+		return PRFileStatusAdded
+	case "DELETED":
+		return PRFileStatusRemoved
+	case "MODIFIED":
+		return PRFileStatusModified
+	case "RENAMED":
+		return PRFileStatusRenamed
+	default:
+		return strings.ToLower(changeType)
+	}
+}
+
+// query sends the query and decodes its data into data. A query rejected
+// because of the rate limit is retried after the rate limit is reset.
+func (gql *GraphQL) query(ctx context.Context, query string, variables map[string]any, data any) error {
+	body, err := json.Marshal(graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return fmt.Errorf("encode graphql request: %w", err)
+	}
+
+	var rateLimitErr *retryError
+
+	for i := range maxHTTPRetries {
+		if i > 0 {
+			log.Printf("[%d/%d] retry graphql query", i, maxHTTPRetries)
+		}
+
+		var response *graphQLResponse
+
+		response, rateLimitErr, err = gql.post(ctx, body)
+		if err != nil {
+			return err
+		}
+
+		if rateLimitErr == nil {
+			return decodeGraphQLData(response, data)
+		}
+
+		log.Printf("graphql rate limit: %+v", rateLimitErr)
+
+		if waitErr := waitForRetry(ctx, rateLimitErr); waitErr != nil {
+			return waitErr
+		}
+	}
+
+	return rateLimitErr
+}
+
+// post sends the encoded query. It returns a retry error if GitHub rejected the query
+// because of the rate limit.
+func (gql *GraphQL) post(ctx context.Context, body []byte) (*graphQLResponse, *retryError, error) {
+	resp, err := gql.gitHub.httpPostWithRetry(ctx, gql.gitHub.baseURL+graphQLPath, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer resp.Body.Close()
+
+	var response graphQLResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, nil, fmt.Errorf("decode graphql response: %w", err)
+	}
+
+	for _, graphQLErr := range response.Errors {
+		if graphQLErr.Type == graphQLRateLimited {
+			//nolint:exhaustruct
+			return nil, &retryError{
+				err:        ErrRateLimitExceeded,
+				statusCode: resp.StatusCode,
+				resetStr:   resp.Header.Get("X-Ratelimit-Reset"),
+			}, nil
+		}
+	}
+
+	return &response, nil, nil
+}
+
+func decodeGraphQLData(response *graphQLResponse, data any) error {
+	if len(response.Errors) > 0 {
+		messages := make([]string, 0, len(response.Errors))
+
+		for _, graphQLErr := range response.Errors {
+			messages = append(messages, graphQLErr.Message)
+		}
+
+		return fmt.Errorf("%w: %s", ErrGraphQLQuery, strings.Join(messages, "; "))
+	}
+
+	if err := json.Unmarshal(response.Data, data); err != nil {
+		return fmt.Errorf("decode graphql data: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,415 @@
+package github_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dkarczmarski/go-kweb-lang/github"
+)
+
+type graphQLTestRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+// graphQLTestServer answers the GraphQL queries with the responses for the query
+// kinds ("search", search cursors and "pullRequest" files by the "after" cursor,
+// "reviewRequests") and the REST files requests with restFiles. It records the received requests.
+type graphQLTestServer struct {
+	t                  *testing.T
+	search             string
+	searchCursors      map[string]string
+	requestedReviewers string
+	prFiles            map[string]string
+	restFiles          string
+
+	mu       sync.Mutex
+	requests []string
+}
+
+func (s *graphQLTestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method == http.MethodGet {
+		s.record("GET " + r.URL.Path)
+		_, _ = w.Write([]byte(s.restFiles))
+
+		return
+	}
+
+	if r.URL.Path != "/graphql" {
+		s.t.Errorf("unexpected URL: %s", r.URL)
+	}
+
+	var req graphQLTestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.t.Errorf("decode request: %v", err)
+	}
+
+	after, _ := req.Variables["after"].(string)
+
+	switch {
+	case strings.Contains(req.Query, "search(") && !strings.Contains(req.Query, "nodes"):
+		s.record("cursor " + after)
+		_, _ = w.Write([]byte(s.searchCursors[after]))
+	case strings.Contains(req.Query, "search("):
+		s.record(strings.TrimSpace("search " + req.Variables["q"].(string) + " " + after))
+		_, _ = w.Write([]byte(s.search))
+	case strings.Contains(req.Query, "reviewRequests("):
+		s.record("reviewers " + strconv.Itoa(int(req.Variables["number"].(float64))))
+		_, _ = w.Write([]byte(s.requestedReviewers))
+	case strings.Contains(req.Query, "pullRequest("):
+		number := strconv.Itoa(int(req.Variables["number"].(float64)))
+		s.record(strings.TrimSpace("files " + number + " " + after))
+		_, _ = w.Write([]byte(s.prFiles[number+" "+after]))
+	default:
+		s.t.Errorf("unexpected query: %s", req.Query)
+	}
+}
+
+func (s *graphQLTestServer) record(request string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, request)
+}
+
+func (s *graphQLTestServer) takeRequests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	requests := s.requests
+	s.requests = nil
+
+	return requests
+}
+
+// newTestGraphQL creates a GraphQL client of a server with handler,
+// which uses a REST client of a server with restHandler if it is not nil.
+func newTestGraphQL(t *testing.T, handler, restHandler http.Handler) *github.GraphQL {
+	t.Helper()
+
+	newTestGitHub := func(handler http.Handler) *github.GitHub {
+		mockServer := httptest.NewServer(handler)
+		t.Cleanup(mockServer.Close)
+
+		return github.NewGitHub(func(config *github.Config) {
+			config.HTTPClient = mockServer.Client()
+			config.BaseURL = mockServer.URL
+		})
+	}
+
+	if restHandler == nil {
+		return github.NewGraphQL(newTestGitHub(handler))
+	}
+
+	rest := newTestGitHub(restHandler)
+
+	return github.NewGraphQL(newTestGitHub(handler), func(config *github.GraphQLConfig) {
+		config.REST = rest
+	})
+}
+
+func TestGraphQL_PRSearch_GetPRFiles(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	server := &graphQLTestServer{
+		t: t,
+		search: `{"data":{"search":{"issueCount":3,"nodes":[
+			{"number":12,"title":"[pl] a","author":{"login":"alice"},"isDraft":false,
+			 "createdAt":"2025-02-01T10:00:00Z","updatedAt":"2025-02-04T14:53:37Z","headRefOid":"h12","reviewDecision":"APPROVED",
+			 "labels":{"nodes":[{"name":"language/pl"},{"name":"lgtm"}]},
+			 "reviewRequests":{"nodes":[{"requestedReviewer":{"login":"bob"}},{"requestedReviewer":{"combinedSlug":"kubernetes/sig-docs-pl-reviews"}}]},
+			 "files":{"nodes":[{"path":"content/pl/a.md","changeType":"MODIFIED"},{"path":"content/pl/b.md","changeType":"ADDED"}],
+			          "pageInfo":{"hasNextPage":false,"endCursor":"c1"}}},
+			{"number":14,"title":"[pl] c","author":null,"isDraft":true,
+			 "createdAt":"2025-02-02T10:00:00Z","updatedAt":"2025-02-05T10:00:00Z","headRefOid":"h14","reviewDecision":null,
+			 "labels":{"nodes":[{"name":"language/pl"}]},
+			 "files":{"nodes":[{"path":"content/pl/c.md","changeType":"DELETED"}],
+			          "pageInfo":{"hasNextPage":true,"endCursor":"c1"}}},
+			{"number":15,"title":"[pl] new","author":{"login":"carol"},"isDraft":false,
+			 "createdAt":"2025-02-03T10:00:00Z","updatedAt":"2025-02-06T10:00:00Z","headRefOid":"h15","reviewDecision":"REVIEW_REQUIRED",
+			 "labels":{"nodes":[{"name":"language/pl"}]},
+			 "files":{"nodes":[{"path":"content/pl/new.md","changeType":"RENAMED"}],
+			          "pageInfo":{"hasNextPage":false,"endCursor":"c1"}}}
+		]}}}`,
+		prFiles: map[string]string{
+			"14 ": `{"data":{"repository":{"pullRequest":{"files":{
+				"nodes":[{"path":"content/pl/c.md","changeType":"DELETED"}],"pageInfo":{"hasNextPage":true,"endCursor":"c1"}}}}}}`,
+			"14 c1": `{"data":{"repository":{"pullRequest":{"files":{
+				"nodes":[{"path":"content/pl/d.md","changeType":"MODIFIED"}],"pageInfo":{"hasNextPage":false,"endCursor":"c2"}}}}}}`,
+			"15 ": `{"data":{"repository":{"pullRequest":{"files":{
+				"nodes":[{"path":"content/pl/new.md","changeType":"RENAMED"}],"pageInfo":{"hasNextPage":true,"endCursor":"c1"}}}}}}`,
+		},
+		requestedReviewers: `{"data":{"repository":{"pullRequest":{"reviewRequests":{
+			"nodes":[{"requestedReviewer":{"login":"dave"}},{"requestedReviewer":null}]}}}}}`,
+	}
+	restServer := &graphQLTestServer{
+		t:         t,
+		restFiles: `[{"filename":"content/pl/new.md","status":"renamed","previous_filename":"content/pl/old.md"}]`,
+	}
+
+	gql := newTestGraphQL(t, server, restServer)
+
+	result, err := gql.PRSearch(
+		ctx,
+		github.PRSearchFilter{LangCode: "pl", UpdatedFrom: "2025-02-01T00:00:00Z", OnlyOpen: true},
+		github.PageRequest{Sort: "updated", Order: "asc", PerPage: 3},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedResult := &github.PRSearchResult{
+		Items: []github.PRItem{
+			{
+				Number:         12,
+				Title:          "[pl] a",
+				User:           github.User{Login: "alice"},
+				CreatedAt:      "2025-02-01T10:00:00Z",
+				UpdatedAt:      "2025-02-04T14:53:37Z",
+				Labels:         []github.Label{{Name: "language/pl"}, {Name: "lgtm"}},
+				HeadSHA:        "h12",
+				ReviewDecision: "APPROVED",
+				Files: []github.PRFile{
+					{Filename: "content/pl/a.md", Status: github.PRFileStatusModified},
+					{Filename: "content/pl/b.md", Status: github.PRFileStatusAdded},
+				},
+				RequestedReviewers: []string{"bob", "kubernetes/sig-docs-pl-reviews"},
+			},
+			{
+				Number:             14,
+				Title:              "[pl] c",
+				Draft:              true,
+				CreatedAt:          "2025-02-02T10:00:00Z",
+				UpdatedAt:          "2025-02-05T10:00:00Z",
+				Labels:             []github.Label{{Name: "language/pl"}},
+				HeadSHA:            "h14",
+				RequestedReviewers: []string{},
+			},
+			{
+				Number:             15,
+				Title:              "[pl] new",
+				User:               github.User{Login: "carol"},
+				CreatedAt:          "2025-02-03T10:00:00Z",
+				UpdatedAt:          "2025-02-06T10:00:00Z",
+				Labels:             []github.Label{{Name: "language/pl"}},
+				HeadSHA:            "h15",
+				ReviewDecision:     "REVIEW_REQUIRED",
+				RequestedReviewers: []string{},
+			},
+		},
+		TotalCount: 3,
+	}
+
+	if !reflect.DeepEqual(expectedResult, result) {
+		t.Fatalf("result error\nexpected : %+v\nactual   : %+v", expectedResult, result)
+	}
+
+	wantRequests := []string{"search repo:kubernetes/website is:pr state:open label:language/pl " +
+		"updated:>2025-02-01T00:00:00Z sort:updated-asc"}
+	if got := server.takeRequests(); !reflect.DeepEqual(got, wantRequests) {
+		t.Fatalf("unexpected requests\nexpected : %q\nactual   : %q", wantRequests, got)
+	}
+
+	reviewers, err := gql.GetPRRequestedReviewers(ctx, 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if wantReviewers := []string{"dave"}; !reflect.DeepEqual(wantReviewers, reviewers) {
+		t.Errorf("reviewers\nexpected : %+v\nactual   : %+v", wantReviewers, reviewers)
+	}
+
+	if got, want := server.takeRequests(), []string{"reviewers 12"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("reviewers requests\nexpected : %q\nactual   : %q", want, got)
+	}
+
+	for _, tc := range []struct {
+		prNumber         int
+		wantFiles        []github.PRFile
+		wantRequests     []string
+		wantRESTRequests []string
+	}{
+		{
+			prNumber: 14,
+			wantFiles: []github.PRFile{
+				{Filename: "content/pl/c.md", Status: github.PRFileStatusRemoved},
+				{Filename: "content/pl/d.md", Status: github.PRFileStatusModified},
+			},
+			wantRequests: []string{"files 14", "files 14 c1"},
+		},
+		{
+			prNumber: 15,
+			wantFiles: []github.PRFile{
+				{
+					Filename:         "content/pl/new.md",
+					Status:           github.PRFileStatusRenamed,
+					PreviousFilename: "content/pl/old.md",
+				},
+			},
+			wantRequests:     []string{"files 15"},
+			wantRESTRequests: []string{"GET /repos/kubernetes/website/pulls/15/files"},
+		},
+	} {
+		files, err := gql.GetPRFiles(ctx, tc.prNumber)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(tc.wantFiles, files) {
+			t.Errorf("PR #%d files\nexpected : %+v\nactual   : %+v", tc.prNumber, tc.wantFiles, files)
+		}
+
+		if got := server.takeRequests(); !reflect.DeepEqual(got, tc.wantRequests) {
+			t.Errorf("PR #%d requests\nexpected : %q\nactual   : %q", tc.prNumber, tc.wantRequests, got)
+		}
+
+		if got := restServer.takeRequests(); !reflect.DeepEqual(got, tc.wantRESTRequests) {
+			t.Errorf("PR #%d REST requests\nexpected : %q\nactual   : %q", tc.prNumber, tc.wantRESTRequests, got)
+		}
+	}
+}
+
+func TestGraphQL_PRSearch_Page(t *testing.T) {
+	t.Parallel()
+
+	const searchQuery = "repo:kubernetes/website is:pr label:language/pl"
+
+	for _, tc := range []struct {
+		name         string
+		page         int
+		wantItems    []github.PRItem
+		wantRequests []string
+	}{
+		{
+			name: "first page",
+			page: 1,
+			wantItems: []github.PRItem{
+				{Number: 12, Files: []github.PRFile{}, RequestedReviewers: []string{}},
+			},
+			wantRequests: []string{"search " + searchQuery},
+		},
+		{
+			name: "third page",
+			page: 3,
+			wantItems: []github.PRItem{
+				{Number: 12, Files: []github.PRFile{}, RequestedReviewers: []string{}},
+			},
+			wantRequests: []string{"cursor ", "cursor p1", "search " + searchQuery + " p2"},
+		},
+		{
+			name:         "page after the last one",
+			page:         4,
+			wantItems:    []github.PRItem{},
+			wantRequests: []string{"cursor ", "cursor p1", "cursor p2"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := &graphQLTestServer{
+				t: t,
+				search: `{"data":{"search":{"issueCount":5,"nodes":[
+					{"number":12,"files":{"nodes":[],"pageInfo":{"hasNextPage":false}}}]}}}`,
+				searchCursors: map[string]string{
+					"":   `{"data":{"search":{"pageInfo":{"hasNextPage":true,"endCursor":"p1"}}}}`,
+					"p1": `{"data":{"search":{"pageInfo":{"hasNextPage":true,"endCursor":"p2"}}}}`,
+					"p2": `{"data":{"search":{"pageInfo":{"hasNextPage":false,"endCursor":"p3"}}}}`,
+				},
+			}
+
+			result, err := newTestGraphQL(t, server, nil).PRSearch(
+				t.Context(),
+				github.PRSearchFilter{LangCode: "pl"},
+				github.PageRequest{Page: tc.page, PerPage: 2},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(tc.wantItems, result.Items) {
+				t.Errorf("items\nexpected : %+v\nactual   : %+v", tc.wantItems, result.Items)
+			}
+
+			if got := server.takeRequests(); !reflect.DeepEqual(got, tc.wantRequests) {
+				t.Errorf("requests\nexpected : %q\nactual   : %q", tc.wantRequests, got)
+			}
+		})
+	}
+}
+
+func TestGraphQL_PRSearch_Errors(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		response string
+		wantErr  error
+	}{
+		{
+			name:     "query error",
+			response: `{"data":null,"errors":[{"type":"INVALID","message":"bad query"}]}`,
+			wantErr:  github.ErrGraphQLQuery,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			gql := newTestGraphQL(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(tc.response))
+			}), nil)
+
+			_, err := gql.PRSearch(t.Context(), github.PRSearchFilter{LangCode: "pl"}, github.PageRequest{})
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestGraphQL_PRSearch_RetriesRateLimitedQuery(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	gql := newTestGraphQL(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if requests.Add(1) == 1 {
+			// the rate limit has already been reset, so the query is retried without waiting
+			w.Header().Set("X-Ratelimit-Reset", strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10))
+			_, _ = w.Write([]byte(`{"data":null,"errors":[{"type":"RATE_LIMITED","message":"API rate limit exceeded"}]}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"data":{"search":{"issueCount":1,"nodes":[
+			{"number":12,"files":{"nodes":[],"pageInfo":{"hasNextPage":false}}}]}}}`))
+	}), nil)
+
+	result, err := gql.PRSearch(t.Context(), github.PRSearchFilter{LangCode: "pl"}, github.PageRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Items) != 1 || result.Items[0].Number != 12 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	if got := requests.Load(); got != 2 {
+		t.Fatalf("unexpected number of requests: got %d, want 2", got)
+	}
+}
@@ -17,10 +17,28 @@ const (
 	firstPage = 1
 )
 
+// Monitor checks the GitHub repositories for updates and runs the update task
+// of every repository which has changed.
 type Monitor struct {
+	repos []*repoMonitor
+}
+
+// Repository is a repository checked by the monitor together with its own
+// storage of the last seen timestamps and its own update task.
+type Repository struct {
+	Name         string
+	GitHub       GitHub
+	LangProvider LangProvider
+	Storage      MonitorStorage
+	OnUpdateTask OnUpdateTask
+}
+
+type repoMonitor struct {
+	name            string
 	gitHub          GitHub
 	langProvider    LangProvider
 	storage         MonitorStorage
+	onUpdateTask    OnUpdateTask
 	skipGitChecking bool
 	skipPRChecking  bool
 }
@@ -201,30 +219,36 @@ func (s *MonitorFileStorage) WriteLastLangPRUpdatedAt(langCode, value string) er
 }
 
 func NewMonitor(
-	gitHub GitHub,
-	langProvider LangProvider,
-	storage MonitorStorage,
+	repos []Repository,
 	skipGitChecking bool,
 	skipPRChecking bool,
 ) *Monitor {
+	repoMonitors := make([]*repoMonitor, 0, len(repos))
+	for _, repo := range repos {
+		repoMonitors = append(repoMonitors, &repoMonitor{
+			name:            repo.Name,
+			gitHub:          repo.GitHub,
+			langProvider:    repo.LangProvider,
+			storage:         repo.Storage,
+			onUpdateTask:    repo.OnUpdateTask,
+			skipGitChecking: skipGitChecking,
+			skipPRChecking:  skipPRChecking,
+		})
+	}
+
 	return &Monitor{
-		gitHub:          gitHub,
-		langProvider:    langProvider,
-		storage:         storage,
-		skipGitChecking: skipGitChecking,
-		skipPRChecking:  skipPRChecking,
+		repos: repoMonitors,
 	}
 }
 
 func (mon *Monitor) IntervalCheck(
 	ctx context.Context,
 	intervalDelay time.Duration,
-	onUpdateTask OnUpdateTask,
 ) error {
 	retryDelay := defaultRetryDelaySeconds * time.Second
 
 	for {
-		err := mon.RetryCheck(ctx, retryDelay, onUpdateTask)
+		err := mon.RetryCheck(ctx, retryDelay)
 		if err != nil {
 			return err
 		}
@@ -245,10 +269,9 @@ func (mon *Monitor) IntervalCheck(
 func (mon *Monitor) RetryCheck(
 	ctx context.Context,
 	retryDelay time.Duration,
-	onUpdateTask OnUpdateTask,
 ) error {
 	for {
-		err := mon.Check(ctx, onUpdateTask)
+		err := mon.Check(ctx)
 		if err != nil {
 			delay := nextRetryDelay(err, retryDelay)
 
@@ -287,10 +310,27 @@ func nextRetryDelay(err error, baseDelay time.Duration) time.Duration {
 	return nonRetryableFallbackDelayMinutes * time.Minute
 }
 
-func (mon *Monitor) Check(
-	ctx context.Context,
-	onUpdateTask OnUpdateTask,
-) error {
+// Check checks all repositories. A failure of one repository does not stop
+// checking the other ones; all failures are returned joined together.
+func (mon *Monitor) Check(ctx context.Context) error {
+	var errs []error
+
+	for _, repo := range mon.repos {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("check context done: %w", err)
+		}
+
+		if err := repo.check(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("repository %s: %w", repo.name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (mon *repoMonitor) check(ctx context.Context) error {
+	log.Printf("[githubmon] checking repository %s", mon.name)
+
 	repoUpdate, err := mon.checkRepoUpdates(ctx)
 	if err != nil {
 		return err
@@ -301,7 +341,7 @@ func (mon *Monitor) Check(
 		return err
 	}
 
-	if err := mon.runUpdateTask(ctx, onUpdateTask, repoUpdate.Updated, prLangChanges); err != nil {
+	if err := mon.runUpdateTask(ctx, repoUpdate.Updated, prLangChanges); err != nil {
 		return err
 	}
 
@@ -327,7 +367,7 @@ func (mon *Monitor) Check(
 }
 
 //nolint:exhaustruct
-func (mon *Monitor) checkRepoUpdates(ctx context.Context) (repoChange, error) {
+func (mon *repoMonitor) checkRepoUpdates(ctx context.Context) (repoChange, error) {
 	if mon.skipGitChecking {
 		return repoChange{}, nil
 	}
@@ -340,7 +380,7 @@ func (mon *Monitor) checkRepoUpdates(ctx context.Context) (repoChange, error) {
 	return repoUpdate, nil
 }
 
-func (mon *Monitor) checkPRUpdates(ctx context.Context) (bool, string, []langChange, error) {
+func (mon *repoMonitor) checkPRUpdates(ctx context.Context) (bool, string, []langChange, error) {
 	if mon.skipPRChecking {
 		return false, "", nil, nil
 	}
@@ -367,9 +407,8 @@ func (mon *Monitor) checkPRUpdates(ctx context.Context) (bool, string, []langCha
 	return lastPRUpdated, lastPRUpdatedAt, prLangChanges, nil
 }
 
-func (mon *Monitor) runUpdateTask(
+func (mon *repoMonitor) runUpdateTask(
 	ctx context.Context,
-	onUpdateTask OnUpdateTask,
 	repoUpdated bool,
 	prLangChanges []langChange,
 ) error {
@@ -382,14 +421,14 @@ func (mon *Monitor) runUpdateTask(
 		changedLangCodes = append(changedLangCodes, change.Code)
 	}
 
-	if err := onUpdateTask.OnUpdate(ctx, repoUpdated, changedLangCodes); err != nil {
+	if err := mon.onUpdateTask.OnUpdate(ctx, repoUpdated, changedLangCodes); err != nil {
 		return fmt.Errorf("run on-update task: %w", err)
 	}
 
 	return nil
 }
 
-func (mon *Monitor) writeChangedLangCodesInPR(changes []langChange) error {
+func (mon *repoMonitor) writeChangedLangCodesInPR(changes []langChange) error {
 	for _, change := range changes {
 		if err := mon.storage.WriteLastLangPRUpdatedAt(change.Code, change.UpdatedAt); err != nil {
 			return fmt.Errorf("write PR update timestamp for %s: %w", change.Code, err)
@@ -404,7 +443,7 @@ type repoChange struct {
 	Updated   bool
 }
 
-func (mon *Monitor) isRepoUpdated(ctx context.Context) (repoChange, error) {
+func (mon *repoMonitor) isRepoUpdated(ctx context.Context) (repoChange, error) {
 	log.Printf("[githubmon] checking repo updates")
 
 	lastUpdatedAt, err := mon.storage.ReadLastRepoUpdatedAt()
@@ -431,7 +470,7 @@ func (mon *Monitor) isRepoUpdated(ctx context.Context) (repoChange, error) {
 	}, nil
 }
 
-func (mon *Monitor) getCurrentLastRepoUpdatedAt(ctx context.Context) (string, error) {
+func (mon *repoMonitor) getCurrentLastRepoUpdatedAt(ctx context.Context) (string, error) {
 	commitInfo, err := mon.gitHub.GetLatestCommit(ctx)
 	if err != nil {
 		return "", fmt.Errorf("get latest commit: %w", err)
@@ -446,7 +485,7 @@ type langChange struct {
 	Updated   bool
 }
 
-func (mon *Monitor) checkLangChange(ctx context.Context, langCode string) (langChange, error) {
+func (mon *repoMonitor) checkLangChange(ctx context.Context, langCode string) (langChange, error) {
 	lastUpdatedAt, err := mon.storage.ReadLastLangPRUpdatedAt(langCode)
 	if err != nil {
 		return langChange{}, fmt.Errorf("read PR update timestamp for %s: %w", langCode, err)
@@ -466,7 +505,7 @@ func (mon *Monitor) checkLangChange(ctx context.Context, langCode string) (langC
 	}, nil
 }
 
-func (mon *Monitor) changedInPR(ctx context.Context) (bool, string, error) {
+func (mon *repoMonitor) changedInPR(ctx context.Context) (bool, string, error) {
 	lastUpdatedAt, err := mon.storage.ReadLastPRUpdatedAt()
 	if err != nil {
 		return false, "", fmt.Errorf("read PR update timestamp: %w", err)
@@ -482,7 +521,7 @@ func (mon *Monitor) changedInPR(ctx context.Context) (bool, string, error) {
 	return isUpdated, currentUpdatedAt, nil
 }
 
-func (mon *Monitor) changedLangCodesInPR(ctx context.Context) ([]langChange, error) {
+func (mon *repoMonitor) changedLangCodesInPR(ctx context.Context) ([]langChange, error) {
 	log.Printf("[githubmon] checking PR updates for languages")
 
 	langCodes, err := mon.langProvider.LangCodes()
@@ -515,7 +554,7 @@ func (mon *Monitor) changedLangCodesInPR(ctx context.Context) ([]langChange, err
 	return updatedLangCodes, nil
 }
 
-func (mon *Monitor) getLastPRUpdatedAt(ctx context.Context) (string, error) {
+func (mon *repoMonitor) getLastPRUpdatedAt(ctx context.Context) (string, error) {
 	result, err := mon.gitHub.PRSearch(
 		ctx,
 		//nolint:exhaustruct
@@ -538,7 +577,7 @@ func (mon *Monitor) getLastPRUpdatedAt(ctx context.Context) (string, error) {
 	return result.Items[0].UpdatedAt, nil
 }
 
-func (mon *Monitor) getLastLangPRUpdatedAt(ctx context.Context, langCode string) (string, error) {
+func (mon *repoMonitor) getLastLangPRUpdatedAt(ctx context.Context, langCode string) (string, error) {
 	result, err := mon.gitHub.PRSearch(
 		ctx,
 		//nolint:exhaustruct
@@ -2,6 +2,7 @@ package githubmon_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/dkarczmarski/go-kweb-lang/github"
@@ -180,9 +181,15 @@ func TestMonitor_Check(t *testing.T) {
 
 			tc.initMocks(ctx, githubMock, langMock, storageMock, task)
 
-			mon := githubmon.NewMonitor(githubMock, langMock, storageMock, false, false)
+			mon := githubmon.NewMonitor([]githubmon.Repository{{
+				Name:         "website",
+				GitHub:       githubMock,
+				LangProvider: langMock,
+				Storage:      storageMock,
+				OnUpdateTask: task,
+			}}, false, false)
 
-			err := mon.Check(ctx, task)
+			err := mon.Check(ctx)
 
 			if !tc.checkErr(err) {
 				t.Errorf("unexpected error result: %v", err)
@@ -190,3 +197,53 @@ func TestMonitor_Check(t *testing.T) {
 		})
 	}
 }
+
+func TestMonitor_Check_MultipleRepositories(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	ctrl := gomock.NewController(t)
+
+	failingGitHubMock := mocks.NewMockGitHub(ctrl)
+	failingStorageMock := mocks.NewMockMonitorStorage(ctrl)
+	failingTask := mocks.NewMockOnUpdateTask(ctrl)
+
+	githubMock := mocks.NewMockGitHub(ctrl)
+	storageMock := mocks.NewMockMonitorStorage(ctrl)
+	task := mocks.NewMockOnUpdateTask(ctrl)
+
+	errGitHub := errors.New("github error")
+
+	failingStorageMock.EXPECT().ReadLastRepoUpdatedAt().Return("DT-0", nil)
+	failingGitHubMock.EXPECT().GetLatestCommit(ctx).Return(nil, errGitHub)
+
+	// the failure of the first repository does not stop checking the second one
+	storageMock.EXPECT().ReadLastRepoUpdatedAt().Return("DT-0", nil)
+	githubMock.EXPECT().GetLatestCommit(ctx).
+		Return(&github.CommitInfo{CommitID: "C-ID-1", DateTime: "DT-1"}, nil)
+	storageMock.EXPECT().WriteLastRepoUpdatedAt("DT-1").Return(nil)
+	task.EXPECT().OnUpdate(ctx, true, []string{}).Return(nil)
+
+	mon := githubmon.NewMonitor([]githubmon.Repository{
+		{
+			Name:         "website",
+			GitHub:       failingGitHubMock,
+			LangProvider: mocks.NewMockLangProvider(ctrl),
+			Storage:      failingStorageMock,
+			OnUpdateTask: failingTask,
+		},
+		{
+			Name:         "docs",
+			GitHub:       githubMock,
+			LangProvider: mocks.NewMockLangProvider(ctrl),
+			Storage:      storageMock,
+			OnUpdateTask: task,
+		},
+	}, false, true)
+
+	err := mon.Check(ctx)
+	if !errors.Is(err, errGitHub) {
+		t.Errorf("unexpected error result: %v", err)
+	}
+}
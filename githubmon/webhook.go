@@ -0,0 +1,350 @@
+package githubmon
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const (
+	// maxWebhookPayloadBytes is the largest payload GitHub delivers.
+	maxWebhookPayloadBytes = 25 << 20
+
+	signatureHeader = "X-Hub-Signature-256"
+	signaturePrefix = "sha256="
+	eventHeader     = "X-GitHub-Event"
+	deliveryHeader  = "X-GitHub-Delivery"
+
+	eventPing        = "ping"
+	eventPush        = "push"
+	eventPullRequest = "pull_request"
+
+	branchRefPrefix = "refs/heads/"
+	langLabelPrefix = "language/"
+)
+
+var errInvalidSignature = errors.New("invalid signature")
+
+// pullRequestActions are the pull request actions that may change
+// the files or the language labels of a pull request.
+var pullRequestActions = []string{
+	"opened",
+	"reopened",
+	"closed",
+	"synchronize",
+	"edited",
+	"labeled",
+	"unlabeled",
+}
+
+// WebhookReceiver receives GitHub webhook deliveries and runs the update task
+// of the repository they are about. The updates are queued and run in the
+// background by Run, so that the delivery is answered at once; updates queued
+// while the task is running are merged and run together afterwards.
+type WebhookReceiver struct {
+	secret []byte
+	repos  []*webhookRepo
+}
+
+// WebhookRepository is a repository whose webhook deliveries are handled by the receiver.
+type WebhookRepository struct {
+	Name string
+
+	// FullName is the GitHub repository in the owner/name form, like kubernetes/website.
+	FullName string
+
+	// Branch is the tracked branch, pushes to other branches are ignored.
+	Branch string
+
+	LangProvider LangProvider
+	OnUpdateTask OnUpdateTask
+}
+
+type webhookRepo struct {
+	WebhookRepository
+
+	mu      sync.Mutex
+	pending webhookUpdate
+	queued  chan struct{}
+}
+
+type webhookUpdate struct {
+	repoUpdated bool
+	langCodes   []string
+}
+
+func (u webhookUpdate) isEmpty() bool {
+	return !u.repoUpdated && len(u.langCodes) == 0
+}
+
+type webhookRepository struct {
+	FullName string `json:"full_name"`
+}
+
+type webhookLabel struct {
+	Name string `json:"name"`
+}
+
+type pushEvent struct {
+	Ref        string            `json:"ref"`
+	Repository webhookRepository `json:"repository"`
+}
+
+type pullRequestEvent struct {
+	Action      string            `json:"action"`
+	Label       *webhookLabel     `json:"label"`
+	Repository  webhookRepository `json:"repository"`
+	PullRequest struct {
+		Number int            `json:"number"`
+		Labels []webhookLabel `json:"labels"`
+	} `json:"pull_request"`
+}
+
+// NewWebhookReceiver creates a receiver accepting the deliveries signed with the secret.
+func NewWebhookReceiver(secret string, repos []WebhookRepository) *WebhookReceiver {
+	webhookRepos := make([]*webhookRepo, 0, len(repos))
+	for _, repo := range repos {
+		webhookRepos = append(webhookRepos, &webhookRepo{
+			WebhookRepository: repo,
+			queued:            make(chan struct{}, 1),
+		})
+	}
+
+	return &WebhookReceiver{
+		secret: []byte(secret),
+		repos:  webhookRepos,
+	}
+}
+
+// ServeHTTP handles a webhook delivery. It answers 202 Accepted if an update was queued,
+// 204 No Content if the delivery does not concern any tracked data and 401 Unauthorized
+// if the signature does not match the secret.
+func (rcv *WebhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookPayloadBytes))
+	if err != nil {
+		http.Error(w, "cannot read payload", http.StatusBadRequest)
+
+		return
+	}
+
+	if err := rcv.verifySignature(r.Header.Get(signatureHeader), payload); err != nil {
+		log.Printf("[githubmon] webhook delivery %s rejected: %v", r.Header.Get(deliveryHeader), err)
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+
+		return
+	}
+
+	repo, update, err := rcv.parseDelivery(r.Header.Get(eventHeader), payload)
+	if err != nil {
+		log.Printf("[githubmon] webhook delivery %s: %v", r.Header.Get(deliveryHeader), err)
+		http.Error(w, "bad payload", http.StatusBadRequest)
+
+		return
+	}
+
+	if repo == nil || update.isEmpty() {
+		w.WriteHeader(http.StatusNoContent)
+
+		return
+	}
+
+	log.Printf("[githubmon] webhook delivery %s: repository %s updated: %v, language PR changes: %v",
+		r.Header.Get(deliveryHeader), repo.Name, update.repoUpdated, update.langCodes)
+
+	repo.queue(update)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// verifySignature checks the HMAC-SHA256 signature of the payload sent
+// in the X-Hub-Signature-256 header as "sha256=<hex digest>".
+func (rcv *WebhookReceiver) verifySignature(signature string, payload []byte) error {
+	if len(rcv.secret) == 0 {
+		return fmt.Errorf("%w: no webhook secret configured", errInvalidSignature)
+	}
+
+	hexDigest, ok := strings.CutPrefix(signature, signaturePrefix)
+	if !ok {
+		return fmt.Errorf("%w: missing %s header", errInvalidSignature, signatureHeader)
+	}
+
+	digest, err := hex.DecodeString(hexDigest)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidSignature, err)
+	}
+
+	mac := hmac.New(sha256.New, rcv.secret)
+	mac.Write(payload)
+
+	if !hmac.Equal(digest, mac.Sum(nil)) {
+		return errInvalidSignature
+	}
+
+	return nil
+}
+
+// parseDelivery returns the tracked repository the delivery is about and the update to run.
+// The repository is nil for the events and repositories that are not tracked.
+func (rcv *WebhookReceiver) parseDelivery(event string, payload []byte) (*webhookRepo, webhookUpdate, error) {
+	switch event {
+	case eventPush:
+		var push pushEvent
+		if err := json.Unmarshal(payload, &push); err != nil {
+			return nil, webhookUpdate{}, fmt.Errorf("parse push event: %w", err)
+		}
+
+		repo := rcv.findRepo(push.Repository.FullName)
+		if repo == nil {
+			return nil, webhookUpdate{}, nil
+		}
+
+		//nolint:exhaustruct
+		return repo, webhookUpdate{repoUpdated: push.Ref == branchRefPrefix+repo.Branch}, nil
+	case eventPullRequest:
+		var pullRequest pullRequestEvent
+		if err := json.Unmarshal(payload, &pullRequest); err != nil {
+			return nil, webhookUpdate{}, fmt.Errorf("parse pull_request event: %w", err)
+		}
+
+		repo := rcv.findRepo(pullRequest.Repository.FullName)
+		if repo == nil || !slices.Contains(pullRequestActions, pullRequest.Action) {
+			return nil, webhookUpdate{}, nil
+		}
+
+		langCodes, err := repo.trackedLangCodes(pullRequestLangCodes(pullRequest))
+		if err != nil {
+			return nil, webhookUpdate{}, err
+		}
+
+		//nolint:exhaustruct
+		return repo, webhookUpdate{langCodes: langCodes}, nil
+	default:
+		// ping and the events the receiver is not interested in
+		return nil, webhookUpdate{}, nil
+	}
+}
+
+func (rcv *WebhookReceiver) findRepo(fullName string) *webhookRepo {
+	for _, repo := range rcv.repos {
+		if strings.EqualFold(repo.FullName, fullName) {
+			return repo
+		}
+	}
+
+	return nil
+}
+
+// pullRequestLangCodes returns the language codes of the language/* labels of the pull request,
+// including the label that has just been added or removed.
+func pullRequestLangCodes(event pullRequestEvent) []string {
+	labels := event.PullRequest.Labels
+	if event.Label != nil {
+		labels = append(labels, *event.Label)
+	}
+
+	var langCodes []string
+
+	for _, label := range labels {
+		langCode, ok := strings.CutPrefix(label.Name, langLabelPrefix)
+		if ok && langCode != "" && !slices.Contains(langCodes, langCode) {
+			langCodes = append(langCodes, langCode)
+		}
+	}
+
+	return langCodes
+}
+
+// trackedLangCodes returns the language codes tracked for the repository.
+func (repo *webhookRepo) trackedLangCodes(langCodes []string) ([]string, error) {
+	if len(langCodes) == 0 {
+		return nil, nil
+	}
+
+	tracked, err := repo.LangProvider.LangCodes()
+	if err != nil {
+		return nil, fmt.Errorf("get language codes of %s: %w", repo.Name, err)
+	}
+
+	var result []string
+
+	for _, langCode := range langCodes {
+		if slices.Contains(tracked, langCode) {
+			result = append(result, langCode)
+		}
+	}
+
+	return result, nil
+}
+
+// queue merges the update with the pending one and wakes up the worker of the repository.
+func (repo *webhookRepo) queue(update webhookUpdate) {
+	repo.mu.Lock()
+
+	repo.pending.repoUpdated = repo.pending.repoUpdated || update.repoUpdated
+
+	for _, langCode := range update.langCodes {
+		if !slices.Contains(repo.pending.langCodes, langCode) {
+			repo.pending.langCodes = append(repo.pending.langCodes, langCode)
+		}
+	}
+
+	repo.mu.Unlock()
+
+	select {
+	case repo.queued <- struct{}{}:
+	default:
+		// the worker has already been woken up
+	}
+}
+
+func (repo *webhookRepo) takePending() webhookUpdate {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	update := repo.pending
+	repo.pending = webhookUpdate{}
+
+	return update
+}
+
+// Run runs the queued updates until the context is done. A failed update is only logged,
+// the interval check of the monitor picks up the changes later.
+func (rcv *WebhookReceiver) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	for _, repo := range rcv.repos {
+		wg.Go(func() {
+			repo.run(ctx)
+		})
+	}
+
+	wg.Wait()
+}
+
+func (repo *webhookRepo) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-repo.queued:
+		}
+
+		update := repo.takePending()
+		if update.isEmpty() {
+			continue
+		}
+
+		if err := repo.OnUpdateTask.OnUpdate(ctx, update.repoUpdated, update.langCodes); err != nil {
+			log.Printf("[githubmon] repository %s: run on-update task for webhook: %v", repo.Name, err)
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package githubmon_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dkarczmarski/go-kweb-lang/githubmon"
+)
+
+const testWebhookSecret = "webhook-secret"
+
+type fakeLangProvider []string
+
+func (f fakeLangProvider) LangCodes() ([]string, error) {
+	return f, nil
+}
+
+type onUpdateCall struct {
+	repoUpdated bool
+	langCodes   []string
+}
+
+// fakeOnUpdateTask sends the calls of OnUpdate to the channel.
+type fakeOnUpdateTask chan onUpdateCall
+
+func (f fakeOnUpdateTask) OnUpdate(_ context.Context, repoUpdated bool, changedLangCodesInPR []string) error {
+	f <- onUpdateCall{repoUpdated: repoUpdated, langCodes: changedLangCodesInPR}
+
+	return nil
+}
+
+func newTestWebhookReceiver(task fakeOnUpdateTask) *githubmon.WebhookReceiver {
+	return githubmon.NewWebhookReceiver(testWebhookSecret, []githubmon.WebhookRepository{
+		{
+			Name:         "website",
+			FullName:     "kubernetes/website",
+			Branch:       "main",
+			LangProvider: fakeLangProvider{"pl", "de"},
+			OnUpdateTask: task,
+		},
+	})
+}
+
+func sign(secret string, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func deliver(receiver http.Handler, event string, payload string, signature string) int {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/github", strings.NewReader(payload))
+	req.Header.Set("X-GitHub-Event", event)
+	req.Header.Set("X-GitHub-Delivery", "delivery-1")
+
+	if signature != "" {
+		req.Header.Set("X-Hub-Signature-256", signature)
+	}
+
+	rec := httptest.NewRecorder()
+	receiver.ServeHTTP(rec, req)
+
+	return rec.Code
+}
+
+func TestWebhookReceiver_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	const (
+		pushMain = `{"ref":"refs/heads/main","repository":{"full_name":"kubernetes/website"}}`
+		labeled  = `{"action":"labeled","label":{"name":"language/pl"},` +
+			`"pull_request":{"number":1,"labels":[{"name":"area/web"},{"name":"language/pl"}]},` +
+			`"repository":{"full_name":"kubernetes/website"}}`
+	)
+
+	for _, tc := range []struct {
+		name       string
+		event      string
+		payload    string
+		signature  string
+		wantStatus int
+		wantCall   *onUpdateCall
+	}{
+		{
+			name:       "push to the tracked branch",
+			event:      "push",
+			payload:    pushMain,
+			wantStatus: http.StatusAccepted,
+			wantCall:   &onUpdateCall{repoUpdated: true},
+		},
+		{
+			name:       "push to another branch",
+			event:      "push",
+			payload:    `{"ref":"refs/heads/dev","repository":{"full_name":"kubernetes/website"}}`,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "push to an untracked repository",
+			event:      "push",
+			payload:    `{"ref":"refs/heads/main","repository":{"full_name":"example/docs"}}`,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "language label added",
+			event:      "pull_request",
+			payload:    labeled,
+			wantStatus: http.StatusAccepted,
+			wantCall:   &onUpdateCall{langCodes: []string{"pl"}},
+		},
+		{
+			name:  "language label removed",
+			event: "pull_request",
+			payload: `{"action":"unlabeled","label":{"name":"language/de"},` +
+				`"pull_request":{"number":1,"labels":[]},"repository":{"full_name":"Kubernetes/Website"}}`,
+			wantStatus: http.StatusAccepted,
+			wantCall:   &onUpdateCall{langCodes: []string{"de"}},
+		},
+		{
+			name:  "pull request of an untracked language",
+			event: "pull_request",
+			payload: `{"action":"synchronize",` +
+				`"pull_request":{"number":1,"labels":[{"name":"language/fr"}]},"repository":{"full_name":"kubernetes/website"}}`,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:  "pull request action that does not change files or labels",
+			event: "pull_request",
+			payload: `{"action":"assigned",` +
+				`"pull_request":{"number":1,"labels":[{"name":"language/pl"}]},"repository":{"full_name":"kubernetes/website"}}`,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "ping",
+			event:      "ping",
+			payload:    `{"zen":"Keep it logically awesome."}`,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "wrong secret",
+			event:      "push",
+			payload:    pushMain,
+			signature:  sign("other-secret", pushMain),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "missing signature",
+			event:      "push",
+			payload:    pushMain,
+			signature:  "-",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "invalid payload",
+			event:      "push",
+			payload:    `{"ref":`,
+			wantStatus: http.StatusBadRequest,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			task := make(fakeOnUpdateTask, 1)
+			receiver := newTestWebhookReceiver(task)
+
+			signature := tc.signature
+			switch signature {
+			case "":
+				signature = sign(testWebhookSecret, tc.payload)
+			case "-":
+				signature = ""
+			}
+
+			if got := deliver(receiver, tc.event, tc.payload, signature); got != tc.wantStatus {
+				t.Fatalf("unexpected status: got %d, want %d", got, tc.wantStatus)
+			}
+
+			if tc.wantCall == nil {
+				return
+			}
+
+			ctx, cancel := context.WithCancel(t.Context())
+			defer cancel()
+
+			go receiver.Run(ctx)
+
+			select {
+			case got := <-task:
+				if !reflect.DeepEqual(got, *tc.wantCall) {
+					t.Fatalf("unexpected OnUpdate call: got %+v, want %+v", got, *tc.wantCall)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("OnUpdate was not called")
+			}
+		})
+	}
+}
+
+func TestWebhookReceiver_Run_MergesQueuedUpdates(t *testing.T) {
+	t.Parallel()
+
+	task := make(fakeOnUpdateTask, 1)
+	receiver := newTestWebhookReceiver(task)
+
+	deliveries := []struct {
+		event   string
+		payload string
+	}{
+		{
+			event: "pull_request",
+			payload: `{"action":"opened",` +
+				`"pull_request":{"number":1,"labels":[{"name":"language/pl"}]},"repository":{"full_name":"kubernetes/website"}}`,
+		},
+		{
+			event:   "push",
+			payload: `{"ref":"refs/heads/main","repository":{"full_name":"kubernetes/website"}}`,
+		},
+		{
+			event: "pull_request",
+			payload: `{"action":"closed",` +
+				`"pull_request":{"number":2,"labels":[{"name":"language/de"},{"name":"language/pl"}]},` +
+				`"repository":{"full_name":"kubernetes/website"}}`,
+		},
+	}
+
+	for _, delivery := range deliveries {
+		if got := deliver(receiver, delivery.event, delivery.payload, sign(testWebhookSecret, delivery.payload)); got != http.StatusAccepted {
+			t.Fatalf("unexpected status: %d", got)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	go receiver.Run(ctx)
+
+	want := onUpdateCall{repoUpdated: true, langCodes: []string{"pl", "de"}}
+
+	select {
+	case got := <-task:
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("unexpected OnUpdate call: got %+v, want %+v", got, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("OnUpdate was not called")
+	}
+
+	select {
+	case got := <-task:
+		t.Fatalf("unexpected second OnUpdate call: %+v", got)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,267 @@
+package gitseek
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	// ChangeKindUnknown means the change has not been classified, for example
+	// because the entry was cached before classification existed.
+	ChangeKindUnknown = ""
+
+	// ChangeKindWhitespaceOnly means the change only adds, removes or moves whitespace.
+	ChangeKindWhitespaceOnly = "whitespace-only"
+
+	// ChangeKindFrontMatterOnly means all changed lines are inside the front matter block.
+	ChangeKindFrontMatterOnly = "front-matter-only"
+
+	// ChangeKindCodeBlockOnly means all changed lines are inside fenced code blocks.
+	ChangeKindCodeBlockOnly = "code-block-only"
+
+	// ChangeKindLinkOnly means the changed lines differ only in link targets.
+	ChangeKindLinkOnly = "link-only"
+
+	// ChangeKindProse means the change modifies the text of the document.
+	ChangeKindProse = "prose"
+)
+
+// IsSubstantiveChangeKind reports whether a change of the given kind usually
+// has to be applied to the translation as well.
+func IsSubstantiveChangeKind(changeKind string) bool {
+	switch changeKind {
+	case ChangeKindWhitespaceOnly, ChangeKindFrontMatterOnly, ChangeKindCodeBlockOnly, ChangeKindLinkOnly:
+		return false
+	default:
+		return true
+	}
+}
+
+type lineRegion int
+
+const (
+	regionText lineRegion = iota
+	regionFrontMatter
+	regionCodeBlock
+)
+
+//nolint:gochecknoglobals
+var (
+	markdownLinkTargetRegexp = regexp.MustCompile(`\]\([^)]*\)`)
+	markdownLinkRefRegexp    = regexp.MustCompile(`^\s*\[[^\]]+\]:\s*\S+.*$`)
+	htmlHrefRegexp           = regexp.MustCompile(`href="[^"]*"`)
+)
+
+// documentState tracks the region of the lines of one side (old or new)
+// of a diff.
+type documentState struct {
+	lineNumber    int
+	inFrontMatter bool
+	frontMatterID string
+	inCodeBlock   bool
+	codeFence     string
+}
+
+// next returns the region of the given line and advances the state.
+func (s *documentState) next(line string) lineRegion {
+	s.lineNumber++
+	trimmed := strings.TrimSpace(line)
+
+	if s.lineNumber == 1 && (trimmed == "---" || trimmed == "+++") {
+		s.inFrontMatter = true
+		s.frontMatterID = trimmed
+
+		return regionFrontMatter
+	}
+
+	if s.inFrontMatter {
+		if trimmed == s.frontMatterID {
+			s.inFrontMatter = false
+		}
+
+		return regionFrontMatter
+	}
+
+	if s.inCodeBlock {
+		if strings.HasPrefix(trimmed, s.codeFence) {
+			s.inCodeBlock = false
+		}
+
+		return regionCodeBlock
+	}
+
+	for _, fence := range []string{"```", "~~~"} {
+		if strings.HasPrefix(trimmed, fence) {
+			s.inCodeBlock = true
+			s.codeFence = fence
+
+			return regionCodeBlock
+		}
+	}
+
+	return regionText
+}
+
+type changedLine struct {
+	text   string
+	region lineRegion
+}
+
+// changeBlock is a run of removed and added lines between context lines.
+type changeBlock struct {
+	removed []changedLine
+	added   []changedLine
+}
+
+// ClassifyDiff classifies a unified diff of a single file produced with the
+// whole file as context (see git.Git.FileDiff).
+//
+// The classification is conservative: whenever the change does not fit
+// exactly one of the non-substantive kinds, ChangeKindProse is returned.
+// This includes diffs without any changed text lines, such as binary,
+// mode-only or empty diffs, which cannot be told apart from real changes.
+func ClassifyDiff(diff string) string {
+	blocks := parseChangeBlocks(diff)
+
+	if len(blocks) == 0 {
+		return ChangeKindProse
+	}
+
+	if allBlocks(blocks, isWhitespaceOnlyBlock) {
+		return ChangeKindWhitespaceOnly
+	}
+
+	var changed []changedLine
+	for _, block := range blocks {
+		changed = append(changed, block.removed...)
+		changed = append(changed, block.added...)
+	}
+
+	if allInRegion(changed, regionFrontMatter) {
+		return ChangeKindFrontMatterOnly
+	}
+
+	if allInRegion(changed, regionCodeBlock) {
+		return ChangeKindCodeBlockOnly
+	}
+
+	if allInRegion(changed, regionText) && allBlocks(blocks, isLinkOnlyBlock) {
+		return ChangeKindLinkOnly
+	}
+
+	return ChangeKindProse
+}
+
+// parseChangeBlocks returns the blocks of removed and added lines of the diff.
+// Combined diffs of merge commits (hunk headers starting with "@@@") have one
+// prefix column per parent; a line counts as removed when any column is '-'
+// and as added when any column is '+'.
+func parseChangeBlocks(diff string) []changeBlock {
+	var (
+		oldState    documentState
+		newState    documentState
+		blocks      []changeBlock
+		block       changeBlock
+		prefixWidth int
+	)
+
+	endBlock := func() {
+		if len(block.removed) > 0 || len(block.added) > 0 {
+			blocks = append(blocks, block)
+		}
+
+		block = changeBlock{}
+	}
+
+	for _, line := range strings.Split(diff, "\n") {
+		if strings.HasPrefix(line, "@@") {
+			endBlock()
+
+			prefixWidth = len(line) - len(strings.TrimLeft(line, "@")) - 1
+
+			continue
+		}
+
+		if prefixWidth == 0 || len(line) < prefixWidth {
+			continue
+		}
+
+		prefix, text := line[:prefixWidth], line[prefixWidth:]
+
+		switch {
+		case strings.Trim(prefix, " +-") != "":
+			// not a hunk body line, e.g. "\ No newline at end of file"
+		case strings.Contains(prefix, "-"):
+			block.removed = append(block.removed, changedLine{text: text, region: oldState.next(text)})
+		case strings.Contains(prefix, "+"):
+			block.added = append(block.added, changedLine{text: text, region: newState.next(text)})
+		default:
+			endBlock()
+			oldState.next(text)
+			newState.next(text)
+		}
+	}
+
+	endBlock()
+
+	return blocks
+}
+
+func allBlocks(blocks []changeBlock, predicate func(block changeBlock) bool) bool {
+	for _, block := range blocks {
+		if !predicate(block) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isWhitespaceOnlyBlock reports whether the removed and added lines of the block
+// have the same words. Text moved to another block is therefore not whitespace-only.
+func isWhitespaceOnlyBlock(block changeBlock) bool {
+	return collapseWhitespace(block.removed) == collapseWhitespace(block.added)
+}
+
+// collapseWhitespace joins the lines, replacing every run of whitespace,
+// including line breaks, with a single space.
+func collapseWhitespace(lines []changedLine) string {
+	var fields []string
+
+	for _, line := range lines {
+		fields = append(fields, strings.Fields(line.text)...)
+	}
+
+	return strings.Join(fields, " ")
+}
+
+func allInRegion(lines []changedLine, region lineRegion) bool {
+	for _, line := range lines {
+		if line.region != region {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isLinkOnlyBlock(block changeBlock) bool {
+	return normalizeLinks(block.removed) == normalizeLinks(block.added)
+}
+
+func normalizeLinks(lines []changedLine) string {
+	normalized := make([]changedLine, 0, len(lines))
+
+	for _, line := range lines {
+		if markdownLinkRefRegexp.MatchString(line.text) {
+			continue
+		}
+
+		text := markdownLinkTargetRegexp.ReplaceAllString(line.text, "]()")
+		text = htmlHrefRegexp.ReplaceAllString(text, `href=""`)
+
+		normalized = append(normalized, changedLine{text: text, region: line.region})
+	}
+
+	return collapseWhitespace(normalized)
+}
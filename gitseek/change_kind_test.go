@@ -0,0 +1,170 @@
+package gitseek_test
+
+import (
+	"testing"
+
+	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+)
+
+func TestClassifyDiff(t *testing.T) {
+	t.Parallel()
+
+	const header = "diff --git a/content/en/a.md b/content/en/a.md\n" +
+		"index 1111111..2222222 100644\n" +
+		"--- a/content/en/a.md\n" +
+		"+++ b/content/en/a.md\n"
+
+	for _, tc := range []struct {
+		name string
+		diff string
+		want string
+	}{
+		{
+			name: "empty diff",
+			diff: "",
+			want: gitseek.ChangeKindProse,
+		},
+		{
+			name: "binary diff",
+			diff: "diff --git a/static/a.png b/static/a.png\n" +
+				"index 1111111..2222222 100644\n" +
+				"Binary files a/static/a.png and b/static/a.png differ\n",
+			want: gitseek.ChangeKindProse,
+		},
+		{
+			name: "mode-only diff",
+			diff: "diff --git a/content/en/a.md b/content/en/a.md\n" +
+				"old mode 100644\n" +
+				"new mode 100755\n",
+			want: gitseek.ChangeKindProse,
+		},
+		{
+			name: "no newline at end of file marker",
+			diff: header + "@@ -1 +1 @@\n-Some text.\n\\ No newline at end of file\n+Some text.\n",
+			want: gitseek.ChangeKindWhitespaceOnly,
+		},
+		{
+			name: "combined diff with front matter field changed",
+			diff: "diff --cc content/en/a.md\n" +
+				"index 1111111,2222222..3333333\n" +
+				"--- a/content/en/a.md\n" +
+				"+++ b/content/en/a.md\n" +
+				"@@@ -1,4 -1,4 +1,4 @@@\n  ---\n- weight: 10\n+ weight: 20\n  ---\n  Body.\n",
+			want: gitseek.ChangeKindFrontMatterOnly,
+		},
+		{
+			name: "combined diff with line changed against both parents",
+			diff: "diff --cc content/en/a.md\n" +
+				"index 1111111,2222222..3333333\n" +
+				"--- a/content/en/a.md\n" +
+				"+++ b/content/en/a.md\n" +
+				"@@@ -1,2 -1,2 +1,2 @@@\n  Title\n--Some text.   \n++Some text.\n",
+			want: gitseek.ChangeKindWhitespaceOnly,
+		},
+		{
+			name: "trailing whitespace removed",
+			diff: header + "@@ -1,2 +1,2 @@\n Title\n-Some text.   \n+Some text.\n",
+			want: gitseek.ChangeKindWhitespaceOnly,
+		},
+		{
+			name: "line rewrapped",
+			diff: header + "@@ -1,2 +1,3 @@\n Title\n-Some long text.\n+Some long\n+text.\n",
+			want: gitseek.ChangeKindWhitespaceOnly,
+		},
+		{
+			name: "paragraph moved",
+			diff: header + "@@ -1,4 +1,4 @@\n-First.\n \n Second.\n+\n+First.\n",
+			want: gitseek.ChangeKindProse,
+		},
+		{
+			name: "words joined",
+			diff: header + "@@ -1,2 +1,2 @@\n Title\n-Set up the cluster.\n+Setup the cluster.\n",
+			want: gitseek.ChangeKindProse,
+		},
+		{
+			name: "word split",
+			diff: header + "@@ -1,2 +1,2 @@\n Title\n-Run the setup script.\n+Run the set up script.\n",
+			want: gitseek.ChangeKindProse,
+		},
+		{
+			name: "blank line added",
+			diff: header + "@@ -1,2 +1,3 @@\n Title\n+\n Some text.\n",
+			want: gitseek.ChangeKindWhitespaceOnly,
+		},
+		{
+			name: "yaml front matter field changed",
+			diff: header + "@@ -1,5 +1,5 @@\n ---\n title: A\n-weight: 10\n+weight: 20\n ---\n Body.\n",
+			want: gitseek.ChangeKindFrontMatterOnly,
+		},
+		{
+			name: "toml front matter field changed",
+			diff: header + "@@ -1,4 +1,4 @@\n +++\n-title = \"A\"\n+title = \"B\"\n +++\n Body.\n",
+			want: gitseek.ChangeKindFrontMatterOnly,
+		},
+		{
+			name: "code block changed",
+			diff: header + "@@ -1,5 +1,5 @@\n Text.\n ```shell\n-kubectl get pods\n+kubectl get pods -A\n ```\n",
+			want: gitseek.ChangeKindCodeBlockOnly,
+		},
+		{
+			name: "code block added",
+			diff: header + "@@ -1 +1,4 @@\n Text.\n+```yaml\n+a: b\n+```\n",
+			want: gitseek.ChangeKindCodeBlockOnly,
+		},
+		{
+			name: "link target changed",
+			diff: header + "@@ -1 +1 @@\n-See [docs](/docs/old/).\n+See [docs](/docs/new/).\n",
+			want: gitseek.ChangeKindLinkOnly,
+		},
+		{
+			name: "reference link definition changed",
+			diff: header + "@@ -1,2 +1,2 @@\n See [docs].\n-[docs]: /docs/old/\n+[docs]: /docs/new/\n",
+			want: gitseek.ChangeKindLinkOnly,
+		},
+		{
+			name: "link text changed",
+			diff: header + "@@ -1 +1 @@\n-See [docs](/docs/).\n+See [the docs](/docs/).\n",
+			want: gitseek.ChangeKindProse,
+		},
+		{
+			name: "prose changed",
+			diff: header + "@@ -1,2 +1,2 @@\n Title\n-Some text.\n+Other text.\n",
+			want: gitseek.ChangeKindProse,
+		},
+		{
+			name: "front matter and prose changed",
+			diff: header + "@@ -1,4 +1,4 @@\n ---\n-weight: 10\n+weight: 20\n ---\n-Body.\n+New body.\n",
+			want: gitseek.ChangeKindProse,
+		},
+		{
+			name: "front matter and code block changed",
+			diff: header + "@@ -1,6 +1,6 @@\n ---\n-weight: 10\n+weight: 20\n ---\n ```\n-a\n+b\n ```\n",
+			want: gitseek.ChangeKindProse,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := gitseek.ClassifyDiff(tc.diff); got != tc.want {
+				t.Fatalf("unexpected change kind: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsSubstantiveChangeKind(t *testing.T) {
+	t.Parallel()
+
+	for changeKind, want := range map[string]bool{
+		gitseek.ChangeKindUnknown:         true,
+		gitseek.ChangeKindProse:           true,
+		gitseek.ChangeKindWhitespaceOnly:  false,
+		gitseek.ChangeKindFrontMatterOnly: false,
+		gitseek.ChangeKindCodeBlockOnly:   false,
+		gitseek.ChangeKindLinkOnly:        false,
+	} {
+		if got := gitseek.IsSubstantiveChangeKind(changeKind); got != want {
+			t.Fatalf("IsSubstantiveChangeKind(%q) = %v, want %v", changeKind, got, want)
+		}
+	}
+}
@@ -8,7 +8,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/dkarczmarski/go-kweb-lang/git"
 	"github.com/dkarczmarski/go-kweb-lang/githist"
@@ -23,6 +26,10 @@ const (
 	// but has been removed after the fork point.
 	StatusEnFileNoLongerExists = "en-file-no-longer-exists"
 
+	// StatusEnFileMoved indicates that the EN file has been renamed (moved) after
+	// the fork point, so the language file should be moved as well.
+	StatusEnFileMoved = "moved"
+
 	// StatusEnFileUpdated indicates that the EN file has new commits after
 	// the fork or last translation commit.
 	StatusEnFileUpdated = "en-file-updated"
@@ -53,8 +60,38 @@ type FileInfo struct {
 	// FileStatus describes the detected state of the EN file relative to the language file.
 	FileStatus string
 
+	// StartPointSource tells which commit EN updates are looked up after
+	// (see StartPointSource* constants).
+	StartPointSource string
+
+	// SyncedWithCommit is the EN revision named by a sync marker, if the start point
+	// was taken from one.
+	SyncedWithCommit *git.CommitInfo
+
 	// EnUpdates lists commits that modified the EN file after the fork point.
 	EnUpdates []EnUpdate
+
+	// MovedToEnPath is the current path of the EN file if it has been moved.
+	MovedToEnPath string
+
+	// SuggestedLangPath is the path the language file should be moved to
+	// if the EN file has been moved.
+	SuggestedLangPath string
+}
+
+// StartPoint returns the commit after which the EN updates were looked up:
+// the commit named by the sync marker, the fork commit or the last commit
+// of the language file, in this order.
+func (fi FileInfo) StartPoint() git.CommitInfo {
+	if fi.SyncedWithCommit != nil {
+		return *fi.SyncedWithCommit
+	}
+
+	if fi.LangForkCommit != nil {
+		return *fi.LangForkCommit
+	}
+
+	return fi.LangLastCommit
 }
 
 // Pair represents a mapping between an English file and its translated version.
@@ -73,6 +110,25 @@ type EnUpdate struct {
 
 	// MergePoint is the merge commit associated with that change.
 	MergePoint *git.CommitInfo
+
+	// ChangeKind classifies the content of the change (see ChangeKind* constants).
+	ChangeKind string
+
+	// Overlap tells when the change reached the main branch relative to the lifetime
+	// of the branch with the last change of the language file (see Overlap* constants).
+	Overlap string
+}
+
+// IsSubstantive reports whether the update most likely has to be applied
+// to the translation. Unclassified updates are treated as substantive.
+func (u EnUpdate) IsSubstantive() bool {
+	return IsSubstantiveChangeKind(u.ChangeKind)
+}
+
+// IsDefinite reports whether the update is certainly missing from the translation.
+// Unclassified updates are treated as definite.
+func (u EnUpdate) IsDefinite() bool {
+	return IsDefiniteOverlap(u.Overlap)
 }
 
 // GitRepo defines the operations required to inspect the history of files
@@ -86,6 +142,31 @@ type GitRepo interface {
 
 	// FileExists checks whether the given file currently exists in the repository.
 	FileExists(path string) (bool, error)
+
+	// FileDiff returns the diff introduced by the given commit for the file.
+	FileDiff(ctx context.Context, commitID string, path string) (string, error)
+
+	// FindCommit returns the given commit or an empty CommitInfo if it does not exist.
+	FindCommit(ctx context.Context, commitID string) (git.CommitInfo, error)
+
+	// ListCommitTrailerValues returns values of the given trailer in the commit message.
+	ListCommitTrailerValues(ctx context.Context, commitID string, key string) ([]string, error)
+
+	// ReadFile returns the current content of the file.
+	ReadFile(path string) (string, error)
+
+	// FindFileRenames follows the renames of a file that no longer exists at the given path.
+	FindFileRenames(ctx context.Context, path string) ([]git.FileRename, error)
+
+	// FindFileLastCommitAt returns the most recent commit that modified the file
+	// in the history of the given revision.
+	FindFileLastCommitAt(ctx context.Context, revision string, path string) (git.CommitInfo, error)
+
+	// FileExistsAt checks whether the file exists in the given revision.
+	FileExistsAt(ctx context.Context, revision string, path string) (bool, error)
+
+	// ReadFileAt returns the content of the file in the given revision.
+	ReadFileAt(ctx context.Context, revision string, path string) (string, error)
 }
 
 // GitRepoHist defines operations related to merge and fork history in Git.
@@ -97,6 +178,11 @@ type GitRepoHist interface {
 	FindMergeCommit(ctx context.Context, commitID string) (*git.CommitInfo, error)
 }
 
+// LangPathResolver resolves the language file path corresponding to an EN file path.
+type LangPathResolver interface {
+	LangPath(enPath string, langCode string) (string, error)
+}
+
 // CacheStorage defines a storage abstraction used by GitSeek to cache FileInfo
 // results. A bucket usually maps to a directory on disk, and the key identifies
 // a specific cached entry.
@@ -112,15 +198,29 @@ type GitSeek struct {
 	gitRepo     GitRepo
 	gitRepoHist GitRepoHist
 	cache       CacheStorage
+	langPaths   LangPathResolver
+}
+
+type NewConfig struct {
+	// LangPaths is used to suggest the new path of a language file whose EN file
+	// has been moved. Without it, the suggestion is left empty.
+	LangPaths LangPathResolver
 }
 
 // New creates a new GitSeek instance using the provided Git repository
 // implementations and cache storage.
-func New(gitRepo GitRepo, gitRepoHist GitRepoHist, cache CacheStorage) *GitSeek {
+func New(gitRepo GitRepo, gitRepoHist GitRepoHist, cache CacheStorage, opts ...func(config *NewConfig)) *GitSeek {
+	//nolint:exhaustruct
+	config := NewConfig{}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
 	return &GitSeek{
 		gitRepo:     gitRepo,
 		gitRepoHist: gitRepoHist,
 		cache:       cache,
+		langPaths:   config.LangPaths,
 	}
 }
 
@@ -129,6 +229,12 @@ func FileInfoCacheBucket(langCode string) string {
 	return filepath.Join("lang", langCode, "git-file-info")
 }
 
+// MovedFileCacheBucket returns the cache bucket mapping the suggested lang paths
+// of moved files to the lang paths their file info is cached under.
+func MovedFileCacheBucket(langCode string) string {
+	return filepath.Join("lang", langCode, "git-moved-file")
+}
+
 // CheckLang analyzes the provided file pair for a given language and returns
 // FileInfo describing the relationship between the language file and the EN file.
 // The result may be returned from cache if available.
@@ -136,7 +242,19 @@ func (gs *GitSeek) CheckLang(ctx context.Context, langCode string, pair Pair) (F
 	return gs.checkFileCached(ctx, pair, langCode)
 }
 
+// CheckLangAt analyzes the provided file pair like CheckLang, but takes the language file
+// from the given revision (for example the head of a pull request), as if that revision
+// were merged into the main branch now. EN files are taken from the main branch.
+// The result is not cached.
+func (gs *GitSeek) CheckLangAt(ctx context.Context, langCode string, pair Pair, revision string) (FileInfo, error) {
+	return gs.checkFile(ctx, pair, langCode, revision)
+}
+
 // InvalidateFile removes the cached FileInfo entry for the specified language file.
+//
+// If the path is the suggested path of a language file whose EN file has been moved,
+// the entry of that language file is removed as well, because changes of the moved
+// EN file are reported for the suggested path.
 func (gs *GitSeek) InvalidateFile(langCode, path string) error {
 	bucket := FileInfoCacheBucket(langCode)
 
@@ -144,6 +262,27 @@ func (gs *GitSeek) InvalidateFile(langCode, path string) error {
 		return fmt.Errorf("delete file info cache for (%s)%s: %w", langCode, path, err)
 	}
 
+	movedBucket := MovedFileCacheBucket(langCode)
+
+	var movedLangPath string
+
+	exists, err := gs.cache.Read(movedBucket, path, &movedLangPath)
+	if err != nil {
+		return fmt.Errorf("read moved file cache for (%s)%s: %w", langCode, path, err)
+	}
+
+	if !exists {
+		return nil
+	}
+
+	if err := gs.cache.Delete(bucket, movedLangPath); err != nil {
+		return fmt.Errorf("delete file info cache for (%s)%s: %w", langCode, movedLangPath, err)
+	}
+
+	if err := gs.cache.Delete(movedBucket, path); err != nil {
+		return fmt.Errorf("delete moved file cache for (%s)%s: %w", langCode, path, err)
+	}
+
 	return nil
 }
 
@@ -164,7 +303,7 @@ func (gs *GitSeek) checkFileCached(ctx context.Context, pair Pair, langCode stri
 		return cached, nil
 	}
 
-	fileInfo, err := gs.checkFile(ctx, pair)
+	fileInfo, err := gs.checkFile(ctx, pair, langCode, workingTreeRevision)
 	if err != nil {
 		return fileInfo, err
 	}
@@ -175,16 +314,30 @@ func (gs *GitSeek) checkFileCached(ctx context.Context, pair Pair, langCode stri
 		return zero, fmt.Errorf("write file info cache for (%s)%s: %w", langCode, key, err)
 	}
 
+	if fileInfo.SuggestedLangPath != "" {
+		movedBucket := MovedFileCacheBucket(langCode)
+
+		if err := gs.cache.Write(movedBucket, fileInfo.SuggestedLangPath, key); err != nil {
+			var zero FileInfo
+
+			return zero, fmt.Errorf("write moved file cache for (%s)%s: %w", langCode, key, err)
+		}
+	}
+
 	return fileInfo, nil
 }
 
-func (gs *GitSeek) checkFile(ctx context.Context, pair Pair) (FileInfo, error) {
+// workingTreeRevision tells checkFile to take the language file from the working tree
+// of the main branch.
+const workingTreeRevision = ""
+
+func (gs *GitSeek) checkFile(ctx context.Context, pair Pair, langCode string, revision string) (FileInfo, error) {
 	//nolint:exhaustruct
 	fileInfo := FileInfo{
 		LangPath: pair.LangPath,
 	}
 
-	langExists, err := gs.gitRepo.FileExists(pair.LangPath)
+	langExists, err := gs.langFileExists(ctx, revision, pair.LangPath)
 	if err != nil {
 		return fileInfo, fmt.Errorf("check whether %s exists: %w", pair.LangPath, err)
 	}
@@ -195,19 +348,63 @@ func (gs *GitSeek) checkFile(ctx context.Context, pair Pair) (FileInfo, error) {
 		return fileInfo, nil
 	}
 
-	if err := gs.getLangFileInfo(ctx, pair.LangPath, &fileInfo); err != nil {
+	if err := gs.getLangFileInfo(ctx, revision, pair.LangPath, &fileInfo); err != nil {
 		return fileInfo, err
 	}
 
-	if err := gs.getEnFileInfo(ctx, pair.EnPath, &fileInfo); err != nil {
+	startPoint, err := gs.determineStartPoint(ctx, revision, pair.LangPath, &fileInfo)
+	if err != nil {
 		return fileInfo, err
 	}
 
+	if err := gs.getEnFileInfo(ctx, pair.EnPath, startPoint, &fileInfo); err != nil {
+		return fileInfo, err
+	}
+
+	if fileInfo.FileStatus == StatusEnFileNoLongerExists {
+		if err := gs.getMovedEnFileInfo(ctx, pair.EnPath, langCode, startPoint, &fileInfo); err != nil {
+			return fileInfo, err
+		}
+	}
+
 	return fileInfo, nil
 }
 
-func (gs *GitSeek) getLangFileInfo(ctx context.Context, langFilePath string, fileInfo *FileInfo) error {
-	langLastCommit, err := gs.gitRepo.FindFileLastCommit(ctx, langFilePath)
+func (gs *GitSeek) langFileExists(ctx context.Context, revision string, langFilePath string) (bool, error) {
+	if revision == workingTreeRevision {
+		return gs.gitRepo.FileExists(langFilePath)
+	}
+
+	return gs.gitRepo.FileExistsAt(ctx, revision, langFilePath)
+}
+
+func (gs *GitSeek) findLangFileLastCommit(
+	ctx context.Context,
+	revision string,
+	langFilePath string,
+) (git.CommitInfo, error) {
+	if revision == workingTreeRevision {
+		return gs.gitRepo.FindFileLastCommit(ctx, langFilePath)
+	}
+
+	return gs.gitRepo.FindFileLastCommitAt(ctx, revision, langFilePath)
+}
+
+func (gs *GitSeek) readLangFile(ctx context.Context, revision string, langFilePath string) (string, error) {
+	if revision == workingTreeRevision {
+		return gs.gitRepo.ReadFile(langFilePath)
+	}
+
+	return gs.gitRepo.ReadFileAt(ctx, revision, langFilePath)
+}
+
+func (gs *GitSeek) getLangFileInfo(
+	ctx context.Context,
+	revision string,
+	langFilePath string,
+	fileInfo *FileInfo,
+) error {
+	langLastCommit, err := gs.findLangFileLastCommit(ctx, revision, langFilePath)
 	if err != nil {
 		return fmt.Errorf("find last commit for %s: %w", langFilePath, err)
 	}
@@ -216,7 +413,10 @@ func (gs *GitSeek) getLangFileInfo(ctx context.Context, langFilePath string, fil
 
 	mergeCommit, err := gs.gitRepoHist.FindMergeCommit(ctx, langLastCommit.CommitID)
 	if err != nil && !errors.Is(err, githist.ErrCommitOnMainBranch) {
-		return fmt.Errorf("find merge commit for %s: %w", langLastCommit.CommitID, err)
+		// a commit of a revision that is not merged yet has no merge commit
+		if revision == workingTreeRevision || !errors.Is(err, githist.ErrCommitPathsNotConnected) {
+			return fmt.Errorf("find merge commit for %s: %w", langLastCommit.CommitID, err)
+		}
 	}
 
 	fileInfo.LangMergeCommit = mergeCommit
@@ -231,9 +431,109 @@ func (gs *GitSeek) getLangFileInfo(ctx context.Context, langFilePath string, fil
 	return nil
 }
 
-func (gs *GitSeek) getEnFileInfo(ctx context.Context, enFilePath string, fileInfo *FileInfo) error {
-	startPoint := determineStartPoint(fileInfo.LangForkCommit, fileInfo.LangLastCommit)
+// determineStartPoint returns the commit after which EN updates are looked up.
+// An explicit sync marker takes precedence over the fork commit and the last
+// commit of the language file, which are only approximations of the EN revision
+// the translation was based on.
+func (gs *GitSeek) determineStartPoint(
+	ctx context.Context,
+	revision string,
+	langFilePath string,
+	fileInfo *FileInfo,
+) (git.CommitInfo, error) {
+	syncedWithCommit, source, err := gs.findSyncMarker(ctx, revision, langFilePath, fileInfo.LangLastCommit)
+	if err != nil {
+		return git.CommitInfo{}, err
+	}
+
+	if syncedWithCommit != nil {
+		fileInfo.StartPointSource = source
+		fileInfo.SyncedWithCommit = syncedWithCommit
+
+		return *syncedWithCommit, nil
+	}
+
+	if fileInfo.LangForkCommit != nil {
+		fileInfo.StartPointSource = StartPointSourceForkCommit
+
+		return *fileInfo.LangForkCommit, nil
+	}
+
+	fileInfo.StartPointSource = StartPointSourceLangLastCommit
+
+	return fileInfo.LangLastCommit, nil
+}
+
+// findSyncMarker looks for a sync marker in the last commit of the language file
+// and then in its front matter. Markers naming unknown commits or commit IDs
+// that cannot be resolved are ignored.
+func (gs *GitSeek) findSyncMarker(
+	ctx context.Context,
+	revision string,
+	langFilePath string,
+	langLastCommit git.CommitInfo,
+) (*git.CommitInfo, string, error) {
+	trailerValues, err := gs.gitRepo.ListCommitTrailerValues(ctx, langLastCommit.CommitID, SyncMarkerTrailerKey)
+	if err != nil {
+		return nil, "", fmt.Errorf("list %s trailers of %s: %w", SyncMarkerTrailerKey, langLastCommit.CommitID, err)
+	}
+
+	candidates := make([]syncMarkerCandidate, 0, len(trailerValues)+1)
+
+	// the last trailer wins, as git does for repeated trailers
+	for i := len(trailerValues) - 1; i >= 0; i-- {
+		candidates = append(candidates, syncMarkerCandidate{
+			commitID: parseSyncMarker(trailerValues[i]),
+			source:   StartPointSourceSyncTrailer,
+		})
+	}
+
+	content, err := gs.readLangFile(ctx, revision, langFilePath)
+	if err != nil {
+		return nil, "", fmt.Errorf("read %s: %w", langFilePath, err)
+	}
+
+	candidates = append(candidates, syncMarkerCandidate{
+		commitID: findFrontMatterSyncMarker(content),
+		source:   StartPointSourceFrontMatter,
+	})
+
+	for _, candidate := range candidates {
+		if candidate.commitID == "" {
+			continue
+		}
+
+		commit, err := gs.gitRepo.FindCommit(ctx, candidate.commitID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, "", fmt.Errorf("find sync marker commit %s of %s: %w", candidate.commitID, langFilePath, err)
+			}
+
+			// for example an ambiguous short commit ID, treated as a missing marker
+			log.Printf("[gitseek] ignoring sync marker %s of %s: %v", candidate.commitID, langFilePath, err)
+
+			continue
+		}
+
+		if commit.CommitID != "" {
+			return &commit, candidate.source, nil
+		}
+	}
+
+	return nil, "", nil
+}
+
+type syncMarkerCandidate struct {
+	commitID string
+	source   string
+}
 
+func (gs *GitSeek) getEnFileInfo(
+	ctx context.Context,
+	enFilePath string,
+	startPoint git.CommitInfo,
+	fileInfo *FileInfo,
+) error {
 	enCommitsAfter, err := gs.gitRepo.FindFileCommitsAfter(ctx, enFilePath, startPoint.CommitID)
 	if err != nil {
 		return fmt.Errorf("find commits after %s for %s: %w", startPoint.CommitID, enFilePath, err)
@@ -246,7 +546,7 @@ func (gs *GitSeek) getEnFileInfo(ctx context.Context, enFilePath string, fileInf
 
 	fileInfo.FileStatus = determineFileStatus(exists, enCommitsAfter)
 
-	enUpdates, err := gs.getEnUpdates(ctx, enCommitsAfter)
+	enUpdates, err := gs.getEnUpdates(ctx, enFilePath, enCommitsAfter, fileInfo)
 	if err != nil {
 		return err
 	}
@@ -256,7 +556,12 @@ func (gs *GitSeek) getEnFileInfo(ctx context.Context, enFilePath string, fileInf
 	return nil
 }
 
-func (gs *GitSeek) getEnUpdates(ctx context.Context, enCommitsAfter []git.CommitInfo) ([]EnUpdate, error) {
+func (gs *GitSeek) getEnUpdates(
+	ctx context.Context,
+	enFilePath string,
+	enCommitsAfter []git.CommitInfo,
+	fileInfo *FileInfo,
+) ([]EnUpdate, error) {
 	if len(enCommitsAfter) == 0 {
 		return nil, nil
 	}
@@ -271,9 +576,16 @@ func (gs *GitSeek) getEnUpdates(ctx context.Context, enCommitsAfter []git.Commit
 			}
 		}
 
+		diff, err := gs.gitRepo.FileDiff(ctx, enCommitAfter.CommitID, enFilePath)
+		if err != nil {
+			return nil, fmt.Errorf("get diff of EN update %s for %s: %w", enCommitAfter.CommitID, enFilePath, err)
+		}
+
 		enUpdate := EnUpdate{
 			Commit:     enCommitAfter,
 			MergePoint: mergePoint,
+			ChangeKind: ClassifyDiff(diff),
+			Overlap:    classifyOverlap(enCommitAfter, mergePoint, fileInfo),
 		}
 
 		enUpdates = append(enUpdates, enUpdate)
@@ -282,12 +594,86 @@ func (gs *GitSeek) getEnUpdates(ctx context.Context, enCommitsAfter []git.Commit
 	return enUpdates, nil
 }
 
-func determineStartPoint(forkCommit *git.CommitInfo, langLastCommit git.CommitInfo) git.CommitInfo {
-	if forkCommit != nil {
-		return *forkCommit
+// getMovedEnFileInfo checks whether the removed EN file has been renamed. If so,
+// the status is changed to StatusEnFileMoved and EN updates are collected from all
+// the paths of the file after the start point. Renames without content changes
+// are not reported as EN updates.
+func (gs *GitSeek) getMovedEnFileInfo(
+	ctx context.Context,
+	enFilePath string,
+	langCode string,
+	startPoint git.CommitInfo,
+	fileInfo *FileInfo,
+) error {
+	renames, err := gs.gitRepo.FindFileRenames(ctx, enFilePath)
+	if err != nil {
+		return fmt.Errorf("find renames of %s: %w", enFilePath, err)
+	}
+
+	if len(renames) == 0 {
+		return nil
+	}
+
+	movedToEnPath := renames[len(renames)-1].NewPath
+
+	fileInfo.FileStatus = StatusEnFileMoved
+	fileInfo.MovedToEnPath = movedToEnPath
+
+	if gs.langPaths != nil {
+		suggestedLangPath, err := gs.langPaths.LangPath(movedToEnPath, langCode)
+		if err != nil {
+			return fmt.Errorf("resolve lang path for %s: %w", movedToEnPath, err)
+		}
+
+		fileInfo.SuggestedLangPath = suggestedLangPath
+	}
+
+	pureRenames := make(map[string]bool, len(renames))
+
+	for _, rename := range renames {
+		if rename.IsPure() {
+			pureRenames[rename.Commit.CommitID] = true
+		}
+	}
+
+	enUpdates := fileInfo.EnUpdates
+
+	for _, rename := range renames {
+		enCommitsAfter, err := gs.gitRepo.FindFileCommitsAfter(ctx, rename.NewPath, startPoint.CommitID)
+		if err != nil {
+			return fmt.Errorf("find commits after %s for %s: %w", startPoint.CommitID, rename.NewPath, err)
+		}
+
+		// the commit adding the file at the new path is already listed for the old path
+		enCommitsAfter = slices.DeleteFunc(enCommitsAfter, func(commit git.CommitInfo) bool {
+			return commit.CommitID == rename.Commit.CommitID
+		})
+
+		pathUpdates, err := gs.getEnUpdates(ctx, rename.NewPath, enCommitsAfter, fileInfo)
+		if err != nil {
+			return err
+		}
+
+		enUpdates = append(enUpdates, pathUpdates...)
 	}
 
-	return langLastCommit
+	// a pure rename is listed as an update of both its old and new path
+	enUpdates = slices.DeleteFunc(enUpdates, func(enUpdate EnUpdate) bool {
+		return pureRenames[enUpdate.Commit.CommitID]
+	})
+
+	// the newest first, as listed by git log
+	slices.SortStableFunc(enUpdates, func(a, b EnUpdate) int {
+		return strings.Compare(b.Commit.DateTime, a.Commit.DateTime)
+	})
+
+	if len(enUpdates) == 0 {
+		enUpdates = nil
+	}
+
+	fileInfo.EnUpdates = enUpdates
+
+	return nil
 }
 
 func determineFileStatus(exists bool, enCommitsAfter []git.CommitInfo) string {
@@ -5,9 +5,11 @@ import (
 	"context"
 	"errors"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/dkarczmarski/go-kweb-lang/git"
+	"github.com/dkarczmarski/go-kweb-lang/githist"
 	"github.com/dkarczmarski/go-kweb-lang/gitseek"
 )
 
@@ -15,10 +17,23 @@ type fakeGitRepo struct {
 	findFileLastCommitFunc   func(ctx context.Context, path string) (git.CommitInfo, error)
 	findFileCommitsAfterFunc func(ctx context.Context, path string, commitIDFrom string) ([]git.CommitInfo, error)
 	fileExistsFunc           func(path string) (bool, error)
+	fileDiffFunc             func(ctx context.Context, commitID string, path string) (string, error)
+	findCommitFunc           func(ctx context.Context, commitID string) (git.CommitInfo, error)
+	listTrailerValuesFunc    func(ctx context.Context, commitID string, key string) ([]string, error)
+	readFileFunc             func(path string) (string, error)
+	findFileRenamesFunc      func(ctx context.Context, path string) ([]git.FileRename, error)
+	findFileLastCommitAtFunc func(ctx context.Context, revision string, path string) (git.CommitInfo, error)
+	fileExistsAtFunc         func(ctx context.Context, revision string, path string) (bool, error)
+	readFileAtFunc           func(ctx context.Context, revision string, path string) (string, error)
 
 	findFileLastCommitCalls   []string
 	findFileCommitsAfterCalls []findFileCommitsAfterCall
 	fileExistsCalls           []string
+	fileDiffCalls             []string
+	findCommitCalls           []string
+	listTrailerValuesCalls    []string
+	readFileCalls             []string
+	findFileRenamesCalls      []string
 }
 
 type findFileCommitsAfterCall struct {
@@ -63,6 +78,96 @@ func (f *fakeGitRepo) FileExists(path string) (bool, error) {
 	return f.fileExistsFunc(path)
 }
 
+func (f *fakeGitRepo) FileDiff(ctx context.Context, commitID string, path string) (string, error) {
+	f.fileDiffCalls = append(f.fileDiffCalls, commitID)
+
+	if f.fileDiffFunc == nil {
+		return "", errors.New("unexpected call to FileDiff")
+	}
+
+	return f.fileDiffFunc(ctx, commitID, path)
+}
+
+func (f *fakeGitRepo) FindCommit(ctx context.Context, commitID string) (git.CommitInfo, error) {
+	f.findCommitCalls = append(f.findCommitCalls, commitID)
+
+	if f.findCommitFunc == nil {
+		return git.CommitInfo{}, errors.New("unexpected call to FindCommit")
+	}
+
+	return f.findCommitFunc(ctx, commitID)
+}
+
+func (f *fakeGitRepo) ListCommitTrailerValues(ctx context.Context, commitID string, key string) ([]string, error) {
+	f.listTrailerValuesCalls = append(f.listTrailerValuesCalls, commitID)
+
+	if f.listTrailerValuesFunc == nil {
+		return nil, errors.New("unexpected call to ListCommitTrailerValues")
+	}
+
+	return f.listTrailerValuesFunc(ctx, commitID, key)
+}
+
+func (f *fakeGitRepo) ReadFile(path string) (string, error) {
+	f.readFileCalls = append(f.readFileCalls, path)
+
+	if f.readFileFunc == nil {
+		return "", errors.New("unexpected call to ReadFile")
+	}
+
+	return f.readFileFunc(path)
+}
+
+func (f *fakeGitRepo) FindFileRenames(ctx context.Context, path string) ([]git.FileRename, error) {
+	f.findFileRenamesCalls = append(f.findFileRenamesCalls, path)
+
+	if f.findFileRenamesFunc == nil {
+		return nil, errors.New("unexpected call to FindFileRenames")
+	}
+
+	return f.findFileRenamesFunc(ctx, path)
+}
+
+func (f *fakeGitRepo) FindFileLastCommitAt(
+	ctx context.Context,
+	revision string,
+	path string,
+) (git.CommitInfo, error) {
+	if f.findFileLastCommitAtFunc == nil {
+		return git.CommitInfo{}, errors.New("unexpected call to FindFileLastCommitAt")
+	}
+
+	return f.findFileLastCommitAtFunc(ctx, revision, path)
+}
+
+func (f *fakeGitRepo) FileExistsAt(ctx context.Context, revision string, path string) (bool, error) {
+	if f.fileExistsAtFunc == nil {
+		return false, errors.New("unexpected call to FileExistsAt")
+	}
+
+	return f.fileExistsAtFunc(ctx, revision, path)
+}
+
+func (f *fakeGitRepo) ReadFileAt(ctx context.Context, revision string, path string) (string, error) {
+	if f.readFileAtFunc == nil {
+		return "", errors.New("unexpected call to ReadFileAt")
+	}
+
+	return f.readFileAtFunc(ctx, revision, path)
+}
+
+func noTrailerValues(_ context.Context, _ string, _ string) ([]string, error) {
+	return nil, nil
+}
+
+func langFileWithoutSyncMarker(_ string) (string, error) {
+	return "---\ntitle: Foo\n---\nTekst.\n", nil
+}
+
+func proseDiff(_ context.Context, _ string, _ string) (string, error) {
+	return "@@ -1 +1 @@\n-old text\n+new text\n", nil
+}
+
 type fakeGitRepoHist struct {
 	findForkCommitFunc  func(ctx context.Context, commitID string) (*git.CommitInfo, error)
 	findMergeCommitFunc func(ctx context.Context, commitID string) (*git.CommitInfo, error)
@@ -273,12 +378,12 @@ func TestGitSeek_CheckLang_ComputesAndWritesCacheOnMiss(t *testing.T) {
 	t.Parallel()
 
 	langLastCommit := git.CommitInfo{CommitID: "lang-last"}
-	langMergeCommit := &git.CommitInfo{CommitID: "merge-lang"}
-	forkCommit := &git.CommitInfo{CommitID: "fork-1"}
-	enCommit1 := git.CommitInfo{CommitID: "en-1"}
-	enCommit2 := git.CommitInfo{CommitID: "en-2"}
-	mergeEn1 := &git.CommitInfo{CommitID: "merge-en-1"}
-	mergeEn2 := &git.CommitInfo{CommitID: "merge-en-2"}
+	langMergeCommit := &git.CommitInfo{CommitID: "merge-lang", DateTime: "2020-01-03T00:00:00+00:00"}
+	forkCommit := &git.CommitInfo{CommitID: "fork-1", DateTime: "2020-01-01T00:00:00+00:00"}
+	enCommit1 := git.CommitInfo{CommitID: "en-1", DateTime: "2020-01-01T12:00:00+00:00"}
+	enCommit2 := git.CommitInfo{CommitID: "en-2", DateTime: "2020-01-01T13:00:00+00:00"}
+	mergeEn1 := &git.CommitInfo{CommitID: "merge-en-1", DateTime: "2020-01-02T00:00:00+00:00"}
+	mergeEn2 := &git.CommitInfo{CommitID: "merge-en-2", DateTime: "2020-01-04T00:00:00+00:00"}
 
 	cache := &fakeCacheStorage{
 		readFunc: func(_, _ string, _ any) (bool, error) {
@@ -302,6 +407,15 @@ func TestGitSeek_CheckLang_ComputesAndWritesCacheOnMiss(t *testing.T) {
 		fileExistsFunc: func(_ string) (bool, error) {
 			return true, nil
 		},
+		fileDiffFunc: func(_ context.Context, commitID string, _ string) (string, error) {
+			if commitID == "en-2" {
+				return "@@ -1 +1 @@\n-some  text\n+some text\n", nil
+			}
+
+			return "@@ -1 +1 @@\n-old text\n+new text\n", nil
+		},
+		listTrailerValuesFunc: noTrailerValues,
+		readFileFunc:          langFileWithoutSyncMarker,
 	}
 
 	hist := &fakeGitRepoHist{
@@ -335,14 +449,25 @@ func TestGitSeek_CheckLang_ComputesAndWritesCacheOnMiss(t *testing.T) {
 	}
 
 	want := gitseek.FileInfo{
-		LangPath:        "content/pl/foo.md",
-		LangLastCommit:  langLastCommit,
-		LangMergeCommit: langMergeCommit,
-		LangForkCommit:  forkCommit,
-		FileStatus:      gitseek.StatusEnFileUpdated,
+		LangPath:         "content/pl/foo.md",
+		LangLastCommit:   langLastCommit,
+		LangMergeCommit:  langMergeCommit,
+		LangForkCommit:   forkCommit,
+		FileStatus:       gitseek.StatusEnFileUpdated,
+		StartPointSource: gitseek.StartPointSourceForkCommit,
 		EnUpdates: []gitseek.EnUpdate{
-			{Commit: enCommit1, MergePoint: mergeEn1},
-			{Commit: enCommit2, MergePoint: mergeEn2},
+			{
+				Commit:     enCommit1,
+				MergePoint: mergeEn1,
+				ChangeKind: gitseek.ChangeKindProse,
+				Overlap:    gitseek.OverlapDuringBranch,
+			},
+			{
+				Commit:     enCommit2,
+				MergePoint: mergeEn2,
+				ChangeKind: gitseek.ChangeKindWhitespaceOnly,
+				Overlap:    gitseek.OverlapAfterMerge,
+			},
 		},
 	}
 
@@ -401,6 +526,8 @@ func TestGitSeek_CheckLang_ReturnsErrorWhenCacheWriteFails(t *testing.T) {
 		fileExistsFunc: func(_ string) (bool, error) {
 			return true, nil
 		},
+		listTrailerValuesFunc: noTrailerValues,
+		readFileFunc:          langFileWithoutSyncMarker,
 	}
 
 	hist := &fakeGitRepoHist{
@@ -457,6 +584,8 @@ func TestGitSeek_CheckLang_UsesForkCommitAsStartPoint(t *testing.T) {
 		fileExistsFunc: func(_ string) (bool, error) {
 			return true, nil
 		},
+		listTrailerValuesFunc: noTrailerValues,
+		readFileFunc:          langFileWithoutSyncMarker,
 	}
 
 	hist := &fakeGitRepoHist{
@@ -508,6 +637,8 @@ func TestGitSeek_CheckLang_UsesLangLastCommitAsStartPointWhenForkIsNil(t *testin
 		fileExistsFunc: func(_ string) (bool, error) {
 			return true, nil
 		},
+		listTrailerValuesFunc: noTrailerValues,
+		readFileFunc:          langFileWithoutSyncMarker,
 	}
 
 	hist := &fakeGitRepoHist{
@@ -530,6 +661,136 @@ func TestGitSeek_CheckLang_UsesLangLastCommitAsStartPointWhenForkIsNil(t *testin
 	}
 }
 
+func TestGitSeek_CheckLang_UsesSyncMarkerAsStartPoint(t *testing.T) {
+	t.Parallel()
+
+	syncedWith := git.CommitInfo{CommitID: "1234567890abcdef1234567890abcdef12345678"}
+
+	for _, tc := range []struct {
+		name          string
+		trailerValues []string
+		langContent   string
+		wantSource    string
+		wantCommitID  string
+	}{
+		{
+			name:          "commit trailer",
+			trailerValues: []string{"abcdef0", syncedWith.CommitID},
+			langContent:   "---\ntitle: Foo\nsynced_with: fedcba9\n---\n",
+			wantSource:    gitseek.StartPointSourceSyncTrailer,
+			wantCommitID:  syncedWith.CommitID,
+		},
+		{
+			name:         "yaml front matter",
+			langContent:  "---\ntitle: Foo\nsynced_with: \"" + syncedWith.CommitID + "\"\n---\n",
+			wantSource:   gitseek.StartPointSourceFrontMatter,
+			wantCommitID: syncedWith.CommitID,
+		},
+		{
+			name:         "toml front matter",
+			langContent:  "+++\ntitle = \"Foo\"\nsynced_with = \"" + syncedWith.CommitID + "\"\n+++\n",
+			wantSource:   gitseek.StartPointSourceFrontMatter,
+			wantCommitID: syncedWith.CommitID,
+		},
+		{
+			name:          "unknown or invalid markers fall back to fork commit",
+			trailerValues: []string{"not-a-commit", "0000000"},
+			langContent:   "---\nsynced_with: main\n---\n",
+			wantSource:    gitseek.StartPointSourceForkCommit,
+			wantCommitID:  "fork-1",
+		},
+		{
+			name:          "ambiguous marker falls back to fork commit",
+			trailerValues: []string{"abc1234"},
+			langContent:   "---\ntitle: Foo\n---\n",
+			wantSource:    gitseek.StartPointSourceForkCommit,
+			wantCommitID:  "fork-1",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cache := &fakeCacheStorage{
+				readFunc: func(_, _ string, _ any) (bool, error) {
+					return false, nil
+				},
+				writeFunc: func(_, _ string, _ any) error {
+					return nil
+				},
+			}
+
+			repo := &fakeGitRepo{
+				findFileLastCommitFunc: func(_ context.Context, _ string) (git.CommitInfo, error) {
+					return git.CommitInfo{CommitID: "lang-last"}, nil
+				},
+				findFileCommitsAfterFunc: func(_ context.Context, _ string, _ string) ([]git.CommitInfo, error) {
+					return nil, nil
+				},
+				fileExistsFunc: func(_ string) (bool, error) {
+					return true, nil
+				},
+				listTrailerValuesFunc: func(_ context.Context, commitID string, key string) ([]string, error) {
+					if commitID != "lang-last" || key != gitseek.SyncMarkerTrailerKey {
+						t.Fatalf("unexpected ListCommitTrailerValues call: %s %s", commitID, key)
+					}
+
+					return tc.trailerValues, nil
+				},
+				readFileFunc: func(_ string) (string, error) {
+					return tc.langContent, nil
+				},
+				findCommitFunc: func(_ context.Context, commitID string) (git.CommitInfo, error) {
+					if commitID == syncedWith.CommitID {
+						return syncedWith, nil
+					}
+
+					if commitID == "abc1234" {
+						return git.CommitInfo{}, errors.New("short object ID abc1234 is ambiguous")
+					}
+
+					return git.CommitInfo{}, nil
+				},
+			}
+
+			hist := &fakeGitRepoHist{
+				findForkCommitFunc: func(_ context.Context, _ string) (*git.CommitInfo, error) {
+					return &git.CommitInfo{CommitID: "fork-1"}, nil
+				},
+				findMergeCommitFunc: func(_ context.Context, _ string) (*git.CommitInfo, error) {
+					return nil, nil
+				},
+			}
+
+			gs := gitseek.New(repo, hist, cache)
+
+			got, err := gs.CheckLang(t.Context(), "pl", gitseek.Pair{
+				EnPath:   "content/en/foo.md",
+				LangPath: "content/pl/foo.md",
+			})
+			if err != nil {
+				t.Fatalf("CheckLang returned error: %v", err)
+			}
+
+			if got.StartPointSource != tc.wantSource {
+				t.Fatalf("unexpected start point source: got %q, want %q", got.StartPointSource, tc.wantSource)
+			}
+
+			if len(repo.findFileCommitsAfterCalls) != 1 ||
+				repo.findFileCommitsAfterCalls[0].CommitIDFrom != tc.wantCommitID {
+				t.Fatalf("unexpected FindFileCommitsAfter calls: %#v", repo.findFileCommitsAfterCalls)
+			}
+
+			if tc.wantSource == gitseek.StartPointSourceForkCommit {
+				if got.SyncedWithCommit != nil {
+					t.Fatalf("expected nil SyncedWithCommit, got %#v", got.SyncedWithCommit)
+				}
+			} else if got.SyncedWithCommit == nil || *got.SyncedWithCommit != syncedWith {
+				t.Fatalf("unexpected SyncedWithCommit: %#v", got.SyncedWithCommit)
+			}
+		})
+	}
+}
+
 func TestGitSeek_CheckLang_SetsStatusLangFileMissing(t *testing.T) {
 	t.Parallel()
 
@@ -695,6 +956,8 @@ func TestGitSeek_CheckLang_ReturnsErrorWhenFindFileLastCommitFails(t *testing.T)
 		findFileLastCommitFunc: func(_ context.Context, _ string) (git.CommitInfo, error) {
 			return git.CommitInfo{}, expectedErr
 		},
+		listTrailerValuesFunc: noTrailerValues,
+		readFileFunc:          langFileWithoutSyncMarker,
 	}
 	hist := &fakeGitRepoHist{}
 
@@ -742,6 +1005,8 @@ func TestGitSeek_CheckLang_ReturnsErrorWhenFindMergeCommitForLangFails(t *testin
 		findFileLastCommitFunc: func(_ context.Context, _ string) (git.CommitInfo, error) {
 			return git.CommitInfo{CommitID: "lang-last"}, nil
 		},
+		listTrailerValuesFunc: noTrailerValues,
+		readFileFunc:          langFileWithoutSyncMarker,
 	}
 	hist := &fakeGitRepoHist{
 		findMergeCommitFunc: func(_ context.Context, _ string) (*git.CommitInfo, error) {
@@ -793,6 +1058,8 @@ func TestGitSeek_CheckLang_ReturnsErrorWhenFindForkCommitFails(t *testing.T) {
 		findFileLastCommitFunc: func(_ context.Context, _ string) (git.CommitInfo, error) {
 			return git.CommitInfo{CommitID: "lang-last"}, nil
 		},
+		listTrailerValuesFunc: noTrailerValues,
+		readFileFunc:          langFileWithoutSyncMarker,
 	}
 	hist := &fakeGitRepoHist{
 		findMergeCommitFunc: func(_ context.Context, _ string) (*git.CommitInfo, error) {
@@ -850,6 +1117,8 @@ func TestGitSeek_CheckLang_ReturnsErrorWhenFindFileCommitsAfterFails(t *testing.
 		findFileCommitsAfterFunc: func(_ context.Context, _, _ string) ([]git.CommitInfo, error) {
 			return nil, expectedErr
 		},
+		listTrailerValuesFunc: noTrailerValues,
+		readFileFunc:          langFileWithoutSyncMarker,
 	}
 	hist := &fakeGitRepoHist{
 		findMergeCommitFunc: func(_ context.Context, _ string) (*git.CommitInfo, error) {
@@ -907,6 +1176,8 @@ func TestGitSeek_CheckLang_ReturnsErrorWhenEnFileExistsCheckFails(t *testing.T)
 
 			return false, expectedErr
 		},
+		listTrailerValuesFunc: noTrailerValues,
+		readFileFunc:          langFileWithoutSyncMarker,
 	}
 	hist := &fakeGitRepoHist{
 		findMergeCommitFunc: func(_ context.Context, _ string) (*git.CommitInfo, error) {
@@ -960,6 +1231,8 @@ func TestGitSeek_CheckLang_ReturnsErrorWhenFindingENUpdateMergeCommitFails(t *te
 		fileExistsFunc: func(_ string) (bool, error) {
 			return true, nil
 		},
+		listTrailerValuesFunc: noTrailerValues,
+		readFileFunc:          langFileWithoutSyncMarker,
 	}
 	hist := &fakeGitRepoHist{
 		findMergeCommitFunc: func(_ context.Context, commitID string) (*git.CommitInfo, error) {
@@ -1089,6 +1362,11 @@ func runCheckLangForStatus(t *testing.T, enFileExists bool, enCommitsAfter []git
 			}
 
 			return enFileExists, nil
+		}, fileDiffFunc: proseDiff,
+		listTrailerValuesFunc: noTrailerValues,
+		readFileFunc:          langFileWithoutSyncMarker,
+		findFileRenamesFunc: func(_ context.Context, _ string) ([]git.FileRename, error) {
+			return nil, nil
 		},
 	}
 
@@ -1113,3 +1391,324 @@ func runCheckLangForStatus(t *testing.T, enFileExists bool, enCommitsAfter []git
 
 	return got
 }
+
+type fakeLangPathResolver struct{}
+
+func (fakeLangPathResolver) LangPath(enPath string, langCode string) (string, error) {
+	return strings.Replace(enPath, "/en/", "/"+langCode+"/", 1), nil
+}
+
+func TestGitSeek_CheckLang_SetsStatusEnFileMoved(t *testing.T) {
+	t.Parallel()
+
+	renameCommit := git.CommitInfo{CommitID: "en-move", DateTime: "2020-01-03T00:00:00+00:00"}
+	updateBeforeMove := git.CommitInfo{CommitID: "en-1", DateTime: "2020-01-02T00:00:00+00:00"}
+	updateAfterMove := git.CommitInfo{CommitID: "en-2", DateTime: "2020-01-04T00:00:00+00:00"}
+
+	cache := &fakeCacheStorage{
+		readFunc: func(_, _ string, _ any) (bool, error) {
+			return false, nil
+		},
+		writeFunc: func(_, _ string, _ any) error {
+			return nil
+		},
+	}
+
+	repo := &fakeGitRepo{
+		findFileLastCommitFunc: func(_ context.Context, _ string) (git.CommitInfo, error) {
+			return git.CommitInfo{CommitID: "lang-last"}, nil
+		},
+		findFileCommitsAfterFunc: func(_ context.Context, path, _ string) ([]git.CommitInfo, error) {
+			if path == "content/en/foo.md" {
+				return []git.CommitInfo{renameCommit, updateBeforeMove}, nil
+			}
+
+			return []git.CommitInfo{updateAfterMove, renameCommit}, nil
+		},
+		fileExistsFunc: func(path string) (bool, error) {
+			return path == "content/pl/foo.md", nil
+		},
+		fileDiffFunc:          proseDiff,
+		listTrailerValuesFunc: noTrailerValues,
+		readFileFunc:          langFileWithoutSyncMarker,
+		findFileRenamesFunc: func(_ context.Context, _ string) ([]git.FileRename, error) {
+			return []git.FileRename{{
+				Commit:     renameCommit,
+				OldPath:    "content/en/foo.md",
+				NewPath:    "content/en/bar/foo.md",
+				Similarity: 100,
+			}}, nil
+		},
+	}
+
+	hist := &fakeGitRepoHist{
+		findMergeCommitFunc: func(_ context.Context, _ string) (*git.CommitInfo, error) {
+			return nil, nil
+		},
+		findForkCommitFunc: func(_ context.Context, _ string) (*git.CommitInfo, error) {
+			return nil, nil
+		},
+	}
+
+	gs := gitseek.New(repo, hist, cache, func(config *gitseek.NewConfig) {
+		config.LangPaths = fakeLangPathResolver{}
+	})
+
+	got, err := gs.CheckLang(t.Context(), "pl", gitseek.Pair{
+		EnPath:   "content/en/foo.md",
+		LangPath: "content/pl/foo.md",
+	})
+	if err != nil {
+		t.Fatalf("CheckLang returned error: %v", err)
+	}
+
+	if got.FileStatus != gitseek.StatusEnFileMoved {
+		t.Fatalf("unexpected status: got %q, want %q", got.FileStatus, gitseek.StatusEnFileMoved)
+	}
+
+	if got.MovedToEnPath != "content/en/bar/foo.md" {
+		t.Fatalf("unexpected moved to EN path: %q", got.MovedToEnPath)
+	}
+
+	if got.SuggestedLangPath != "content/pl/bar/foo.md" {
+		t.Fatalf("unexpected suggested lang path: %q", got.SuggestedLangPath)
+	}
+
+	gotCommitIDs := make([]string, 0, len(got.EnUpdates))
+	for _, enUpdate := range got.EnUpdates {
+		gotCommitIDs = append(gotCommitIDs, enUpdate.Commit.CommitID)
+	}
+
+	wantCommitIDs := []string{"en-2", "en-1"}
+	if !reflect.DeepEqual(gotCommitIDs, wantCommitIDs) {
+		t.Fatalf("unexpected EN updates: got %v, want %v", gotCommitIDs, wantCommitIDs)
+	}
+
+	wantMovedWrite := cacheWriteCall{
+		Bucket: gitseek.MovedFileCacheBucket("pl"),
+		Key:    "content/pl/bar/foo.md",
+		Data:   "content/pl/foo.md",
+	}
+
+	if len(cache.writeCalls) != 2 || !reflect.DeepEqual(cache.writeCalls[1], wantMovedWrite) {
+		t.Fatalf("unexpected cache writes: %#v", cache.writeCalls)
+	}
+}
+
+func TestGitSeek_CheckLang_SkipsChainedPureRenames(t *testing.T) {
+	t.Parallel()
+
+	firstMove := git.CommitInfo{CommitID: "en-move-1", DateTime: "2020-01-03T00:00:00+00:00"}
+	secondMove := git.CommitInfo{CommitID: "en-move-2", DateTime: "2020-01-05T00:00:00+00:00"}
+	updateBeforeMoves := git.CommitInfo{CommitID: "en-1", DateTime: "2020-01-02T00:00:00+00:00"}
+	updateBetweenMoves := git.CommitInfo{CommitID: "en-2", DateTime: "2020-01-04T00:00:00+00:00"}
+
+	cache := &fakeCacheStorage{
+		readFunc: func(_, _ string, _ any) (bool, error) {
+			return false, nil
+		},
+		writeFunc: func(_, _ string, _ any) error {
+			return nil
+		},
+	}
+
+	repo := &fakeGitRepo{
+		findFileLastCommitFunc: func(_ context.Context, _ string) (git.CommitInfo, error) {
+			return git.CommitInfo{CommitID: "lang-last"}, nil
+		},
+		findFileCommitsAfterFunc: func(_ context.Context, path, _ string) ([]git.CommitInfo, error) {
+			switch path {
+			case "content/en/foo.md":
+				return []git.CommitInfo{firstMove, updateBeforeMoves}, nil
+			case "content/en/bar/foo.md":
+				return []git.CommitInfo{secondMove, updateBetweenMoves, firstMove}, nil
+			default:
+				return []git.CommitInfo{secondMove}, nil
+			}
+		},
+		fileExistsFunc: func(path string) (bool, error) {
+			return path == "content/pl/foo.md", nil
+		},
+		fileDiffFunc:          proseDiff,
+		listTrailerValuesFunc: noTrailerValues,
+		readFileFunc:          langFileWithoutSyncMarker,
+		findFileRenamesFunc: func(_ context.Context, _ string) ([]git.FileRename, error) {
+			return []git.FileRename{
+				{
+					Commit:     firstMove,
+					OldPath:    "content/en/foo.md",
+					NewPath:    "content/en/bar/foo.md",
+					Similarity: 100,
+				},
+				{
+					Commit:     secondMove,
+					OldPath:    "content/en/bar/foo.md",
+					NewPath:    "content/en/baz/foo.md",
+					Similarity: 100,
+				},
+			}, nil
+		},
+	}
+
+	hist := &fakeGitRepoHist{
+		findMergeCommitFunc: func(_ context.Context, _ string) (*git.CommitInfo, error) {
+			return nil, nil
+		},
+		findForkCommitFunc: func(_ context.Context, _ string) (*git.CommitInfo, error) {
+			return nil, nil
+		},
+	}
+
+	gs := gitseek.New(repo, hist, cache, func(config *gitseek.NewConfig) {
+		config.LangPaths = fakeLangPathResolver{}
+	})
+
+	got, err := gs.CheckLang(t.Context(), "pl", gitseek.Pair{
+		EnPath:   "content/en/foo.md",
+		LangPath: "content/pl/foo.md",
+	})
+	if err != nil {
+		t.Fatalf("CheckLang returned error: %v", err)
+	}
+
+	if got.MovedToEnPath != "content/en/baz/foo.md" {
+		t.Fatalf("unexpected moved to EN path: %q", got.MovedToEnPath)
+	}
+
+	gotCommitIDs := make([]string, 0, len(got.EnUpdates))
+	for _, enUpdate := range got.EnUpdates {
+		gotCommitIDs = append(gotCommitIDs, enUpdate.Commit.CommitID)
+	}
+
+	wantCommitIDs := []string{"en-2", "en-1"}
+	if !reflect.DeepEqual(gotCommitIDs, wantCommitIDs) {
+		t.Fatalf("unexpected EN updates: got %v, want %v", gotCommitIDs, wantCommitIDs)
+	}
+}
+
+func TestGitSeek_InvalidateFile_DeletesMovedFileEntry(t *testing.T) {
+	t.Parallel()
+
+	cache := &fakeCacheStorage{
+		readFunc: func(bucket, _ string, buff any) (bool, error) {
+			if bucket != gitseek.MovedFileCacheBucket("pl") {
+				return false, nil
+			}
+
+			langPath, ok := buff.(*string)
+			if !ok {
+				t.Fatalf("unexpected buffer type %T", buff)
+			}
+
+			*langPath = "content/pl/foo.md"
+
+			return true, nil
+		},
+		deleteFunc: func(_, _ string) error {
+			return nil
+		},
+	}
+
+	gs := gitseek.New(&fakeGitRepo{}, &fakeGitRepoHist{}, cache)
+
+	if err := gs.InvalidateFile("pl", "content/pl/bar/foo.md"); err != nil {
+		t.Fatalf("InvalidateFile returned error: %v", err)
+	}
+
+	want := []cacheKey{
+		{Bucket: gitseek.FileInfoCacheBucket("pl"), Key: "content/pl/bar/foo.md"},
+		{Bucket: gitseek.FileInfoCacheBucket("pl"), Key: "content/pl/foo.md"},
+		{Bucket: gitseek.MovedFileCacheBucket("pl"), Key: "content/pl/bar/foo.md"},
+	}
+
+	if !reflect.DeepEqual(cache.deleteCalls, want) {
+		t.Fatalf("unexpected delete calls:\n got: %#v\nwant: %#v", cache.deleteCalls, want)
+	}
+}
+
+func TestGitSeek_CheckLangAt_ChecksLangFileAtRevisionWithoutCache(t *testing.T) {
+	t.Parallel()
+
+	const revision = "pr-head"
+
+	checkRevision := func(got string) {
+		if got != revision {
+			t.Fatalf("unexpected revision: got %q, want %q", got, revision)
+		}
+	}
+
+	cache := &fakeCacheStorage{}
+
+	repo := &fakeGitRepo{
+		fileExistsAtFunc: func(_ context.Context, rev string, _ string) (bool, error) {
+			checkRevision(rev)
+
+			return true, nil
+		},
+		findFileLastCommitAtFunc: func(_ context.Context, rev string, _ string) (git.CommitInfo, error) {
+			checkRevision(rev)
+
+			return git.CommitInfo{CommitID: "pr-commit"}, nil
+		},
+		readFileAtFunc: func(_ context.Context, rev string, _ string) (string, error) {
+			checkRevision(rev)
+
+			return langFileWithoutSyncMarker("")
+		},
+		listTrailerValuesFunc: noTrailerValues,
+		fileExistsFunc: func(_ string) (bool, error) {
+			return true, nil
+		},
+		findFileCommitsAfterFunc: func(_ context.Context, _ string, commitIDFrom string) ([]git.CommitInfo, error) {
+			if commitIDFrom != "fork-1" {
+				t.Fatalf("unexpected commitIDFrom: got %q, want %q", commitIDFrom, "fork-1")
+			}
+
+			return []git.CommitInfo{{CommitID: "en-1", DateTime: "2024-01-02T10:00:00Z"}}, nil
+		},
+		fileDiffFunc: proseDiff,
+	}
+
+	hist := &fakeGitRepoHist{
+		findForkCommitFunc: func(_ context.Context, _ string) (*git.CommitInfo, error) {
+			return &git.CommitInfo{CommitID: "fork-1", DateTime: "2024-01-01T10:00:00Z"}, nil
+		},
+		findMergeCommitFunc: func(_ context.Context, commitID string) (*git.CommitInfo, error) {
+			if commitID == "pr-commit" {
+				return nil, githist.ErrCommitPathsNotConnected
+			}
+
+			return nil, githist.ErrCommitOnMainBranch
+		},
+	}
+
+	gs := gitseek.New(repo, hist, cache)
+
+	fileInfo, err := gs.CheckLangAt(t.Context(), "pl", gitseek.Pair{
+		EnPath:   "content/en/foo.md",
+		LangPath: "content/pl/foo.md",
+	}, revision)
+	if err != nil {
+		t.Fatalf("CheckLangAt returned error: %v", err)
+	}
+
+	if fileInfo.FileStatus != gitseek.StatusEnFileUpdated {
+		t.Fatalf("unexpected status: got %q, want %q", fileInfo.FileStatus, gitseek.StatusEnFileUpdated)
+	}
+
+	if fileInfo.LangMergeCommit != nil {
+		t.Fatalf("expected nil merge commit, got %#v", fileInfo.LangMergeCommit)
+	}
+
+	if len(fileInfo.EnUpdates) != 1 || fileInfo.EnUpdates[0].Overlap != gitseek.OverlapDuringBranch {
+		t.Fatalf("expected one during-branch EN update, got %#v", fileInfo.EnUpdates)
+	}
+
+	if len(repo.fileExistsCalls) != 1 || repo.fileExistsCalls[0] != "content/en/foo.md" {
+		t.Fatalf("expected only the EN file to be checked in the working tree, got %v", repo.fileExistsCalls)
+	}
+
+	if len(cache.readCalls) != 0 || len(cache.writeCalls) != 0 {
+		t.Fatalf("expected no cache access, got reads %v and writes %v", cache.readCalls, cache.writeCalls)
+	}
+}
@@ -0,0 +1,60 @@
+package gitseek
+
+import "github.com/dkarczmarski/go-kweb-lang/git"
+
+const (
+	// OverlapUnknown means the update has not been classified, for example
+	// because the entry was cached before classification existed.
+	OverlapUnknown = ""
+
+	// OverlapBeforeFork means the update reached the main branch before the fork commit
+	// of the language branch, but it is not part of the fork commit history,
+	// so the translation could not include it.
+	OverlapBeforeFork = "before-fork"
+
+	// OverlapDuringBranch means the update reached the main branch while the language
+	// branch was open. It is indeterminate whether the translation includes it.
+	OverlapDuringBranch = "during-branch"
+
+	// OverlapAfterMerge means the update reached the main branch after the language
+	// change did, so the translation cannot include it.
+	OverlapAfterMerge = "after-merge"
+
+	// OverlapAfterSyncMarker means the update comes after the EN revision named
+	// by the sync marker of the language file, so the translation does not include it.
+	OverlapAfterSyncMarker = "after-sync-marker"
+)
+
+// IsDefiniteOverlap reports whether an update with the given overlap is certainly
+// missing from the translation.
+func IsDefiniteOverlap(overlap string) bool {
+	return overlap != OverlapDuringBranch
+}
+
+// classifyOverlap compares the date on which the EN commit reached the main branch
+// (its merge commit date or its own date) with the dates on which the language
+// branch was forked and merged.
+func classifyOverlap(enCommit git.CommitInfo, enMergePoint *git.CommitInfo, fileInfo *FileInfo) string {
+	if fileInfo.SyncedWithCommit != nil {
+		return OverlapAfterSyncMarker
+	}
+
+	enDate := enCommit.DateTime
+	if enMergePoint != nil {
+		enDate = enMergePoint.DateTime
+	}
+
+	if fileInfo.LangForkCommit == nil {
+		return OverlapAfterMerge
+	}
+
+	if enDate < fileInfo.LangForkCommit.DateTime {
+		return OverlapBeforeFork
+	}
+
+	if fileInfo.LangMergeCommit == nil || enDate <= fileInfo.LangMergeCommit.DateTime {
+		return OverlapDuringBranch
+	}
+
+	return OverlapAfterMerge
+}
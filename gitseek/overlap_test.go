@@ -0,0 +1,75 @@
+//nolint:testpackage
+package gitseek
+
+import (
+	"testing"
+
+	"github.com/dkarczmarski/go-kweb-lang/git"
+)
+
+func TestClassifyOverlap(t *testing.T) {
+	t.Parallel()
+
+	forkCommit := &git.CommitInfo{CommitID: "fork", DateTime: "2020-01-02T00:00:00+00:00"}
+	langMergeCommit := &git.CommitInfo{CommitID: "lang-merge", DateTime: "2020-01-04T00:00:00+00:00"}
+
+	for _, tc := range []struct {
+		name         string
+		enCommitDate string
+		enMergePoint *git.CommitInfo
+		fileInfo     FileInfo
+		want         string
+	}{
+		{
+			name:         "lang file committed directly to main",
+			enCommitDate: "2020-01-03T00:00:00+00:00",
+			fileInfo:     FileInfo{},
+			want:         OverlapAfterMerge,
+		},
+		{
+			name:         "en commit dated before fork",
+			enCommitDate: "2020-01-01T00:00:00+00:00",
+			fileInfo:     FileInfo{LangForkCommit: forkCommit, LangMergeCommit: langMergeCommit},
+			want:         OverlapBeforeFork,
+		},
+		{
+			name:         "en commit on main while lang branch was open",
+			enCommitDate: "2020-01-03T00:00:00+00:00",
+			fileInfo:     FileInfo{LangForkCommit: forkCommit, LangMergeCommit: langMergeCommit},
+			want:         OverlapDuringBranch,
+		},
+		{
+			name:         "en branch merged after lang branch",
+			enCommitDate: "2020-01-03T00:00:00+00:00",
+			enMergePoint: &git.CommitInfo{CommitID: "en-merge", DateTime: "2020-01-05T00:00:00+00:00"},
+			fileInfo:     FileInfo{LangForkCommit: forkCommit, LangMergeCommit: langMergeCommit},
+			want:         OverlapAfterMerge,
+		},
+		{
+			name:         "lang branch not merged",
+			enCommitDate: "2020-01-05T00:00:00+00:00",
+			fileInfo:     FileInfo{LangForkCommit: forkCommit},
+			want:         OverlapDuringBranch,
+		},
+		{
+			name:         "sync marker",
+			enCommitDate: "2020-01-03T00:00:00+00:00",
+			fileInfo: FileInfo{
+				LangForkCommit:   forkCommit,
+				LangMergeCommit:  langMergeCommit,
+				SyncedWithCommit: &git.CommitInfo{CommitID: "synced"},
+			},
+			want: OverlapAfterSyncMarker,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			enCommit := git.CommitInfo{CommitID: "en", DateTime: tc.enCommitDate}
+
+			if got := classifyOverlap(enCommit, tc.enMergePoint, &tc.fileInfo); got != tc.want {
+				t.Fatalf("unexpected overlap: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package gitseek
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	// SyncMarkerTrailerKey is the commit trailer naming the EN revision the translation
+	// matches, for example "Synced-With: 1a2b3c4d".
+	SyncMarkerTrailerKey = "Synced-With"
+
+	// SyncMarkerFrontMatterKey is the front matter field of the language file naming
+	// the EN revision the translation matches.
+	SyncMarkerFrontMatterKey = "synced_with"
+)
+
+const (
+	// StartPointSourceSyncTrailer means the start point was taken from the SyncMarkerTrailerKey
+	// trailer of the last commit of the language file.
+	StartPointSourceSyncTrailer = "sync-trailer"
+
+	// StartPointSourceFrontMatter means the start point was taken from the SyncMarkerFrontMatterKey
+	// field of the language file.
+	StartPointSourceFrontMatter = "front-matter"
+
+	// StartPointSourceForkCommit means the start point is the fork commit of the branch
+	// with the last commit of the language file.
+	StartPointSourceForkCommit = "fork-commit"
+
+	// StartPointSourceLangLastCommit means the start point is the last commit of the language file.
+	StartPointSourceLangLastCommit = "lang-last-commit"
+)
+
+//nolint:gochecknoglobals
+var syncMarkerCommitIDRegexp = regexp.MustCompile(`^[0-9a-fA-F]{7,40}$`)
+
+// parseSyncMarker returns the commit ID from a sync marker value
+// or an empty string if the value is not a commit ID.
+func parseSyncMarker(value string) string {
+	value = strings.Trim(strings.TrimSpace(value), `"'`)
+	if !syncMarkerCommitIDRegexp.MatchString(value) {
+		return ""
+	}
+
+	return value
+}
+
+// findFrontMatterSyncMarker returns the SyncMarkerFrontMatterKey value of a YAML (---)
+// or TOML (+++) front matter.
+func findFrontMatterSyncMarker(content string) string {
+	lines := strings.Split(content, "\n")
+	if len(lines) == 0 {
+		return ""
+	}
+
+	delimiter := strings.TrimSpace(lines[0])
+	if delimiter != "---" && delimiter != "+++" {
+		return ""
+	}
+
+	separator := ":"
+	if delimiter == "+++" {
+		separator = "="
+	}
+
+	for _, line := range lines[1:] {
+		if strings.TrimSpace(line) == delimiter {
+			break
+		}
+
+		key, value, found := strings.Cut(line, separator)
+		if !found || strings.TrimSpace(key) != SyncMarkerFrontMatterKey {
+			continue
+		}
+
+		return parseSyncMarker(value)
+	}
+
+	return ""
+}
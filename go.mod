@@ -2,4 +2,8 @@ module github.com/dkarczmarski/go-kweb-lang
 
 go 1.26
 
-require go.uber.org/mock v0.6.0
+require (
+	github.com/BurntSushi/toml v1.6.0
+	go.uber.org/mock v0.6.0
+	gopkg.in/yaml.v3 v3.0.1
+)
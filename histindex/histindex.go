@@ -0,0 +1,389 @@
+// Package histindex provides a per-file index of the git history built by walking
+// the whole history once, so that file history queries do not run git per file.
+package histindex
+
+//go:generate mockgen -typed -source=histindex.go -destination=./internal/mocks/mocks.go -package=mocks
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+
+	"github.com/dkarczmarski/go-kweb-lang/git"
+)
+
+// GitRepo is an interface used to decouple this package from the concrete git implementation.
+type GitRepo interface {
+	ListCommitsWithFiles(ctx context.Context, revisionRange string) ([]git.CommitFiles, error)
+	FindFileCommitsAfter(ctx context.Context, path string, commitIDFrom string) ([]git.CommitInfo, error)
+}
+
+// CacheStorage is an interface used to decouple this package from the concrete store implementation.
+type CacheStorage interface {
+	Read(bucket, key string, buff any) (bool, error)
+	Write(bucket, key string, data any) error
+	Delete(bucket, key string) error
+}
+
+const (
+	bucketHistoryIndex = "git-history-index"
+	keyHistoryIndex    = ""
+	headRevision       = "HEAD"
+)
+
+// CacheBucket returns the cache bucket name used for storing the history index.
+func CacheBucket() string {
+	return bucketHistoryIndex
+}
+
+// CacheKey returns the cache key used for storing the history index
+// inside the history index bucket.
+func CacheKey() string {
+	return keyHistoryIndex
+}
+
+// indexData is the persisted form of the index.
+//
+// Commits are ordered so that parents always precede their children,
+// the last commit is the indexed HEAD. Paths and Parents refer to commits
+// by their position in Commits.
+type indexData struct {
+	Commits []indexedCommit  `json:"commits"`
+	Paths   map[string][]int `json:"paths"`
+}
+
+type indexedCommit struct {
+	ID      string `json:"id"`
+	Date    string `json:"date"`
+	Comment string `json:"comment"`
+	Parents []int  `json:"parents,omitempty"`
+}
+
+// Index answers FindFileLastCommit and FindFileCommitsAfter from an index built
+// by a single walk over the history. The index is built lazily on the first query,
+// persisted in the cache and extended with Extend after new commits are pulled.
+type Index struct {
+	gitRepo GitRepo
+	cache   CacheStorage
+
+	mu        sync.RWMutex
+	loaded    bool
+	data      indexData
+	positions map[string]int
+}
+
+func New(gitRepo GitRepo, cache CacheStorage) *Index {
+	//nolint:exhaustruct
+	return &Index{
+		gitRepo: gitRepo,
+		cache:   cache,
+	}
+}
+
+// FindFileLastCommit returns the most recent commit that modified the file
+// or an empty CommitInfo if there is no such commit.
+func (idx *Index) FindFileLastCommit(ctx context.Context, path string) (git.CommitInfo, error) {
+	if err := idx.ensureLoaded(ctx); err != nil {
+		return git.CommitInfo{}, err
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	positions := idx.sortedByDateDesc(idx.data.Paths[path])
+	if len(positions) == 0 {
+		return git.CommitInfo{}, nil
+	}
+
+	return idx.commitInfo(positions[0]), nil
+}
+
+// FindFileCommitsAfter returns commits affecting the file that are not reachable
+// from commitIDFrom, the newest first, like "git log <commitIDFrom>.. -- <path>".
+//
+// Commits that are not indexed are passed to the git repository.
+func (idx *Index) FindFileCommitsAfter(
+	ctx context.Context,
+	path string,
+	commitIDFrom string,
+) ([]git.CommitInfo, error) {
+	if err := idx.ensureLoaded(ctx); err != nil {
+		return nil, err
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	fromPosition, ok := idx.positions[commitIDFrom]
+	if !ok {
+		log.Printf("[histindex] commit %s is not indexed, falling back to git", commitIDFrom)
+
+		return idx.gitRepo.FindFileCommitsAfter(ctx, path, commitIDFrom)
+	}
+
+	candidates := idx.data.Paths[path]
+	reachable := idx.reachableAmong(fromPosition, candidates)
+
+	var commits []git.CommitInfo
+
+	for _, position := range idx.sortedByDateDesc(candidates) {
+		if !reachable[position] {
+			commits = append(commits, idx.commitInfo(position))
+		}
+	}
+
+	return commits, nil
+}
+
+// Extend adds commits made after the indexed HEAD to the index.
+// It does nothing if the index has not been built yet.
+func (idx *Index) Extend(ctx context.Context) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if !idx.loaded {
+		found, err := idx.readCache()
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			return nil
+		}
+	}
+
+	head := idx.head()
+	if head == "" {
+		return idx.build(ctx)
+	}
+
+	commits, err := idx.gitRepo.ListCommitsWithFiles(ctx, head+".."+headRevision)
+	if err != nil {
+		return fmt.Errorf("list commits with files after %s: %w", head, err)
+	}
+
+	if len(commits) == 0 {
+		return nil
+	}
+
+	log.Printf("[histindex] extending index after %s with %d commits", head, len(commits))
+
+	idx.appendCommits(commits)
+
+	return idx.writeCache()
+}
+
+// Invalidate drops the index, so that it is built again on the next query.
+// It is required when the indexed history was rewritten.
+func (idx *Index) Invalidate() error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.loaded = false
+	idx.data = indexData{}
+	idx.positions = nil
+
+	if err := idx.cache.Delete(CacheBucket(), CacheKey()); err != nil {
+		return fmt.Errorf("delete history index cache: %w", err)
+	}
+
+	return nil
+}
+
+func (idx *Index) ensureLoaded(ctx context.Context) error {
+	idx.mu.RLock()
+	loaded := idx.loaded
+	idx.mu.RUnlock()
+
+	if loaded {
+		return nil
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if idx.loaded {
+		return nil
+	}
+
+	found, err := idx.readCache()
+	if err != nil {
+		return err
+	}
+
+	if found {
+		return nil
+	}
+
+	return idx.build(ctx)
+}
+
+func (idx *Index) build(ctx context.Context) error {
+	commits, err := idx.gitRepo.ListCommitsWithFiles(ctx, headRevision)
+	if err != nil {
+		return fmt.Errorf("list commits with files: %w", err)
+	}
+
+	log.Printf("[histindex] building index of %d commits", len(commits))
+
+	idx.setData(indexData{
+		Commits: make([]indexedCommit, 0, len(commits)),
+		Paths:   make(map[string][]int),
+	})
+	idx.appendCommits(commits)
+
+	return idx.writeCache()
+}
+
+func (idx *Index) readCache() (bool, error) {
+	var data indexData
+
+	found, err := idx.cache.Read(CacheBucket(), CacheKey(), &data)
+	if err != nil {
+		return false, fmt.Errorf("read history index cache: %w", err)
+	}
+
+	if !found {
+		return false, nil
+	}
+
+	if data.Paths == nil {
+		data.Paths = make(map[string][]int)
+	}
+
+	idx.setData(data)
+
+	return true, nil
+}
+
+func (idx *Index) writeCache() error {
+	if err := idx.cache.Write(CacheBucket(), CacheKey(), idx.data); err != nil {
+		return fmt.Errorf("write history index cache: %w", err)
+	}
+
+	return nil
+}
+
+func (idx *Index) setData(data indexData) {
+	idx.data = data
+	idx.positions = make(map[string]int, len(data.Commits))
+
+	for position, commit := range data.Commits {
+		idx.positions[commit.ID] = position
+	}
+
+	idx.loaded = true
+}
+
+// appendCommits adds commits listed by GitRepo.ListCommitsWithFiles (children first)
+// to the index. Parents that are not indexed, for example in a shallow clone, are skipped.
+func (idx *Index) appendCommits(commits []git.CommitFiles) {
+	for i := len(commits) - 1; i >= 0; i-- {
+		commit := commits[i]
+		position := len(idx.data.Commits)
+
+		var parents []int
+
+		for _, parentID := range commit.ParentIDs {
+			if parentPosition, ok := idx.positions[parentID]; ok {
+				parents = append(parents, parentPosition)
+			}
+		}
+
+		idx.data.Commits = append(idx.data.Commits, indexedCommit{
+			ID:      commit.CommitID,
+			Date:    commit.DateTime,
+			Comment: commit.Comment,
+			Parents: parents,
+		})
+		idx.positions[commit.CommitID] = position
+
+		for _, path := range commit.Files {
+			idx.data.Paths[path] = append(idx.data.Paths[path], position)
+		}
+	}
+}
+
+func (idx *Index) head() string {
+	if len(idx.data.Commits) == 0 {
+		return ""
+	}
+
+	return idx.data.Commits[len(idx.data.Commits)-1].ID
+}
+
+// reachableAmong returns those of the candidate commits that are reachable from
+// the commit at fromPosition (including that commit itself).
+//
+// Since parents always precede their children, the walk never has to go below
+// the oldest candidate.
+func (idx *Index) reachableAmong(fromPosition int, candidates []int) map[int]bool {
+	reachable := make(map[int]bool)
+
+	lowest := fromPosition + 1
+
+	for _, position := range candidates {
+		if position <= fromPosition {
+			lowest = min(lowest, position)
+		}
+	}
+
+	if lowest > fromPosition {
+		return reachable
+	}
+
+	visited := make([]bool, fromPosition-lowest+1)
+	visited[fromPosition-lowest] = true
+	queue := []int{fromPosition}
+
+	for len(queue) > 0 {
+		position := queue[0]
+		queue = queue[1:]
+
+		for _, parent := range idx.data.Commits[position].Parents {
+			if parent < lowest || visited[parent-lowest] {
+				continue
+			}
+
+			visited[parent-lowest] = true
+			queue = append(queue, parent)
+		}
+	}
+
+	for _, position := range candidates {
+		if position >= lowest && position <= fromPosition && visited[position-lowest] {
+			reachable[position] = true
+		}
+	}
+
+	return reachable
+}
+
+// sortedByDateDesc returns the positions ordered like the default git log output:
+// the newest commit date first, children before parents for equal dates.
+func (idx *Index) sortedByDateDesc(positions []int) []int {
+	sorted := slices.Clone(positions)
+
+	slices.SortFunc(sorted, func(a, b int) int {
+		if c := cmp.Compare(idx.data.Commits[b].Date, idx.data.Commits[a].Date); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(b, a)
+	})
+
+	return sorted
+}
+
+func (idx *Index) commitInfo(position int) git.CommitInfo {
+	commit := idx.data.Commits[position]
+
+	return git.CommitInfo{
+		CommitID: commit.ID,
+		DateTime: commit.Date,
+		Comment:  commit.Comment,
+	}
+}
@@ -0,0 +1,340 @@
+package histindex_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/dkarczmarski/go-kweb-lang/git"
+	"github.com/dkarczmarski/go-kweb-lang/histindex"
+	"github.com/dkarczmarski/go-kweb-lang/histindex/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+// jsonCache keeps cache entries marshaled, like the file store does.
+type jsonCache struct {
+	entries map[string][]byte
+}
+
+func newJSONCache() *jsonCache {
+	return &jsonCache{entries: make(map[string][]byte)}
+}
+
+func (c *jsonCache) Read(bucket, key string, buff any) (bool, error) {
+	data, ok := c.entries[bucket+"/"+key]
+	if !ok {
+		return false, nil
+	}
+
+	return true, json.Unmarshal(data, buff)
+}
+
+func (c *jsonCache) Write(bucket, key string, data any) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	c.entries[bucket+"/"+key] = encoded
+
+	return nil
+}
+
+func (c *jsonCache) Delete(bucket, key string) error {
+	delete(c.entries, bucket+"/"+key)
+
+	return nil
+}
+
+func commitInfo(id, dateTime string) git.CommitInfo {
+	return git.CommitInfo{CommitID: id, DateTime: dateTime, Comment: "TEXT-" + id}
+}
+
+// testHistory is:
+//
+//	C1 (a.md, b.md) --- C2 (a.md) --- M4 --- C5 (b.md)
+//	             \                    /
+//	              ---- C3 (a.md) -----
+func testHistory() []git.CommitFiles {
+	return []git.CommitFiles{
+		{CommitInfo: commitInfo("C5", "DT-5"), ParentIDs: []string{"M4"}, Files: []string{"b.md"}},
+		{CommitInfo: commitInfo("M4", "DT-4"), ParentIDs: []string{"C2", "C3"}},
+		{CommitInfo: commitInfo("C3", "DT-3"), ParentIDs: []string{"C1"}, Files: []string{"a.md"}},
+		{CommitInfo: commitInfo("C2", "DT-2"), ParentIDs: []string{"C1"}, Files: []string{"a.md"}},
+		{CommitInfo: commitInfo("C1", "DT-1"), Files: []string{"a.md", "b.md"}},
+	}
+}
+
+func TestIndex_FindFileLastCommit(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		path     string
+		expected git.CommitInfo
+	}{
+		{
+			name:     "the newest commit is on a merged branch",
+			path:     "a.md",
+			expected: commitInfo("C3", "DT-3"),
+		},
+		{
+			name:     "the newest commit is on the main branch",
+			path:     "b.md",
+			expected: commitInfo("C5", "DT-5"),
+		},
+		{
+			name:     "the file does not exist",
+			path:     "fake.md",
+			expected: git.CommitInfo{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			ctrl := gomock.NewController(t)
+			gitRepo := mocks.NewMockGitRepo(ctrl)
+
+			gitRepo.EXPECT().
+				ListCommitsWithFiles(ctx, "HEAD").
+				Return(testHistory(), nil).
+				Times(1)
+
+			index := histindex.New(gitRepo, newJSONCache())
+
+			commit, err := index.FindFileLastCommit(ctx, tc.path)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(tc.expected, commit) {
+				t.Errorf("unexpected result: %+v", commit)
+			}
+		})
+	}
+}
+
+func TestIndex_FindFileCommitsAfter(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name         string
+		path         string
+		commitIDFrom string
+		expected     []git.CommitInfo
+	}{
+		{
+			name:         "commits after the root",
+			path:         "a.md",
+			commitIDFrom: "C1",
+			expected:     []git.CommitInfo{commitInfo("C3", "DT-3"), commitInfo("C2", "DT-2")},
+		},
+		{
+			name:         "commit on a parallel branch is not reachable",
+			path:         "a.md",
+			commitIDFrom: "C2",
+			expected:     []git.CommitInfo{commitInfo("C3", "DT-3")},
+		},
+		{
+			name:         "commits are reachable from the merge commit",
+			path:         "a.md",
+			commitIDFrom: "M4",
+			expected:     nil,
+		},
+		{
+			name:         "commit after the merge commit",
+			path:         "b.md",
+			commitIDFrom: "C3",
+			expected:     []git.CommitInfo{commitInfo("C5", "DT-5")},
+		},
+		{
+			name:         "the file does not exist",
+			path:         "fake.md",
+			commitIDFrom: "C1",
+			expected:     nil,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			ctrl := gomock.NewController(t)
+			gitRepo := mocks.NewMockGitRepo(ctrl)
+
+			gitRepo.EXPECT().
+				ListCommitsWithFiles(ctx, "HEAD").
+				Return(testHistory(), nil).
+				Times(1)
+
+			index := histindex.New(gitRepo, newJSONCache())
+
+			commits, err := index.FindFileCommitsAfter(ctx, tc.path, tc.commitIDFrom)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(tc.expected, commits) {
+				t.Errorf("unexpected result: %+v", commits)
+			}
+		})
+	}
+}
+
+func TestIndex_FindFileCommitsAfter_FallsBackToGitForUnknownCommit(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	ctrl := gomock.NewController(t)
+	gitRepo := mocks.NewMockGitRepo(ctrl)
+
+	expected := []git.CommitInfo{commitInfo("C2", "DT-2")}
+
+	gitRepo.EXPECT().
+		ListCommitsWithFiles(ctx, "HEAD").
+		Return(testHistory(), nil).
+		Times(1)
+
+	gitRepo.EXPECT().
+		FindFileCommitsAfter(ctx, "a.md", "C0").
+		Return(expected, nil).
+		Times(1)
+
+	index := histindex.New(gitRepo, newJSONCache())
+
+	commits, err := index.FindFileCommitsAfter(ctx, "a.md", "C0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(expected, commits) {
+		t.Errorf("unexpected result: %+v", commits)
+	}
+}
+
+func TestIndex_UsesPersistedIndex(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	ctrl := gomock.NewController(t)
+	gitRepo := mocks.NewMockGitRepo(ctrl)
+	cache := newJSONCache()
+
+	gitRepo.EXPECT().
+		ListCommitsWithFiles(ctx, "HEAD").
+		Return(testHistory(), nil).
+		Times(1)
+
+	if _, err := histindex.New(gitRepo, cache).FindFileLastCommit(ctx, "a.md"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	commits, err := histindex.New(gitRepo, cache).FindFileCommitsAfter(ctx, "a.md", "C2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []git.CommitInfo{commitInfo("C3", "DT-3")}
+	if !reflect.DeepEqual(expected, commits) {
+		t.Errorf("unexpected result: %+v", commits)
+	}
+}
+
+func TestIndex_Extend(t *testing.T) {
+	t.Parallel()
+
+	t.Run("does nothing when the index is not built", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := t.Context()
+		ctrl := gomock.NewController(t)
+		gitRepo := mocks.NewMockGitRepo(ctrl)
+
+		if err := histindex.New(gitRepo, newJSONCache()).Extend(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("adds commits after the indexed head", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := t.Context()
+		ctrl := gomock.NewController(t)
+		gitRepo := mocks.NewMockGitRepo(ctrl)
+		cache := newJSONCache()
+
+		gitRepo.EXPECT().
+			ListCommitsWithFiles(ctx, "HEAD").
+			Return(testHistory(), nil).
+			Times(1)
+
+		gitRepo.EXPECT().
+			ListCommitsWithFiles(ctx, "C5..HEAD").
+			Return([]git.CommitFiles{
+				{CommitInfo: commitInfo("C6", "DT-6"), ParentIDs: []string{"C5"}, Files: []string{"a.md"}},
+			}, nil).
+			Times(1)
+
+		index := histindex.New(gitRepo, cache)
+
+		if _, err := index.FindFileLastCommit(ctx, "a.md"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := index.Extend(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		commits, err := histindex.New(gitRepo, cache).FindFileCommitsAfter(ctx, "a.md", "M4")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := []git.CommitInfo{commitInfo("C6", "DT-6")}
+		if !reflect.DeepEqual(expected, commits) {
+			t.Errorf("unexpected result: %+v", commits)
+		}
+	})
+}
+
+func TestIndex_Invalidate(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	ctrl := gomock.NewController(t)
+	gitRepo := mocks.NewMockGitRepo(ctrl)
+	cache := newJSONCache()
+
+	rewritten := []git.CommitFiles{
+		{CommitInfo: commitInfo("C2B", "DT-2"), ParentIDs: []string{"C1"}, Files: []string{"a.md"}},
+		{CommitInfo: commitInfo("C1", "DT-1"), Files: []string{"a.md", "b.md"}},
+	}
+
+	gomock.InOrder(
+		gitRepo.EXPECT().
+			ListCommitsWithFiles(ctx, "HEAD").
+			Return(testHistory(), nil),
+		gitRepo.EXPECT().
+			ListCommitsWithFiles(ctx, "HEAD").
+			Return(rewritten, nil),
+	)
+
+	index := histindex.New(gitRepo, cache)
+
+	if _, err := index.FindFileLastCommit(ctx, "a.md"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := index.Invalidate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	commit, err := histindex.New(gitRepo, cache).FindFileLastCommit(ctx, "a.md")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(commitInfo("C2B", "DT-2"), commit) {
+		t.Errorf("unexpected result: %+v", commit)
+	}
+}
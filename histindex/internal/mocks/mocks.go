@@ -0,0 +1,259 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: histindex.go
+//
+// Generated by this command:
+//
+//	mockgen -typed -source=histindex.go -destination=./internal/mocks/mocks.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	git "github.com/dkarczmarski/go-kweb-lang/git"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGitRepo is a mock of GitRepo interface.
+type MockGitRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockGitRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockGitRepoMockRecorder is the mock recorder for MockGitRepo.
+type MockGitRepoMockRecorder struct {
+	mock *MockGitRepo
+}
+
+// NewMockGitRepo creates a new mock instance.
+func NewMockGitRepo(ctrl *gomock.Controller) *MockGitRepo {
+	mock := &MockGitRepo{ctrl: ctrl}
+	mock.recorder = &MockGitRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGitRepo) EXPECT() *MockGitRepoMockRecorder {
+	return m.recorder
+}
+
+// FindFileCommitsAfter mocks base method.
+func (m *MockGitRepo) FindFileCommitsAfter(ctx context.Context, path, commitIDFrom string) ([]git.CommitInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindFileCommitsAfter", ctx, path, commitIDFrom)
+	ret0, _ := ret[0].([]git.CommitInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindFileCommitsAfter indicates an expected call of FindFileCommitsAfter.
+func (mr *MockGitRepoMockRecorder) FindFileCommitsAfter(ctx, path, commitIDFrom any) *MockGitRepoFindFileCommitsAfterCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFileCommitsAfter", reflect.TypeOf((*MockGitRepo)(nil).FindFileCommitsAfter), ctx, path, commitIDFrom)
+	return &MockGitRepoFindFileCommitsAfterCall{Call: call}
+}
+
+// MockGitRepoFindFileCommitsAfterCall wrap *gomock.Call
+type MockGitRepoFindFileCommitsAfterCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockGitRepoFindFileCommitsAfterCall) Return(arg0 []git.CommitInfo, arg1 error) *MockGitRepoFindFileCommitsAfterCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockGitRepoFindFileCommitsAfterCall) Do(f func(context.Context, string, string) ([]git.CommitInfo, error)) *MockGitRepoFindFileCommitsAfterCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockGitRepoFindFileCommitsAfterCall) DoAndReturn(f func(context.Context, string, string) ([]git.CommitInfo, error)) *MockGitRepoFindFileCommitsAfterCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListCommitsWithFiles mocks base method.
+func (m *MockGitRepo) ListCommitsWithFiles(ctx context.Context, revisionRange string) ([]git.CommitFiles, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCommitsWithFiles", ctx, revisionRange)
+	ret0, _ := ret[0].([]git.CommitFiles)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCommitsWithFiles indicates an expected call of ListCommitsWithFiles.
+func (mr *MockGitRepoMockRecorder) ListCommitsWithFiles(ctx, revisionRange any) *MockGitRepoListCommitsWithFilesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCommitsWithFiles", reflect.TypeOf((*MockGitRepo)(nil).ListCommitsWithFiles), ctx, revisionRange)
+	return &MockGitRepoListCommitsWithFilesCall{Call: call}
+}
+
+// MockGitRepoListCommitsWithFilesCall wrap *gomock.Call
+type MockGitRepoListCommitsWithFilesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockGitRepoListCommitsWithFilesCall) Return(arg0 []git.CommitFiles, arg1 error) *MockGitRepoListCommitsWithFilesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockGitRepoListCommitsWithFilesCall) Do(f func(context.Context, string) ([]git.CommitFiles, error)) *MockGitRepoListCommitsWithFilesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockGitRepoListCommitsWithFilesCall) DoAndReturn(f func(context.Context, string) ([]git.CommitFiles, error)) *MockGitRepoListCommitsWithFilesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockCacheStorage is a mock of CacheStorage interface.
+type MockCacheStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockCacheStorageMockRecorder
+	isgomock struct{}
+}
+
+// MockCacheStorageMockRecorder is the mock recorder for MockCacheStorage.
+type MockCacheStorageMockRecorder struct {
+	mock *MockCacheStorage
+}
+
+// NewMockCacheStorage creates a new mock instance.
+func NewMockCacheStorage(ctrl *gomock.Controller) *MockCacheStorage {
+	mock := &MockCacheStorage{ctrl: ctrl}
+	mock.recorder = &MockCacheStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCacheStorage) EXPECT() *MockCacheStorageMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockCacheStorage) Delete(bucket, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", bucket, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCacheStorageMockRecorder) Delete(bucket, key any) *MockCacheStorageDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCacheStorage)(nil).Delete), bucket, key)
+	return &MockCacheStorageDeleteCall{Call: call}
+}
+
+// MockCacheStorageDeleteCall wrap *gomock.Call
+type MockCacheStorageDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCacheStorageDeleteCall) Return(arg0 error) *MockCacheStorageDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCacheStorageDeleteCall) Do(f func(string, string) error) *MockCacheStorageDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCacheStorageDeleteCall) DoAndReturn(f func(string, string) error) *MockCacheStorageDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Read mocks base method.
+func (m *MockCacheStorage) Read(bucket, key string, buff any) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", bucket, key, buff)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockCacheStorageMockRecorder) Read(bucket, key, buff any) *MockCacheStorageReadCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockCacheStorage)(nil).Read), bucket, key, buff)
+	return &MockCacheStorageReadCall{Call: call}
+}
+
+// MockCacheStorageReadCall wrap *gomock.Call
+type MockCacheStorageReadCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCacheStorageReadCall) Return(arg0 bool, arg1 error) *MockCacheStorageReadCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCacheStorageReadCall) Do(f func(string, string, any) (bool, error)) *MockCacheStorageReadCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCacheStorageReadCall) DoAndReturn(f func(string, string, any) (bool, error)) *MockCacheStorageReadCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Write mocks base method.
+func (m *MockCacheStorage) Write(bucket, key string, data any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Write", bucket, key, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Write indicates an expected call of Write.
+func (mr *MockCacheStorageMockRecorder) Write(bucket, key, data any) *MockCacheStorageWriteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockCacheStorage)(nil).Write), bucket, key, data)
+	return &MockCacheStorageWriteCall{Call: call}
+}
+
+// MockCacheStorageWriteCall wrap *gomock.Call
+type MockCacheStorageWriteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCacheStorageWriteCall) Return(arg0 error) *MockCacheStorageWriteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCacheStorageWriteCall) Do(f func(string, string, any) error) *MockCacheStorageWriteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCacheStorageWriteCall) DoAndReturn(f func(string, string, any) error) *MockCacheStorageWriteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,31 @@
+package histindex
+
+import (
+	"context"
+
+	"github.com/dkarczmarski/go-kweb-lang/git"
+)
+
+// Repo is a git repository whose file history queries are answered by the Index.
+type Repo struct {
+	*git.Git
+
+	index *Index
+}
+
+func NewRepo(gitRepo *git.Git, index *Index) *Repo {
+	return &Repo{
+		Git:   gitRepo,
+		index: index,
+	}
+}
+
+// FindFileLastCommit provides information about the last commit for the given file.
+func (r *Repo) FindFileLastCommit(ctx context.Context, path string) (git.CommitInfo, error) {
+	return r.index.FindFileLastCommit(ctx, path)
+}
+
+// FindFileCommitsAfter lists commits affecting the file after commitIDFrom.
+func (r *Repo) FindFileCommitsAfter(ctx context.Context, path string, commitIDFrom string) ([]git.CommitInfo, error) {
+	return r.index.FindFileCommitsAfter(ctx, path, commitIDFrom)
+}
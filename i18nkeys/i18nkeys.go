@@ -0,0 +1,276 @@
+// Package i18nkeys compares the keys of Hugo i18n TOML files, such as
+// i18n/en/en.toml and i18n/pl/pl.toml, which are the files paired by the pair
+// rule named filepairs.I18NPairRuleName. Instead of reporting the whole language
+// file as outdated whenever the EN file changes, it tells which keys are missing
+// in the language file, which are no longer present in the EN file and which
+// have a different EN value than at the start point of the language file.
+package i18nkeys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/dkarczmarski/go-kweb-lang/filepairs"
+	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+	"github.com/dkarczmarski/go-kweb-lang/pagecontent"
+)
+
+const (
+	// KeyStatusMissing indicates that the key exists in the EN file
+	// but not in the language file.
+	KeyStatusMissing = "key-missing"
+
+	// KeyStatusObsolete indicates that the key exists in the language file
+	// but no longer in the EN file.
+	KeyStatusObsolete = "key-obsolete"
+
+	// KeyStatusEnValueChanged indicates that the EN value of the key has changed
+	// after the start point of the language file.
+	KeyStatusEnValueChanged = "en-value-changed"
+)
+
+// KeyChange describes a key of the i18n file that needs attention.
+type KeyChange struct {
+	Key    string
+	Status string
+
+	// EnValue is the current EN value of the key, empty for obsolete keys.
+	EnValue string
+
+	// OldEnValue is the EN value at the start point of the language file,
+	// set only for keys whose EN value has changed.
+	OldEnValue string
+}
+
+// GitRepo is an interface used to decouple this package from the concrete git implementation.
+type GitRepo interface {
+	ReadFile(path string) (string, error)
+	FileExistsAt(ctx context.Context, revision string, path string) (bool, error)
+	ReadFileAt(ctx context.Context, revision string, path string) (string, error)
+}
+
+type Comparator struct {
+	gitRepo   GitRepo
+	filePaths *filepairs.FilePaths
+}
+
+type ComparatorConfig struct {
+	// FilePaths recognizes the pair rule of the files.
+	// By default the built-in pair patterns are used.
+	FilePaths *filepairs.FilePaths
+}
+
+func New(gitRepo GitRepo, opts ...func(config *ComparatorConfig)) *Comparator {
+	config := ComparatorConfig{
+		FilePaths: filepairs.New(),
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return &Comparator{
+		gitRepo:   gitRepo,
+		filePaths: config.FilePaths,
+	}
+}
+
+// isI18NPair reports whether the pair is a pair of TOML files matched by the i18n pair rule.
+func (c *Comparator) isI18NPair(pair gitseek.Pair) (bool, error) {
+	for _, filePath := range []string{pair.EnPath, pair.LangPath} {
+		if path.Ext(filepath.ToSlash(filePath)) != ".toml" {
+			return false, nil
+		}
+
+		pathInfo, err := c.filePaths.CheckPath(filePath)
+		if err != nil {
+			if errors.Is(err, filepairs.ErrPairMatcherNotFound) {
+				return false, nil
+			}
+
+			return false, fmt.Errorf("check pair rule of %s: %w", filePath, err)
+		}
+
+		if pathInfo.PairMatcherName != filepairs.I18NPairRuleName {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// CompareKeys compares the keys of the EN file and the language file of the pair.
+// The EN values at the start point of the language file are read from the commit
+// the EN updates of fileInfo were looked up after.
+//
+// It returns nil for pairs that are not i18n pairs and for pairs in which
+// the language file or the EN file does not exist.
+func (c *Comparator) CompareKeys(
+	ctx context.Context,
+	pair gitseek.Pair,
+	fileInfo gitseek.FileInfo,
+) ([]KeyChange, error) {
+	if !pagecontent.HasBothFiles(fileInfo.FileStatus) {
+		return nil, nil
+	}
+
+	isI18NPair, err := c.isI18NPair(pair)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isI18NPair {
+		return nil, nil
+	}
+
+	enContent, err := c.gitRepo.ReadFile(pair.EnPath)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", pair.EnPath, err)
+	}
+
+	enKeys, err := parseKeys(pair.EnPath, enContent)
+	if err != nil {
+		return nil, err
+	}
+
+	langContent, err := c.gitRepo.ReadFile(pair.LangPath)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", pair.LangPath, err)
+	}
+
+	langKeys, err := parseKeys(pair.LangPath, langContent)
+	if err != nil {
+		return nil, err
+	}
+
+	oldEnKeys, err := c.readKeysAt(ctx, fileInfo.StartPoint().CommitID, pair.EnPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return compareKeys(enKeys, langKeys, oldEnKeys), nil
+}
+
+// readKeysAt returns the keys of the file in the given revision,
+// or no keys if the file does not exist there.
+func (c *Comparator) readKeysAt(ctx context.Context, revision string, filePath string) (map[string]any, error) {
+	if revision == "" {
+		return map[string]any{}, nil
+	}
+
+	exists, err := c.gitRepo.FileExistsAt(ctx, revision, filePath)
+	if err != nil {
+		return nil, fmt.Errorf("check whether %s exists at %s: %w", filePath, revision, err)
+	}
+
+	if !exists {
+		return map[string]any{}, nil
+	}
+
+	content, err := c.gitRepo.ReadFileAt(ctx, revision, filePath)
+	if err != nil {
+		return nil, fmt.Errorf("read %s at %s: %w", filePath, revision, err)
+	}
+
+	return parseKeys(filePath+"@"+revision, content)
+}
+
+// parseKeys returns the top-level keys of the i18n file with their values.
+// A value is either a string or a table of plural forms like {other = "..."}.
+func parseKeys(name string, content string) (map[string]any, error) {
+	keys := make(map[string]any)
+
+	if _, err := toml.Decode(content, &keys); err != nil {
+		return nil, fmt.Errorf("parse i18n file %s: %w", name, err)
+	}
+
+	return keys, nil
+}
+
+func compareKeys(enKeys, langKeys, oldEnKeys map[string]any) []KeyChange {
+	var changes []KeyChange
+
+	for _, key := range sortedKeys(enKeys) {
+		enValue := enKeys[key]
+
+		if _, ok := langKeys[key]; !ok {
+			changes = append(changes, KeyChange{
+				Key:        key,
+				Status:     KeyStatusMissing,
+				EnValue:    formatValue(enValue),
+				OldEnValue: "",
+			})
+
+			continue
+		}
+
+		// a key added to EN after the start point is already translated
+		oldEnValue, ok := oldEnKeys[key]
+		if !ok || reflect.DeepEqual(oldEnValue, enValue) {
+			continue
+		}
+
+		changes = append(changes, KeyChange{
+			Key:        key,
+			Status:     KeyStatusEnValueChanged,
+			EnValue:    formatValue(enValue),
+			OldEnValue: formatValue(oldEnValue),
+		})
+	}
+
+	for _, key := range sortedKeys(langKeys) {
+		if _, ok := enKeys[key]; ok {
+			continue
+		}
+
+		changes = append(changes, KeyChange{
+			Key:        key,
+			Status:     KeyStatusObsolete,
+			EnValue:    "",
+			OldEnValue: "",
+		})
+	}
+
+	return changes
+}
+
+func sortedKeys(keys map[string]any) []string {
+	out := make([]string, 0, len(keys))
+	for key := range keys {
+		out = append(out, key)
+	}
+
+	slices.Sort(out)
+
+	return out
+}
+
+// formatValue formats the value of a key for display. A table with a single
+// plural form is shown as the text of that form.
+func formatValue(value any) string {
+	switch typedValue := value.(type) {
+	case string:
+		return typedValue
+	case map[string]any:
+		if len(typedValue) == 1 {
+			for _, formValue := range typedValue {
+				return formatValue(formValue)
+			}
+		}
+
+		forms := make([]string, 0, len(typedValue))
+		for _, form := range sortedKeys(typedValue) {
+			forms = append(forms, form+": "+formatValue(typedValue[form]))
+		}
+
+		return strings.Join(forms, "; ")
+	default:
+		return fmt.Sprint(typedValue)
+	}
+}
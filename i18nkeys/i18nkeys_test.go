@@ -0,0 +1,308 @@
+package i18nkeys_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/dkarczmarski/go-kweb-lang/filepairs"
+	"github.com/dkarczmarski/go-kweb-lang/git"
+	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+	"github.com/dkarczmarski/go-kweb-lang/i18nkeys"
+)
+
+var errFileNotFound = errors.New("file not found")
+
+type fakeGitRepo struct {
+	files   map[string]string
+	filesAt map[string]map[string]string
+}
+
+func (f fakeGitRepo) ReadFile(path string) (string, error) {
+	content, ok := f.files[path]
+	if !ok {
+		return "", errFileNotFound
+	}
+
+	return content, nil
+}
+
+func (f fakeGitRepo) FileExistsAt(_ context.Context, revision string, path string) (bool, error) {
+	_, ok := f.filesAt[revision][path]
+
+	return ok, nil
+}
+
+func (f fakeGitRepo) ReadFileAt(_ context.Context, revision string, path string) (string, error) {
+	content, ok := f.filesAt[revision][path]
+	if !ok {
+		return "", errFileNotFound
+	}
+
+	return content, nil
+}
+
+var i18nPair = gitseek.Pair{
+	EnPath:   "i18n/en/en.toml",
+	LangPath: "i18n/pl/pl.toml",
+}
+
+func TestComparator_CompareKeys(t *testing.T) {
+	t.Parallel()
+
+	gitRepo := fakeGitRepo{
+		files: map[string]string{
+			"i18n/en/en.toml": `
+[caution]
+other = "Caution!"
+
+[note]
+other = "Note:"
+
+[new_key]
+other = "New"
+
+plain = "Plain text"
+
+[plural]
+one = "{{ .Count }} file"
+other = "{{ .Count }} files"
+`,
+			"i18n/pl/pl.toml": `
+[caution]
+other = "Uwaga:"
+
+[new_key]
+other = "Nowy"
+
+plain = "Zwykły tekst"
+
+[plural]
+one = "{{ .Count }} plik"
+other = "{{ .Count }} plików"
+
+[removed]
+other = "Usunięty"
+`,
+		},
+		filesAt: map[string]map[string]string{
+			"fork": {
+				"i18n/en/en.toml": `
+[caution]
+other = "Caution:"
+
+[note]
+other = "Note:"
+
+plain = "Plain text"
+
+[plural]
+one = "{{ .Count }} file"
+other = "{{ .Count }} file(s)"
+
+[removed]
+other = "Removed"
+`,
+			},
+		},
+	}
+
+	fileInfo := gitseek.FileInfo{
+		LangPath:       "i18n/pl/pl.toml",
+		FileStatus:     gitseek.StatusEnFileUpdated,
+		LangLastCommit: git.CommitInfo{CommitID: "last"},
+		LangForkCommit: &git.CommitInfo{CommitID: "fork"},
+	}
+
+	got, err := i18nkeys.New(gitRepo).CompareKeys(t.Context(), i18nPair, fileInfo)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []i18nkeys.KeyChange{
+		{
+			Key:        "caution",
+			Status:     i18nkeys.KeyStatusEnValueChanged,
+			EnValue:    "Caution!",
+			OldEnValue: "Caution:",
+		},
+		{
+			Key:     "note",
+			Status:  i18nkeys.KeyStatusMissing,
+			EnValue: "Note:",
+		},
+		{
+			Key:        "plural",
+			Status:     i18nkeys.KeyStatusEnValueChanged,
+			EnValue:    "one: {{ .Count }} file; other: {{ .Count }} files",
+			OldEnValue: "one: {{ .Count }} file; other: {{ .Count }} file(s)",
+		},
+		{
+			Key:    "removed",
+			Status: i18nkeys.KeyStatusObsolete,
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected key changes:\n got:  %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestComparator_CompareKeys_UsesSyncMarkerStartPoint(t *testing.T) {
+	t.Parallel()
+
+	gitRepo := fakeGitRepo{
+		files: map[string]string{
+			"i18n/en/en.toml": `caution = "Caution!"`,
+			"i18n/pl/pl.toml": `caution = "Uwaga!"`,
+		},
+		filesAt: map[string]map[string]string{
+			"fork":   {"i18n/en/en.toml": `caution = "Caution:"`},
+			"synced": {"i18n/en/en.toml": `caution = "Caution!"`},
+		},
+	}
+
+	fileInfo := gitseek.FileInfo{
+		LangPath:         "i18n/pl/pl.toml",
+		FileStatus:       gitseek.StatusLangFileUpToDate,
+		LangLastCommit:   git.CommitInfo{CommitID: "last"},
+		LangForkCommit:   &git.CommitInfo{CommitID: "fork"},
+		SyncedWithCommit: &git.CommitInfo{CommitID: "synced"},
+	}
+
+	got, err := i18nkeys.New(gitRepo).CompareKeys(t.Context(), i18nPair, fileInfo)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(got) != 0 {
+		t.Fatalf("expected no key changes, got %#v", got)
+	}
+}
+
+func TestComparator_CompareKeys_Skipped(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		pair     gitseek.Pair
+		fileInfo gitseek.FileInfo
+	}{
+		{
+			name:     "content pair",
+			pair:     gitseek.Pair{EnPath: "content/en/docs/a.md", LangPath: "content/pl/docs/a.md"},
+			fileInfo: gitseek.FileInfo{FileStatus: gitseek.StatusEnFileUpdated},
+		},
+		{
+			name:     "missing lang file",
+			pair:     i18nPair,
+			fileInfo: gitseek.FileInfo{FileStatus: gitseek.StatusLangFileMissing},
+		},
+		{
+			name:     "removed EN file",
+			pair:     i18nPair,
+			fileInfo: gitseek.FileInfo{FileStatus: gitseek.StatusEnFileNoLongerExists},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := i18nkeys.New(fakeGitRepo{}).CompareKeys(t.Context(), tc.pair, tc.fileInfo)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != nil {
+				t.Fatalf("expected nil, got %#v", got)
+			}
+		})
+	}
+}
+
+func TestComparator_CompareKeys_PairRules(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name  string
+		rules []filepairs.PairRule
+		pair  gitseek.Pair
+		want  []i18nkeys.KeyChange
+	}{
+		{
+			name: "i18n rule with a custom path",
+			rules: []filepairs.PairRule{
+				{Name: filepairs.I18NPairRuleName, Path: "translations/{lang}.toml"},
+			},
+			pair: gitseek.Pair{EnPath: "translations/en.toml", LangPath: "translations/pl.toml"},
+			want: []i18nkeys.KeyChange{{Key: "note", Status: i18nkeys.KeyStatusMissing, EnValue: "Note:"}},
+		},
+		{
+			name: "i18n directory matched by another rule",
+			rules: []filepairs.PairRule{
+				{Name: "data", Path: "i18n/{lang}/{lang}.toml"},
+			},
+			pair: i18nPair,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			matchers, err := filepairs.CompilePairRules(tc.rules)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			pairMatchers := make([]filepairs.PairMatcher, 0, len(matchers))
+			for _, matcher := range matchers {
+				pairMatchers = append(pairMatchers, matcher)
+			}
+
+			gitRepo := fakeGitRepo{
+				files: map[string]string{
+					tc.pair.EnPath:   `note = "Note:"`,
+					tc.pair.LangPath: ``,
+				},
+			}
+
+			comparator := i18nkeys.New(gitRepo, func(config *i18nkeys.ComparatorConfig) {
+				config.FilePaths = filepairs.New(func(config *filepairs.FilePathsConfig) {
+					config.PairMatchers = pairMatchers
+				})
+			})
+
+			got, err := comparator.CompareKeys(
+				t.Context(),
+				tc.pair,
+				gitseek.FileInfo{FileStatus: gitseek.StatusEnFileUpdated},
+			)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("unexpected key changes:\n got:  %#v\nwant: %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestComparator_CompareKeys_InvalidTOML(t *testing.T) {
+	t.Parallel()
+
+	gitRepo := fakeGitRepo{
+		files: map[string]string{
+			"i18n/en/en.toml": `[caution`,
+			"i18n/pl/pl.toml": `caution = "Uwaga!"`,
+		},
+	}
+
+	fileInfo := gitseek.FileInfo{
+		FileStatus:     gitseek.StatusEnFileUpdated,
+		LangLastCommit: git.CommitInfo{CommitID: "last"},
+	}
+
+	if _, err := i18nkeys.New(gitRepo).CompareKeys(t.Context(), i18nPair, fileInfo); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
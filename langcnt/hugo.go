@@ -0,0 +1,161 @@
+package langcnt
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// hugoConfigFiles are the Hugo site configuration files, relative to the
+// repository directory, in the order they are looked up.
+var hugoConfigFiles = []string{
+	"hugo.toml",
+	"config.toml",
+	"config/_default/hugo.toml",
+	"config/_default/config.toml",
+}
+
+// hugoLanguagesFile is the Hugo configuration file holding only the
+// languages section.
+const hugoLanguagesFile = "config/_default/languages.toml"
+
+// Language describes a language of the site.
+type Language struct {
+	Code string
+	// Name is the display name of the language, empty if not configured.
+	Name string
+	// ContentDir is the content directory of the language, relative to the
+	// repository directory and slash-separated.
+	ContentDir string
+	Weight     int
+}
+
+type hugoLanguage struct {
+	LanguageName string `toml:"languageName"`
+	ContentDir   string `toml:"contentDir"`
+	Weight       int    `toml:"weight"`
+}
+
+type hugoConfig struct {
+	Languages map[string]hugoLanguage `toml:"languages"`
+}
+
+// readHugoLanguages returns the languages configured in the Hugo site
+// configuration of the repository, sorted by weight.
+// It returns nil if there is no configuration file or it has no languages.
+func readHugoLanguages(repoDir string) ([]Language, error) {
+	configured, err := readHugoLanguagesSection(repoDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(configured) == 0 {
+		return nil, nil
+	}
+
+	languages := make([]Language, 0, len(configured))
+
+	for code, lang := range configured {
+		code = strings.ToLower(code)
+
+		contentDir := path.Clean(filepath.ToSlash(lang.ContentDir))
+		if lang.ContentDir == "" {
+			contentDir = contentDirName + "/" + code
+		}
+
+		languages = append(languages, Language{
+			Code:       code,
+			Name:       lang.LanguageName,
+			ContentDir: contentDir,
+			Weight:     lang.Weight,
+		})
+	}
+
+	slices.SortFunc(languages, func(a, b Language) int {
+		if a.Weight != b.Weight {
+			return a.Weight - b.Weight
+		}
+
+		return strings.Compare(a.Code, b.Code)
+	})
+
+	return languages, nil
+}
+
+func readHugoLanguagesSection(repoDir string) (map[string]hugoLanguage, error) {
+	for _, name := range hugoConfigFiles {
+		var config hugoConfig
+
+		found, err := decodeTOMLFile(filepath.Join(repoDir, name), &config)
+		if err != nil {
+			return nil, err
+		}
+
+		if found && len(config.Languages) > 0 {
+			return config.Languages, nil
+		}
+	}
+
+	var languages map[string]hugoLanguage
+
+	if _, err := decodeTOMLFile(filepath.Join(repoDir, hugoLanguagesFile), &languages); err != nil {
+		return nil, err
+	}
+
+	return languages, nil
+}
+
+// hugoConfigVersion returns a key that changes whenever one of the Hugo
+// configuration files of the repository is created, modified or removed.
+func hugoConfigVersion(repoDir string) (string, error) {
+	var key strings.Builder
+
+	for _, name := range append(slices.Clone(hugoConfigFiles), hugoLanguagesFile) {
+		info, err := os.Stat(filepath.Join(repoDir, name))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				key.WriteString("-;")
+
+				continue
+			}
+
+			return "", fmt.Errorf("stat %s: %w", name, err)
+		}
+
+		fmt.Fprintf(&key, "%d:%d;", info.Size(), info.ModTime().UnixNano())
+	}
+
+	return key.String(), nil
+}
+
+func decodeTOMLFile(file string, v any) (bool, error) {
+	if _, err := toml.DecodeFile(file, v); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("parse Hugo configuration file %s: %w", file, err)
+	}
+
+	return true, nil
+}
+
+func dirExists(path string) (bool, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("stat %s: %w", path, err)
+	}
+
+	return info.IsDir(), nil
+}
@@ -1,5 +1,5 @@
-// Package langcnt provides language code information from the content
-// directory in the Kubernetes repository.
+// Package langcnt provides language information from the Hugo site
+// configuration or the content directory of the repository.
 package langcnt
 
 import (
@@ -7,14 +7,27 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"sync"
 )
 
-const contentDirName = "content"
+const (
+	contentDirName        = "content"
+	defaultSourceLangCode = "en"
+)
 
 type LangCodesProvider struct {
 	RepoDir string
+	// SourceLangCode is the language code of the files that are translated.
+	// Its directory is excluded. The default is "en".
+	SourceLangCode string
 
 	langCodesFilter []string
+
+	contentDirsMu sync.Mutex
+	// contentDirsKey identifies the versions of the Hugo configuration files
+	// the cached content directories were read from.
+	contentDirsKey string
+	contentDirs    map[string]string
 }
 
 // SetLangCodesFilter sets the allowed language codes.
@@ -23,42 +36,132 @@ func (p *LangCodesProvider) SetLangCodesFilter(langCodes []string) {
 	p.langCodesFilter = langCodes
 }
 
-// LangCodes returns language codes found in the repository content directory.
+// Languages returns the languages of the repository.
 //
-// The "en" directory is excluded.
+// The languages are read from the [languages] section of the Hugo site
+// configuration (hugo.toml or config.toml), sorted by weight, and those without
+// a content directory in the repository are skipped. If the site has no such
+// configuration, the languages are the directories of the content directory.
 //
-// If a filter was set with SetLangCodesFilter, only language codes present in
-// that filter are returned.
+// The source language is excluded. If a filter was set with
+// SetLangCodesFilter, only languages present in that filter are returned.
+func (p *LangCodesProvider) Languages() ([]Language, error) {
+	sourceLangCode := p.SourceLangCode
+	if sourceLangCode == "" {
+		sourceLangCode = defaultSourceLangCode
+	}
+
+	allLanguages, err := p.detectLanguages(sourceLangCode)
+	if err != nil {
+		return nil, err
+	}
+
+	languages := make([]Language, 0, len(allLanguages))
+
+	for _, language := range allLanguages {
+		if len(p.langCodesFilter) > 0 && !slices.Contains(p.langCodesFilter, language.Code) {
+			continue
+		}
+
+		languages = append(languages, language)
+	}
+
+	return languages, nil
+}
+
+// LangCodes returns the codes of the languages returned by Languages.
 func (p *LangCodesProvider) LangCodes() ([]string, error) {
-	allLangCodes, err := listLangDirectories(filepath.Join(p.RepoDir, contentDirName))
+	languages, err := p.Languages()
 	if err != nil {
 		return nil, err
 	}
 
-	if len(p.langCodesFilter) == 0 {
-		return allLangCodes, nil
+	langCodes := make([]string, 0, len(languages))
+	for _, language := range languages {
+		langCodes = append(langCodes, language.Code)
 	}
 
-	langCodes := make([]string, 0, len(allLangCodes))
+	return langCodes, nil
+}
+
+// ContentDirs returns the content directories of the languages configured in the
+// Hugo site configuration by language code, including the source language and
+// languages without a content directory in the repository. It returns nil if the
+// site has no such configuration.
+//
+// The result is cached until one of the configuration files changes.
+func (p *LangCodesProvider) ContentDirs() (map[string]string, error) {
+	key, err := hugoConfigVersion(p.RepoDir)
+	if err != nil {
+		return nil, err
+	}
+
+	p.contentDirsMu.Lock()
+	defer p.contentDirsMu.Unlock()
+
+	if p.contentDirsKey == key {
+		return p.contentDirs, nil
+	}
 
-	for _, langCode := range allLangCodes {
-		if !slices.Contains(p.langCodesFilter, langCode) {
+	hugoLanguages, err := readHugoLanguages(p.RepoDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var contentDirs map[string]string
+
+	if hugoLanguages != nil {
+		contentDirs = make(map[string]string, len(hugoLanguages))
+		for _, language := range hugoLanguages {
+			contentDirs[language.Code] = language.ContentDir
+		}
+	}
+
+	p.contentDirsKey = key
+	p.contentDirs = contentDirs
+
+	return contentDirs, nil
+}
+
+func (p *LangCodesProvider) detectLanguages(sourceLangCode string) ([]Language, error) {
+	hugoLanguages, err := readHugoLanguages(p.RepoDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if hugoLanguages == nil {
+		return listLangDirectories(filepath.Join(p.RepoDir, contentDirName), sourceLangCode)
+	}
+
+	languages := make([]Language, 0, len(hugoLanguages))
+
+	for _, language := range hugoLanguages {
+		if language.Code == sourceLangCode {
+			continue
+		}
+
+		exists, err := dirExists(filepath.Join(p.RepoDir, filepath.FromSlash(language.ContentDir)))
+		if err != nil {
+			return nil, err
+		}
+
+		if !exists {
 			continue
 		}
 
-		langCodes = append(langCodes, langCode)
+		languages = append(languages, language)
 	}
 
-	return langCodes, nil
+	return languages, nil
 }
 
-func listLangDirectories(path string) ([]string, error) {
+func listLangDirectories(path string, sourceLangCode string) ([]Language, error) {
 	entries, err := os.ReadDir(path)
 	if err != nil {
 		return nil, fmt.Errorf("read directory %s: %w", path, err)
 	}
 
-	langCodes := make([]string, 0, len(entries))
+	languages := make([]Language, 0, len(entries))
 
 	for _, entry := range entries {
 		if !entry.IsDir() {
@@ -66,12 +169,15 @@ func listLangDirectories(path string) ([]string, error) {
 		}
 
 		langCode := entry.Name()
-		if langCode == "en" {
+		if langCode == sourceLangCode {
 			continue
 		}
 
-		langCodes = append(langCodes, langCode)
+		languages = append(languages, Language{
+			Code:       langCode,
+			ContentDir: contentDirName + "/" + langCode,
+		})
 	}
 
-	return langCodes, nil
+	return languages, nil
 }
@@ -68,6 +68,31 @@ func TestLangCodesProvider_LangCodes(t *testing.T) {
 	}
 }
 
+func TestLangCodesProvider_LangCodes_ExcludesSourceLanguage(t *testing.T) {
+	t.Parallel()
+
+	repoDir := t.TempDir()
+	contentDir := filepath.Join(repoDir, "content")
+
+	mustMkdir(t, filepath.Join(contentDir, "de"))
+	mustMkdir(t, filepath.Join(contentDir, "en"))
+	mustMkdir(t, filepath.Join(contentDir, "pl"))
+
+	provider := &langcnt.LangCodesProvider{
+		RepoDir:        repoDir,
+		SourceLangCode: "de",
+	}
+
+	got, err := provider.LangCodes()
+	if err != nil {
+		t.Fatalf("LangCodes returned error: %v", err)
+	}
+
+	if want := []string{"en", "pl"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected lang codes\nactual:   %v\nexpected: %v", got, want)
+	}
+}
+
 func TestLangCodesProvider_LangCodes_ReturnsErrorWhenContentDirDoesNotExist(t *testing.T) {
 	t.Parallel()
 
@@ -121,6 +146,231 @@ func TestLangCodesProvider_SetLangCodesFilter_OverridesPreviousFilter(t *testing
 	}
 }
 
+func TestLangCodesProvider_Languages_FromHugoConfig(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name       string
+		configFile string
+		config     string
+	}{
+		{
+			name:       "hugo.toml",
+			configFile: "hugo.toml",
+			config: `
+title = "Site"
+
+[languages]
+[languages.en]
+languageName = "English"
+weight = 1
+contentDir = "content/en"
+
+[languages.zh-cn]
+languageName = "中文 (Chinese)"
+weight = 2
+contentDir = "content/zh-cn"
+
+[languages.pl]
+languageName = "Polski (Polish)"
+weight = 3
+contentDir = "content/pl"
+
+[languages.fr]
+languageName = "Français (French)"
+weight = 2
+contentDir = "translations/fr"
+
+[languages.de]
+languageName = "Deutsch (German)"
+weight = 4
+contentDir = "content/de"
+`,
+		},
+		{
+			name:       "config.toml with lowercase keys",
+			configFile: "config.toml",
+			config: `
+[languages.en]
+weight = 1
+
+[languages.zh-cn]
+languagename = "中文 (Chinese)"
+weight = 2
+contentdir = "content/zh-cn"
+
+[languages.pl]
+languagename = "Polski (Polish)"
+weight = 3
+
+[languages.fr]
+languagename = "Français (French)"
+weight = 2
+contentdir = "translations/fr/"
+
+[languages.de]
+languagename = "Deutsch (German)"
+weight = 4
+`,
+		},
+		{
+			name:       "languages.toml",
+			configFile: "config/_default/languages.toml",
+			config: `
+[en]
+weight = 1
+
+[zh-cn]
+languageName = "中文 (Chinese)"
+weight = 2
+
+[pl]
+languageName = "Polski (Polish)"
+weight = 3
+
+[fr]
+languageName = "Français (French)"
+weight = 2
+contentDir = "translations/fr"
+
+[de]
+languageName = "Deutsch (German)"
+weight = 4
+`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repoDir := t.TempDir()
+
+			mustMkdir(t, filepath.Join(repoDir, "content", "en"))
+			mustMkdir(t, filepath.Join(repoDir, "content", "zh-cn"))
+			mustMkdir(t, filepath.Join(repoDir, "content", "pl"))
+			mustMkdir(t, filepath.Join(repoDir, "content", "static"))
+			mustMkdir(t, filepath.Join(repoDir, "translations", "fr"))
+			mustMkdir(t, filepath.Dir(filepath.Join(repoDir, tc.configFile)))
+			mustWriteFile(t, filepath.Join(repoDir, tc.configFile), tc.config)
+
+			provider := &langcnt.LangCodesProvider{
+				RepoDir: repoDir,
+			}
+
+			got, err := provider.Languages()
+			if err != nil {
+				t.Fatalf("Languages returned error: %v", err)
+			}
+
+			want := []langcnt.Language{
+				{Code: "fr", Name: "Français (French)", ContentDir: "translations/fr", Weight: 2},
+				{Code: "zh-cn", Name: "中文 (Chinese)", ContentDir: "content/zh-cn", Weight: 2},
+				{Code: "pl", Name: "Polski (Polish)", ContentDir: "content/pl", Weight: 3},
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("unexpected languages\nactual:   %+v\nexpected: %+v", got, want)
+			}
+
+			langCodes, err := provider.LangCodes()
+			if err != nil {
+				t.Fatalf("LangCodes returned error: %v", err)
+			}
+
+			if want := []string{"fr", "zh-cn", "pl"}; !reflect.DeepEqual(langCodes, want) {
+				t.Fatalf("unexpected lang codes\nactual:   %v\nexpected: %v", langCodes, want)
+			}
+		})
+	}
+}
+
+func TestLangCodesProvider_Languages_FallsBackToContentDirectories(t *testing.T) {
+	t.Parallel()
+
+	repoDir := t.TempDir()
+
+	mustMkdir(t, filepath.Join(repoDir, "content", "en"))
+	mustMkdir(t, filepath.Join(repoDir, "content", "pl"))
+	mustWriteFile(t, filepath.Join(repoDir, "hugo.toml"), `title = "Site without languages"`)
+
+	provider := &langcnt.LangCodesProvider{
+		RepoDir: repoDir,
+	}
+
+	got, err := provider.Languages()
+	if err != nil {
+		t.Fatalf("Languages returned error: %v", err)
+	}
+
+	want := []langcnt.Language{{Code: "pl", ContentDir: "content/pl"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected languages\nactual:   %+v\nexpected: %+v", got, want)
+	}
+}
+
+func TestLangCodesProvider_Languages_ReturnsErrorForInvalidHugoConfig(t *testing.T) {
+	t.Parallel()
+
+	repoDir := t.TempDir()
+
+	mustMkdir(t, filepath.Join(repoDir, "content", "pl"))
+	mustWriteFile(t, filepath.Join(repoDir, "hugo.toml"), `[languages`)
+
+	provider := &langcnt.LangCodesProvider{
+		RepoDir: repoDir,
+	}
+
+	if _, err := provider.Languages(); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestLangCodesProvider_ContentDirs(t *testing.T) {
+	t.Parallel()
+
+	repoDir := t.TempDir()
+
+	mustWriteFile(t, filepath.Join(repoDir, "hugo.toml"), `
+[languages.en]
+weight = 1
+
+[languages.pt-br]
+weight = 2
+contentDir = "translations/pt"
+`)
+
+	provider := &langcnt.LangCodesProvider{
+		RepoDir: repoDir,
+	}
+
+	got, err := provider.ContentDirs()
+	if err != nil {
+		t.Fatalf("ContentDirs returned error: %v", err)
+	}
+
+	want := map[string]string{"en": "content/en", "pt-br": "translations/pt"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected content directories\nactual:   %v\nexpected: %v", got, want)
+	}
+
+	mustWriteFile(t, filepath.Join(repoDir, "hugo.toml"), `
+[languages.en]
+weight = 1
+
+[languages.pt-br]
+weight = 2
+contentDir = "content/pt-br"
+`)
+
+	got, err = provider.ContentDirs()
+	if err != nil {
+		t.Fatalf("ContentDirs returned error: %v", err)
+	}
+
+	want = map[string]string{"en": "content/en", "pt-br": "content/pt-br"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected content directories after change\nactual:   %v\nexpected: %v", got, want)
+	}
+}
+
 func mustMkdir(t *testing.T, path string) {
 	t.Helper()
 
@@ -0,0 +1,77 @@
+// Package pagecontent provides the helpers shared by the checks of the content
+// of Markdown and HTML pages: recognizing page pairs that can be compared and
+// splitting a page into the front matter and the body.
+package pagecontent
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+)
+
+const (
+	YAMLDelimiter = "---"
+	TOMLDelimiter = "+++"
+)
+
+// Page is the content of a page split into the front matter and the body.
+type Page struct {
+	// Delimiter is the front matter delimiter, YAMLDelimiter or TOMLDelimiter,
+	// or empty if the page has no front matter.
+	Delimiter string
+
+	// FrontMatter is the front matter without the delimiter lines.
+	FrontMatter string
+
+	// FrontMatterLines is the number of lines of the front matter
+	// including the delimiter lines.
+	FrontMatterLines int
+
+	// Body is the content after the front matter.
+	Body string
+}
+
+// IsPage reports whether the path is a Markdown or HTML page.
+func IsPage(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+
+	return ext == ".md" || ext == ".html"
+}
+
+// IsPagePair reports whether both files of the pair are pages that exist
+// in the repository, so their content can be compared.
+func IsPagePair(pair gitseek.Pair, fileInfo gitseek.FileInfo) bool {
+	return IsPage(pair.EnPath) && IsPage(pair.LangPath) && HasBothFiles(fileInfo.FileStatus)
+}
+
+// HasBothFiles reports whether the EN file and the language file exist
+// for the file status.
+func HasBothFiles(fileStatus string) bool {
+	return fileStatus == gitseek.StatusEnFileUpdated || fileStatus == gitseek.StatusLangFileUpToDate
+}
+
+// Split splits the content into the YAML (---) or TOML (+++) front matter
+// and the body. If the content has no front matter or it is not closed,
+// the whole content is the body.
+func Split(content string) Page {
+	lines := strings.Split(content, "\n")
+
+	delimiter := strings.TrimSpace(lines[0])
+	if delimiter != YAMLDelimiter && delimiter != TOMLDelimiter {
+		return Page{Body: content}
+	}
+
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == delimiter {
+			return Page{
+				Delimiter:        delimiter,
+				FrontMatter:      strings.Join(lines[1:i], "\n"),
+				FrontMatterLines: i + 1,
+				Body:             strings.Join(lines[i+1:], "\n"),
+			}
+		}
+	}
+
+	return Page{Body: content}
+}
@@ -0,0 +1,96 @@
+package pagecontent_test
+
+import (
+	"testing"
+
+	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+	"github.com/dkarczmarski/go-kweb-lang/pagecontent"
+)
+
+func TestSplit(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name    string
+		content string
+		want    pagecontent.Page
+	}{
+		{
+			name:    "YAML front matter",
+			content: "---\ntitle: A\nweight: 10\n---\n# A\n",
+			want: pagecontent.Page{
+				Delimiter:        pagecontent.YAMLDelimiter,
+				FrontMatter:      "title: A\nweight: 10",
+				FrontMatterLines: 4,
+				Body:             "# A\n",
+			},
+		},
+		{
+			name:    "TOML front matter",
+			content: "+++\ntitle = \"A\"\n+++\nText",
+			want: pagecontent.Page{
+				Delimiter:        pagecontent.TOMLDelimiter,
+				FrontMatter:      `title = "A"`,
+				FrontMatterLines: 3,
+				Body:             "Text",
+			},
+		},
+		{
+			name:    "no front matter",
+			content: "# A\n\nText\n",
+			want:    pagecontent.Page{Body: "# A\n\nText\n"},
+		},
+		{
+			name:    "front matter not closed",
+			content: "---\ntitle: A\n# A\n",
+			want:    pagecontent.Page{Body: "---\ntitle: A\n# A\n"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := pagecontent.Split(tc.content); got != tc.want {
+				t.Fatalf("unexpected page\nactual:   %#v\nexpected: %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsPagePair(t *testing.T) {
+	t.Parallel()
+
+	pagePair := gitseek.Pair{EnPath: "content/en/docs/a.md", LangPath: "content/pl/docs/a.md"}
+
+	for _, tc := range []struct {
+		name       string
+		pair       gitseek.Pair
+		fileStatus string
+		want       bool
+	}{
+		{name: "up to date page", pair: pagePair, fileStatus: gitseek.StatusLangFileUpToDate, want: true},
+		{name: "outdated page", pair: pagePair, fileStatus: gitseek.StatusEnFileUpdated, want: true},
+		{
+			name:       "HTML page",
+			pair:       gitseek.Pair{EnPath: "content/en/a.HTML", LangPath: "content/pl/a.html"},
+			fileStatus: gitseek.StatusLangFileUpToDate,
+			want:       true,
+		},
+		{name: "missing language file", pair: pagePair, fileStatus: gitseek.StatusLangFileMissing, want: false},
+		{
+			name:       "not a page",
+			pair:       gitseek.Pair{EnPath: "content/en/a.yaml", LangPath: "content/pl/a.yaml"},
+			fileStatus: gitseek.StatusLangFileUpToDate,
+			want:       false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			fileInfo := gitseek.FileInfo{FileStatus: tc.fileStatus}
+
+			if got := pagecontent.IsPagePair(tc.pair, fileInfo); got != tc.want {
+				t.Fatalf("IsPagePair() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,425 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: prpreview.go
+//
+// Generated by this command:
+//
+//	mockgen -typed -source=prpreview.go -destination=./internal/mocks/mocks.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	git "github.com/dkarczmarski/go-kweb-lang/git"
+	gitseek "github.com/dkarczmarski/go-kweb-lang/gitseek"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGitRepo is a mock of GitRepo interface.
+type MockGitRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockGitRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockGitRepoMockRecorder is the mock recorder for MockGitRepo.
+type MockGitRepoMockRecorder struct {
+	mock *MockGitRepo
+}
+
+// NewMockGitRepo creates a new mock instance.
+func NewMockGitRepo(ctrl *gomock.Controller) *MockGitRepo {
+	mock := &MockGitRepo{ctrl: ctrl}
+	mock.recorder = &MockGitRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGitRepo) EXPECT() *MockGitRepoMockRecorder {
+	return m.recorder
+}
+
+// FetchPullRequestHeads mocks base method.
+func (m *MockGitRepo) FetchPullRequestHeads(ctx context.Context, prNumbers []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchPullRequestHeads", ctx, prNumbers)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FetchPullRequestHeads indicates an expected call of FetchPullRequestHeads.
+func (mr *MockGitRepoMockRecorder) FetchPullRequestHeads(ctx, prNumbers any) *MockGitRepoFetchPullRequestHeadsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchPullRequestHeads", reflect.TypeOf((*MockGitRepo)(nil).FetchPullRequestHeads), ctx, prNumbers)
+	return &MockGitRepoFetchPullRequestHeadsCall{Call: call}
+}
+
+// MockGitRepoFetchPullRequestHeadsCall wrap *gomock.Call
+type MockGitRepoFetchPullRequestHeadsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockGitRepoFetchPullRequestHeadsCall) Return(arg0 error) *MockGitRepoFetchPullRequestHeadsCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockGitRepoFetchPullRequestHeadsCall) Do(f func(context.Context, []int) error) *MockGitRepoFetchPullRequestHeadsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockGitRepoFetchPullRequestHeadsCall) DoAndReturn(f func(context.Context, []int) error) *MockGitRepoFetchPullRequestHeadsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// FindCommit mocks base method.
+func (m *MockGitRepo) FindCommit(ctx context.Context, commitID string) (git.CommitInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCommit", ctx, commitID)
+	ret0, _ := ret[0].(git.CommitInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCommit indicates an expected call of FindCommit.
+func (mr *MockGitRepoMockRecorder) FindCommit(ctx, commitID any) *MockGitRepoFindCommitCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCommit", reflect.TypeOf((*MockGitRepo)(nil).FindCommit), ctx, commitID)
+	return &MockGitRepoFindCommitCall{Call: call}
+}
+
+// MockGitRepoFindCommitCall wrap *gomock.Call
+type MockGitRepoFindCommitCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockGitRepoFindCommitCall) Return(arg0 git.CommitInfo, arg1 error) *MockGitRepoFindCommitCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockGitRepoFindCommitCall) Do(f func(context.Context, string) (git.CommitInfo, error)) *MockGitRepoFindCommitCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockGitRepoFindCommitCall) DoAndReturn(f func(context.Context, string) (git.CommitInfo, error)) *MockGitRepoFindCommitCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// HeadCommitID mocks base method.
+func (m *MockGitRepo) HeadCommitID(ctx context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HeadCommitID", ctx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HeadCommitID indicates an expected call of HeadCommitID.
+func (mr *MockGitRepoMockRecorder) HeadCommitID(ctx any) *MockGitRepoHeadCommitIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HeadCommitID", reflect.TypeOf((*MockGitRepo)(nil).HeadCommitID), ctx)
+	return &MockGitRepoHeadCommitIDCall{Call: call}
+}
+
+// MockGitRepoHeadCommitIDCall wrap *gomock.Call
+type MockGitRepoHeadCommitIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockGitRepoHeadCommitIDCall) Return(arg0 string, arg1 error) *MockGitRepoHeadCommitIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockGitRepoHeadCommitIDCall) Do(f func(context.Context) (string, error)) *MockGitRepoHeadCommitIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockGitRepoHeadCommitIDCall) DoAndReturn(f func(context.Context) (string, error)) *MockGitRepoHeadCommitIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// PrunePullRequestHeads mocks base method.
+func (m *MockGitRepo) PrunePullRequestHeads(ctx context.Context, openPRNumbers []int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrunePullRequestHeads", ctx, openPRNumbers)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PrunePullRequestHeads indicates an expected call of PrunePullRequestHeads.
+func (mr *MockGitRepoMockRecorder) PrunePullRequestHeads(ctx, openPRNumbers any) *MockGitRepoPrunePullRequestHeadsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrunePullRequestHeads", reflect.TypeOf((*MockGitRepo)(nil).PrunePullRequestHeads), ctx, openPRNumbers)
+	return &MockGitRepoPrunePullRequestHeadsCall{Call: call}
+}
+
+// MockGitRepoPrunePullRequestHeadsCall wrap *gomock.Call
+type MockGitRepoPrunePullRequestHeadsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockGitRepoPrunePullRequestHeadsCall) Return(arg0 []int, arg1 error) *MockGitRepoPrunePullRequestHeadsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockGitRepoPrunePullRequestHeadsCall) Do(f func(context.Context, []int) ([]int, error)) *MockGitRepoPrunePullRequestHeadsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockGitRepoPrunePullRequestHeadsCall) DoAndReturn(f func(context.Context, []int) ([]int, error)) *MockGitRepoPrunePullRequestHeadsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockCacheStorage is a mock of CacheStorage interface.
+type MockCacheStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockCacheStorageMockRecorder
+	isgomock struct{}
+}
+
+// MockCacheStorageMockRecorder is the mock recorder for MockCacheStorage.
+type MockCacheStorageMockRecorder struct {
+	mock *MockCacheStorage
+}
+
+// NewMockCacheStorage creates a new mock instance.
+func NewMockCacheStorage(ctrl *gomock.Controller) *MockCacheStorage {
+	mock := &MockCacheStorage{ctrl: ctrl}
+	mock.recorder = &MockCacheStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCacheStorage) EXPECT() *MockCacheStorageMockRecorder {
+	return m.recorder
+}
+
+// Read mocks base method.
+func (m *MockCacheStorage) Read(bucket, key string, buff any) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", bucket, key, buff)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockCacheStorageMockRecorder) Read(bucket, key, buff any) *MockCacheStorageReadCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockCacheStorage)(nil).Read), bucket, key, buff)
+	return &MockCacheStorageReadCall{Call: call}
+}
+
+// MockCacheStorageReadCall wrap *gomock.Call
+type MockCacheStorageReadCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCacheStorageReadCall) Return(arg0 bool, arg1 error) *MockCacheStorageReadCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCacheStorageReadCall) Do(f func(string, string, any) (bool, error)) *MockCacheStorageReadCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCacheStorageReadCall) DoAndReturn(f func(string, string, any) (bool, error)) *MockCacheStorageReadCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Write mocks base method.
+func (m *MockCacheStorage) Write(bucket, key string, data any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Write", bucket, key, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Write indicates an expected call of Write.
+func (mr *MockCacheStorageMockRecorder) Write(bucket, key, data any) *MockCacheStorageWriteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockCacheStorage)(nil).Write), bucket, key, data)
+	return &MockCacheStorageWriteCall{Call: call}
+}
+
+// MockCacheStorageWriteCall wrap *gomock.Call
+type MockCacheStorageWriteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCacheStorageWriteCall) Return(arg0 error) *MockCacheStorageWriteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCacheStorageWriteCall) Do(f func(string, string, any) error) *MockCacheStorageWriteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCacheStorageWriteCall) DoAndReturn(f func(string, string, any) error) *MockCacheStorageWriteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockLangChecker is a mock of LangChecker interface.
+type MockLangChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockLangCheckerMockRecorder
+	isgomock struct{}
+}
+
+// MockLangCheckerMockRecorder is the mock recorder for MockLangChecker.
+type MockLangCheckerMockRecorder struct {
+	mock *MockLangChecker
+}
+
+// NewMockLangChecker creates a new mock instance.
+func NewMockLangChecker(ctrl *gomock.Controller) *MockLangChecker {
+	mock := &MockLangChecker{ctrl: ctrl}
+	mock.recorder = &MockLangCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLangChecker) EXPECT() *MockLangCheckerMockRecorder {
+	return m.recorder
+}
+
+// CheckLangAt mocks base method.
+func (m *MockLangChecker) CheckLangAt(ctx context.Context, langCode string, pair gitseek.Pair, revision string) (gitseek.FileInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckLangAt", ctx, langCode, pair, revision)
+	ret0, _ := ret[0].(gitseek.FileInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckLangAt indicates an expected call of CheckLangAt.
+func (mr *MockLangCheckerMockRecorder) CheckLangAt(ctx, langCode, pair, revision any) *MockLangCheckerCheckLangAtCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckLangAt", reflect.TypeOf((*MockLangChecker)(nil).CheckLangAt), ctx, langCode, pair, revision)
+	return &MockLangCheckerCheckLangAtCall{Call: call}
+}
+
+// MockLangCheckerCheckLangAtCall wrap *gomock.Call
+type MockLangCheckerCheckLangAtCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockLangCheckerCheckLangAtCall) Return(arg0 gitseek.FileInfo, arg1 error) *MockLangCheckerCheckLangAtCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockLangCheckerCheckLangAtCall) Do(f func(context.Context, string, gitseek.Pair, string) (gitseek.FileInfo, error)) *MockLangCheckerCheckLangAtCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockLangCheckerCheckLangAtCall) DoAndReturn(f func(context.Context, string, gitseek.Pair, string) (gitseek.FileInfo, error)) *MockLangCheckerCheckLangAtCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockPathResolver is a mock of PathResolver interface.
+type MockPathResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockPathResolverMockRecorder
+	isgomock struct{}
+}
+
+// MockPathResolverMockRecorder is the mock recorder for MockPathResolver.
+type MockPathResolverMockRecorder struct {
+	mock *MockPathResolver
+}
+
+// NewMockPathResolver creates a new mock instance.
+func NewMockPathResolver(ctrl *gomock.Controller) *MockPathResolver {
+	mock := &MockPathResolver{ctrl: ctrl}
+	mock.recorder = &MockPathResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPathResolver) EXPECT() *MockPathResolverMockRecorder {
+	return m.recorder
+}
+
+// EnPath mocks base method.
+func (m *MockPathResolver) EnPath(langPath string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnPath", langPath)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnPath indicates an expected call of EnPath.
+func (mr *MockPathResolverMockRecorder) EnPath(langPath any) *MockPathResolverEnPathCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnPath", reflect.TypeOf((*MockPathResolver)(nil).EnPath), langPath)
+	return &MockPathResolverEnPathCall{Call: call}
+}
+
+// MockPathResolverEnPathCall wrap *gomock.Call
+type MockPathResolverEnPathCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPathResolverEnPathCall) Return(arg0 string, arg1 error) *MockPathResolverEnPathCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPathResolverEnPathCall) Do(f func(string) (string, error)) *MockPathResolverEnPathCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPathResolverEnPathCall) DoAndReturn(f func(string) (string, error)) *MockPathResolverEnPathCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,285 @@
+// Package prpreview computes the status of language files changed by open pull requests
+// as if the pull requests were merged into the main branch now. It tells whether a pull
+// request translates the current EN revision or misses EN updates made after its fork point.
+package prpreview
+
+//go:generate mockgen -typed -source=prpreview.go -destination=./internal/mocks/mocks.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+
+	"github.com/dkarczmarski/go-kweb-lang/filepairs"
+	"github.com/dkarczmarski/go-kweb-lang/git"
+	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+	"github.com/dkarczmarski/go-kweb-lang/proxycache"
+	"github.com/dkarczmarski/go-kweb-lang/pullreq"
+)
+
+// GitRepo is an interface used to decouple this package from the concrete git implementation.
+type GitRepo interface {
+	FetchPullRequestHeads(ctx context.Context, prNumbers []int) error
+	PrunePullRequestHeads(ctx context.Context, openPRNumbers []int) ([]int, error)
+	FindCommit(ctx context.Context, commitID string) (git.CommitInfo, error)
+	HeadCommitID(ctx context.Context) (string, error)
+}
+
+// CacheStorage is an interface used to decouple this package from the concrete store implementation.
+type CacheStorage interface {
+	Read(bucket, key string, buff any) (bool, error)
+	Write(bucket, key string, data any) error
+}
+
+// LangChecker is an interface used to decouple this package from the concrete gitseek implementation.
+type LangChecker interface {
+	CheckLangAt(ctx context.Context, langCode string, pair gitseek.Pair, revision string) (gitseek.FileInfo, error)
+}
+
+// PathResolver resolves the EN file path corresponding to a language file path.
+type PathResolver interface {
+	EnPath(langPath string) (string, error)
+}
+
+// FilePreview is the status of a language file at the head of a pull request.
+type FilePreview struct {
+	// PRNumber is the number of the pull request.
+	PRNumber int
+
+	// HeadCommit is the head commit of the pull request the preview was computed for.
+	HeadCommit git.CommitInfo
+
+	// FileInfo describes the language file of the pull request compared
+	// to the EN file on the main branch.
+	FileInfo gitseek.FileInfo
+}
+
+// IsOutdated reports whether the pull request misses EN updates.
+func (p FilePreview) IsOutdated() bool {
+	return len(p.FileInfo.EnUpdates) > 0
+}
+
+// IsDeleted reports whether the pull request deletes the language file.
+func (p FilePreview) IsDeleted() bool {
+	return p.FileInfo.FileStatus == gitseek.StatusLangFileMissing
+}
+
+// CachedFilePreview is a FilePreview stored in the cache. It is valid as long as
+// the head of the pull request and the HEAD of the main branch, which the EN file
+// is taken from, stay the same.
+type CachedFilePreview struct {
+	MainHeadCommitID string
+	Preview          FilePreview
+}
+
+func LangFilePreviewsBucket(langCode string) string {
+	return fmt.Sprintf("lang/%s/pr-file-previews", langCode)
+}
+
+func FilePreviewKey(prNumber int, langPath string) string {
+	return strconv.Itoa(prNumber) + "/" + langPath
+}
+
+// LangPreviews maps language file paths to previews of the pull requests changing them.
+type LangPreviews map[string][]FilePreview
+
+type Previewer struct {
+	gitRepo     GitRepo
+	langChecker LangChecker
+	paths       PathResolver
+	cache       CacheStorage
+}
+
+func New(gitRepo GitRepo, langChecker LangChecker, paths PathResolver, cache CacheStorage) *Previewer {
+	return &Previewer{
+		gitRepo:     gitRepo,
+		langChecker: langChecker,
+		paths:       paths,
+		cache:       cache,
+	}
+}
+
+// FetchHeads fetches the heads of all pull requests from the index into the local clone.
+// If they cannot be fetched at once, they are fetched one by one and pull requests
+// whose heads cannot be fetched are skipped, so that they are not previewed.
+func (p *Previewer) FetchHeads(ctx context.Context, prIndex pullreq.FilePRIndexData) error {
+	prNumbers := uniquePRNumbers(prIndex)
+
+	log.Printf("[prpreview] fetching heads of %d pull requests", len(prNumbers))
+
+	err := p.gitRepo.FetchPullRequestHeads(ctx, prNumbers)
+	if err == nil {
+		return nil
+	}
+
+	if ctx.Err() != nil {
+		return fmt.Errorf("fetch pull request heads: %w", err)
+	}
+
+	log.Printf("[prpreview] fetching heads one by one: %v", err)
+
+	for _, prNumber := range prNumbers {
+		if err := p.gitRepo.FetchPullRequestHeads(ctx, []int{prNumber}); err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("fetch head of PR #%d: %w", prNumber, err)
+			}
+
+			log.Printf("[prpreview][pr:%d] skipping the head that cannot be fetched: %v", prNumber, err)
+		}
+	}
+
+	return nil
+}
+
+// PruneHeads removes the fetched heads of pull requests that are not open anymore.
+func (p *Previewer) PruneHeads(ctx context.Context, openPRNumbers []int) error {
+	prunedPRNumbers, err := p.gitRepo.PrunePullRequestHeads(ctx, openPRNumbers)
+	if err != nil {
+		return fmt.Errorf("prune pull request heads: %w", err)
+	}
+
+	if len(prunedPRNumbers) > 0 {
+		log.Printf("[prpreview] pruned heads of closed pull requests: %v", prunedPRNumbers)
+	}
+
+	return nil
+}
+
+// LangPreviews computes previews for all language files from the index. Pull requests
+// whose heads have not been fetched yet are skipped.
+func (p *Previewer) LangPreviews(
+	ctx context.Context,
+	langCode string,
+	prIndex pullreq.FilePRIndexData,
+) (LangPreviews, error) {
+	heads := make(map[int]git.CommitInfo)
+	previews := make(LangPreviews, len(prIndex))
+
+	if len(prIndex) == 0 {
+		return previews, nil
+	}
+
+	mainHeadCommitID, err := p.gitRepo.HeadCommitID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("find main branch head: %w", err)
+	}
+
+	langPaths := make([]string, 0, len(prIndex))
+	for langPath := range prIndex {
+		langPaths = append(langPaths, langPath)
+	}
+
+	slices.Sort(langPaths)
+
+	for _, langPath := range langPaths {
+		enPath, err := p.paths.EnPath(langPath)
+		if err != nil {
+			if errors.Is(err, filepairs.ErrPairMatcherNotFound) {
+				continue
+			}
+
+			return nil, fmt.Errorf("resolve EN path for %s: %w", langPath, err)
+		}
+
+		for _, prNumber := range prIndex.PRNumbers(langPath) {
+			head, err := p.findHead(ctx, heads, prNumber)
+			if err != nil {
+				return nil, err
+			}
+
+			if head.CommitID == "" {
+				log.Printf("[prpreview][%s][pr:%d] head is not fetched, skipping %s", langCode, prNumber, langPath)
+
+				continue
+			}
+
+			preview, err := p.filePreview(ctx, langCode, gitseek.Pair{
+				EnPath:   enPath,
+				LangPath: langPath,
+			}, prNumber, head, mainHeadCommitID)
+			if err != nil {
+				return nil, err
+			}
+
+			previews[langPath] = append(previews[langPath], preview)
+		}
+	}
+
+	return previews, nil
+}
+
+// filePreview checks the language file at the head of the pull request. The result
+// is cached until the head of the pull request or the HEAD of the main branch moves.
+func (p *Previewer) filePreview(
+	ctx context.Context,
+	langCode string,
+	pair gitseek.Pair,
+	prNumber int,
+	head git.CommitInfo,
+	mainHeadCommitID string,
+) (FilePreview, error) {
+	cached, err := proxycache.Get(
+		ctx,
+		p.cache,
+		LangFilePreviewsBucket(langCode),
+		FilePreviewKey(prNumber, pair.LangPath),
+		func(cached CachedFilePreview) bool {
+			return cached.MainHeadCommitID != mainHeadCommitID ||
+				cached.Preview.HeadCommit.CommitID != head.CommitID
+		},
+		func(ctx context.Context) (CachedFilePreview, error) {
+			fileInfo, err := p.langChecker.CheckLangAt(ctx, langCode, pair, head.CommitID)
+			if err != nil {
+				return CachedFilePreview{}, err
+			}
+
+			return CachedFilePreview{
+				MainHeadCommitID: mainHeadCommitID,
+				Preview: FilePreview{
+					PRNumber:   prNumber,
+					HeadCommit: head,
+					FileInfo:   fileInfo,
+				},
+			}, nil
+		},
+	)
+	if err != nil {
+		return FilePreview{}, fmt.Errorf("check %s at the head of PR #%d: %w", pair.LangPath, prNumber, err)
+	}
+
+	return cached.Preview, nil
+}
+
+func (p *Previewer) findHead(ctx context.Context, heads map[int]git.CommitInfo, prNumber int) (git.CommitInfo, error) {
+	if head, ok := heads[prNumber]; ok {
+		return head, nil
+	}
+
+	head, err := p.gitRepo.FindCommit(ctx, git.PullRequestHeadRef(prNumber))
+	if err != nil {
+		return git.CommitInfo{}, fmt.Errorf("find head of PR #%d: %w", prNumber, err)
+	}
+
+	heads[prNumber] = head
+
+	return head, nil
+}
+
+func uniquePRNumbers(prIndex pullreq.FilePRIndexData) []int {
+	var prNumbers []int
+
+	for _, filePRs := range prIndex {
+		for _, filePR := range filePRs {
+			if !slices.Contains(prNumbers, filePR.Number) {
+				prNumbers = append(prNumbers, filePR.Number)
+			}
+		}
+	}
+
+	slices.Sort(prNumbers)
+
+	return prNumbers
+}
@@ -0,0 +1,299 @@
+//nolint:paralleltest
+package prpreview_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/dkarczmarski/go-kweb-lang/filepairs"
+	"github.com/dkarczmarski/go-kweb-lang/git"
+	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+	"github.com/dkarczmarski/go-kweb-lang/prpreview"
+	"github.com/dkarczmarski/go-kweb-lang/prpreview/internal/mocks"
+	"github.com/dkarczmarski/go-kweb-lang/pullreq"
+	"github.com/dkarczmarski/go-kweb-lang/testing/storetests"
+	"go.uber.org/mock/gomock"
+)
+
+func newPreviewer(ctrl *gomock.Controller, gitRepo *mocks.MockGitRepo) *prpreview.Previewer {
+	return prpreview.New(
+		gitRepo,
+		mocks.NewMockLangChecker(ctrl),
+		mocks.NewMockPathResolver(ctrl),
+		mocks.NewMockCacheStorage(ctrl),
+	)
+}
+
+func TestPreviewer_FetchHeads(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	gitRepo := mocks.NewMockGitRepo(ctrl)
+
+	gitRepo.EXPECT().
+		FetchPullRequestHeads(gomock.Any(), []int{100, 200, 300}).
+		Return(nil)
+
+	err := newPreviewer(ctrl, gitRepo).FetchHeads(ctx, pullreq.FilePRIndexData{
+		"content/pl/a.md": {{Number: 300}, {Number: 100}},
+		"content/pl/b.md": {{Number: 200}, {Number: 100}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestPreviewer_FetchHeads_SkipsHeadsThatCannotBeFetched(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	gitRepo := mocks.NewMockGitRepo(ctrl)
+
+	gomock.InOrder(
+		gitRepo.EXPECT().FetchPullRequestHeads(gomock.Any(), []int{100, 200}).Return(errTest),
+		gitRepo.EXPECT().FetchPullRequestHeads(gomock.Any(), []int{100}).Return(errTest),
+		gitRepo.EXPECT().FetchPullRequestHeads(gomock.Any(), []int{200}).Return(nil),
+	)
+
+	err := newPreviewer(ctrl, gitRepo).FetchHeads(ctx, pullreq.FilePRIndexData{
+		"content/pl/a.md": {{Number: 100}, {Number: 200}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestPreviewer_PruneHeads(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	gitRepo := mocks.NewMockGitRepo(ctrl)
+
+	gitRepo.EXPECT().
+		PrunePullRequestHeads(gomock.Any(), []int{100, 200}).
+		Return([]int{50}, nil)
+
+	if err := newPreviewer(ctrl, gitRepo).PruneHeads(ctx, []int{100, 200}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestPreviewer_LangPreviews(t *testing.T) {
+	ctx := context.Background()
+	langCode := "pl"
+
+	head100 := git.CommitInfo{CommitID: "head100", DateTime: "2024-01-02T10:00:00Z", Comment: "translate a"}
+	head200 := git.CommitInfo{CommitID: "head200", DateTime: "2024-01-03T10:00:00Z", Comment: "translate a and b"}
+
+	upToDate := gitseek.FileInfo{
+		LangPath:   "content/pl/a.md",
+		FileStatus: gitseek.StatusLangFileUpToDate,
+	}
+	outdated := gitseek.FileInfo{
+		LangPath:   "content/pl/b.md",
+		FileStatus: gitseek.StatusEnFileUpdated,
+		EnUpdates: []gitseek.EnUpdate{
+			{Commit: git.CommitInfo{CommitID: "en1"}, Overlap: gitseek.OverlapDuringBranch},
+		},
+	}
+
+	for _, tc := range []struct {
+		name             string
+		prIndex          pullreq.FilePRIndexData
+		init             func(gitRepo *mocks.MockGitRepo, checker *mocks.MockLangChecker, paths *mocks.MockPathResolver)
+		expectedPreviews prpreview.LangPreviews
+		expectedErr      error
+	}{
+		{
+			name: "check each file at the head of each of its pull requests",
+			prIndex: pullreq.FilePRIndexData{
+				"content/pl/a.md": {{Number: 100}, {Number: 200}},
+				"content/pl/b.md": {{Number: 200}},
+			},
+			init: func(gitRepo *mocks.MockGitRepo, checker *mocks.MockLangChecker, paths *mocks.MockPathResolver) {
+				paths.EXPECT().EnPath("content/pl/a.md").Return("content/en/a.md", nil)
+				paths.EXPECT().EnPath("content/pl/b.md").Return("content/en/b.md", nil)
+
+				gitRepo.EXPECT().FindCommit(gomock.Any(), "refs/pull/100/head").Return(head100, nil)
+				gitRepo.EXPECT().FindCommit(gomock.Any(), "refs/pull/200/head").Return(head200, nil)
+
+				pairA := gitseek.Pair{EnPath: "content/en/a.md", LangPath: "content/pl/a.md"}
+				pairB := gitseek.Pair{EnPath: "content/en/b.md", LangPath: "content/pl/b.md"}
+
+				checker.EXPECT().CheckLangAt(gomock.Any(), langCode, pairA, "head100").Return(upToDate, nil)
+				checker.EXPECT().CheckLangAt(gomock.Any(), langCode, pairA, "head200").Return(upToDate, nil)
+				checker.EXPECT().CheckLangAt(gomock.Any(), langCode, pairB, "head200").Return(outdated, nil)
+			},
+			expectedPreviews: prpreview.LangPreviews{
+				"content/pl/a.md": {
+					{PRNumber: 100, HeadCommit: head100, FileInfo: upToDate},
+					{PRNumber: 200, HeadCommit: head200, FileInfo: upToDate},
+				},
+				"content/pl/b.md": {
+					{PRNumber: 200, HeadCommit: head200, FileInfo: outdated},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "skip pull requests whose heads are not fetched and files without a pair",
+			prIndex: pullreq.FilePRIndexData{
+				"content/pl/a.md":    {{Number: 100}},
+				"content/pl/misc.md": {{Number: 200}},
+			},
+			init: func(gitRepo *mocks.MockGitRepo, _ *mocks.MockLangChecker, paths *mocks.MockPathResolver) {
+				paths.EXPECT().EnPath("content/pl/a.md").Return("content/en/a.md", nil)
+				paths.EXPECT().EnPath("content/pl/misc.md").Return("", filepairs.ErrPairMatcherNotFound)
+
+				gitRepo.EXPECT().FindCommit(gomock.Any(), "refs/pull/100/head").Return(git.CommitInfo{}, nil)
+			},
+			expectedPreviews: prpreview.LangPreviews{},
+			expectedErr:      nil,
+		},
+		{
+			name: "return an error when the check fails",
+			prIndex: pullreq.FilePRIndexData{
+				"content/pl/a.md": {{Number: 100}},
+			},
+			init: func(gitRepo *mocks.MockGitRepo, checker *mocks.MockLangChecker, paths *mocks.MockPathResolver) {
+				paths.EXPECT().EnPath("content/pl/a.md").Return("content/en/a.md", nil)
+				gitRepo.EXPECT().FindCommit(gomock.Any(), "refs/pull/100/head").Return(head100, nil)
+				checker.EXPECT().
+					CheckLangAt(gomock.Any(), langCode, gomock.Any(), "head100").
+					Return(gitseek.FileInfo{}, errTest)
+			},
+			expectedPreviews: nil,
+			expectedErr:      errTest,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			gitRepo := mocks.NewMockGitRepo(ctrl)
+			checker := mocks.NewMockLangChecker(ctrl)
+			paths := mocks.NewMockPathResolver(ctrl)
+
+			cache := mocks.NewMockCacheStorage(ctrl)
+
+			tc.init(gitRepo, checker, paths)
+
+			gitRepo.EXPECT().HeadCommitID(gomock.Any()).Return("main1", nil).AnyTimes()
+			cache.EXPECT().Read(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(storetests.MockReadNotFound()).
+				AnyTimes()
+			cache.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			previewer := prpreview.New(gitRepo, checker, paths, cache)
+
+			previews, err := previewer.LangPreviews(ctx, langCode, tc.prIndex)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("unexpected error\nexpected: %v\nactual  : %v", tc.expectedErr, err)
+			}
+
+			if !reflect.DeepEqual(tc.expectedPreviews, previews) {
+				t.Errorf("unexpected result\nexpected: %v\nactual  : %v", tc.expectedPreviews, previews)
+			}
+		})
+	}
+}
+
+func TestPreviewer_LangPreviews_Cache(t *testing.T) {
+	ctx := context.Background()
+	langCode := "pl"
+	pair := gitseek.Pair{EnPath: "content/en/a.md", LangPath: "content/pl/a.md"}
+
+	head := git.CommitInfo{CommitID: "head100"}
+	cachedFileInfo := gitseek.FileInfo{LangPath: pair.LangPath, FileStatus: gitseek.StatusLangFileUpToDate}
+	freshFileInfo := gitseek.FileInfo{LangPath: pair.LangPath, FileStatus: gitseek.StatusLangFileMissing}
+
+	for _, tc := range []struct {
+		name             string
+		cachedMainHead   string
+		cachedHead       string
+		expectedFileInfo gitseek.FileInfo
+	}{
+		{
+			name:             "use the cached preview while both heads are unchanged",
+			cachedMainHead:   "main1",
+			cachedHead:       "head100",
+			expectedFileInfo: cachedFileInfo,
+		},
+		{
+			name:             "check again after the head of the pull request moves",
+			cachedMainHead:   "main1",
+			cachedHead:       "head99",
+			expectedFileInfo: freshFileInfo,
+		},
+		{
+			name:             "check again after the main branch moves",
+			cachedMainHead:   "main0",
+			cachedHead:       "head100",
+			expectedFileInfo: freshFileInfo,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			gitRepo := mocks.NewMockGitRepo(ctrl)
+			checker := mocks.NewMockLangChecker(ctrl)
+			paths := mocks.NewMockPathResolver(ctrl)
+			cache := mocks.NewMockCacheStorage(ctrl)
+
+			paths.EXPECT().EnPath(pair.LangPath).Return(pair.EnPath, nil)
+			gitRepo.EXPECT().HeadCommitID(gomock.Any()).Return("main1", nil)
+			gitRepo.EXPECT().FindCommit(gomock.Any(), "refs/pull/100/head").Return(head, nil)
+
+			cache.EXPECT().
+				Read(prpreview.LangFilePreviewsBucket(langCode), prpreview.FilePreviewKey(100, pair.LangPath), gomock.Any()).
+				DoAndReturn(storetests.MockReadReturn(true, prpreview.CachedFilePreview{
+					MainHeadCommitID: tc.cachedMainHead,
+					Preview: prpreview.FilePreview{
+						PRNumber:   100,
+						HeadCommit: git.CommitInfo{CommitID: tc.cachedHead},
+						FileInfo:   cachedFileInfo,
+					},
+				}, nil))
+
+			if tc.expectedFileInfo.FileStatus != cachedFileInfo.FileStatus {
+				checker.EXPECT().CheckLangAt(gomock.Any(), langCode, pair, "head100").Return(freshFileInfo, nil)
+				cache.EXPECT().
+					Write(prpreview.LangFilePreviewsBucket(langCode), prpreview.FilePreviewKey(100, pair.LangPath), gomock.Any()).
+					Return(nil)
+			}
+
+			previews, err := prpreview.New(gitRepo, checker, paths, cache).
+				LangPreviews(ctx, langCode, pullreq.FilePRIndexData{pair.LangPath: {{Number: 100}}})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := previews[pair.LangPath][0].FileInfo; !reflect.DeepEqual(tc.expectedFileInfo, got) {
+				t.Errorf("unexpected file info\nexpected: %v\nactual  : %v", tc.expectedFileInfo, got)
+			}
+		})
+	}
+}
+
+func TestFilePreview_IsDeleted(t *testing.T) {
+	if (prpreview.FilePreview{FileInfo: gitseek.FileInfo{FileStatus: gitseek.StatusLangFileUpToDate}}).IsDeleted() {
+		t.Error("expected preview of an existing file not to be deleted")
+	}
+
+	if !(prpreview.FilePreview{FileInfo: gitseek.FileInfo{FileStatus: gitseek.StatusLangFileMissing}}).IsDeleted() {
+		t.Error("expected preview of a missing file to be deleted")
+	}
+}
+
+func TestFilePreview_IsOutdated(t *testing.T) {
+	if (prpreview.FilePreview{}).IsOutdated() {
+		t.Error("expected preview without EN updates to be up to date")
+	}
+
+	preview := prpreview.FilePreview{
+		FileInfo: gitseek.FileInfo{
+			EnUpdates: []gitseek.EnUpdate{{Commit: git.CommitInfo{CommitID: "en1"}}},
+		},
+	}
+	if !preview.IsOutdated() {
+		t.Error("expected preview with EN updates to be outdated")
+	}
+}
+
+var errTest = errors.New("test error")
@@ -1,6 +0,0 @@
-package cachetypes
-
-type PRCommits struct {
-	UpdatedAt string
-	CommitIDs []string
-}
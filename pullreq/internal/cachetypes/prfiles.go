@@ -0,0 +1,8 @@
+package cachetypes
+
+import "github.com/dkarczmarski/go-kweb-lang/github"
+
+type PRFiles struct {
+	UpdatedAt string
+	Files     []github.PRFile
+}
@@ -0,0 +1,6 @@
+package cachetypes
+
+type PRRequestedReviewers struct {
+	UpdatedAt          string
+	RequestedReviewers []string
+}
@@ -41,80 +41,80 @@ func (m *MockGitHub) EXPECT() *MockGitHubMockRecorder {
 	return m.recorder
 }
 
-// GetCommitFiles mocks base method.
-func (m *MockGitHub) GetCommitFiles(ctx context.Context, commitID string) (*github.CommitFiles, error) {
+// GetPRFiles mocks base method.
+func (m *MockGitHub) GetPRFiles(ctx context.Context, prNumber int) ([]github.PRFile, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCommitFiles", ctx, commitID)
-	ret0, _ := ret[0].(*github.CommitFiles)
+	ret := m.ctrl.Call(m, "GetPRFiles", ctx, prNumber)
+	ret0, _ := ret[0].([]github.PRFile)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCommitFiles indicates an expected call of GetCommitFiles.
-func (mr *MockGitHubMockRecorder) GetCommitFiles(ctx, commitID any) *MockGitHubGetCommitFilesCall {
+// GetPRFiles indicates an expected call of GetPRFiles.
+func (mr *MockGitHubMockRecorder) GetPRFiles(ctx, prNumber any) *MockGitHubGetPRFilesCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommitFiles", reflect.TypeOf((*MockGitHub)(nil).GetCommitFiles), ctx, commitID)
-	return &MockGitHubGetCommitFilesCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPRFiles", reflect.TypeOf((*MockGitHub)(nil).GetPRFiles), ctx, prNumber)
+	return &MockGitHubGetPRFilesCall{Call: call}
 }
 
-// MockGitHubGetCommitFilesCall wrap *gomock.Call
-type MockGitHubGetCommitFilesCall struct {
+// MockGitHubGetPRFilesCall wrap *gomock.Call
+type MockGitHubGetPRFilesCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockGitHubGetCommitFilesCall) Return(arg0 *github.CommitFiles, arg1 error) *MockGitHubGetCommitFilesCall {
+func (c *MockGitHubGetPRFilesCall) Return(arg0 []github.PRFile, arg1 error) *MockGitHubGetPRFilesCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockGitHubGetCommitFilesCall) Do(f func(context.Context, string) (*github.CommitFiles, error)) *MockGitHubGetCommitFilesCall {
+func (c *MockGitHubGetPRFilesCall) Do(f func(context.Context, int) ([]github.PRFile, error)) *MockGitHubGetPRFilesCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockGitHubGetCommitFilesCall) DoAndReturn(f func(context.Context, string) (*github.CommitFiles, error)) *MockGitHubGetCommitFilesCall {
+func (c *MockGitHubGetPRFilesCall) DoAndReturn(f func(context.Context, int) ([]github.PRFile, error)) *MockGitHubGetPRFilesCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// GetPRCommits mocks base method.
-func (m *MockGitHub) GetPRCommits(ctx context.Context, prNumber int) ([]string, error) {
+// GetPRRequestedReviewers mocks base method.
+func (m *MockGitHub) GetPRRequestedReviewers(ctx context.Context, prNumber int) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPRCommits", ctx, prNumber)
+	ret := m.ctrl.Call(m, "GetPRRequestedReviewers", ctx, prNumber)
 	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetPRCommits indicates an expected call of GetPRCommits.
-func (mr *MockGitHubMockRecorder) GetPRCommits(ctx, prNumber any) *MockGitHubGetPRCommitsCall {
+// GetPRRequestedReviewers indicates an expected call of GetPRRequestedReviewers.
+func (mr *MockGitHubMockRecorder) GetPRRequestedReviewers(ctx, prNumber any) *MockGitHubGetPRRequestedReviewersCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPRCommits", reflect.TypeOf((*MockGitHub)(nil).GetPRCommits), ctx, prNumber)
-	return &MockGitHubGetPRCommitsCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPRRequestedReviewers", reflect.TypeOf((*MockGitHub)(nil).GetPRRequestedReviewers), ctx, prNumber)
+	return &MockGitHubGetPRRequestedReviewersCall{Call: call}
 }
 
-// MockGitHubGetPRCommitsCall wrap *gomock.Call
-type MockGitHubGetPRCommitsCall struct {
+// MockGitHubGetPRRequestedReviewersCall wrap *gomock.Call
+type MockGitHubGetPRRequestedReviewersCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockGitHubGetPRCommitsCall) Return(arg0 []string, arg1 error) *MockGitHubGetPRCommitsCall {
+func (c *MockGitHubGetPRRequestedReviewersCall) Return(arg0 []string, arg1 error) *MockGitHubGetPRRequestedReviewersCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockGitHubGetPRCommitsCall) Do(f func(context.Context, int) ([]string, error)) *MockGitHubGetPRCommitsCall {
+func (c *MockGitHubGetPRRequestedReviewersCall) Do(f func(context.Context, int) ([]string, error)) *MockGitHubGetPRRequestedReviewersCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockGitHubGetPRCommitsCall) DoAndReturn(f func(context.Context, int) ([]string, error)) *MockGitHubGetPRCommitsCall {
+func (c *MockGitHubGetPRRequestedReviewersCall) DoAndReturn(f func(context.Context, int) ([]string, error)) *MockGitHubGetPRRequestedReviewersCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
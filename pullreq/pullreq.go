@@ -5,9 +5,11 @@ package pullreq
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
+	"slices"
 	"sort"
 	"strconv"
 
@@ -19,8 +21,8 @@ import (
 
 type GitHub interface {
 	PRSearch(ctx context.Context, filter github.PRSearchFilter, page github.PageRequest) (*github.PRSearchResult, error)
-	GetPRCommits(ctx context.Context, prNumber int) ([]string, error)
-	GetCommitFiles(ctx context.Context, commitID string) (*github.CommitFiles, error)
+	GetPRFiles(ctx context.Context, prNumber int) ([]github.PRFile, error)
+	GetPRRequestedReviewers(ctx context.Context, prNumber int) ([]string, error)
 }
 
 type CacheStorage interface {
@@ -29,7 +31,111 @@ type CacheStorage interface {
 	Delete(bucket, key string) error
 }
 
-type FilePRIndexData map[string][]int
+// FilePR is an open pull request changing a language file.
+type FilePR struct {
+	Number int
+
+	// Status is the status of the file in the pull request (see github.PRFileStatus* constants).
+	// A renamed file is indexed under both its previous and its new path with the renamed status.
+	// It is empty in indexes built by earlier versions.
+	Status string
+}
+
+// UnmarshalJSON decodes the pull request also from a bare number,
+// the format of indexes built by earlier versions.
+func (pr *FilePR) UnmarshalJSON(data []byte) error {
+	var number int
+	if err := json.Unmarshal(data, &number); err == nil {
+		*pr = FilePR{Number: number}
+
+		return nil
+	}
+
+	type filePR FilePR
+
+	var decoded filePR
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return fmt.Errorf("decode file pull request: %w", err)
+	}
+
+	*pr = FilePR(decoded)
+
+	return nil
+}
+
+// FilePRIndexData maps the paths of language files to the open pull requests
+// changing them, sorted by descending number.
+type FilePRIndexData map[string][]FilePR
+
+// PRNumbers returns the numbers of the pull requests changing the file.
+func (d FilePRIndexData) PRNumbers(langPath string) []int {
+	filePRs := d[langPath]
+	if filePRs == nil {
+		return nil
+	}
+
+	prNumbers := make([]int, 0, len(filePRs))
+	for _, filePR := range filePRs {
+		prNumbers = append(prNumbers, filePR.Number)
+	}
+
+	return prNumbers
+}
+
+// PRInfoIndexData maps the numbers of the pull requests in the file-to-PR index
+// to their details.
+type PRInfoIndexData map[int]PRInfo
+
+// The labels set by reviewers and approvers of the Kubernetes repositories.
+const (
+	LabelLGTM     = "lgtm"
+	LabelApproved = "approved"
+)
+
+// PRInfo describes an open pull request.
+type PRInfo struct {
+	Number             int
+	Title              string
+	Author             string
+	Draft              bool
+	CreatedAt          string
+	UpdatedAt          string
+	Labels             []string
+	RequestedReviewers []string
+
+	// LGTM is set if the pull request has the lgtm label.
+	LGTM bool
+
+	// Approved is set if the pull request has the approved label
+	// or if its review decision is approved.
+	Approved bool
+
+	// ChangesRequested is set if the review decision is that changes are requested.
+	// The review decision is known only with the GraphQL API.
+	ChangesRequested bool
+}
+
+func newPRInfo(pullRequest github.PRItem, requestedReviewers []string) PRInfo {
+	labels := make([]string, 0, len(pullRequest.Labels))
+	for _, label := range pullRequest.Labels {
+		labels = append(labels, label.Name)
+	}
+
+	return PRInfo{
+		Number:             pullRequest.Number,
+		Title:              pullRequest.Title,
+		Author:             pullRequest.User.Login,
+		Draft:              pullRequest.Draft,
+		CreatedAt:          pullRequest.CreatedAt,
+		UpdatedAt:          pullRequest.UpdatedAt,
+		Labels:             labels,
+		RequestedReviewers: requestedReviewers,
+		LGTM:               slices.Contains(labels, LabelLGTM),
+		Approved: slices.Contains(labels, LabelApproved) ||
+			pullRequest.ReviewDecision == github.ReviewDecisionApproved,
+		ChangesRequested: pullRequest.ReviewDecision == github.ReviewDecisionChangesRequested,
+	}
+}
 
 type FilePRIndex struct {
 	gitHub       GitHub
@@ -39,10 +145,11 @@ type FilePRIndex struct {
 }
 
 const (
-	bucketPRCommits    = "pr-pr-commits"
-	bucketCommitFiles  = "pr-commit-files"
-	bucketFilePRsIndex = "pr-fileprs-index"
-	maxPages           = 20
+	bucketPRFiles              = "pr-pr-files"
+	bucketPRRequestedReviewers = "pr-pr-reviewers"
+	bucketFilePRsIndex         = "pr-fileprs-index"
+	bucketPRInfoIndex          = "pr-prinfo-index"
+	maxPages                   = 20
 )
 
 var (
@@ -51,35 +158,53 @@ var (
 	ErrLangIndexNotFound       = errors.New("language pull request index not found")
 )
 
-func NewFilePRIndex(gitHub GitHub, cacheStore CacheStorage, perPage int) *FilePRIndex {
+type FilePRIndexConfig struct {
+	// FilePaths recognizes language files changed by pull requests.
+	// By default the built-in pair patterns are used.
+	FilePaths *filepairs.FilePaths
+}
+
+func NewFilePRIndex(
+	gitHub GitHub,
+	cacheStore CacheStorage,
+	perPage int,
+	opts ...func(config *FilePRIndexConfig),
+) *FilePRIndex {
+	config := FilePRIndexConfig{
+		FilePaths: filepairs.New(),
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
 	return &FilePRIndex{
 		gitHub:       gitHub,
 		cacheStorage: cacheStore,
-		filePaths:    filepairs.New(),
+		filePaths:    config.FilePaths,
 		perPage:      perPage,
 	}
 }
 
-// PRCommitsCacheBucket returns the cache bucket used for PR commit lists
-// for the given language.
-func PRCommitsCacheBucket(langCode string) string {
-	return fmt.Sprintf("lang/%s/%s", langCode, bucketPRCommits)
+// PRFilesCacheBucket returns the cache bucket used for the files changed
+// by pull requests for the given language.
+func PRFilesCacheBucket(langCode string) string {
+	return fmt.Sprintf("lang/%s/%s", langCode, bucketPRFiles)
 }
 
-// PRCommitsCacheKey returns the cache key used for a PR commit list.
-func PRCommitsCacheKey(prNumber int) string {
+// PRFilesCacheKey returns the cache key used for the files changed by a pull request.
+func PRFilesCacheKey(prNumber int) string {
 	return strconv.Itoa(prNumber)
 }
 
-// CommitFilesCacheBucket returns the cache bucket used for commit file lists
-// for the given language.
-func CommitFilesCacheBucket(langCode string) string {
-	return fmt.Sprintf("lang/%s/%s", langCode, bucketCommitFiles)
+// PRRequestedReviewersCacheBucket returns the cache bucket used for the requested
+// reviewers of pull requests for the given language.
+func PRRequestedReviewersCacheBucket(langCode string) string {
+	return fmt.Sprintf("lang/%s/%s", langCode, bucketPRRequestedReviewers)
 }
 
-// CommitFilesCacheKey returns the cache key used for a commit file list.
-func CommitFilesCacheKey(commitID string) string {
-	return commitID
+// PRRequestedReviewersCacheKey returns the cache key used for the requested reviewers of a pull request.
+func PRRequestedReviewersCacheKey(prNumber int) string {
+	return strconv.Itoa(prNumber)
 }
 
 // FilePRsIndexCacheBucket returns the cache bucket used for the file-to-PR index
@@ -94,6 +219,18 @@ func FilePRsIndexCacheKey(langCode string) string {
 	return langCode
 }
 
+// PRInfoIndexCacheBucket returns the cache bucket used for the details
+// of the indexed pull requests for the given language.
+func PRInfoIndexCacheBucket(langCode string) string {
+	return fmt.Sprintf("lang/%s/%s", langCode, bucketPRInfoIndex)
+}
+
+// PRInfoIndexCacheKey returns the cache key used for the details
+// of the indexed pull requests for the given language.
+func PRInfoIndexCacheKey(langCode string) string {
+	return langCode
+}
+
 const firstPage = 1
 
 func (p *FilePRIndex) fetchOpenPRsForLang(ctx context.Context, langCode string) ([]github.PRItem, error) {
@@ -155,188 +292,89 @@ func (p *FilePRIndex) fetchOpenPRsForLang(ctx context.Context, langCode string)
 	)
 }
 
-func (p *FilePRIndex) fetchPRCommits(
+// fetchFilesForPR returns the files changed by the pull request. They are cached
+// until the pull request is updated.
+func (p *FilePRIndex) fetchFilesForPR(
 	ctx context.Context,
 	langCode string,
 	pullRequest github.PRItem,
-) ([]string, error) {
-	commits, err := proxycache.Get(
+	pullRequestIndex int,
+	pullRequestsCount int,
+) ([]github.PRFile, error) {
+	log.Printf(
+		"[pullreq][%s][%d/%d][pr:%d] loading PR files (updatedAt=%s)",
+		langCode,
+		pullRequestIndex+1,
+		pullRequestsCount,
+		pullRequest.Number,
+		pullRequest.UpdatedAt,
+	)
+
+	prFiles, err := proxycache.Get(
 		ctx,
 		p.cacheStorage,
-		PRCommitsCacheBucket(langCode),
-		PRCommitsCacheKey(pullRequest.Number),
-		func(cachedPRCommits cachetypes.PRCommits) bool {
-			isStale := cachedPRCommits.UpdatedAt != pullRequest.UpdatedAt
+		PRFilesCacheBucket(langCode),
+		PRFilesCacheKey(pullRequest.Number),
+		func(cachedPRFiles cachetypes.PRFiles) bool {
+			isStale := cachedPRFiles.UpdatedAt != pullRequest.UpdatedAt
 
 			if isStale {
 				log.Printf(
-					"[pullreq][%s][pr:%d] commit cache stale: cached=%s current=%s",
+					"[pullreq][%s][pr:%d] files cache stale: cached=%s current=%s",
 					langCode,
 					pullRequest.Number,
-					cachedPRCommits.UpdatedAt,
+					cachedPRFiles.UpdatedAt,
 					pullRequest.UpdatedAt,
 				)
 			}
 
 			return isStale
 		},
-		func(ctx context.Context) (cachetypes.PRCommits, error) {
-			log.Printf("[pullreq][%s][pr:%d] fetching commit IDs", langCode, pullRequest.Number)
+		func(ctx context.Context) (cachetypes.PRFiles, error) {
+			log.Printf("[pullreq][%s][pr:%d] fetching files", langCode, pullRequest.Number)
 
-			commitIDs, err := p.gitHub.GetPRCommits(ctx, pullRequest.Number)
+			files, err := p.getPRFiles(ctx, pullRequest)
 			if err != nil {
-				return cachetypes.PRCommits{}, fmt.Errorf(
-					"fetch commit IDs for PR #%d in %s: %w",
+				return cachetypes.PRFiles{}, fmt.Errorf(
+					"fetch files for PR #%d in %s: %w",
 					pullRequest.Number,
 					langCode,
 					err,
 				)
 			}
 
-			return cachetypes.PRCommits{
+			return cachetypes.PRFiles{
 				UpdatedAt: pullRequest.UpdatedAt,
-				CommitIDs: commitIDs,
+				Files:     files,
 			}, nil
 		},
 	)
 	if err != nil {
-		return nil, fmt.Errorf(
-			"load commit IDs for PR #%d in %s: %w",
-			pullRequest.Number,
-			langCode,
-			err,
-		)
-	}
-
-	return commits.CommitIDs, nil
-}
-
-func (p *FilePRIndex) fetchCommitFiles(
-	ctx context.Context,
-	langCode string,
-	commitID string,
-) (*github.CommitFiles, error) {
-	commitFiles, err := proxycache.Get(
-		ctx,
-		p.cacheStorage,
-		CommitFilesCacheBucket(langCode),
-		CommitFilesCacheKey(commitID),
-		nil,
-		func(ctx context.Context) (*github.CommitFiles, error) {
-			log.Printf("[pullreq][%s][commit:%s] fetching files", langCode, commitID)
-
-			files, err := p.gitHub.GetCommitFiles(ctx, commitID)
-			if err != nil {
-				return nil, fmt.Errorf("fetch files for commit %s in %s: %w", commitID, langCode, err)
-			}
-
-			return files, nil
-		},
-	)
-	if err != nil {
-		return nil, fmt.Errorf("load files for commit %s in %s: %w", commitID, langCode, err)
-	}
-
-	return commitFiles, nil
-}
-
-func (p *FilePRIndex) fetchFilesForCommitList(
-	ctx context.Context,
-	langCode string,
-	pullRequest github.PRItem,
-	pullRequestIndex int,
-	pullRequestsCount int,
-	commitIDs []string,
-) ([]string, error) {
-	files := make([]string, 0)
-	commitCount := len(commitIDs)
-
-	for commitIndex, commitID := range commitIDs {
-		log.Printf(
-			"[pullreq][%s][%d/%d][pr:%d][%d/%d] loading files for commit %s",
-			langCode,
-			pullRequestIndex+1,
-			pullRequestsCount,
-			pullRequest.Number,
-			commitIndex+1,
-			commitCount,
-			commitID,
-		)
-
-		commitFiles, err := p.fetchCommitFiles(ctx, langCode, commitID)
-		if err != nil {
-			return nil, fmt.Errorf(
-				"load files for commit %s in PR #%d for %s: %w",
-				commitID,
-				pullRequest.Number,
-				langCode,
-				err,
-			)
-		}
-
-		log.Printf(
-			"[pullreq][%s][%d/%d][pr:%d][%d/%d] commit %s contains %d files",
-			langCode,
-			pullRequestIndex+1,
-			pullRequestsCount,
-			pullRequest.Number,
-			commitIndex+1,
-			commitCount,
-			commitID,
-			len(commitFiles.Files),
-		)
-
-		files = append(files, commitFiles.Files...)
+		return nil, fmt.Errorf("load files for PR #%d in %s: %w", pullRequest.Number, langCode, err)
 	}
 
-	return files, nil
-}
-
-func (p *FilePRIndex) fetchFilesForPR(
-	ctx context.Context,
-	langCode string,
-	pullRequest github.PRItem,
-	pullRequestIndex int,
-	pullRequestsCount int,
-) ([]string, error) {
 	log.Printf(
-		"[pullreq][%s][%d/%d][pr:%d] loading PR details (updatedAt=%s)",
+		"[pullreq][%s][%d/%d][pr:%d] PR changes %d files",
 		langCode,
 		pullRequestIndex+1,
 		pullRequestsCount,
 		pullRequest.Number,
-		pullRequest.UpdatedAt,
+		len(prFiles.Files),
 	)
 
-	commitIDs, err := p.fetchPRCommits(ctx, langCode, pullRequest)
-	if err != nil {
-		return nil, fmt.Errorf(
-			"load commit IDs for PR #%d in %s: %w",
-			pullRequest.Number,
-			langCode,
-			err,
-		)
-	}
+	return prFiles.Files, nil
+}
 
-	log.Printf(
-		"[pullreq][%s][%d/%d][pr:%d] found %d commits",
-		langCode,
-		pullRequestIndex+1,
-		pullRequestsCount,
-		pullRequest.Number,
-		len(commitIDs),
-	)
+// getPRFiles returns the files of the pull request found by the search
+// or fetches them if the search did not return them.
+func (p *FilePRIndex) getPRFiles(ctx context.Context, pullRequest github.PRItem) ([]github.PRFile, error) {
+	if pullRequest.Files != nil {
+		return pullRequest.Files, nil
+	}
 
-	files, err := p.fetchFilesForCommitList(
-		ctx,
-		langCode,
-		pullRequest,
-		pullRequestIndex,
-		pullRequestsCount,
-		commitIDs,
-	)
+	files, err := p.gitHub.GetPRFiles(ctx, pullRequest.Number)
 	if err != nil {
-		return nil, fmt.Errorf("load files for PR #%d in %s: %w", pullRequest.Number, langCode, err)
+		return nil, fmt.Errorf("get PR files: %w", err)
 	}
 
 	return files, nil
@@ -346,8 +384,8 @@ func (p *FilePRIndex) fetchPRFiles(
 	ctx context.Context,
 	langCode string,
 	pullRequests []github.PRItem,
-) (map[int][]string, error) {
-	prsFiles := make(map[int][]string, len(pullRequests))
+) (map[int][]github.PRFile, error) {
+	prsFiles := make(map[int][]github.PRFile, len(pullRequests))
 	pullRequestsCount := len(pullRequests)
 
 	for pullRequestIndex, pullRequest := range pullRequests {
@@ -373,57 +411,150 @@ func (p *FilePRIndex) fetchPRFiles(
 	return prsFiles, nil
 }
 
-func (p *FilePRIndex) filterFilesForLang(files []string, langCode string) []string {
-	filtered := make([]string, 0, len(files))
+// fetchRequestedReviewersForPR returns the requested reviewers of the pull request found
+// by the search. If the search did not return them, they are fetched and cached until
+// the pull request is updated.
+func (p *FilePRIndex) fetchRequestedReviewersForPR(
+	ctx context.Context,
+	langCode string,
+	pullRequest github.PRItem,
+) ([]string, error) {
+	if pullRequest.RequestedReviewers != nil {
+		return pullRequest.RequestedReviewers, nil
+	}
 
-	for _, file := range files {
-		pathInfo, err := p.filePaths.CheckPath(file)
-		if err != nil {
-			log.Printf("[pullreq][%s] skipping file %q: path check failed: %v", langCode, file, err)
+	prRequestedReviewers, err := proxycache.Get(
+		ctx,
+		p.cacheStorage,
+		PRRequestedReviewersCacheBucket(langCode),
+		PRRequestedReviewersCacheKey(pullRequest.Number),
+		func(cachedPRRequestedReviewers cachetypes.PRRequestedReviewers) bool {
+			return cachedPRRequestedReviewers.UpdatedAt != pullRequest.UpdatedAt
+		},
+		func(ctx context.Context) (cachetypes.PRRequestedReviewers, error) {
+			log.Printf("[pullreq][%s][pr:%d] fetching requested reviewers", langCode, pullRequest.Number)
 
-			continue
-		}
+			requestedReviewers, err := p.gitHub.GetPRRequestedReviewers(ctx, pullRequest.Number)
+			if err != nil {
+				return cachetypes.PRRequestedReviewers{}, fmt.Errorf(
+					"fetch requested reviewers for PR #%d in %s: %w",
+					pullRequest.Number,
+					langCode,
+					err,
+				)
+			}
 
-		if pathInfo.LangCode != langCode {
-			continue
+			return cachetypes.PRRequestedReviewers{
+				UpdatedAt:          pullRequest.UpdatedAt,
+				RequestedReviewers: requestedReviewers,
+			}, nil
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("load requested reviewers for PR #%d in %s: %w", pullRequest.Number, langCode, err)
+	}
+
+	return prRequestedReviewers.RequestedReviewers, nil
+}
+
+func (p *FilePRIndex) isLangFile(file string, langCode string) bool {
+	pathInfo, err := p.filePaths.CheckPath(file)
+	if err != nil {
+		log.Printf("[pullreq][%s] skipping file %q: path check failed: %v", langCode, file, err)
+
+		return false
+	}
+
+	return pathInfo.LangCode == langCode
+}
+
+// fileChange is a file changed by a pull request.
+type fileChange struct {
+	path   string
+	status string
+}
+
+// prFileChanges returns the files changed by a pull request.
+// A renamed file is listed under both its previous and its new path.
+func prFileChanges(prFiles []github.PRFile) []fileChange {
+	changes := make([]fileChange, 0, len(prFiles))
+
+	for _, prFile := range prFiles {
+		if prFile.Status == github.PRFileStatusRenamed && prFile.PreviousFilename != "" {
+			changes = append(changes, fileChange{path: prFile.PreviousFilename, status: prFile.Status})
 		}
 
-		filtered = append(filtered, file)
+		changes = append(changes, fileChange{path: prFile.Filename, status: prFile.Status})
 	}
 
-	return filtered
+	return changes
 }
 
-func (p *FilePRIndex) buildFilePRIndex(prsFiles map[int][]string, langCode string) FilePRIndexData {
+func (p *FilePRIndex) buildFilePRIndex(prsFiles map[int][]github.PRFile, langCode string) FilePRIndexData {
 	filePRs := make(FilePRIndexData, len(prsFiles))
 	seen := make(map[string]map[int]struct{}, len(prsFiles))
 
-	for prNumber, files := range prsFiles {
-		langFiles := p.filterFilesForLang(files, langCode)
+	for prNumber, prFiles := range prsFiles {
+		for _, change := range prFileChanges(prFiles) {
+			if !p.isLangFile(change.path, langCode) {
+				continue
+			}
 
-		for _, file := range langFiles {
-			if seen[file] == nil {
-				seen[file] = make(map[int]struct{})
+			if seen[change.path] == nil {
+				seen[change.path] = make(map[int]struct{})
 			}
 
-			if _, exists := seen[file][prNumber]; exists {
+			if _, exists := seen[change.path][prNumber]; exists {
 				continue
 			}
 
-			seen[file][prNumber] = struct{}{}
+			seen[change.path][prNumber] = struct{}{}
 
-			filePRs[file] = append(filePRs[file], prNumber)
+			filePRs[change.path] = append(filePRs[change.path], FilePR{Number: prNumber, Status: change.status})
 		}
 	}
 
-	for file, prs := range filePRs {
-		sort.Sort(sort.Reverse(sort.IntSlice(prs)))
-		filePRs[file] = prs
+	for _, prs := range filePRs {
+		sort.Slice(prs, func(i, j int) bool {
+			return prs[i].Number > prs[j].Number
+		})
 	}
 
 	return filePRs
 }
 
+// buildPRInfoIndex returns the details of the pull requests in the file-to-PR index.
+func (p *FilePRIndex) buildPRInfoIndex(
+	ctx context.Context,
+	langCode string,
+	pullRequests []github.PRItem,
+	filePRs FilePRIndexData,
+) (PRInfoIndexData, error) {
+	indexed := make(map[int]struct{}, len(pullRequests))
+	for _, prs := range filePRs {
+		for _, filePR := range prs {
+			indexed[filePR.Number] = struct{}{}
+		}
+	}
+
+	prInfos := make(PRInfoIndexData, len(indexed))
+
+	for _, pullRequest := range pullRequests {
+		if _, ok := indexed[pullRequest.Number]; !ok {
+			continue
+		}
+
+		requestedReviewers, err := p.fetchRequestedReviewersForPR(ctx, langCode, pullRequest)
+		if err != nil {
+			return nil, err
+		}
+
+		prInfos[pullRequest.Number] = newPRInfo(pullRequest, requestedReviewers)
+	}
+
+	return prInfos, nil
+}
+
 func (p *FilePRIndex) writeLangIndex(langCode string, filePRs FilePRIndexData) error {
 	if err := p.cacheStorage.Write(
 		FilePRsIndexCacheBucket(langCode),
@@ -436,8 +567,20 @@ func (p *FilePRIndex) writeLangIndex(langCode string, filePRs FilePRIndexData) e
 	return nil
 }
 
+func (p *FilePRIndex) writePRInfoIndex(langCode string, prInfos PRInfoIndexData) error {
+	if err := p.cacheStorage.Write(
+		PRInfoIndexCacheBucket(langCode),
+		PRInfoIndexCacheKey(langCode),
+		prInfos,
+	); err != nil {
+		return fmt.Errorf("write PR info index for %s: %w", langCode, err)
+	}
+
+	return nil
+}
+
 // RefreshIndex fetches current PR data and rebuilds the file-to-PR index
-// for the given language.
+// and the details of the indexed pull requests for the given language.
 func (p *FilePRIndex) RefreshIndex(ctx context.Context, langCode string) error {
 	log.Printf("[pullreq][%s] refreshing file PR index", langCode)
 
@@ -461,13 +604,22 @@ func (p *FilePRIndex) RefreshIndex(ctx context.Context, langCode string) error {
 		return fmt.Errorf("store file PR index for %s: %w", langCode, err)
 	}
 
+	prInfos, err := p.buildPRInfoIndex(ctx, langCode, pullRequests, filePRs)
+	if err != nil {
+		return fmt.Errorf("fetch pull request details for %s: %w", langCode, err)
+	}
+
+	if err := p.writePRInfoIndex(langCode, prInfos); err != nil {
+		return fmt.Errorf("store PR info index for %s: %w", langCode, err)
+	}
+
 	log.Printf("[pullreq][%s] file PR index refreshed", langCode)
 
 	return nil
 }
 
-// LangIndex returns a map from file names to a list of pull request indices for
-// the given langCode.
+// LangIndex returns a map from file names to the pull requests changing them
+// for the given langCode.
 func (p *FilePRIndex) LangIndex(langCode string) (FilePRIndexData, error) {
 	bucket := FilePRsIndexCacheBucket(langCode)
 	key := FilePRsIndexCacheKey(langCode)
@@ -485,3 +637,21 @@ func (p *FilePRIndex) LangIndex(langCode string) (FilePRIndexData, error) {
 
 	return filePRs, nil
 }
+
+// LangPRInfos returns the details of the pull requests in the file-to-PR index
+// for the given langCode. It returns an empty index if the details have not been
+// stored yet, because the file-to-PR index was built by an earlier version.
+func (p *FilePRIndex) LangPRInfos(langCode string) (PRInfoIndexData, error) {
+	var prInfos PRInfoIndexData
+
+	exists, err := p.cacheStorage.Read(PRInfoIndexCacheBucket(langCode), PRInfoIndexCacheKey(langCode), &prInfos)
+	if err != nil {
+		return nil, fmt.Errorf("read PR info index for %s: %w", langCode, err)
+	}
+
+	if !exists {
+		return PRInfoIndexData{}, nil
+	}
+
+	return prInfos, nil
+}
@@ -2,6 +2,8 @@
 package pullreq_test
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"reflect"
 	"testing"
@@ -53,15 +55,15 @@ func TestFilePRIndex_LangIndex(t *testing.T) {
 					DoAndReturn(storetests.MockReadReturn(
 						true,
 						pullreq.FilePRIndexData{
-							"/file1": {100, 200},
-							"/file2": {300},
+							"/file1": {{Number: 100}, {Number: 200}},
+							"/file2": {{Number: 300}},
 						},
 						nil,
 					))
 			},
 			expectedLangIndex: pullreq.FilePRIndexData{
-				"/file1": {100, 200},
-				"/file2": {300},
+				"/file1": {{Number: 100}, {Number: 200}},
+				"/file2": {{Number: 300}},
 			},
 			expectedErr: nil,
 		},
@@ -87,263 +89,289 @@ func TestFilePRIndex_LangIndex(t *testing.T) {
 	}
 }
 
-func TestFilePRIndex_RefreshIndex(t *testing.T) {
-	ctx := t.Context()
+func TestFilePRIndex_LangPRInfos(t *testing.T) {
 	langCode := "pl"
 
 	for _, tc := range []struct {
-		name string
-		init func(
-			t *testing.T,
-			gitHubMock *mocks.MockGitHub,
-			cacheStore *mocks.MockCacheStorage,
-		)
+		name            string
+		exists          bool
+		stored          pullreq.PRInfoIndexData
+		expectedPRInfos pullreq.PRInfoIndexData
 	}{
 		{
-			name: "first run",
-			init: func(
-				t *testing.T,
-				gitHubMock *mocks.MockGitHub,
-				cacheStore *mocks.MockCacheStorage,
-			) {
-				t.Helper()
-
-				gitHubMock.EXPECT().
-					PRSearch(
-						ctx,
-						github.PRSearchFilter{
-							LangCode:    langCode,
-							UpdatedFrom: "",
-							OnlyOpen:    true,
-						},
-						github.PageRequest{
-							Sort:    "updated",
-							Order:   "asc",
-							Page:    1,
-							PerPage: 2,
-						},
-					).
-					Return(
-						&github.PRSearchResult{
-							Items: []github.PRItem{
-								{Number: 12, UpdatedAt: "D001"},
-								{Number: 14, UpdatedAt: "D003"},
-							},
-							TotalCount: 3,
-						},
-						nil,
-					).Times(1)
-
-				gitHubMock.EXPECT().
-					PRSearch(
-						ctx,
-						github.PRSearchFilter{
-							LangCode:    langCode,
-							UpdatedFrom: "D003",
-							OnlyOpen:    true,
-						},
-						github.PageRequest{
-							Sort:    "updated",
-							Order:   "asc",
-							Page:    1,
-							PerPage: 2,
-						},
-					).
-					Return(
-						&github.PRSearchResult{
-							Items: []github.PRItem{
-								{Number: 15, UpdatedAt: "D004"},
-							},
-							TotalCount: 3,
-						},
-						nil,
-					).Times(1)
-
-				gitHubMock.EXPECT().
-					PRSearch(
-						ctx,
-						github.PRSearchFilter{
-							LangCode:    langCode,
-							UpdatedFrom: "D004",
-							OnlyOpen:    true,
-						},
-						github.PageRequest{
-							Sort:    "updated",
-							Order:   "asc",
-							Page:    1,
-							PerPage: 2,
-						},
-					).
-					Return(
-						&github.PRSearchResult{
-							Items:      []github.PRItem{},
-							TotalCount: 3,
-						},
-						nil,
-					).Times(1)
+			name:            "returns an empty index if the details have not been stored yet",
+			exists:          false,
+			expectedPRInfos: pullreq.PRInfoIndexData{},
+		},
+		{
+			name:            "returns the stored details",
+			exists:          true,
+			stored:          pullreq.PRInfoIndexData{100: {Number: 100, Title: "[pl] Translate pods", Draft: true}},
+			expectedPRInfos: pullreq.PRInfoIndexData{100: {Number: 100, Title: "[pl] Translate pods", Draft: true}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			gitHubMock := mocks.NewMockGitHub(ctrl)
+			cacheStore := mocks.NewMockCacheStorage(ctrl)
 
-				cacheStore.EXPECT().
-					Read(
-						pullreq.PRCommitsCacheBucket(langCode),
-						pullreq.PRCommitsCacheKey(12),
-						gomock.Any(),
-					).
-					DoAndReturn(storetests.MockReadNotFound())
+			cacheStore.EXPECT().
+				Read(
+					pullreq.PRInfoIndexCacheBucket(langCode),
+					pullreq.PRInfoIndexCacheKey(langCode),
+					gomock.Any(),
+				).
+				DoAndReturn(storetests.MockReadReturn(tc.exists, tc.stored, nil))
 
-				gitHubMock.EXPECT().
-					GetPRCommits(ctx, 12).
-					Return([]string{"C1"}, nil)
+			filePRIndex := pullreq.NewFilePRIndex(gitHubMock, cacheStore, 2)
 
-				cacheStore.EXPECT().
-					Write(
-						pullreq.PRCommitsCacheBucket(langCode),
-						pullreq.PRCommitsCacheKey(12),
-						cachetypes.PRCommits{
-							UpdatedAt: "D001",
-							CommitIDs: []string{"C1"},
-						},
-					).
-					Return(nil)
+			prInfos, err := filePRIndex.LangPRInfos(langCode)
+			if err != nil {
+				t.Fatal(err)
+			}
 
-				cacheStore.EXPECT().
-					Read(
-						pullreq.CommitFilesCacheBucket(langCode),
-						pullreq.CommitFilesCacheKey("C1"),
-						gomock.Any(),
-					).
-					DoAndReturn(storetests.MockReadNotFound())
+			if !reflect.DeepEqual(tc.expectedPRInfos, prInfos) {
+				t.Errorf("unexpected result\nexpected: %v\nactual  : %v", tc.expectedPRInfos, prInfos)
+			}
+		})
+	}
+}
 
-				gitHubMock.EXPECT().
-					GetCommitFiles(ctx, "C1").
-					Return(&github.CommitFiles{Files: []string{"content/pl/F1"}}, nil)
+// expectOpenPRSearch expects a search for open pull requests updated after updatedFrom.
+func expectOpenPRSearch(
+	ctx context.Context,
+	gitHubMock *mocks.MockGitHub,
+	langCode string,
+	updatedFrom string,
+	items ...github.PRItem,
+) {
+	gitHubMock.EXPECT().
+		PRSearch(
+			ctx,
+			github.PRSearchFilter{
+				LangCode:    langCode,
+				UpdatedFrom: updatedFrom,
+				OnlyOpen:    true,
+			},
+			github.PageRequest{
+				Sort:    "updated",
+				Order:   "asc",
+				Page:    1,
+				PerPage: 2,
+			},
+		).
+		Return(
+			&github.PRSearchResult{
+				Items:      append([]github.PRItem{}, items...),
+				TotalCount: len(items),
+			},
+			nil,
+		).Times(1)
+}
 
-				cacheStore.EXPECT().
-					Write(
-						pullreq.CommitFilesCacheBucket(langCode),
-						pullreq.CommitFilesCacheKey("C1"),
-						gomock.Any(),
-					).
-					Return(nil)
+func modifiedFiles(paths ...string) []github.PRFile {
+	files := make([]github.PRFile, 0, len(paths))
+	for _, path := range paths {
+		files = append(files, github.PRFile{Filename: path, Status: github.PRFileStatusModified})
+	}
 
-				cacheStore.EXPECT().
-					Read(
-						pullreq.PRCommitsCacheBucket(langCode),
-						pullreq.PRCommitsCacheKey(14),
-						gomock.Any(),
-					).
-					DoAndReturn(storetests.MockReadNotFound())
+	return files
+}
 
-				gitHubMock.EXPECT().
-					GetPRCommits(ctx, 14).
-					Return([]string{"C2", "C3"}, nil)
+func expectPRInfoIndexWrite(cacheStore *mocks.MockCacheStorage, langCode string, prInfos pullreq.PRInfoIndexData) {
+	cacheStore.EXPECT().
+		Write(
+			pullreq.PRInfoIndexCacheBucket(langCode),
+			pullreq.PRInfoIndexCacheKey(langCode),
+			prInfos,
+		).
+		Return(nil)
+}
 
-				cacheStore.EXPECT().
-					Write(
-						pullreq.PRCommitsCacheBucket(langCode),
-						pullreq.PRCommitsCacheKey(14),
-						cachetypes.PRCommits{
-							UpdatedAt: "D003",
-							CommitIDs: []string{"C2", "C3"},
-						},
-					).
-					Return(nil)
+// expectRequestedReviewersFetch expects the requested reviewers of a pull request
+// to be fetched and cached.
+func expectRequestedReviewersFetch(
+	ctx context.Context,
+	gitHubMock *mocks.MockGitHub,
+	cacheStore *mocks.MockCacheStorage,
+	langCode string,
+	pr github.PRItem,
+	requestedReviewers []string,
+) {
+	cacheStore.EXPECT().
+		Read(
+			pullreq.PRRequestedReviewersCacheBucket(langCode),
+			pullreq.PRRequestedReviewersCacheKey(pr.Number),
+			gomock.Any(),
+		).
+		DoAndReturn(storetests.MockReadNotFound())
+
+	gitHubMock.EXPECT().
+		GetPRRequestedReviewers(ctx, pr.Number).
+		Return(requestedReviewers, nil)
+
+	cacheStore.EXPECT().
+		Write(
+			pullreq.PRRequestedReviewersCacheBucket(langCode),
+			pullreq.PRRequestedReviewersCacheKey(pr.Number),
+			cachetypes.PRRequestedReviewers{
+				UpdatedAt:          pr.UpdatedAt,
+				RequestedReviewers: requestedReviewers,
+			},
+		).
+		Return(nil)
+}
 
-				cacheStore.EXPECT().
-					Read(
-						pullreq.CommitFilesCacheBucket(langCode),
-						pullreq.CommitFilesCacheKey("C2"),
-						gomock.Any(),
-					).
-					DoAndReturn(storetests.MockReadNotFound())
+// expectRequestedReviewersCached expects the requested reviewers of a pull request
+// to be read from the cache.
+func expectRequestedReviewersCached(
+	cacheStore *mocks.MockCacheStorage,
+	langCode string,
+	pr github.PRItem,
+	requestedReviewers []string,
+) {
+	cacheStore.EXPECT().
+		Read(
+			pullreq.PRRequestedReviewersCacheBucket(langCode),
+			pullreq.PRRequestedReviewersCacheKey(pr.Number),
+			gomock.Any(),
+		).
+		DoAndReturn(storetests.MockReadReturn(
+			true,
+			cachetypes.PRRequestedReviewers{
+				UpdatedAt:          pr.UpdatedAt,
+				RequestedReviewers: requestedReviewers,
+			},
+			nil,
+		))
+}
 
-				gitHubMock.EXPECT().
-					GetCommitFiles(ctx, "C2").
-					Return(&github.CommitFiles{Files: []string{"content/pl/F2", "content/pl/F3"}}, nil)
+// modifiedIn returns the pull requests modifying a file.
+func modifiedIn(prNumbers ...int) []pullreq.FilePR {
+	filePRs := make([]pullreq.FilePR, 0, len(prNumbers))
+	for _, prNumber := range prNumbers {
+		filePRs = append(filePRs, pullreq.FilePR{Number: prNumber, Status: github.PRFileStatusModified})
+	}
 
-				cacheStore.EXPECT().
-					Write(
-						pullreq.CommitFilesCacheBucket(langCode),
-						pullreq.CommitFilesCacheKey("C2"),
-						gomock.Any(),
-					).
-					Return(nil)
+	return filePRs
+}
 
-				cacheStore.EXPECT().
-					Read(
-						pullreq.CommitFilesCacheBucket(langCode),
-						pullreq.CommitFilesCacheKey("C3"),
-						gomock.Any(),
-					).
-					DoAndReturn(storetests.MockReadNotFound())
+// prInfo returns the details of a pull request found with only its number and update date.
+func prInfo(number int, updatedAt string, requestedReviewers ...string) pullreq.PRInfo {
+	return pullreq.PRInfo{
+		Number:             number,
+		UpdatedAt:          updatedAt,
+		Labels:             []string{},
+		RequestedReviewers: requestedReviewers,
+	}
+}
 
-				gitHubMock.EXPECT().
-					GetCommitFiles(ctx, "C3").
-					Return(&github.CommitFiles{Files: []string{"content/pl/F1", "content/pl/F4"}}, nil)
+func TestFilePRIndexData_UnmarshalJSON(t *testing.T) {
+	var got pullreq.FilePRIndexData
 
-				cacheStore.EXPECT().
-					Write(
-						pullreq.CommitFilesCacheBucket(langCode),
-						pullreq.CommitFilesCacheKey("C3"),
-						gomock.Any(),
-					).
-					Return(nil)
+	// the index built by earlier versions holds only the numbers of the pull requests
+	data := `{"content/pl/a.md": [12, {"Number": 14, "Status": "removed"}]}`
+	if err := json.Unmarshal([]byte(data), &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
 
-				cacheStore.EXPECT().
-					Read(
-						pullreq.PRCommitsCacheBucket(langCode),
-						pullreq.PRCommitsCacheKey(15),
-						gomock.Any(),
-					).
-					DoAndReturn(storetests.MockReadNotFound())
+	want := pullreq.FilePRIndexData{
+		"content/pl/a.md": {{Number: 12}, {Number: 14, Status: github.PRFileStatusRemoved}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected index\nactual:   %#v\nexpected: %#v", got, want)
+	}
 
-				gitHubMock.EXPECT().
-					GetPRCommits(ctx, 15).
-					Return([]string{"C4"}, nil)
+	if prNumbers := got.PRNumbers("content/pl/a.md"); !reflect.DeepEqual(prNumbers, []int{12, 14}) {
+		t.Fatalf("unexpected PR numbers: %v", prNumbers)
+	}
+}
 
-				cacheStore.EXPECT().
-					Write(
-						pullreq.PRCommitsCacheBucket(langCode),
-						pullreq.PRCommitsCacheKey(15),
-						cachetypes.PRCommits{
-							UpdatedAt: "D004",
-							CommitIDs: []string{"C4"},
-						},
-					).
-					Return(nil)
+func TestFilePRIndex_RefreshIndex(t *testing.T) {
+	ctx := t.Context()
+	langCode := "pl"
 
-				cacheStore.EXPECT().
-					Read(
-						pullreq.CommitFilesCacheBucket(langCode),
-						pullreq.CommitFilesCacheKey("C4"),
-						gomock.Any(),
-					).
-					DoAndReturn(storetests.MockReadNotFound())
+	for _, tc := range []struct {
+		name string
+		init func(
+			t *testing.T,
+			gitHubMock *mocks.MockGitHub,
+			cacheStore *mocks.MockCacheStorage,
+		)
+	}{
+		{
+			name: "first run",
+			init: func(
+				t *testing.T,
+				gitHubMock *mocks.MockGitHub,
+				cacheStore *mocks.MockCacheStorage,
+			) {
+				t.Helper()
 
-				gitHubMock.EXPECT().
-					GetCommitFiles(ctx, "C4").
-					Return(&github.CommitFiles{Files: []string{"content/pl/F5"}}, nil)
+				expectOpenPRSearch(ctx, gitHubMock, langCode, "",
+					github.PRItem{Number: 12, UpdatedAt: "D001"},
+					github.PRItem{Number: 14, UpdatedAt: "D003"},
+				)
+				expectOpenPRSearch(ctx, gitHubMock, langCode, "D003",
+					github.PRItem{Number: 15, UpdatedAt: "D004"},
+				)
+				expectOpenPRSearch(ctx, gitHubMock, langCode, "D004")
+
+				for _, pr := range []struct {
+					number    int
+					updatedAt string
+					files     []github.PRFile
+				}{
+					{number: 12, updatedAt: "D001", files: modifiedFiles("content/pl/F1")},
+					{number: 14, updatedAt: "D003", files: modifiedFiles("content/pl/F1", "content/pl/F2", "content/pl/F3", "content/pl/F4")},
+					{number: 15, updatedAt: "D004", files: modifiedFiles("content/pl/F5")},
+				} {
+					cacheStore.EXPECT().
+						Read(
+							pullreq.PRFilesCacheBucket(langCode),
+							pullreq.PRFilesCacheKey(pr.number),
+							gomock.Any(),
+						).
+						DoAndReturn(storetests.MockReadNotFound())
+
+					gitHubMock.EXPECT().
+						GetPRFiles(ctx, pr.number).
+						Return(pr.files, nil)
+
+					cacheStore.EXPECT().
+						Write(
+							pullreq.PRFilesCacheBucket(langCode),
+							pullreq.PRFilesCacheKey(pr.number),
+							cachetypes.PRFiles{
+								UpdatedAt: pr.updatedAt,
+								Files:     pr.files,
+							},
+						).
+						Return(nil)
 
-				cacheStore.EXPECT().
-					Write(
-						pullreq.CommitFilesCacheBucket(langCode),
-						pullreq.CommitFilesCacheKey("C4"),
-						gomock.Any(),
-					).
-					Return(nil)
+					expectRequestedReviewersFetch(ctx, gitHubMock, cacheStore, langCode,
+						github.PRItem{Number: pr.number, UpdatedAt: pr.updatedAt}, []string{"reviewer"})
+				}
 
 				cacheStore.EXPECT().
 					Write(
 						pullreq.FilePRsIndexCacheBucket(langCode),
 						pullreq.FilePRsIndexCacheKey(langCode),
 						pullreq.FilePRIndexData{
-							"content/pl/F1": {14, 12},
-							"content/pl/F2": {14},
-							"content/pl/F3": {14},
-							"content/pl/F4": {14},
-							"content/pl/F5": {15},
+							"content/pl/F1": modifiedIn(14, 12),
+							"content/pl/F2": modifiedIn(14),
+							"content/pl/F3": modifiedIn(14),
+							"content/pl/F4": modifiedIn(14),
+							"content/pl/F5": modifiedIn(15),
 						},
 					).
 					Return(nil)
+
+				expectPRInfoIndexWrite(cacheStore, langCode, pullreq.PRInfoIndexData{
+					12: prInfo(12, "D001", "reviewer"),
+					14: prInfo(14, "D003", "reviewer"),
+					15: prInfo(15, "D004", "reviewer"),
+				})
 			},
 		},
 		{
@@ -355,198 +383,60 @@ func TestFilePRIndex_RefreshIndex(t *testing.T) {
 			) {
 				t.Helper()
 
-				gitHubMock.EXPECT().
-					PRSearch(
-						ctx,
-						github.PRSearchFilter{
-							LangCode:    langCode,
-							UpdatedFrom: "",
-							OnlyOpen:    true,
-						},
-						github.PageRequest{
-							Sort:    "updated",
-							Order:   "asc",
-							Page:    1,
-							PerPage: 2,
-						},
-					).
-					Return(
-						&github.PRSearchResult{
-							Items: []github.PRItem{
-								{Number: 12, UpdatedAt: "D001"},
-								{Number: 14, UpdatedAt: "D003"},
+				expectOpenPRSearch(ctx, gitHubMock, langCode, "",
+					github.PRItem{Number: 12, UpdatedAt: "D001"},
+					github.PRItem{Number: 14, UpdatedAt: "D003"},
+				)
+				expectOpenPRSearch(ctx, gitHubMock, langCode, "D003",
+					github.PRItem{Number: 15, UpdatedAt: "D004"},
+				)
+				expectOpenPRSearch(ctx, gitHubMock, langCode, "D004")
+
+				for _, pr := range []struct {
+					number    int
+					updatedAt string
+					files     []github.PRFile
+				}{
+					{number: 12, updatedAt: "D001", files: modifiedFiles("content/pl/F1")},
+					{number: 14, updatedAt: "D003", files: modifiedFiles("content/pl/F1", "content/pl/F2")},
+					{number: 15, updatedAt: "D004", files: modifiedFiles("content/pl/F5")},
+				} {
+					cacheStore.EXPECT().
+						Read(
+							pullreq.PRFilesCacheBucket(langCode),
+							pullreq.PRFilesCacheKey(pr.number),
+							gomock.Any(),
+						).
+						DoAndReturn(storetests.MockReadReturn(
+							true,
+							cachetypes.PRFiles{
+								UpdatedAt: pr.updatedAt,
+								Files:     pr.files,
 							},
-							TotalCount: 3,
-						},
-						nil,
-					).Times(1)
+							nil,
+						))
 
-				gitHubMock.EXPECT().
-					PRSearch(
-						ctx,
-						github.PRSearchFilter{
-							LangCode:    langCode,
-							UpdatedFrom: "D003",
-							OnlyOpen:    true,
-						},
-						github.PageRequest{
-							Sort:    "updated",
-							Order:   "asc",
-							Page:    1,
-							PerPage: 2,
-						},
-					).
-					Return(
-						&github.PRSearchResult{
-							Items: []github.PRItem{
-								{Number: 15, UpdatedAt: "D004"},
-							},
-							TotalCount: 3,
-						},
-						nil,
-					).Times(1)
-
-				gitHubMock.EXPECT().
-					PRSearch(
-						ctx,
-						github.PRSearchFilter{
-							LangCode:    langCode,
-							UpdatedFrom: "D004",
-							OnlyOpen:    true,
-						},
-						github.PageRequest{
-							Sort:    "updated",
-							Order:   "asc",
-							Page:    1,
-							PerPage: 2,
-						},
-					).
-					Return(
-						&github.PRSearchResult{
-							Items:      []github.PRItem{},
-							TotalCount: 3,
-						},
-						nil,
-					).Times(1)
-
-				cacheStore.EXPECT().
-					Read(
-						pullreq.PRCommitsCacheBucket(langCode),
-						pullreq.PRCommitsCacheKey(12),
-						gomock.Any(),
-					).
-					DoAndReturn(storetests.MockReadReturn(
-						true,
-						cachetypes.PRCommits{
-							UpdatedAt: "D001",
-							CommitIDs: []string{"C1"},
-						},
-						nil,
-					))
-
-				cacheStore.EXPECT().
-					Read(
-						pullreq.CommitFilesCacheBucket(langCode),
-						pullreq.CommitFilesCacheKey("C1"),
-						gomock.Any(),
-					).
-					DoAndReturn(storetests.MockReadReturn(
-						true,
-						&github.CommitFiles{
-							CommitID: "C1",
-							Files:    []string{"content/pl/F1"},
-						},
-						nil,
-					))
-
-				cacheStore.EXPECT().
-					Read(
-						pullreq.PRCommitsCacheBucket(langCode),
-						pullreq.PRCommitsCacheKey(14),
-						gomock.Any(),
-					).
-					DoAndReturn(storetests.MockReadReturn(
-						true,
-						cachetypes.PRCommits{
-							UpdatedAt: "D003",
-							CommitIDs: []string{"C2", "C3"},
-						},
-						nil,
-					))
-
-				cacheStore.EXPECT().
-					Read(
-						pullreq.CommitFilesCacheBucket(langCode),
-						pullreq.CommitFilesCacheKey("C2"),
-						gomock.Any(),
-					).
-					DoAndReturn(storetests.MockReadReturn(
-						true,
-						&github.CommitFiles{
-							CommitID: "C2",
-							Files:    []string{"content/pl/F2", "content/pl/F3"},
-						},
-						nil,
-					))
-
-				cacheStore.EXPECT().
-					Read(
-						pullreq.CommitFilesCacheBucket(langCode),
-						pullreq.CommitFilesCacheKey("C3"),
-						gomock.Any(),
-					).
-					DoAndReturn(storetests.MockReadReturn(
-						true,
-						&github.CommitFiles{
-							CommitID: "C3",
-							Files:    []string{"content/pl/F1", "content/pl/F4"},
-						},
-						nil,
-					))
-
-				cacheStore.EXPECT().
-					Read(
-						pullreq.PRCommitsCacheBucket(langCode),
-						pullreq.PRCommitsCacheKey(15),
-						gomock.Any(),
-					).
-					DoAndReturn(storetests.MockReadReturn(
-						true,
-						cachetypes.PRCommits{
-							UpdatedAt: "D004",
-							CommitIDs: []string{"C4"},
-						},
-						nil,
-					))
-
-				cacheStore.EXPECT().
-					Read(
-						pullreq.CommitFilesCacheBucket(langCode),
-						pullreq.CommitFilesCacheKey("C4"),
-						gomock.Any(),
-					).
-					DoAndReturn(storetests.MockReadReturn(
-						true,
-						&github.CommitFiles{
-							CommitID: "C4",
-							Files:    []string{"content/pl/F5"},
-						},
-						nil,
-					))
+					expectRequestedReviewersCached(cacheStore, langCode,
+						github.PRItem{Number: pr.number, UpdatedAt: pr.updatedAt}, nil)
+				}
 
 				cacheStore.EXPECT().
 					Write(
 						pullreq.FilePRsIndexCacheBucket(langCode),
 						pullreq.FilePRsIndexCacheKey(langCode),
 						pullreq.FilePRIndexData{
-							"content/pl/F1": {14, 12},
-							"content/pl/F2": {14},
-							"content/pl/F3": {14},
-							"content/pl/F4": {14},
-							"content/pl/F5": {15},
+							"content/pl/F1": modifiedIn(14, 12),
+							"content/pl/F2": modifiedIn(14),
+							"content/pl/F5": modifiedIn(15),
 						},
 					).
 					Return(nil)
+
+				expectPRInfoIndexWrite(cacheStore, langCode, pullreq.PRInfoIndexData{
+					12: prInfo(12, "D001"),
+					14: prInfo(14, "D003"),
+					15: prInfo(15, "D004"),
+				})
 			},
 		},
 		{
@@ -558,201 +448,118 @@ func TestFilePRIndex_RefreshIndex(t *testing.T) {
 			) {
 				t.Helper()
 
-				gitHubMock.EXPECT().
-					PRSearch(
-						ctx,
-						github.PRSearchFilter{
-							LangCode:    langCode,
-							UpdatedFrom: "",
-							OnlyOpen:    true,
-						},
-						github.PageRequest{
-							Sort:    "updated",
-							Order:   "asc",
-							Page:    1,
-							PerPage: 2,
-						},
-					).
-					Return(
-						&github.PRSearchResult{
-							Items: []github.PRItem{
-								{Number: 12, UpdatedAt: "D001"},
-								{Number: 14, UpdatedAt: "D003"},
-							},
-							TotalCount: 3,
-						},
-						nil,
-					).Times(1)
-
-				gitHubMock.EXPECT().
-					PRSearch(
-						ctx,
-						github.PRSearchFilter{
-							LangCode:    langCode,
-							UpdatedFrom: "D003",
-							OnlyOpen:    true,
-						},
-						github.PageRequest{
-							Sort:    "updated",
-							Order:   "asc",
-							Page:    1,
-							PerPage: 2,
-						},
-					).
-					Return(
-						&github.PRSearchResult{
-							Items: []github.PRItem{
-								{Number: 15, UpdatedAt: "D005"},
-							},
-							TotalCount: 3,
-						},
-						nil,
-					).Times(1)
-
-				gitHubMock.EXPECT().
-					PRSearch(
-						ctx,
-						github.PRSearchFilter{
-							LangCode:    langCode,
-							UpdatedFrom: "D005",
-							OnlyOpen:    true,
-						},
-						github.PageRequest{
-							Sort:    "updated",
-							Order:   "asc",
-							Page:    1,
-							PerPage: 2,
-						},
-					).
-					Return(
-						&github.PRSearchResult{
-							Items:      []github.PRItem{},
-							TotalCount: 3,
-						},
-						nil,
-					).Times(1)
+				expectOpenPRSearch(ctx, gitHubMock, langCode, "",
+					github.PRItem{Number: 12, UpdatedAt: "D001"},
+				)
+				expectOpenPRSearch(ctx, gitHubMock, langCode, "D001",
+					github.PRItem{Number: 15, UpdatedAt: "D005"},
+				)
+				expectOpenPRSearch(ctx, gitHubMock, langCode, "D005")
 
 				cacheStore.EXPECT().
 					Read(
-						pullreq.PRCommitsCacheBucket(langCode),
-						pullreq.PRCommitsCacheKey(12),
+						pullreq.PRFilesCacheBucket(langCode),
+						pullreq.PRFilesCacheKey(12),
 						gomock.Any(),
 					).
 					DoAndReturn(storetests.MockReadReturn(
 						true,
-						cachetypes.PRCommits{
+						cachetypes.PRFiles{
 							UpdatedAt: "D001",
-							CommitIDs: []string{"C1"},
+							Files:     modifiedFiles("content/pl/F1"),
 						},
 						nil,
 					))
 
 				cacheStore.EXPECT().
 					Read(
-						pullreq.CommitFilesCacheBucket(langCode),
-						pullreq.CommitFilesCacheKey("C1"),
+						pullreq.PRFilesCacheBucket(langCode),
+						pullreq.PRFilesCacheKey(15),
 						gomock.Any(),
 					).
 					DoAndReturn(storetests.MockReadReturn(
 						true,
-						&github.CommitFiles{
-							CommitID: "C1",
-							Files:    []string{"content/pl/F1"},
-						},
-						nil,
-					))
-
-				cacheStore.EXPECT().
-					Read(
-						pullreq.PRCommitsCacheBucket(langCode),
-						pullreq.PRCommitsCacheKey(14),
-						gomock.Any(),
-					).
-					DoAndReturn(storetests.MockReadReturn(
-						true,
-						cachetypes.PRCommits{
-							UpdatedAt: "D003",
-							CommitIDs: []string{"C2", "C3"},
+						cachetypes.PRFiles{
+							UpdatedAt: "D004",
+							Files:     modifiedFiles("content/pl/F5"),
 						},
 						nil,
 					))
 
-				cacheStore.EXPECT().
-					Read(
-						pullreq.CommitFilesCacheBucket(langCode),
-						pullreq.CommitFilesCacheKey("C2"),
-						gomock.Any(),
-					).
-					DoAndReturn(storetests.MockReadReturn(
-						true,
-						&github.CommitFiles{
-							CommitID: "C2",
-							Files:    []string{"content/pl/F2", "content/pl/F3"},
-						},
-						nil,
-					))
+				gitHubMock.EXPECT().
+					GetPRFiles(ctx, 15).
+					Return(modifiedFiles("content/pl/F5", "content/pl/F6"), nil)
 
 				cacheStore.EXPECT().
-					Read(
-						pullreq.CommitFilesCacheBucket(langCode),
-						pullreq.CommitFilesCacheKey("C3"),
-						gomock.Any(),
-					).
-					DoAndReturn(storetests.MockReadReturn(
-						true,
-						&github.CommitFiles{
-							CommitID: "C3",
-							Files:    []string{"content/pl/F1", "content/pl/F4"},
+					Write(
+						pullreq.PRFilesCacheBucket(langCode),
+						pullreq.PRFilesCacheKey(15),
+						cachetypes.PRFiles{
+							UpdatedAt: "D005",
+							Files:     modifiedFiles("content/pl/F5", "content/pl/F6"),
 						},
-						nil,
-					))
-
-				cacheStore.EXPECT().
-					Read(
-						pullreq.PRCommitsCacheBucket(langCode),
-						pullreq.PRCommitsCacheKey(15),
-						gomock.Any(),
 					).
-					DoAndReturn(storetests.MockReadReturn(
-						true,
-						cachetypes.PRCommits{
-							UpdatedAt: "D004",
-							CommitIDs: []string{"C4"},
-						},
-						nil,
-					))
-
-				gitHubMock.EXPECT().
-					GetPRCommits(ctx, 15).
-					Return([]string{"C5"}, nil)
+					Return(nil)
 
 				cacheStore.EXPECT().
 					Write(
-						pullreq.PRCommitsCacheBucket(langCode),
-						pullreq.PRCommitsCacheKey(15),
-						cachetypes.PRCommits{
-							UpdatedAt: "D005",
-							CommitIDs: []string{"C5"},
+						pullreq.FilePRsIndexCacheBucket(langCode),
+						pullreq.FilePRsIndexCacheKey(langCode),
+						pullreq.FilePRIndexData{
+							"content/pl/F1": modifiedIn(12),
+							"content/pl/F5": modifiedIn(15),
+							"content/pl/F6": modifiedIn(15),
 						},
 					).
 					Return(nil)
 
+				expectRequestedReviewersCached(cacheStore, langCode, github.PRItem{Number: 12, UpdatedAt: "D001"}, nil)
+				expectRequestedReviewersFetch(ctx, gitHubMock, cacheStore, langCode,
+					github.PRItem{Number: 15, UpdatedAt: "D005"}, nil)
+
+				expectPRInfoIndexWrite(cacheStore, langCode, pullreq.PRInfoIndexData{
+					12: prInfo(12, "D001"),
+					15: prInfo(15, "D005"),
+				})
+			},
+		},
+		{
+			name: "filters out files outside content/<langCode>",
+			init: func(
+				t *testing.T,
+				gitHubMock *mocks.MockGitHub,
+				cacheStore *mocks.MockCacheStorage,
+			) {
+				t.Helper()
+
+				expectOpenPRSearch(ctx, gitHubMock, langCode, "",
+					github.PRItem{Number: 12, UpdatedAt: "D001"},
+				)
+				expectOpenPRSearch(ctx, gitHubMock, langCode, "D001")
+
 				cacheStore.EXPECT().
 					Read(
-						pullreq.CommitFilesCacheBucket(langCode),
-						pullreq.CommitFilesCacheKey("C5"),
+						pullreq.PRFilesCacheBucket(langCode),
+						pullreq.PRFilesCacheKey(12),
 						gomock.Any(),
 					).
 					DoAndReturn(storetests.MockReadNotFound())
 
+				files := modifiedFiles(
+					"content/pl/OK.md",
+					"content/en/SHOULD_IGNORE.md",
+					"README.md",
+					"content/pl/sub/file.txt",
+				)
+
 				gitHubMock.EXPECT().
-					GetCommitFiles(ctx, "C5").
-					Return(&github.CommitFiles{Files: []string{"content/pl/F5", "content/pl/F6"}}, nil)
+					GetPRFiles(ctx, 12).
+					Return(files, nil)
 
 				cacheStore.EXPECT().
 					Write(
-						pullreq.CommitFilesCacheBucket(langCode),
-						pullreq.CommitFilesCacheKey("C5"),
+						pullreq.PRFilesCacheBucket(langCode),
+						pullreq.PRFilesCacheKey(12),
 						gomock.Any(),
 					).
 					Return(nil)
@@ -762,19 +569,20 @@ func TestFilePRIndex_RefreshIndex(t *testing.T) {
 						pullreq.FilePRsIndexCacheBucket(langCode),
 						pullreq.FilePRsIndexCacheKey(langCode),
 						pullreq.FilePRIndexData{
-							"content/pl/F1": {14, 12},
-							"content/pl/F2": {14},
-							"content/pl/F3": {14},
-							"content/pl/F4": {14},
-							"content/pl/F5": {15},
-							"content/pl/F6": {15},
+							"content/pl/OK.md":        modifiedIn(12),
+							"content/pl/sub/file.txt": modifiedIn(12),
 						},
 					).
 					Return(nil)
+
+				expectRequestedReviewersFetch(ctx, gitHubMock, cacheStore, langCode,
+					github.PRItem{Number: 12, UpdatedAt: "D001"}, nil)
+
+				expectPRInfoIndexWrite(cacheStore, langCode, pullreq.PRInfoIndexData{12: prInfo(12, "D001")})
 			},
 		},
 		{
-			name: "filters out files outside content/<langCode>",
+			name: "uses the files and the requested reviewers returned by the search",
 			init: func(
 				t *testing.T,
 				gitHubMock *mocks.MockGitHub,
@@ -782,103 +590,84 @@ func TestFilePRIndex_RefreshIndex(t *testing.T) {
 			) {
 				t.Helper()
 
-				gitHubMock.EXPECT().
-					PRSearch(
-						ctx,
-						github.PRSearchFilter{
-							LangCode:    langCode,
-							UpdatedFrom: "",
-							OnlyOpen:    true,
-						},
-						github.PageRequest{
-							Sort:    "updated",
-							Order:   "asc",
-							Page:    1,
-							PerPage: 2,
-						},
-					).
-					Return(
-						&github.PRSearchResult{
-							Items: []github.PRItem{
-								{Number: 12, UpdatedAt: "D001"},
-							},
-							TotalCount: 1,
-						},
-						nil,
-					).Times(1)
-
-				gitHubMock.EXPECT().
-					PRSearch(
-						ctx,
-						github.PRSearchFilter{
-							LangCode:    langCode,
-							UpdatedFrom: "D001",
-							OnlyOpen:    true,
-						},
-						github.PageRequest{
-							Sort:    "updated",
-							Order:   "asc",
-							Page:    1,
-							PerPage: 2,
-						},
-					).
-					Return(
-						&github.PRSearchResult{
-							Items:      []github.PRItem{},
-							TotalCount: 1,
-						},
-						nil,
-					).Times(1)
+				expectOpenPRSearch(ctx, gitHubMock, langCode, "",
+					github.PRItem{
+						Number:             12,
+						UpdatedAt:          "D001",
+						Files:              modifiedFiles("content/pl/F1"),
+						RequestedReviewers: []string{"reviewer"},
+					},
+				)
+				expectOpenPRSearch(ctx, gitHubMock, langCode, "D001")
 
 				cacheStore.EXPECT().
 					Read(
-						pullreq.PRCommitsCacheBucket(langCode),
-						pullreq.PRCommitsCacheKey(12),
+						pullreq.PRFilesCacheBucket(langCode),
+						pullreq.PRFilesCacheKey(12),
 						gomock.Any(),
 					).
 					DoAndReturn(storetests.MockReadNotFound())
 
-				gitHubMock.EXPECT().
-					GetPRCommits(ctx, 12).
-					Return([]string{"C1"}, nil)
-
 				cacheStore.EXPECT().
 					Write(
-						pullreq.PRCommitsCacheBucket(langCode),
-						pullreq.PRCommitsCacheKey(12),
-						cachetypes.PRCommits{
+						pullreq.PRFilesCacheBucket(langCode),
+						pullreq.PRFilesCacheKey(12),
+						cachetypes.PRFiles{
 							UpdatedAt: "D001",
-							CommitIDs: []string{"C1"},
+							Files:     modifiedFiles("content/pl/F1"),
 						},
 					).
 					Return(nil)
 
+				cacheStore.EXPECT().
+					Write(
+						pullreq.FilePRsIndexCacheBucket(langCode),
+						pullreq.FilePRsIndexCacheKey(langCode),
+						pullreq.FilePRIndexData{"content/pl/F1": modifiedIn(12)},
+					).
+					Return(nil)
+
+				expectPRInfoIndexWrite(cacheStore, langCode, pullreq.PRInfoIndexData{12: prInfo(12, "D001", "reviewer")})
+			},
+		},
+		{
+			name: "indexes renamed files under both paths and removed files",
+			init: func(
+				t *testing.T,
+				gitHubMock *mocks.MockGitHub,
+				cacheStore *mocks.MockCacheStorage,
+			) {
+				t.Helper()
+
+				expectOpenPRSearch(ctx, gitHubMock, langCode, "",
+					github.PRItem{Number: 12, UpdatedAt: "D001"},
+				)
+				expectOpenPRSearch(ctx, gitHubMock, langCode, "D001")
+
 				cacheStore.EXPECT().
 					Read(
-						pullreq.CommitFilesCacheBucket(langCode),
-						pullreq.CommitFilesCacheKey("C1"),
+						pullreq.PRFilesCacheBucket(langCode),
+						pullreq.PRFilesCacheKey(12),
 						gomock.Any(),
 					).
 					DoAndReturn(storetests.MockReadNotFound())
 
 				gitHubMock.EXPECT().
-					GetCommitFiles(ctx, "C1").
-					Return(
-						&github.CommitFiles{
-							Files: []string{
-								"content/pl/OK.md",
-								"content/en/SHOULD_IGNORE.md",
-								"README.md",
-								"content/pl/sub/file.txt",
-							},
+					GetPRFiles(ctx, 12).
+					Return([]github.PRFile{
+						{
+							Filename:         "content/pl/new.md",
+							Status:           github.PRFileStatusRenamed,
+							PreviousFilename: "content/pl/old.md",
 						},
-						nil,
-					)
+						{Filename: "content/pl/gone.md", Status: github.PRFileStatusRemoved},
+						{Filename: "content/pl/added.md", Status: github.PRFileStatusAdded},
+					}, nil)
 
 				cacheStore.EXPECT().
 					Write(
-						pullreq.CommitFilesCacheBucket(langCode),
-						pullreq.CommitFilesCacheKey("C1"),
+						pullreq.PRFilesCacheBucket(langCode),
+						pullreq.PRFilesCacheKey(12),
 						gomock.Any(),
 					).
 					Return(nil)
@@ -888,11 +677,100 @@ func TestFilePRIndex_RefreshIndex(t *testing.T) {
 						pullreq.FilePRsIndexCacheBucket(langCode),
 						pullreq.FilePRsIndexCacheKey(langCode),
 						pullreq.FilePRIndexData{
-							"content/pl/OK.md":        {12},
-							"content/pl/sub/file.txt": {12},
+							"content/pl/old.md":   {{Number: 12, Status: github.PRFileStatusRenamed}},
+							"content/pl/new.md":   {{Number: 12, Status: github.PRFileStatusRenamed}},
+							"content/pl/gone.md":  {{Number: 12, Status: github.PRFileStatusRemoved}},
+							"content/pl/added.md": {{Number: 12, Status: github.PRFileStatusAdded}},
 						},
 					).
 					Return(nil)
+
+				expectRequestedReviewersFetch(ctx, gitHubMock, cacheStore, langCode,
+					github.PRItem{Number: 12, UpdatedAt: "D001"}, nil)
+
+				expectPRInfoIndexWrite(cacheStore, langCode, pullreq.PRInfoIndexData{12: prInfo(12, "D001")})
+			},
+		},
+		{
+			name: "keeps the details of the indexed pull requests",
+			init: func(
+				t *testing.T,
+				gitHubMock *mocks.MockGitHub,
+				cacheStore *mocks.MockCacheStorage,
+			) {
+				t.Helper()
+
+				expectOpenPRSearch(ctx, gitHubMock, langCode, "",
+					github.PRItem{
+						Number:    12,
+						Title:     "[pl] Translate pods",
+						User:      github.User{Login: "author"},
+						CreatedAt: "D000",
+						UpdatedAt: "D001",
+						Labels: []github.Label{
+							{Name: "language/pl"},
+							{Name: pullreq.LabelLGTM},
+							{Name: pullreq.LabelApproved},
+						},
+					},
+					github.PRItem{
+						Number:         14,
+						Title:          "[pl] Fix the EN link",
+						Draft:          true,
+						UpdatedAt:      "D002",
+						ReviewDecision: github.ReviewDecisionChangesRequested,
+					},
+				)
+				expectOpenPRSearch(ctx, gitHubMock, langCode, "D002")
+
+				for _, pr := range []struct {
+					number    int
+					updatedAt string
+					files     []github.PRFile
+				}{
+					{number: 12, updatedAt: "D001", files: modifiedFiles("content/pl/F1")},
+					{number: 14, updatedAt: "D002", files: modifiedFiles("content/en/F1")},
+				} {
+					cacheStore.EXPECT().
+						Read(
+							pullreq.PRFilesCacheBucket(langCode),
+							pullreq.PRFilesCacheKey(pr.number),
+							gomock.Any(),
+						).
+						DoAndReturn(storetests.MockReadReturn(
+							true,
+							cachetypes.PRFiles{
+								UpdatedAt: pr.updatedAt,
+								Files:     pr.files,
+							},
+							nil,
+						))
+				}
+
+				expectRequestedReviewersCached(cacheStore, langCode, github.PRItem{Number: 12, UpdatedAt: "D001"},
+					[]string{"kubernetes/sig-docs-pl-reviews"})
+
+				cacheStore.EXPECT().
+					Write(
+						pullreq.FilePRsIndexCacheBucket(langCode),
+						pullreq.FilePRsIndexCacheKey(langCode),
+						pullreq.FilePRIndexData{"content/pl/F1": modifiedIn(12)},
+					).
+					Return(nil)
+
+				expectPRInfoIndexWrite(cacheStore, langCode, pullreq.PRInfoIndexData{
+					12: {
+						Number:             12,
+						Title:              "[pl] Translate pods",
+						Author:             "author",
+						CreatedAt:          "D000",
+						UpdatedAt:          "D001",
+						Labels:             []string{"language/pl", pullreq.LabelLGTM, pullreq.LabelApproved},
+						RequestedReviewers: []string{"kubernetes/sig-docs-pl-reviews"},
+						LGTM:               true,
+						Approved:           true,
+					},
+				})
 			},
 		},
 	} {
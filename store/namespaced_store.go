@@ -0,0 +1,76 @@
+package store
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// Store is the set of operations provided by JSONFileStore.
+type Store interface {
+	Read(bucket, key string, dst any) (bool, error)
+	Write(bucket, key string, data any) error
+	Delete(bucket, key string) error
+	ListBuckets(bucketPath string) ([]string, error)
+}
+
+// NamespacedStore prefixes all buckets of the underlying store with a namespace,
+// so that several repositories can share one cache directory.
+type NamespacedStore struct {
+	store     Store
+	namespace string
+}
+
+// NewNamespacedStore returns a store that keeps its buckets under the namespace
+// in the given store. An empty namespace leaves bucket names unchanged.
+func NewNamespacedStore(store Store, namespace string) *NamespacedStore {
+	return &NamespacedStore{
+		store:     store,
+		namespace: namespace,
+	}
+}
+
+// Read reads a JSON value from the given bucket of the namespace.
+func (ns *NamespacedStore) Read(bucket, key string, dst any) (bool, error) {
+	found, err := ns.store.Read(ns.bucket(bucket), key, dst)
+	if err != nil {
+		return false, fmt.Errorf("namespace %s: %w", ns.namespace, err)
+	}
+
+	return found, nil
+}
+
+// Write writes data as JSON to the given bucket of the namespace.
+func (ns *NamespacedStore) Write(bucket, key string, data any) error {
+	if err := ns.store.Write(ns.bucket(bucket), key, data); err != nil {
+		return fmt.Errorf("namespace %s: %w", ns.namespace, err)
+	}
+
+	return nil
+}
+
+// Delete removes the entry for the given bucket of the namespace.
+func (ns *NamespacedStore) Delete(bucket, key string) error {
+	if err := ns.store.Delete(ns.bucket(bucket), key); err != nil {
+		return fmt.Errorf("namespace %s: %w", ns.namespace, err)
+	}
+
+	return nil
+}
+
+// ListBuckets lists subdirectories under the given bucket path prefix of the namespace.
+func (ns *NamespacedStore) ListBuckets(bucketPath string) ([]string, error) {
+	buckets, err := ns.store.ListBuckets(ns.bucket(bucketPath))
+	if err != nil {
+		return nil, fmt.Errorf("namespace %s: %w", ns.namespace, err)
+	}
+
+	return buckets, nil
+}
+
+func (ns *NamespacedStore) bucket(bucket string) string {
+	if ns.namespace == "" {
+		return bucket
+	}
+
+	return filepath.Join(ns.namespace, bucket)
+}
@@ -0,0 +1,85 @@
+package store_test
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/dkarczmarski/go-kweb-lang/store"
+)
+
+func TestNamespacedStore(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	fileStore := store.NewFileStore(dir)
+	docsStore := store.NewNamespacedStore(fileStore, "repos/docs")
+	websiteStore := store.NewNamespacedStore(fileStore, "repos/website")
+
+	if err := docsStore.Write("lang/pl/dashboard", "", "docs"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := websiteStore.Write("lang/pl/dashboard", "", "website"); err != nil {
+		t.Fatal(err)
+	}
+
+	exists, err := fileExists(filepath.Join(dir, "repos/docs/lang/pl/dashboard/_single.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !exists {
+		t.Fatal("expected the bucket to be stored under the namespace")
+	}
+
+	var value string
+
+	found, err := docsStore.Read("lang/pl/dashboard", "", &value)
+	if err != nil || !found || value != "docs" {
+		t.Fatalf("unexpected read result: found=%v value=%q err=%v", found, value, err)
+	}
+
+	buckets, err := websiteStore.ListBuckets("lang")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(buckets, []string{"pl"}) {
+		t.Fatalf("unexpected buckets: %v", buckets)
+	}
+
+	if err := docsStore.Delete("lang/pl/dashboard", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err = docsStore.Read("lang/pl/dashboard", "", &value)
+	if err != nil || found {
+		t.Fatalf("expected deleted entry, found=%v err=%v", found, err)
+	}
+
+	found, err = websiteStore.Read("lang/pl/dashboard", "", &value)
+	if err != nil || !found || value != "website" {
+		t.Fatalf("entry of another namespace changed: found=%v value=%q err=%v", found, value, err)
+	}
+}
+
+func TestNamespacedStore_EmptyNamespace(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	namespacedStore := store.NewNamespacedStore(store.NewFileStore(dir), "")
+
+	if err := namespacedStore.Write("dashboard-index", "", "value"); err != nil {
+		t.Fatal(err)
+	}
+
+	exists, err := fileExists(filepath.Join(dir, "dashboard-index/_single.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !exists {
+		t.Fatal("expected the bucket to be stored without a prefix")
+	}
+}
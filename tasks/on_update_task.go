@@ -3,9 +3,14 @@ package tasks
 import (
 	"context"
 	"fmt"
+	"sync"
 )
 
+// OnGitHubUpdateTask refreshes the data of a repository after a change on GitHub.
+// It is run both by the monitor and by the webhook receiver, so runs are serialized.
 type OnGitHubUpdateTask struct {
+	mu sync.Mutex
+
 	refreshRepoTask      *RefreshRepoTask
 	refreshPRTask        *RefreshPRTask
 	refreshDashboardTask *RefreshDashboardTask
@@ -16,6 +21,7 @@ func NewOnGitHubUpdateTask(
 	refreshPRTask *RefreshPRTask,
 	refreshDashboardTask *RefreshDashboardTask,
 ) *OnGitHubUpdateTask {
+	//nolint:exhaustruct
 	return &OnGitHubUpdateTask{
 		refreshRepoTask:      refreshRepoTask,
 		refreshPRTask:        refreshPRTask,
@@ -28,6 +34,9 @@ func (t *OnGitHubUpdateTask) OnUpdate(
 	repoUpdated bool,
 	changedLangCodesInPR []string,
 ) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
 	uniqueLangCodes := uniqueStrings(changedLangCodesInPR)
 
 	if repoUpdated {
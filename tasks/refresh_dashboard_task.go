@@ -5,14 +5,25 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/dkarczmarski/go-kweb-lang/alignment"
 	"github.com/dkarczmarski/go-kweb-lang/dashboard"
 	"github.com/dkarczmarski/go-kweb-lang/filepairs"
+	"github.com/dkarczmarski/go-kweb-lang/frontmatter"
 	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+	"github.com/dkarczmarski/go-kweb-lang/i18nkeys"
+	"github.com/dkarczmarski/go-kweb-lang/langcnt"
+	"github.com/dkarczmarski/go-kweb-lang/prpreview"
 	"github.com/dkarczmarski/go-kweb-lang/pullreq"
+	"github.com/dkarczmarski/go-kweb-lang/untranslated"
 )
 
+type LangProvider interface {
+	LangCodes() ([]string, error)
+	Languages() ([]langcnt.Language, error)
+}
+
 type PairLister interface {
-	ListPairs(langCode string) ([]filepairs.Pair, error)
+	ListLangPairs(ctx context.Context, langCode string) (filepairs.LangPairs, error)
 }
 
 type LangChecker interface {
@@ -21,6 +32,40 @@ type LangChecker interface {
 
 type FilePRIndexer interface {
 	LangIndex(langCode string) (pullreq.FilePRIndexData, error)
+	LangPRInfos(langCode string) (pullreq.PRInfoIndexData, error)
+}
+
+type PRPreviewer interface {
+	LangPreviews(ctx context.Context, langCode string, prIndex pullreq.FilePRIndexData) (prpreview.LangPreviews, error)
+}
+
+type I18NKeyComparator interface {
+	CompareKeys(ctx context.Context, pair gitseek.Pair, fileInfo gitseek.FileInfo) ([]i18nkeys.KeyChange, error)
+}
+
+type FrontMatterChecker interface {
+	CheckPair(ctx context.Context, pair gitseek.Pair, fileInfo gitseek.FileInfo) ([]frontmatter.Mismatch, error)
+}
+
+type AlignmentChecker interface {
+	CheckPair(ctx context.Context, pair gitseek.Pair, fileInfo gitseek.FileInfo) (*alignment.Misalignment, error)
+}
+
+type UntranslatedDetector interface {
+	CheckPair(ctx context.Context, pair gitseek.Pair, fileInfo gitseek.FileInfo) (*untranslated.Content, error)
+}
+
+// ContentCheckers are the checks of the content of file pairs
+// whose findings are attached to the dashboard items.
+type ContentCheckers struct {
+	I18NKeys     I18NKeyComparator
+	FrontMatter  FrontMatterChecker
+	Alignment    AlignmentChecker
+	Untranslated UntranslatedDetector
+}
+
+type AckedUpdatesReader interface {
+	ReadAckedUpdates(langCode string) (dashboard.AckedUpdates, error)
 }
 
 type DashboardStore interface {
@@ -29,25 +74,34 @@ type DashboardStore interface {
 }
 
 type RefreshDashboardTask struct {
-	langCodesProvider dashboard.LangCodesProvider
+	langCodesProvider LangProvider
 	pairProviders     PairLister
 	gitSeeker         LangChecker
+	contentCheckers   ContentCheckers
 	filePRIndex       FilePRIndexer
+	prPreviewer       PRPreviewer
+	ackedUpdates      AckedUpdatesReader
 	store             DashboardStore
 }
 
 func NewRefreshDashboardTask(
-	langCodesProvider dashboard.LangCodesProvider,
+	langCodesProvider LangProvider,
 	pairProviders PairLister,
 	gitSeeker LangChecker,
+	contentCheckers ContentCheckers,
 	filePRIndex FilePRIndexer,
+	prPreviewer PRPreviewer,
+	ackedUpdates AckedUpdatesReader,
 	store DashboardStore,
 ) *RefreshDashboardTask {
 	return &RefreshDashboardTask{
 		langCodesProvider: langCodesProvider,
 		pairProviders:     pairProviders,
 		gitSeeker:         gitSeeker,
+		contentCheckers:   contentCheckers,
 		filePRIndex:       filePRIndex,
+		prPreviewer:       prPreviewer,
+		ackedUpdates:      ackedUpdates,
 		store:             store,
 	}
 }
@@ -85,7 +139,7 @@ func (task *RefreshDashboardTask) buildDashboard(
 	ctx context.Context,
 	langCode string,
 ) (dashboard.Dashboard, error) {
-	pairs, err := task.pairProviders.ListPairs(langCode)
+	langPairs, err := task.pairProviders.ListLangPairs(ctx, langCode)
 	if err != nil {
 		return dashboard.Dashboard{}, fmt.Errorf(
 			"list file pairs for lang code %s: %w",
@@ -94,7 +148,10 @@ func (task *RefreshDashboardTask) buildDashboard(
 		)
 	}
 
+	pairs := langPairs.Pairs
 	seekerFileInfos := make([]gitseek.FileInfo, 0, len(pairs))
+	enPaths := make(map[string]string, len(pairs))
+	findings := make(map[string]pairFindings)
 
 	for pairIndex, pair := range pairs {
 		log.Printf(
@@ -105,10 +162,12 @@ func (task *RefreshDashboardTask) buildDashboard(
 			pair.LangPath,
 		)
 
-		fileInfo, err := task.gitSeeker.CheckLang(ctx, langCode, gitseek.Pair{
+		seekPair := gitseek.Pair{
 			EnPath:   pair.EnPath,
 			LangPath: pair.LangPath,
-		})
+		}
+
+		fileInfo, err := task.gitSeeker.CheckLang(ctx, langCode, seekPair)
 		if err != nil {
 			return dashboard.Dashboard{}, fmt.Errorf(
 				"check file pair %s for lang code %s: %w",
@@ -119,6 +178,19 @@ func (task *RefreshDashboardTask) buildDashboard(
 		}
 
 		seekerFileInfos = append(seekerFileInfos, fileInfo)
+		enPaths[pair.LangPath] = pair.EnPath
+
+		pairFindings, err := task.checkContent(ctx, langCode, seekPair, fileInfo)
+		if err != nil {
+			return dashboard.Dashboard{}, fmt.Errorf(
+				"check content of %s for lang code %s: %w",
+				pair.LangPath,
+				langCode,
+				err,
+			)
+		}
+
+		findings[pair.LangPath] = pairFindings
 	}
 
 	prIndex, err := task.filePRIndex.LangIndex(langCode)
@@ -130,7 +202,120 @@ func (task *RefreshDashboardTask) buildDashboard(
 		)
 	}
 
-	return dashboard.BuildDashboard(langCode, seekerFileInfos, prIndex), nil
+	prInfos, err := task.filePRIndex.LangPRInfos(langCode)
+	if err != nil {
+		return dashboard.Dashboard{}, fmt.Errorf(
+			"get pull request details for lang code %s: %w",
+			langCode,
+			err,
+		)
+	}
+
+	prPreviews, err := task.prPreviewer.LangPreviews(ctx, langCode, prIndex)
+	if err != nil {
+		return dashboard.Dashboard{}, fmt.Errorf(
+			"preview pull requests for lang code %s: %w",
+			langCode,
+			err,
+		)
+	}
+
+	ackedUpdates, err := task.ackedUpdates.ReadAckedUpdates(langCode)
+	if err != nil {
+		return dashboard.Dashboard{}, fmt.Errorf(
+			"read acked updates for lang code %s: %w",
+			langCode,
+			err,
+		)
+	}
+
+	langDashboard := dashboard.BuildDashboard(
+		langCode,
+		seekerFileInfos,
+		prIndex,
+		prInfos,
+		prPreviews,
+		ackedUpdates,
+	)
+	langDashboard.ExcludedCounts = langPairs.ExcludedCounts
+
+	for i := range langDashboard.Items {
+		item := &langDashboard.Items[i]
+		itemFindings := findings[item.LangPath]
+
+		item.EnPath = enPaths[item.LangPath]
+		item.I18NKeyChanges = itemFindings.keyChanges
+		item.FrontMatterMismatches = itemFindings.frontMatterMismatches
+		item.Misalignment = itemFindings.misalignment
+		item.UntranslatedContent = itemFindings.untranslatedContent
+	}
+
+	return langDashboard, nil
+}
+
+type pairFindings struct {
+	keyChanges            []i18nkeys.KeyChange
+	frontMatterMismatches []frontmatter.Mismatch
+	misalignment          *alignment.Misalignment
+	untranslatedContent   *untranslated.Content
+}
+
+// checkContent runs the content checks of the pair. A check that fails, for example
+// because of malformed front matter, is logged and skipped, so that a single file
+// does not stop the dashboard of the language from being built.
+func (task *RefreshDashboardTask) checkContent(
+	ctx context.Context,
+	langCode string,
+	pair gitseek.Pair,
+	fileInfo gitseek.FileInfo,
+) (pairFindings, error) {
+	var findings pairFindings
+
+	keyChanges, err := task.contentCheckers.I18NKeys.CompareKeys(ctx, pair, fileInfo)
+	if err = skipFailedCheck(ctx, langCode, pair, "i18n keys comparison", err); err != nil {
+		return pairFindings{}, err
+	}
+
+	findings.keyChanges = keyChanges
+
+	frontMatterMismatches, err := task.contentCheckers.FrontMatter.CheckPair(ctx, pair, fileInfo)
+	if err = skipFailedCheck(ctx, langCode, pair, "front matter check", err); err != nil {
+		return pairFindings{}, err
+	}
+
+	findings.frontMatterMismatches = frontMatterMismatches
+
+	misalignment, err := task.contentCheckers.Alignment.CheckPair(ctx, pair, fileInfo)
+	if err = skipFailedCheck(ctx, langCode, pair, "line alignment check", err); err != nil {
+		return pairFindings{}, err
+	}
+
+	findings.misalignment = misalignment
+
+	untranslatedContent, err := task.contentCheckers.Untranslated.CheckPair(ctx, pair, fileInfo)
+	if err = skipFailedCheck(ctx, langCode, pair, "untranslated content check", err); err != nil {
+		return pairFindings{}, err
+	}
+
+	findings.untranslatedContent = untranslatedContent
+
+	return findings, nil
+}
+
+// skipFailedCheck logs the error of a content check and returns nil,
+// unless the error is caused by the context being done.
+func skipFailedCheck(ctx context.Context, langCode string, pair gitseek.Pair, check string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if ctx.Err() != nil {
+		return fmt.Errorf("%s: %w", check, err)
+	}
+
+	log.Printf("[tasks][%s] skip %s of %s: %v", langCode, check, pair.LangPath, err)
+
+	return nil
 }
 
 func (task *RefreshDashboardTask) buildLangIndex() (dashboard.LangIndex, error) {
@@ -3,30 +3,81 @@ package tasks
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/dkarczmarski/go-kweb-lang/langcnt"
+	"github.com/dkarczmarski/go-kweb-lang/prpreview"
 	"github.com/dkarczmarski/go-kweb-lang/pullreq"
 )
 
 type RefreshPRTask struct {
 	filePRIndex       *pullreq.FilePRIndex
+	prPreviewer       *prpreview.Previewer
 	langCodesProvider *langcnt.LangCodesProvider
 }
 
 func NewRefreshPRTask(
 	filePRIndex *pullreq.FilePRIndex,
+	prPreviewer *prpreview.Previewer,
 	langCodesProvider *langcnt.LangCodesProvider,
 ) *RefreshPRTask {
 	return &RefreshPRTask{
 		filePRIndex:       filePRIndex,
+		prPreviewer:       prPreviewer,
 		langCodesProvider: langCodesProvider,
 	}
 }
 
+// Run refreshes the pull request index of the language and fetches the heads
+// of its pull requests, so that they can be previewed in the dashboard.
 func (t *RefreshPRTask) Run(ctx context.Context, langCode string) error {
 	if err := t.filePRIndex.RefreshIndex(ctx, langCode); err != nil {
 		return fmt.Errorf("refresh PR index for lang code %s: %w", langCode, err)
 	}
 
+	prIndex, err := t.filePRIndex.LangIndex(langCode)
+	if err != nil {
+		return fmt.Errorf("read PR index for lang code %s: %w", langCode, err)
+	}
+
+	if err := t.prPreviewer.FetchHeads(ctx, prIndex); err != nil {
+		return fmt.Errorf("fetch PR heads for lang code %s: %w", langCode, err)
+	}
+
+	openPRNumbers, err := t.listOpenPRNumbers()
+	if err != nil {
+		return err
+	}
+
+	if err := t.prPreviewer.PruneHeads(ctx, openPRNumbers); err != nil {
+		return fmt.Errorf("prune PR heads: %w", err)
+	}
+
 	return nil
 }
+
+// listOpenPRNumbers lists the pull requests indexed for any of the languages,
+// whose heads are kept in the local clone.
+func (t *RefreshPRTask) listOpenPRNumbers() ([]int, error) {
+	langCodes, err := t.langCodesProvider.LangCodes()
+	if err != nil {
+		return nil, fmt.Errorf("list lang codes: %w", err)
+	}
+
+	var prNumbers []int
+
+	for _, langCode := range langCodes {
+		prIndex, err := t.filePRIndex.LangIndex(langCode)
+		if err != nil {
+			return nil, fmt.Errorf("read PR index for lang code %s: %w", langCode, err)
+		}
+
+		for langPath := range prIndex {
+			prNumbers = append(prNumbers, prIndex.PRNumbers(langPath)...)
+		}
+	}
+
+	slices.Sort(prNumbers)
+
+	return slices.Compact(prNumbers), nil
+}
@@ -16,6 +16,7 @@ type RefreshRepoTask struct {
 	filePaths         *filepairs.FilePaths
 	langCodesProvider *langcnt.LangCodesProvider
 	invalidator       githist.Invalidator
+	exclusions        *filepairs.Exclusions
 }
 
 func NewRefreshRepoTask(
@@ -23,12 +24,14 @@ func NewRefreshRepoTask(
 	filePaths *filepairs.FilePaths,
 	langCodesProvider *langcnt.LangCodesProvider,
 	invalidator githist.Invalidator,
+	exclusions *filepairs.Exclusions,
 ) *RefreshRepoTask {
 	return &RefreshRepoTask{
 		gitRepoHist:       gitRepoHist,
 		filePaths:         filePaths,
 		langCodesProvider: langCodesProvider,
 		invalidator:       invalidator,
+		exclusions:        exclusions,
 	}
 }
 
@@ -72,6 +75,7 @@ func (t *RefreshRepoTask) Run(ctx context.Context) error {
 }
 
 // invalidateChangedPath invalidates the cache for a given file path.
+// Excluded files are skipped because they are not listed in dashboards.
 func (t *RefreshRepoTask) invalidateChangedPath(changedPath string) error {
 	if t.invalidator == nil {
 		return nil
@@ -98,6 +102,12 @@ func (t *RefreshRepoTask) invalidateChangedPath(changedPath string) error {
 				return fmt.Errorf("build language path for lang code %s: %w", langCode, err)
 			}
 
+			if t.exclusions.IsPairExcluded(filepairs.Pair{EnPath: changedPath, LangPath: langPath}, langCode) {
+				log.Printf("[githist][%s] skip excluded file %s", langCode, langPath)
+
+				continue
+			}
+
 			if err := t.invalidator.InvalidateFile(langCode, langPath); err != nil {
 				return fmt.Errorf(
 					"invalidate gitseek cache for lang code %s and path %s: %w",
@@ -111,6 +121,12 @@ func (t *RefreshRepoTask) invalidateChangedPath(changedPath string) error {
 		return nil
 	}
 
+	if t.exclusions.IsExcluded(changedPath, pathInfo.LangCode) {
+		log.Printf("[githist][%s] skip excluded file %s", pathInfo.LangCode, changedPath)
+
+		return nil
+	}
+
 	if err := t.invalidator.InvalidateFile(pathInfo.LangCode, changedPath); err != nil {
 		return fmt.Errorf(
 			"invalidate gitseek cache for lang code %s and path %s: %w",
@@ -41,6 +41,36 @@ func TestGitHub_GetPRCommits_E2E(t *testing.T) {
 	t.Log(commitIds)
 }
 
+func TestGitHub_GetPRFiles_E2E(t *testing.T) {
+	ctx := context.Background()
+	gh := github.NewGitHub(
+		github.WithDefaults(),
+		github.WithThrottle(3*time.Second),
+	)
+
+	files, err := gh.GetPRFiles(ctx, 50193)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log(files)
+}
+
+func TestGitHub_GetPRRequestedReviewers_E2E(t *testing.T) {
+	ctx := context.Background()
+	gh := github.NewGitHub(
+		github.WithDefaults(),
+		github.WithThrottle(3*time.Second),
+	)
+
+	reviewers, err := gh.GetPRRequestedReviewers(ctx, 50193)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log(reviewers)
+}
+
 func TestGitHub_PRSearch_E2E(t *testing.T) {
 	ctx := context.Background()
 	gh := github.NewGitHub(
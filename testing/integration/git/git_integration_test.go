@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/dkarczmarski/go-kweb-lang/git"
@@ -55,6 +56,53 @@ func TestGit_Create_Integration(t *testing.T) {
 	}
 }
 
+func TestGit_Create_Integration_CustomBranch(t *testing.T) {
+	ctx := t.Context()
+	env := newIntegrationEnv(t, "initrepo")
+	repoURL := "file://" + filepath.Join(env.tmpDir, "repo")
+
+	repoPath := filepath.Join(env.tmpDir, "test-repo")
+	if err := os.Mkdir(repoPath, 0o755); err != nil {
+		t.Fatalf("failed to create directory %s: %v", repoPath, err)
+	}
+
+	gitRepo := git.NewRepo(repoPath, func(config *git.NewRepoConfig) {
+		config.Branch = "branch2"
+	})
+
+	if err := gitRepo.Create(ctx, repoURL); err != nil {
+		t.Fatalf("unexpected error when creating git repo: %v", err)
+	}
+
+	commits, err := gitRepo.ListMainBranchCommits(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(commits) != 6 || commits[0].Comment != "commit (branch2) file6.txt" {
+		t.Errorf("unexpected commits of branch2: %+v", commits)
+	}
+
+	parents, err := gitRepo.ListMainBranchCommitParents(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(parents) != len(commits) || len(parents[commits[0].CommitID]) == 0 ||
+		parents[commits[0].CommitID][0] != commits[1].CommitID {
+		t.Errorf("unexpected parents of branch2 commits: %+v", parents)
+	}
+
+	freshCommits, err := gitRepo.ListFreshCommits(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(freshCommits) != 0 {
+		t.Errorf("expected no fresh commits, got %+v", freshCommits)
+	}
+}
+
 func TestGit_Checkout_Integration(t *testing.T) {
 	for _, tc := range []struct {
 		name     string
@@ -790,3 +838,241 @@ func TestGit_ListFilesBetweenCommits_Integration(t *testing.T) {
 		})
 	}
 }
+
+func TestGit_FindCommit_Integration(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		commitID       string
+		expectedErr    func(err error) bool
+		expectedResult git.CommitInfo
+	}{
+		{
+			name:     "when commit exists",
+			commitID: "9705628",
+			expectedErr: func(err error) bool {
+				return err == nil
+			},
+			expectedResult: git.CommitInfo{
+				CommitID: "97056283dccdf83d7a2994e58684048d697d9ba0",
+				DateTime: "2020-01-13T00:00:00+00:00",
+				Comment:  "commit (main) file3.txt",
+			},
+		},
+		{
+			name:     "when commit does not exist",
+			commitID: "0000000000000000000000000000000000000000",
+			expectedErr: func(err error) bool {
+				return err == nil
+			},
+			expectedResult: git.CommitInfo{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := t.Context()
+			env := newIntegrationEnv(t, "initrepo")
+
+			result, err := env.gitRepo.FindCommit(ctx, tc.commitID)
+
+			if !tc.expectedErr(err) {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(tc.expectedResult, result) {
+				t.Errorf("unexpected result: %+v", result)
+			}
+		})
+	}
+}
+
+func TestGit_ListCommitsWithFiles_Integration(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		revisionRange  string
+		expectedResult []git.CommitFiles
+	}{
+		{
+			name:          "when the range is given",
+			revisionRange: "40eda29f9d285779f07b474a02920b6a379d8af0..8dd9d9f078564d285e1945ef75d17d87eef55c33",
+			expectedResult: []git.CommitFiles{
+				{
+					CommitInfo: git.CommitInfo{
+						CommitID: "8dd9d9f078564d285e1945ef75d17d87eef55c33",
+						DateTime: "2020-01-09T00:00:00+00:00",
+						Comment:  "commit (branch1) file4.txt 2",
+					},
+					ParentIDs: []string{"5a941744bc6dbdd39032cf076101f3f530afb295"},
+					Files:     []string{"file4.txt"},
+				},
+				{
+					CommitInfo: git.CommitInfo{
+						CommitID: "5a941744bc6dbdd39032cf076101f3f530afb295",
+						DateTime: "2020-01-06T00:00:00+00:00",
+						Comment:  "commit (branch1) file4.txt",
+					},
+					ParentIDs: []string{"2a2c911b4a8e0e681dafa7b236446a9e42f47533"},
+					Files:     []string{"file4.txt"},
+				},
+				{
+					CommitInfo: git.CommitInfo{
+						CommitID: "2a2c911b4a8e0e681dafa7b236446a9e42f47533",
+						DateTime: "2020-01-05T00:00:00+00:00",
+						Comment:  "commit (main) file1.txt 2",
+					},
+					ParentIDs: []string{"40eda29f9d285779f07b474a02920b6a379d8af0"},
+					Files:     []string{"file1.txt"},
+				},
+			},
+		},
+		{
+			name:           "when the range is empty",
+			revisionRange:  "2a2c911b4a8e0e681dafa7b236446a9e42f47533..40eda29f9d285779f07b474a02920b6a379d8af0",
+			expectedResult: nil,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := t.Context()
+			env := newIntegrationEnv(t, "initrepo")
+
+			result, err := env.gitRepo.ListCommitsWithFiles(ctx, tc.revisionRange)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(tc.expectedResult, result) {
+				t.Errorf("unexpected result: %+v", result)
+			}
+		})
+	}
+}
+
+func TestGit_FindFileRenames_Integration(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		filePath       string
+		expectedResult []git.FileRename
+	}{
+		{
+			name:     "when file was moved twice",
+			filePath: "file1.txt",
+			expectedResult: []git.FileRename{
+				{
+					Commit: git.CommitInfo{
+						CommitID: "eb5452ed464e08988d34c4f37fce1a3f5db5f481",
+						DateTime: "2020-01-03T00:00:00+00:00",
+						Comment:  "move file1.txt to dir1/file1.txt",
+					},
+					OldPath:    "file1.txt",
+					NewPath:    "dir1/file1.txt",
+					Similarity: 100,
+				},
+				{
+					Commit: git.CommitInfo{
+						CommitID: "25b15d8f8bd2a817f63076e3e7ba42587b221f63",
+						DateTime: "2020-01-04T00:00:00+00:00",
+						Comment:  "move and update dir1/file1.txt",
+					},
+					OldPath:    "dir1/file1.txt",
+					NewPath:    "dir2/file1-renamed.txt",
+					Similarity: 83,
+				},
+			},
+		},
+		{
+			name:           "when file was deleted",
+			filePath:       "file2.txt",
+			expectedResult: nil,
+		},
+		{
+			name:           "when file exists",
+			filePath:       "dir2/file1-renamed.txt",
+			expectedResult: nil,
+		},
+		{
+			name:           "when file never existed",
+			filePath:       "fake-file",
+			expectedResult: nil,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := t.Context()
+			env := newIntegrationEnv(t, "renames")
+
+			renames, err := env.gitRepo.FindFileRenames(ctx, tc.filePath)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(tc.expectedResult, renames) {
+				t.Errorf("unexpected result: %+v", renames)
+			}
+		})
+	}
+}
+
+func TestGit_FindRenamedPath_Integration(t *testing.T) {
+	ctx := t.Context()
+	env := newIntegrationEnv(t, "renames")
+
+	path, err := env.gitRepo.FindRenamedPath(ctx, "file1.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if path != "dir2/file1-renamed.txt" {
+		t.Errorf("unexpected result: %q", path)
+	}
+}
+
+func TestGit_FileRangeDiff_Integration(t *testing.T) {
+	ctx := t.Context()
+	env := newIntegrationEnv(t, "renames")
+
+	diff, err := env.gitRepo.FileRangeDiff(ctx, "HEAD~3", "HEAD", "file1.txt", "dir2/file1-renamed.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{
+		"rename from file1.txt",
+		"rename to dir2/file1-renamed.txt",
+		"+line 6",
+	} {
+		if !strings.Contains(diff, want) {
+			t.Errorf("diff does not contain %q:\n%s", want, diff)
+		}
+	}
+}
+
+func TestGit_FilePatch_Integration(t *testing.T) {
+	ctx := t.Context()
+	env := newIntegrationEnv(t, "renames")
+
+	patch, err := env.gitRepo.FilePatch(ctx, "25b15d8f8bd2a817f63076e3e7ba42587b221f63", "dir1/file1.txt", "dir2/file1-renamed.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{
+		"Subject: [PATCH] move and update dir1/file1.txt",
+		"rename from dir1/file1.txt",
+		"+line 6",
+	} {
+		if !strings.Contains(patch, want) {
+			t.Errorf("patch does not contain %q:\n%s", want, patch)
+		}
+	}
+}
+
+func TestGit_FullFileRangeDiff_Integration(t *testing.T) {
+	ctx := t.Context()
+	env := newIntegrationEnv(t, "renames")
+
+	diff, err := env.gitRepo.FullFileRangeDiff(ctx, "HEAD~3", "HEAD", "file1.txt", "dir2/file1-renamed.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(diff, "@@ -1,5 +1,6 @@\n line 1\n line 2\n line 3\n line 4\n line 5\n+line 6\n") {
+		t.Errorf("diff does not contain the whole file:\n%s", diff)
+	}
+}
@@ -0,0 +1,47 @@
+#!/bin/bash
+
+set -e
+
+rm -rf repo
+mkdir -p repo
+cd repo
+
+git init -b main
+git config --local user.name testuser
+git config --local user.email testuser@foo.com
+
+BASE_DATE="2020-01-01T00:00:00Z"
+DATE="$BASE_DATE"
+
+increment_date() {
+  DATE=$(TZ=UTC date -u -d "$DATE +1 day" +"%Y-%m-%dT%H:%M:%SZ")
+}
+
+export GIT_AUTHOR_NAME="testuser"
+export GIT_AUTHOR_EMAIL="testuser@foo.com"
+export GIT_COMMITTER_NAME="testuser"
+export GIT_COMMITTER_EMAIL="testuser@foo.com"
+
+increment_date
+printf "line 1\nline 2\nline 3\nline 4\nline 5\n" > file1.txt
+printf "line 1\nline 2\nline 3\nline 4\nline 5\n" > file2.txt
+git add file1.txt file2.txt
+GIT_AUTHOR_DATE=$DATE GIT_COMMITTER_DATE=$DATE git commit -m "commit (main) file1.txt file2.txt"
+
+increment_date
+mkdir -p dir1
+git mv file1.txt dir1/file1.txt
+GIT_AUTHOR_DATE=$DATE GIT_COMMITTER_DATE=$DATE git commit -m "move file1.txt to dir1/file1.txt"
+
+increment_date
+mkdir -p dir2
+git mv dir1/file1.txt dir2/file1-renamed.txt
+echo "line 6" >> dir2/file1-renamed.txt
+git add dir2/file1-renamed.txt
+GIT_AUTHOR_DATE=$DATE GIT_COMMITTER_DATE=$DATE git commit -m "move and update dir1/file1.txt"
+
+increment_date
+git rm file2.txt
+GIT_AUTHOR_DATE=$DATE GIT_COMMITTER_DATE=$DATE git commit -m "delete file2.txt"
+
+git --no-pager log --graph --all --decorate --date=iso-strict --pretty=format:"%H %cd %s"
@@ -469,3 +469,52 @@ func TestGitHist_PullRefresh_ReturnsFilesForMergedBranch_Integration(t *testing.
 		t.Fatalf("unexpected HEAD after PullRefresh: got %q", headSubject)
 	}
 }
+
+// TestGitHist_PullRefresh_ResetsToOriginAfterForcePush_Integration verifies that
+// PullRefresh returns files changed by both dropped and fresh commits when
+// the history of origin/main was rewritten, and resets the local clone to origin/main.
+func TestGitHist_PullRefresh_ResetsToOriginAfterForcePush_Integration(t *testing.T) {
+	ctx := t.Context()
+	env := newGitHistRemoteIntegrationEnv(t, "pull_refresh_force_push")
+
+	droppedCommitID := mustGitRevParseBySubject(t, env.repoDir, "B: main second commit")
+
+	isMain, err := env.gitHist.IsMainBranchCommit(ctx, droppedCommitID)
+	if err != nil {
+		t.Fatalf("IsMainBranchCommit returned error: %v", err)
+	}
+
+	if !isMain {
+		t.Fatal("expected the commit to be on the main branch before the force-push")
+	}
+
+	runScenarioScript(t, env.tmpDir, env.scenarioDir, "step_force_push.sh")
+
+	files, err := env.gitHist.PullRefresh(ctx)
+	if err != nil {
+		t.Fatalf("PullRefresh returned error: %v", err)
+	}
+
+	expectedFiles := []string{
+		"docs/dropped.md",
+		"docs/main.md",
+	}
+
+	if !reflect.DeepEqual(expectedFiles, files) {
+		t.Fatalf("unexpected changed files: got %v, want %v", files, expectedFiles)
+	}
+
+	headSubject := mustGitHeadSubject(t, env.repoDir)
+	if headSubject != "C: rewritten main second commit" {
+		t.Fatalf("unexpected HEAD after PullRefresh: got %q", headSubject)
+	}
+
+	isMain, err = env.gitHist.IsMainBranchCommit(ctx, droppedCommitID)
+	if err != nil {
+		t.Fatalf("IsMainBranchCommit returned error: %v", err)
+	}
+
+	if isMain {
+		t.Fatal("expected the dropped commit not to be on the main branch after PullRefresh")
+	}
+}
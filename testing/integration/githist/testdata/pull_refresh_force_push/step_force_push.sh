@@ -0,0 +1,24 @@
+#!/bin/bash
+
+set -e
+
+cd updater
+
+git config --local user.name testuser
+git config --local user.email testuser@foo.com
+
+git reset --hard HEAD~1
+
+LAST_DATE=$(git log -1 --format=%cI)
+NEXT_DATE=$(TZ=UTC date -u -d "$LAST_DATE +2 day" +"%Y-%m-%dT%H:%M:%SZ")
+
+export GIT_AUTHOR_NAME="testuser"
+export GIT_AUTHOR_EMAIL="testuser@foo.com"
+export GIT_COMMITTER_NAME="testuser"
+export GIT_COMMITTER_EMAIL="testuser@foo.com"
+
+echo "C" >> docs/main.md
+git add docs/main.md
+GIT_AUTHOR_DATE=$NEXT_DATE GIT_COMMITTER_DATE=$NEXT_DATE git commit -m "C: rewritten main second commit"
+
+git push --force origin main
@@ -0,0 +1,45 @@
+//nolint:paralleltest
+package gitseek_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dkarczmarski/go-kweb-lang/git"
+	"github.com/dkarczmarski/go-kweb-lang/githist"
+	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+	"github.com/dkarczmarski/go-kweb-lang/histindex"
+	"github.com/dkarczmarski/go-kweb-lang/store"
+)
+
+func TestGitSeek_CheckLang_HistoryIndexMatchesGit_Integration(t *testing.T) {
+	scenarios, err := os.ReadDir(scenarioPath(t, ""))
+	if err != nil {
+		t.Fatalf("failed to list scenarios: %v", err)
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name(), func(t *testing.T) {
+			ctx := t.Context()
+			env := newIntegrationEnv(t, scenario.Name())
+
+			expected, err := env.gitSeeker.CheckLang(ctx, "pl", env.pair)
+			if err != nil {
+				t.Fatalf("CheckLang returned error: %v", err)
+			}
+
+			gitRepo := git.NewRepo(filepath.Join(env.tmpDir, "repo"))
+			cache := store.NewFileStore(filepath.Join(env.tmpDir, "histindex-cache"))
+			index := histindex.New(gitRepo, cache)
+			gitSeeker := gitseek.New(histindex.NewRepo(gitRepo, index), githist.New(gitRepo, cache), cache)
+
+			result, err := gitSeeker.CheckLang(ctx, "pl", env.pair)
+			if err != nil {
+				t.Fatalf("CheckLang with history index returned error: %v", err)
+			}
+
+			assertEqualFileInfo(t, expected, result)
+		})
+	}
+}
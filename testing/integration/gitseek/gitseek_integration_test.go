@@ -83,9 +83,10 @@ func expectedFileInfoABC() gitseek.FileInfo {
 			DateTime: "2020-01-03T00:00:00+00:00",
 			Comment:  "B: add content/pl/docs/test.md",
 		},
-		LangMergeCommit: nil,
-		LangForkCommit:  nil,
-		FileStatus:      gitseek.StatusEnFileUpdated,
+		LangMergeCommit:  nil,
+		LangForkCommit:   nil,
+		FileStatus:       gitseek.StatusEnFileUpdated,
+		StartPointSource: gitseek.StartPointSourceLangLastCommit,
 		EnUpdates: []gitseek.EnUpdate{
 			{
 				Commit: git.CommitInfo{
@@ -94,6 +95,8 @@ func expectedFileInfoABC() gitseek.FileInfo {
 					Comment:  "C: update content/en/docs/test.md",
 				},
 				MergePoint: nil,
+				ChangeKind: gitseek.ChangeKindProse,
+				Overlap:    gitseek.OverlapAfterMerge,
 			},
 		},
 	}
@@ -107,9 +110,10 @@ func expectedFileInfoABCD() gitseek.FileInfo {
 			DateTime: "2020-01-03T00:00:00+00:00",
 			Comment:  "B: add content/pl/docs/test.md",
 		},
-		LangMergeCommit: nil,
-		LangForkCommit:  nil,
-		FileStatus:      gitseek.StatusEnFileUpdated,
+		LangMergeCommit:  nil,
+		LangForkCommit:   nil,
+		FileStatus:       gitseek.StatusEnFileUpdated,
+		StartPointSource: gitseek.StartPointSourceLangLastCommit,
 		EnUpdates: []gitseek.EnUpdate{
 			{
 				Commit: git.CommitInfo{
@@ -118,6 +122,8 @@ func expectedFileInfoABCD() gitseek.FileInfo {
 					Comment:  "D: update content/en/docs/test.md again",
 				},
 				MergePoint: nil,
+				ChangeKind: gitseek.ChangeKindProse,
+				Overlap:    gitseek.OverlapAfterMerge,
 			},
 			{
 				Commit: git.CommitInfo{
@@ -126,6 +132,8 @@ func expectedFileInfoABCD() gitseek.FileInfo {
 					Comment:  "C: update content/en/docs/test.md",
 				},
 				MergePoint: nil,
+				ChangeKind: gitseek.ChangeKindProse,
+				Overlap:    gitseek.OverlapAfterMerge,
 			},
 		},
 	}
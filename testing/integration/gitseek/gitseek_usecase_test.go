@@ -2,8 +2,10 @@
 package gitseek_test
 
 import (
+	"path/filepath"
 	"testing"
 
+	"github.com/dkarczmarski/go-kweb-lang/filepairs"
 	"github.com/dkarczmarski/go-kweb-lang/git"
 	"github.com/dkarczmarski/go-kweb-lang/gitseek"
 )
@@ -24,10 +26,11 @@ func TestGitSeek_CheckLang_UseCase_EnUnchangedAfterLang(t *testing.T) {
 			DateTime: "2020-01-03T00:00:00+00:00",
 			Comment:  "B: add content/pl/docs/test.md",
 		},
-		LangMergeCommit: nil,
-		LangForkCommit:  nil,
-		FileStatus:      gitseek.StatusLangFileUpToDate,
-		EnUpdates:       nil,
+		LangMergeCommit:  nil,
+		LangForkCommit:   nil,
+		FileStatus:       gitseek.StatusLangFileUpToDate,
+		StartPointSource: gitseek.StartPointSourceLangLastCommit,
+		EnUpdates:        nil,
 	}
 
 	assertEqualFileInfo(t, expected, result)
@@ -49,9 +52,10 @@ func TestGitSeek_CheckLang_UseCase_EnUpdatedOnMainAfterLang(t *testing.T) {
 			DateTime: "2020-01-03T00:00:00+00:00",
 			Comment:  "B: add content/pl/docs/test.md",
 		},
-		LangMergeCommit: nil,
-		LangForkCommit:  nil,
-		FileStatus:      gitseek.StatusEnFileUpdated,
+		LangMergeCommit:  nil,
+		LangForkCommit:   nil,
+		FileStatus:       gitseek.StatusEnFileUpdated,
+		StartPointSource: gitseek.StartPointSourceLangLastCommit,
 		EnUpdates: []gitseek.EnUpdate{
 			{
 				Commit: git.CommitInfo{
@@ -60,6 +64,8 @@ func TestGitSeek_CheckLang_UseCase_EnUpdatedOnMainAfterLang(t *testing.T) {
 					Comment:  "C: update content/en/docs/test.md",
 				},
 				MergePoint: nil,
+				ChangeKind: gitseek.ChangeKindProse,
+				Overlap:    gitseek.OverlapAfterMerge,
 			},
 		},
 	}
@@ -83,9 +89,10 @@ func TestGitSeek_CheckLang_UseCase_MultipleEnUpdatesAfterLang(t *testing.T) {
 			DateTime: "2020-01-03T00:00:00+00:00",
 			Comment:  "B: add content/pl/docs/test.md",
 		},
-		LangMergeCommit: nil,
-		LangForkCommit:  nil,
-		FileStatus:      gitseek.StatusEnFileUpdated,
+		LangMergeCommit:  nil,
+		LangForkCommit:   nil,
+		FileStatus:       gitseek.StatusEnFileUpdated,
+		StartPointSource: gitseek.StartPointSourceLangLastCommit,
 		EnUpdates: []gitseek.EnUpdate{
 			{
 				Commit: git.CommitInfo{
@@ -94,6 +101,8 @@ func TestGitSeek_CheckLang_UseCase_MultipleEnUpdatesAfterLang(t *testing.T) {
 					Comment:  "D: update content/en/docs/test.md again",
 				},
 				MergePoint: nil,
+				ChangeKind: gitseek.ChangeKindProse,
+				Overlap:    gitseek.OverlapAfterMerge,
 			},
 			{
 				Commit: git.CommitInfo{
@@ -102,6 +111,8 @@ func TestGitSeek_CheckLang_UseCase_MultipleEnUpdatesAfterLang(t *testing.T) {
 					Comment:  "C: update content/en/docs/test.md",
 				},
 				MergePoint: nil,
+				ChangeKind: gitseek.ChangeKindProse,
+				Overlap:    gitseek.OverlapAfterMerge,
 			},
 		},
 	}
@@ -125,9 +136,10 @@ func TestGitSeek_CheckLang_UseCase_EnFileNoLongerExists(t *testing.T) {
 			DateTime: "2020-01-03T00:00:00+00:00",
 			Comment:  "B: add content/pl/docs/test.md",
 		},
-		LangMergeCommit: nil,
-		LangForkCommit:  nil,
-		FileStatus:      gitseek.StatusEnFileNoLongerExists,
+		LangMergeCommit:  nil,
+		LangForkCommit:   nil,
+		FileStatus:       gitseek.StatusEnFileNoLongerExists,
+		StartPointSource: gitseek.StartPointSourceLangLastCommit,
 		EnUpdates: []gitseek.EnUpdate{
 			{
 				Commit: git.CommitInfo{
@@ -136,6 +148,8 @@ func TestGitSeek_CheckLang_UseCase_EnFileNoLongerExists(t *testing.T) {
 					Comment:  "C: delete content/en/docs/test.md",
 				},
 				MergePoint: nil,
+				ChangeKind: gitseek.ChangeKindProse,
+				Overlap:    gitseek.OverlapAfterMerge,
 			},
 		},
 	}
@@ -143,6 +157,54 @@ func TestGitSeek_CheckLang_UseCase_EnFileNoLongerExists(t *testing.T) {
 	assertEqualFileInfo(t, expected, result)
 }
 
+func TestGitSeek_CheckLang_UseCase_EnFileMoved(t *testing.T) {
+	ctx := t.Context()
+	env := newIntegrationEnv(t, "en_file_moved")
+
+	gitSeeker := gitseek.New(
+		git.NewRepo(filepath.Join(env.tmpDir, "repo")),
+		env.gitRepoHist,
+		env.cache,
+		func(config *gitseek.NewConfig) {
+			config.LangPaths = filepairs.New()
+		},
+	)
+
+	result, err := gitSeeker.CheckLang(ctx, "pl", env.pair)
+	if err != nil {
+		t.Fatalf("CheckLang returned error: %v", err)
+	}
+
+	expected := gitseek.FileInfo{
+		LangPath: "content/pl/docs/test.md",
+		LangLastCommit: git.CommitInfo{
+			CommitID: "4c0887027d3bbcf5b31519ea9cdb8da01c1c9c31",
+			DateTime: "2020-01-03T00:00:00+00:00",
+			Comment:  "B: add content/pl/docs/test.md",
+		},
+		LangMergeCommit:  nil,
+		LangForkCommit:   nil,
+		FileStatus:       gitseek.StatusEnFileMoved,
+		StartPointSource: gitseek.StartPointSourceLangLastCommit,
+		EnUpdates: []gitseek.EnUpdate{
+			{
+				Commit: git.CommitInfo{
+					CommitID: "22560b6e14cb52754b7791ff8e06c2c1b183bbe0",
+					DateTime: "2020-01-05T00:00:00+00:00",
+					Comment:  "D: update content/en/docs/moved/test.md",
+				},
+				MergePoint: nil,
+				ChangeKind: gitseek.ChangeKindProse,
+				Overlap:    gitseek.OverlapAfterMerge,
+			},
+		},
+		MovedToEnPath:     "content/en/docs/moved/test.md",
+		SuggestedLangPath: "content/pl/docs/moved/test.md",
+	}
+
+	assertEqualFileInfo(t, expected, result)
+}
+
 func TestGitSeek_CheckLang_UseCase_EnFileDoesNotExist(t *testing.T) {
 	ctx := t.Context()
 	env := newIntegrationEnv(t, "en_file_does_not_exist")
@@ -159,10 +221,11 @@ func TestGitSeek_CheckLang_UseCase_EnFileDoesNotExist(t *testing.T) {
 			DateTime: "2020-01-02T00:00:00+00:00",
 			Comment:  "B: add content/pl/docs/test.md",
 		},
-		LangMergeCommit: nil,
-		LangForkCommit:  nil,
-		FileStatus:      gitseek.StatusEnFileDoesNotExist,
-		EnUpdates:       nil,
+		LangMergeCommit:  nil,
+		LangForkCommit:   nil,
+		FileStatus:       gitseek.StatusEnFileDoesNotExist,
+		StartPointSource: gitseek.StartPointSourceLangLastCommit,
+		EnUpdates:        nil,
 	}
 
 	assertEqualFileInfo(t, expected, result)
@@ -194,8 +257,9 @@ func TestGitSeek_CheckLang_UseCase_ForkCommitExistsWithoutEnUpdatesAfterFork(t *
 			DateTime: "2020-01-02T00:00:00+00:00",
 			Comment:  "A: add content/en/docs/test.md",
 		},
-		FileStatus: gitseek.StatusLangFileUpToDate,
-		EnUpdates:  nil,
+		FileStatus:       gitseek.StatusLangFileUpToDate,
+		StartPointSource: gitseek.StartPointSourceForkCommit,
+		EnUpdates:        nil,
 	}
 
 	assertEqualFileInfo(t, expected, result)
@@ -227,7 +291,8 @@ func TestGitSeek_CheckLang_UseCase_ForkCommitIsUsedAsStartPoint(t *testing.T) {
 			DateTime: "2020-01-02T00:00:00+00:00",
 			Comment:  "A: add content/en/docs/test.md",
 		},
-		FileStatus: gitseek.StatusEnFileUpdated,
+		FileStatus:       gitseek.StatusEnFileUpdated,
+		StartPointSource: gitseek.StartPointSourceForkCommit,
 		EnUpdates: []gitseek.EnUpdate{
 			{
 				Commit: git.CommitInfo{
@@ -236,6 +301,8 @@ func TestGitSeek_CheckLang_UseCase_ForkCommitIsUsedAsStartPoint(t *testing.T) {
 					Comment:  "C: update content/en/docs/test.md on main after merge",
 				},
 				MergePoint: nil,
+				ChangeKind: gitseek.ChangeKindProse,
+				Overlap:    gitseek.OverlapAfterMerge,
 			},
 		},
 	}
@@ -269,7 +336,8 @@ func TestGitSeek_CheckLang_UseCase_LangLastCommitNewerByDateButForkStillDetermin
 			DateTime: "2020-01-02T00:00:00+00:00",
 			Comment:  "A: add content/en/docs/test.md",
 		},
-		FileStatus: gitseek.StatusEnFileUpdated,
+		FileStatus:       gitseek.StatusEnFileUpdated,
+		StartPointSource: gitseek.StartPointSourceForkCommit,
 		EnUpdates: []gitseek.EnUpdate{
 			{
 				Commit: git.CommitInfo{
@@ -278,6 +346,8 @@ func TestGitSeek_CheckLang_UseCase_LangLastCommitNewerByDateButForkStillDetermin
 					Comment:  "C: update content/en/docs/test.md on main",
 				},
 				MergePoint: nil,
+				ChangeKind: gitseek.ChangeKindProse,
+				Overlap:    gitseek.OverlapDuringBranch,
 			},
 		},
 	}
@@ -307,9 +377,10 @@ func TestGitSeek_CheckLang_UseCase_EnUpdatedOnMergedBranchAfterLang(t *testing.T
 			DateTime: "2020-01-03T00:00:00+00:00",
 			Comment:  "B: add content/pl/docs/test.md",
 		},
-		LangMergeCommit: nil,
-		LangForkCommit:  nil,
-		FileStatus:      gitseek.StatusEnFileUpdated,
+		LangMergeCommit:  nil,
+		LangForkCommit:   nil,
+		FileStatus:       gitseek.StatusEnFileUpdated,
+		StartPointSource: gitseek.StartPointSourceLangLastCommit,
 		EnUpdates: []gitseek.EnUpdate{
 			{
 				Commit: git.CommitInfo{
@@ -318,6 +389,8 @@ func TestGitSeek_CheckLang_UseCase_EnUpdatedOnMergedBranchAfterLang(t *testing.T
 					Comment:  "C: update content/en/docs/test.md on en-branch",
 				},
 				MergePoint: mergePoint,
+				ChangeKind: gitseek.ChangeKindProse,
+				Overlap:    gitseek.OverlapAfterMerge,
 			},
 		},
 	}
@@ -347,9 +420,10 @@ func TestGitSeek_CheckLang_UseCase_MultipleEnUpdatesOnMergedBranchAfterLang(t *t
 			DateTime: "2020-01-03T00:00:00+00:00",
 			Comment:  "B: add content/pl/docs/test.md",
 		},
-		LangMergeCommit: nil,
-		LangForkCommit:  nil,
-		FileStatus:      gitseek.StatusEnFileUpdated,
+		LangMergeCommit:  nil,
+		LangForkCommit:   nil,
+		FileStatus:       gitseek.StatusEnFileUpdated,
+		StartPointSource: gitseek.StartPointSourceLangLastCommit,
 		EnUpdates: []gitseek.EnUpdate{
 			{
 				Commit: git.CommitInfo{
@@ -358,6 +432,8 @@ func TestGitSeek_CheckLang_UseCase_MultipleEnUpdatesOnMergedBranchAfterLang(t *t
 					Comment:  "D: update content/en/docs/test.md on en-branch again",
 				},
 				MergePoint: mergePoint,
+				ChangeKind: gitseek.ChangeKindProse,
+				Overlap:    gitseek.OverlapAfterMerge,
 			},
 			{
 				Commit: git.CommitInfo{
@@ -366,6 +442,8 @@ func TestGitSeek_CheckLang_UseCase_MultipleEnUpdatesOnMergedBranchAfterLang(t *t
 					Comment:  "C: update content/en/docs/test.md on en-branch",
 				},
 				MergePoint: mergePoint,
+				ChangeKind: gitseek.ChangeKindProse,
+				Overlap:    gitseek.OverlapAfterMerge,
 			},
 		},
 	}
@@ -395,9 +473,10 @@ func TestGitSeek_CheckLang_UseCase_MergeCommitBringsEnUpdatesWithoutDirectEnChan
 			DateTime: "2020-01-03T00:00:00+00:00",
 			Comment:  "B: add content/pl/docs/test.md",
 		},
-		LangMergeCommit: nil,
-		LangForkCommit:  nil,
-		FileStatus:      gitseek.StatusEnFileUpdated,
+		LangMergeCommit:  nil,
+		LangForkCommit:   nil,
+		FileStatus:       gitseek.StatusEnFileUpdated,
+		StartPointSource: gitseek.StartPointSourceLangLastCommit,
 		EnUpdates: []gitseek.EnUpdate{
 			{
 				Commit: git.CommitInfo{
@@ -406,6 +485,95 @@ func TestGitSeek_CheckLang_UseCase_MergeCommitBringsEnUpdatesWithoutDirectEnChan
 					Comment:  "C: update content/en/docs/test.md on en-branch",
 				},
 				MergePoint: mergePoint,
+				ChangeKind: gitseek.ChangeKindProse,
+				Overlap:    gitseek.OverlapAfterMerge,
+			},
+		},
+	}
+
+	assertEqualFileInfo(t, expected, result)
+}
+
+func TestGitSeek_CheckLang_UseCase_EnUpdatesWithDifferentChangeKinds(t *testing.T) {
+	ctx := t.Context()
+	env := newIntegrationEnv(t, "en_updates_with_different_change_kinds")
+
+	result, err := env.gitSeeker.CheckLang(ctx, "pl", env.pair)
+	if err != nil {
+		t.Fatalf("CheckLang returned error: %v", err)
+	}
+
+	if result.FileStatus != gitseek.StatusEnFileUpdated {
+		t.Fatalf("unexpected status: got %q, want %q", result.FileStatus, gitseek.StatusEnFileUpdated)
+	}
+
+	expectedChangeKinds := map[string]string{
+		"G: prose change in content/en/docs/test.md":        gitseek.ChangeKindProse,
+		"F: link change in content/en/docs/test.md":         gitseek.ChangeKindLinkOnly,
+		"E: code block change in content/en/docs/test.md":   gitseek.ChangeKindCodeBlockOnly,
+		"D: front matter change in content/en/docs/test.md": gitseek.ChangeKindFrontMatterOnly,
+		"C: whitespace change in content/en/docs/test.md":   gitseek.ChangeKindWhitespaceOnly,
+	}
+
+	if len(result.EnUpdates) != len(expectedChangeKinds) {
+		t.Fatalf("expected %d EN updates, got %d", len(expectedChangeKinds), len(result.EnUpdates))
+	}
+
+	for _, enUpdate := range result.EnUpdates {
+		expected, ok := expectedChangeKinds[enUpdate.Commit.Comment]
+		if !ok {
+			t.Fatalf("unexpected EN update: %#v", enUpdate.Commit)
+		}
+
+		if enUpdate.ChangeKind != expected {
+			t.Fatalf("unexpected change kind for %q: got %q, want %q", enUpdate.Commit.Comment, enUpdate.ChangeKind, expected)
+		}
+	}
+}
+
+func TestGitSeek_CheckLang_UseCase_SyncTrailerIsUsedAsStartPoint(t *testing.T) {
+	ctx := t.Context()
+	env := newIntegrationEnv(t, "sync_trailer_is_used_as_start_point")
+
+	result, err := env.gitSeeker.CheckLang(ctx, "pl", env.pair)
+	if err != nil {
+		t.Fatalf("CheckLang returned error: %v", err)
+	}
+
+	expected := gitseek.FileInfo{
+		LangPath: "content/pl/docs/test.md",
+		LangLastCommit: git.CommitInfo{
+			CommitID: "d4dcb611162257b50b74cda4a60e31d85b0e7fb6",
+			DateTime: "2020-01-04T00:00:00+00:00",
+			Comment:  "C: add content/pl/docs/test.md on pl-branch",
+		},
+		LangMergeCommit: &git.CommitInfo{
+			CommitID: "c96e5a43bd625605ada127312197714e46197524",
+			DateTime: "2020-01-05T00:00:00+00:00",
+			Comment:  "Merge branch 'pl-branch'",
+		},
+		LangForkCommit: &git.CommitInfo{
+			CommitID: "a0817c4b1ebc34b35b7a726d63532ef3e835b1b6",
+			DateTime: "2020-01-02T00:00:00+00:00",
+			Comment:  "A: add content/en/docs/test.md",
+		},
+		FileStatus:       gitseek.StatusEnFileUpdated,
+		StartPointSource: gitseek.StartPointSourceSyncTrailer,
+		SyncedWithCommit: &git.CommitInfo{
+			CommitID: "c7560e7cc7c958e425c177463262071442ad4366",
+			DateTime: "2020-01-03T00:00:00+00:00",
+			Comment:  "B: update content/en/docs/test.md on main during translation",
+		},
+		EnUpdates: []gitseek.EnUpdate{
+			{
+				Commit: git.CommitInfo{
+					CommitID: "c213b9c8482b61d354e54bbc95e18fcf05ffee19",
+					DateTime: "2020-01-06T00:00:00+00:00",
+					Comment:  "D: update content/en/docs/test.md on main after merge",
+				},
+				MergePoint: nil,
+				ChangeKind: gitseek.ChangeKindProse,
+				Overlap:    gitseek.OverlapAfterSyncMarker,
 			},
 		},
 	}
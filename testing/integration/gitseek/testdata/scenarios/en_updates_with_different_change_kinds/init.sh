@@ -0,0 +1,63 @@
+#!/bin/bash
+
+set -e
+
+rm -rf repo
+mkdir -p repo
+cd repo
+
+git init -b main
+git config --local user.name testuser
+git config --local user.email testuser@foo.com
+
+BASE_DATE="2020-01-01T00:00:00Z"
+DATE="$BASE_DATE"
+
+increment_date() {
+  DATE=$(TZ=UTC date -u -d "$DATE +1 day" +"%Y-%m-%dT%H:%M:%SZ")
+}
+
+export GIT_AUTHOR_NAME="testuser"
+export GIT_AUTHOR_EMAIL="testuser@foo.com"
+export GIT_COMMITTER_NAME="testuser"
+export GIT_COMMITTER_EMAIL="testuser@foo.com"
+
+mkdir -p content/en/docs
+mkdir -p content/pl/docs
+
+increment_date
+printf -- "---\ntitle: Test\nweight: 10\n---\n\nSome text.\n\n\`\`\`shell\nkubectl get pods\n\`\`\`\n\nSee [docs](/docs/old/).\n" > content/en/docs/test.md
+git add content/en/docs/test.md
+GIT_AUTHOR_DATE=$DATE GIT_COMMITTER_DATE=$DATE git commit -m "A: add content/en/docs/test.md"
+
+increment_date
+echo "B" > content/pl/docs/test.md
+git add content/pl/docs/test.md
+GIT_AUTHOR_DATE=$DATE GIT_COMMITTER_DATE=$DATE git commit -m "B: add content/pl/docs/test.md"
+
+increment_date
+sed -i 's/^Some text.$/Some text.   /' content/en/docs/test.md
+git add content/en/docs/test.md
+GIT_AUTHOR_DATE=$DATE GIT_COMMITTER_DATE=$DATE git commit -m "C: whitespace change in content/en/docs/test.md"
+
+increment_date
+sed -i 's/^weight: 10$/weight: 20/' content/en/docs/test.md
+git add content/en/docs/test.md
+GIT_AUTHOR_DATE=$DATE GIT_COMMITTER_DATE=$DATE git commit -m "D: front matter change in content/en/docs/test.md"
+
+increment_date
+sed -i 's/^kubectl get pods$/kubectl get pods -A/' content/en/docs/test.md
+git add content/en/docs/test.md
+GIT_AUTHOR_DATE=$DATE GIT_COMMITTER_DATE=$DATE git commit -m "E: code block change in content/en/docs/test.md"
+
+increment_date
+sed -i 's|/docs/old/|/docs/new/|' content/en/docs/test.md
+git add content/en/docs/test.md
+GIT_AUTHOR_DATE=$DATE GIT_COMMITTER_DATE=$DATE git commit -m "F: link change in content/en/docs/test.md"
+
+increment_date
+sed -i 's/^Some text.   $/Other text./' content/en/docs/test.md
+git add content/en/docs/test.md
+GIT_AUTHOR_DATE=$DATE GIT_COMMITTER_DATE=$DATE git commit -m "G: prose change in content/en/docs/test.md"
+
+git --no-pager log --graph --all --decorate --date=iso-strict --pretty=format:"%H %cd %s"
@@ -0,0 +1,80 @@
+package prpreview_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/dkarczmarski/go-kweb-lang/filepairs"
+	"github.com/dkarczmarski/go-kweb-lang/git"
+	"github.com/dkarczmarski/go-kweb-lang/githist"
+	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+	"github.com/dkarczmarski/go-kweb-lang/prpreview"
+	"github.com/dkarczmarski/go-kweb-lang/store"
+)
+
+type integrationEnv struct {
+	tmpDir    string
+	gitRepo   *git.Git
+	previewer *prpreview.Previewer
+}
+
+func newIntegrationEnv(t *testing.T, scenarioName string) integrationEnv {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	scenarioDir := scenarioPath(t, scenarioName)
+
+	runScenarioScript(t, tmpDir, scenarioDir, "init.sh")
+
+	repoPath := filepath.Join(tmpDir, "repo")
+	cacheDir := filepath.Join(tmpDir, "cache")
+
+	gitRepo := git.NewRepo(repoPath)
+	cache := store.NewFileStore(cacheDir)
+
+	gitRepoHist := githist.New(gitRepo, cache)
+	gitSeeker := gitseek.New(gitRepo, gitRepoHist, cache)
+
+	return integrationEnv{
+		tmpDir:    tmpDir,
+		gitRepo:   gitRepo,
+		previewer: prpreview.New(gitRepo, gitSeeker, filepairs.New(), cache),
+	}
+}
+
+//nolint:gochecknoglobals
+var integrationScriptDebugOutput = true
+
+func scenarioPath(t *testing.T, scenarioName string) string {
+	t.Helper()
+
+	_, thisFile, _, ok := runtime.Caller(0)
+	if !ok {
+		t.Fatal("failed to determine current test file path")
+	}
+
+	baseDir := filepath.Dir(thisFile)
+
+	return filepath.Join(baseDir, "testdata", "scenarios", scenarioName)
+}
+
+func runScenarioScript(t *testing.T, workDir, scenarioDir, scriptName string) {
+	t.Helper()
+
+	scriptPath := filepath.Join(scenarioDir, scriptName)
+
+	cmd := exec.Command("bash", scriptPath)
+	cmd.Dir = workDir
+
+	if integrationScriptDebugOutput {
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+	}
+
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("script execution failed (%s): %v", scriptPath, err)
+	}
+}
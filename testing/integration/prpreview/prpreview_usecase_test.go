@@ -0,0 +1,148 @@
+package prpreview_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dkarczmarski/go-kweb-lang/git"
+	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+	"github.com/dkarczmarski/go-kweb-lang/prpreview"
+	"github.com/dkarczmarski/go-kweb-lang/pullreq"
+)
+
+// The head of PR #1 translates the EN file as of its fork point and misses the EN update
+// made on main afterwards. PR #2 declares with a Synced-With trailer that it translates
+// that update. The head of PR #3 has not been fetched, so it is not previewed. PR #4 does
+// not exist, so its head cannot be fetched and it is skipped.
+func TestPreviewer_UseCase_PRHeads(t *testing.T) {
+	ctx := t.Context()
+	env := newIntegrationEnv(t, "pr_heads")
+
+	const langPath = "content/pl/docs/test.md"
+
+	err := env.previewer.FetchHeads(ctx, pullreq.FilePRIndexData{
+		langPath: {{Number: 1}, {Number: 2}, {Number: 4}},
+	})
+	if err != nil {
+		t.Fatalf("FetchHeads returned error: %v", err)
+	}
+
+	previews, err := env.previewer.LangPreviews(ctx, "pl", pullreq.FilePRIndexData{
+		langPath: {{Number: 1}, {Number: 2}, {Number: 3}},
+	})
+	if err != nil {
+		t.Fatalf("LangPreviews returned error: %v", err)
+	}
+
+	commitA := git.CommitInfo{
+		CommitID: "a0817c4b1ebc34b35b7a726d63532ef3e835b1b6",
+		DateTime: "2020-01-02T00:00:00+00:00",
+		Comment:  "A: add content/en/docs/test.md",
+	}
+	commitB := git.CommitInfo{
+		CommitID: "ce459cf20fde4b675a80e0396943b9d27e1bd367",
+		DateTime: "2020-01-03T00:00:00+00:00",
+		Comment:  "B: add content/pl/docs/test.md on pr-1",
+	}
+	commitC := git.CommitInfo{
+		CommitID: "ea0e3256b3d8d8215940252c4163595a7a40f207",
+		DateTime: "2020-01-04T00:00:00+00:00",
+		Comment:  "C: update content/en/docs/test.md on main",
+	}
+	commitD := git.CommitInfo{
+		CommitID: "c261b675e75393a0463f4d2c88cae1c28cc655ee",
+		DateTime: "2020-01-05T00:00:00+00:00",
+		Comment:  "D: translate C in content/pl/docs/test.md on pr-2",
+	}
+
+	expected := prpreview.LangPreviews{
+		langPath: {
+			{
+				PRNumber:   1,
+				HeadCommit: commitB,
+				FileInfo: gitseek.FileInfo{
+					LangPath:         langPath,
+					LangLastCommit:   commitB,
+					LangMergeCommit:  nil,
+					LangForkCommit:   &commitA,
+					FileStatus:       gitseek.StatusEnFileUpdated,
+					StartPointSource: gitseek.StartPointSourceForkCommit,
+					EnUpdates: []gitseek.EnUpdate{
+						{
+							Commit:     commitC,
+							MergePoint: nil,
+							ChangeKind: gitseek.ChangeKindProse,
+							Overlap:    gitseek.OverlapDuringBranch,
+						},
+					},
+				},
+			},
+			{
+				PRNumber:   2,
+				HeadCommit: commitD,
+				FileInfo: gitseek.FileInfo{
+					LangPath:         langPath,
+					LangLastCommit:   commitD,
+					LangMergeCommit:  nil,
+					LangForkCommit:   &commitA,
+					FileStatus:       gitseek.StatusLangFileUpToDate,
+					StartPointSource: gitseek.StartPointSourceSyncTrailer,
+					SyncedWithCommit: &commitC,
+				},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(expected, previews) {
+		t.Fatalf("unexpected previews:\n got:  %#v\nwant: %#v", previews, expected)
+	}
+
+	if !previews[langPath][0].IsOutdated() || previews[langPath][1].IsOutdated() {
+		t.Fatal("expected only the preview of PR #1 to be outdated")
+	}
+
+	cachedPreviews, err := env.previewer.LangPreviews(ctx, "pl", pullreq.FilePRIndexData{
+		langPath: {{Number: 1}, {Number: 2}, {Number: 3}},
+	})
+	if err != nil {
+		t.Fatalf("LangPreviews returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(expected, cachedPreviews) {
+		t.Fatalf("unexpected cached previews:\n got:  %#v\nwant: %#v", cachedPreviews, expected)
+	}
+}
+
+// After PR #2 is closed, its fetched head is pruned and it is no longer previewed.
+func TestPreviewer_UseCase_PruneHeads(t *testing.T) {
+	ctx := t.Context()
+	env := newIntegrationEnv(t, "pr_heads")
+
+	const langPath = "content/pl/docs/test.md"
+
+	if err := env.previewer.FetchHeads(ctx, pullreq.FilePRIndexData{langPath: {{Number: 1}, {Number: 2}}}); err != nil {
+		t.Fatalf("FetchHeads returned error: %v", err)
+	}
+
+	if err := env.previewer.PruneHeads(ctx, []int{1}); err != nil {
+		t.Fatalf("PruneHeads returned error: %v", err)
+	}
+
+	head, err := env.gitRepo.FindCommit(ctx, git.PullRequestHeadRef(2))
+	if err != nil {
+		t.Fatalf("FindCommit returned error: %v", err)
+	}
+
+	if head.CommitID != "" {
+		t.Fatalf("expected the head of PR #2 to be pruned, got %v", head)
+	}
+
+	head, err = env.gitRepo.FindCommit(ctx, git.PullRequestHeadRef(1))
+	if err != nil {
+		t.Fatalf("FindCommit returned error: %v", err)
+	}
+
+	if head.CommitID == "" {
+		t.Fatal("expected the head of PR #1 to be kept")
+	}
+}
@@ -13,15 +13,20 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/dkarczmarski/go-kweb-lang/alignment"
 	"github.com/dkarczmarski/go-kweb-lang/dashboard"
 	"github.com/dkarczmarski/go-kweb-lang/filepairs"
+	"github.com/dkarczmarski/go-kweb-lang/frontmatter"
 	"github.com/dkarczmarski/go-kweb-lang/git"
 	"github.com/dkarczmarski/go-kweb-lang/githist"
 	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+	"github.com/dkarczmarski/go-kweb-lang/i18nkeys"
 	"github.com/dkarczmarski/go-kweb-lang/langcnt"
+	"github.com/dkarczmarski/go-kweb-lang/prpreview"
 	"github.com/dkarczmarski/go-kweb-lang/pullreq"
 	"github.com/dkarczmarski/go-kweb-lang/store"
 	"github.com/dkarczmarski/go-kweb-lang/tasks"
+	"github.com/dkarczmarski/go-kweb-lang/untranslated"
 	"github.com/dkarczmarski/go-kweb-lang/web"
 )
 
@@ -31,6 +36,8 @@ type refreshDashboardRenderEnv struct {
 	tmpDir         string
 	scenarioDir    string
 	dashboardStore *dashboard.Store
+	ackStore       *dashboard.AckStore
+	gitRepo        *git.Git
 	task           *tasks.RefreshDashboardTask
 }
 
@@ -44,8 +51,8 @@ func TestRefreshDashboardTask_Run_RenderHTML_Integration(t *testing.T) {
 		"multiple_en_updates_on_merged_branch_after_lang",
 		map[string]pullreq.FilePRIndexData{
 			"pl": {
-				"content/pl/docs/test.md":    {101, 102},
-				"content/pl/docs/missing.md": {999},
+				"content/pl/docs/test.md":    {{Number: 101}, {Number: 102}},
+				"content/pl/docs/missing.md": {{Number: 999}},
 			},
 		},
 	)
@@ -67,32 +74,46 @@ func TestRefreshDashboardTask_Run_RenderHTML_Integration(t *testing.T) {
 		t.Fatalf("expected 2 dashboard items, got %d", len(langDashboard.Items))
 	}
 
-	indexHTML := renderResponseBody(
+	reposHTML := renderResponseBody(
 		t,
-		env.dashboardStore,
+		env,
 		http.MethodGet,
 		"/",
 		"",
 	)
+	assertContainsAll(
+		t,
+		string(reposHTML),
+		"Repository",
+		`href="/repos/website"`,
+	)
+
+	indexHTML := renderResponseBody(
+		t,
+		env,
+		http.MethodGet,
+		"/repos/website",
+		"",
+	)
 	assertContainsAll(
 		t,
 		string(indexHTML),
 		"Lang",
-		`href="/lang/pl"`,
+		`href="/repos/website/lang/pl"`,
 	)
 	writeRenderedHTMLIfEnabled(t, "dashboard-index.html", indexHTML)
 
 	pageHTML := renderResponseBody(
 		t,
-		env.dashboardStore,
+		env,
 		http.MethodGet,
-		"/lang/pl",
+		"/repos/website/lang/pl",
 		"",
 	)
 	assertContainsAll(
 		t,
 		string(pageHTML),
-		"<h3>pl</h3>",
+		`<a href="/repos/website" class="text-decoration-none">website</a> / pl</h3>`,
 		"content/pl/docs/test.md",
 		"content/pl/docs/missing.md",
 		"waiting-for-review",
@@ -104,11 +125,26 @@ func TestRefreshDashboardTask_Run_RenderHTML_Integration(t *testing.T) {
 	)
 	writeRenderedHTMLIfEnabled(t, "dashboard-pl-full.html", pageHTML)
 
+	// links from before repositories were introduced show the first repository
+	legacyPageHTML := renderResponseBody(
+		t,
+		env,
+		http.MethodGet,
+		"/lang/pl",
+		"",
+	)
+	assertContainsAll(
+		t,
+		string(legacyPageHTML),
+		`hx-post="/repos/website/lang/pl"`,
+		"content/pl/docs/test.md",
+	)
+
 	tableHTML := renderResponseBody(
 		t,
-		env.dashboardStore,
+		env,
 		http.MethodPost,
-		"/lang/pl",
+		"/repos/website/lang/pl",
 		url.Values{
 			"itemsType": []string{"with-update-or-pr"},
 			"filepath":  []string{"content/pl/docs"},
@@ -127,9 +163,9 @@ func TestRefreshDashboardTask_Run_RenderHTML_Integration(t *testing.T) {
 
 	filteredHTML := renderResponseBody(
 		t,
-		env.dashboardStore,
+		env,
 		http.MethodPost,
-		"/lang/pl",
+		"/repos/website/lang/pl",
 		url.Values{
 			"itemsType": []string{"with-pr"},
 			"filepath":  []string{"missing.md"},
@@ -145,6 +181,222 @@ func TestRefreshDashboardTask_Run_RenderHTML_Integration(t *testing.T) {
 	writeRenderedHTMLIfEnabled(t, "dashboard-pl-filtered-missing.html", filteredHTML)
 }
 
+func TestRefreshDashboardTask_Run_AckedUpdates_Integration(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	env := newRefreshDashboardRenderEnv(
+		t,
+		"multiple_en_updates_on_merged_branch_after_lang",
+		map[string]pullreq.FilePRIndexData{},
+	)
+
+	if err := env.task.Run(ctx); err != nil {
+		t.Fatalf("RefreshDashboardTask.Run returned error: %v", err)
+	}
+
+	item := findDashboardItem(t, env, "content/pl/docs/test.md")
+	if len(item.EnUpdates) == 0 {
+		t.Fatalf("expected EN updates for %s", item.LangPath)
+	}
+
+	enUpdates := item.EnUpdates
+	for _, enUpdate := range enUpdates {
+		tableHTML := renderResponseBody(
+			t,
+			env,
+			http.MethodPost,
+			"/repos/website/lang/pl/ack?"+url.Values{
+				"langPath": []string{item.LangPath},
+				"commitId": []string{enUpdate.Commit.CommitID},
+			}.Encode(),
+			url.Values{"filename": []string{item.LangPath}}.Encode(),
+		)
+		assertContainsAll(t, string(tableHTML), "<table", "Acknowledged:")
+	}
+
+	// the acknowledgements must survive a dashboard refresh
+	if err := env.task.Run(ctx); err != nil {
+		t.Fatalf("RefreshDashboardTask.Run returned error: %v", err)
+	}
+
+	item = findDashboardItem(t, env, "content/pl/docs/test.md")
+	if item.FileStatus != gitseek.StatusLangFileUpToDate {
+		t.Fatalf("expected status %q, got %q", gitseek.StatusLangFileUpToDate, item.FileStatus)
+	}
+
+	if len(item.EnUpdates) != 0 || len(item.AckedEnUpdates) != len(enUpdates) {
+		t.Fatalf("unexpected updates after ack: %#v / %#v", item.EnUpdates, item.AckedEnUpdates)
+	}
+
+	renderResponseBody(
+		t,
+		env,
+		http.MethodPost,
+		"/repos/website/lang/pl/unack",
+		url.Values{
+			"langPath": []string{item.LangPath},
+			"commitId": []string{enUpdates[0].Commit.CommitID},
+		}.Encode(),
+	)
+
+	// the handler changes only the ack store, the stored dashboard follows on the next refresh
+	if err := env.task.Run(ctx); err != nil {
+		t.Fatalf("RefreshDashboardTask.Run returned error: %v", err)
+	}
+
+	item = findDashboardItem(t, env, "content/pl/docs/test.md")
+	if item.FileStatus != gitseek.StatusEnFileUpdated {
+		t.Fatalf("expected status %q, got %q", gitseek.StatusEnFileUpdated, item.FileStatus)
+	}
+
+	if len(item.EnUpdates) != 1 || item.EnUpdates[0].Commit.CommitID != enUpdates[0].Commit.CommitID {
+		t.Fatalf("unexpected updates after unack: %#v", item.EnUpdates)
+	}
+}
+
+func TestRefreshDashboardTask_Run_SkipsFailedContentChecks_Integration(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	env := newRefreshDashboardRenderEnv(
+		t,
+		"multiple_en_updates_on_merged_branch_after_lang",
+		map[string]pullreq.FilePRIndexData{},
+	)
+
+	// the front matter of the language file cannot be parsed
+	for path, content := range map[string]string{
+		"content/en/docs/test.md": "---\ntitle: Test\n---\n\nText.\n",
+		"content/pl/docs/test.md": "---\ntitle: [unclosed\n---\n\nTekst.\n",
+	} {
+		if err := os.WriteFile(filepath.Join(env.tmpDir, "repo", path), []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile returned error: %v", err)
+		}
+	}
+
+	if err := env.task.Run(ctx); err != nil {
+		t.Fatalf("RefreshDashboardTask.Run returned error: %v", err)
+	}
+
+	item := findDashboardItem(t, env, "content/pl/docs/test.md")
+	if item.FrontMatterMismatches != nil {
+		t.Fatalf("expected no front matter findings, got %#v", item.FrontMatterMismatches)
+	}
+
+	if len(item.EnUpdates) == 0 {
+		t.Fatalf("expected EN updates for %s", item.LangPath)
+	}
+}
+
+func TestRefreshDashboardTask_Run_EnPatch_Integration(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	env := newRefreshDashboardRenderEnv(
+		t,
+		"multiple_en_updates_on_merged_branch_after_lang",
+		map[string]pullreq.FilePRIndexData{},
+	)
+
+	if err := env.task.Run(ctx); err != nil {
+		t.Fatalf("RefreshDashboardTask.Run returned error: %v", err)
+	}
+
+	item := findDashboardItem(t, env, "content/pl/docs/test.md")
+	if item.EnPath != "content/en/docs/test.md" {
+		t.Fatalf("unexpected EN path %q", item.EnPath)
+	}
+
+	if len(item.EnUpdates) == 0 {
+		t.Fatalf("expected EN updates for %s", item.LangPath)
+	}
+
+	patch := renderResponseBody(
+		t,
+		env,
+		http.MethodGet,
+		"/repos/website/lang/pl/patch?"+url.Values{"langPath": []string{item.LangPath}}.Encode(),
+		"",
+	)
+	assertContainsAll(t, string(patch), "diff --git a/content/en/docs/test.md b/content/en/docs/test.md")
+
+	commitPatch := renderResponseBody(
+		t,
+		env,
+		http.MethodGet,
+		"/repos/website/lang/pl/patch?"+url.Values{
+			"langPath": []string{item.LangPath},
+			"commitId": []string{item.EnUpdates[0].Commit.CommitID},
+		}.Encode(),
+		"",
+	)
+	assertContainsAll(
+		t,
+		string(commitPatch),
+		"From "+item.EnUpdates[0].Commit.CommitID,
+		"Subject: [PATCH]",
+		"diff --git a/content/en/docs/test.md b/content/en/docs/test.md",
+	)
+}
+
+func TestRefreshDashboardTask_Run_FileViewer_Integration(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	env := newRefreshDashboardRenderEnv(
+		t,
+		"multiple_en_updates_on_merged_branch_after_lang",
+		map[string]pullreq.FilePRIndexData{},
+	)
+
+	if err := env.task.Run(ctx); err != nil {
+		t.Fatalf("RefreshDashboardTask.Run returned error: %v", err)
+	}
+
+	dashboardHTML := renderResponseBody(t, env, http.MethodGet, "/repos/website/lang/pl", "")
+	assertContainsAll(t, string(dashboardHTML), "/repos/website/lang/pl/view?langPath=content%2Fpl%2Fdocs%2Ftest.md")
+
+	viewerHTML := renderResponseBody(
+		t,
+		env,
+		http.MethodGet,
+		"/repos/website/lang/pl/view?"+url.Values{"langPath": []string{"content/pl/docs/test.md"}}.Encode(),
+		"",
+	)
+	assertContainsAll(
+		t,
+		string(viewerHTML),
+		"content/en/docs/test.md at ",
+		"content/en/docs/test.md at HEAD",
+		`class="added"`,
+	)
+	writeRenderedHTMLIfEnabled(t, "file-viewer-pl.html", viewerHTML)
+}
+
+func findDashboardItem(t *testing.T, env refreshDashboardRenderEnv, langPath string) dashboard.Item {
+	t.Helper()
+
+	langDashboard, err := env.dashboardStore.ReadDashboard("pl")
+	if err != nil {
+		t.Fatalf("ReadDashboard returned error: %v", err)
+	}
+
+	for _, item := range langDashboard.Items {
+		if item.LangPath == langPath {
+			return item
+		}
+	}
+
+	t.Fatalf("dashboard item %s not found", langPath)
+
+	return dashboard.Item{}
+}
+
 func newRefreshDashboardRenderEnv(
 	t *testing.T,
 	scenarioName string,
@@ -169,16 +421,29 @@ func newRefreshDashboardRenderEnv(
 		RepoDir: repoPath,
 	}
 
-	contentPairProvider := filepairs.NewContentPairProvider(gitRepo)
-	pairProviders := filepairs.NewPairProviders(contentPairProvider)
+	contentMatchers, err := filepairs.CompilePairRules(filepairs.DefaultPairRules()[:1])
+	if err != nil {
+		t.Fatalf("CompilePairRules returned error: %v", err)
+	}
+
+	pairProviders := filepairs.NewPairProviders(filepairs.NewRulePairProvider(contentMatchers[0], gitRepo))
 
 	dashboardStore := dashboard.NewStore(cacheStore)
+	ackStore := dashboard.NewAckStore(cacheStore)
 
 	task := tasks.NewRefreshDashboardTask(
 		langCodesProvider,
 		pairProviders,
 		gitSeeker,
+		tasks.ContentCheckers{
+			I18NKeys:     i18nkeys.New(gitRepo),
+			FrontMatter:  frontmatter.New(gitRepo),
+			Alignment:    alignment.New(gitRepo, cacheStore),
+			Untranslated: untranslated.New(gitRepo),
+		},
 		fakeFilePRIndex{data: prIndexByLang},
+		prpreview.New(gitRepo, gitSeeker, filepairs.New(), cacheStore),
+		ackStore,
 		dashboardStore,
 	)
 
@@ -186,6 +451,8 @@ func newRefreshDashboardRenderEnv(
 		tmpDir:         tmpDir,
 		scenarioDir:    scenarioDir,
 		dashboardStore: dashboardStore,
+		ackStore:       ackStore,
+		gitRepo:        gitRepo,
 		task:           task,
 	}
 }
@@ -202,16 +469,26 @@ func (f fakeFilePRIndex) LangIndex(langCode string) (pullreq.FilePRIndexData, er
 	return pullreq.FilePRIndexData{}, nil
 }
 
+func (f fakeFilePRIndex) LangPRInfos(string) (pullreq.PRInfoIndexData, error) {
+	return pullreq.PRInfoIndexData{}, nil
+}
+
 func renderResponseBody(
 	t *testing.T,
-	dashboardStore *dashboard.Store,
+	env refreshDashboardRenderEnv,
 	method string,
 	targetURL string,
 	formBody string,
 ) []byte {
 	t.Helper()
 
-	handler := web.NewHandler(dashboardStore)
+	handler := web.NewHandler([]web.Repository{{
+		Name:           "website",
+		DashboardStore: env.dashboardStore,
+		AckStore:       env.ackStore,
+		Links:          web.DefaultGitHubLinks(),
+		GitRepo:        env.gitRepo,
+	}})
 	mux := http.NewServeMux()
 	handler.Register(mux)
 
@@ -0,0 +1,193 @@
+// Package untranslated detects language files that were copied from the EN file
+// and never translated, or that contain large untranslated EN sections. Such files
+// are reported by gitseek as up to date, because they were committed after the EN file.
+//
+// The bodies of both files, without the front matter and code blocks, are split
+// into paragraphs, and the share of the language file that is made of paragraphs
+// identical to paragraphs of the EN file is measured.
+package untranslated
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+	"github.com/dkarczmarski/go-kweb-lang/pagecontent"
+)
+
+// DefaultMinPercent is the default share of untranslated content
+// from which a file is reported.
+const DefaultMinPercent = 30
+
+// Content describes the untranslated content of a language file.
+type Content struct {
+	// Percent is the share of the bytes of the language file paragraphs
+	// that are identical to paragraphs of the EN file.
+	Percent int
+
+	IdenticalParagraphs int
+	Paragraphs          int
+
+	// Copy is true if the body of the language file is byte-identical to the EN file.
+	Copy bool
+}
+
+// GitRepo is an interface used to decouple this package from the concrete git implementation.
+type GitRepo interface {
+	ReadFile(path string) (string, error)
+}
+
+type Detector struct {
+	gitRepo    GitRepo
+	minPercent int
+}
+
+type NewConfig struct {
+	// MinPercent is the share of untranslated content from which a file is reported.
+	// The default is DefaultMinPercent.
+	MinPercent int
+}
+
+func New(gitRepo GitRepo, opts ...func(config *NewConfig)) *Detector {
+	config := NewConfig{
+		MinPercent: DefaultMinPercent,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return &Detector{
+		gitRepo:    gitRepo,
+		minPercent: config.MinPercent,
+	}
+}
+
+// CheckPair measures the untranslated content of the current language file
+// of the pair compared with the current EN file.
+//
+// It returns nil if the share of untranslated content is below the configured minimum,
+// for files other than Markdown and HTML pages and if the language file or the EN file
+// does not exist.
+func (d *Detector) CheckPair(_ context.Context, pair gitseek.Pair, fileInfo gitseek.FileInfo) (*Content, error) {
+	if !pagecontent.IsPagePair(pair, fileInfo) {
+		return nil, nil
+	}
+
+	enContent, err := d.gitRepo.ReadFile(pair.EnPath)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", pair.EnPath, err)
+	}
+
+	langContent, err := d.gitRepo.ReadFile(pair.LangPath)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", pair.LangPath, err)
+	}
+
+	content := Measure(enContent, langContent)
+	if content == nil || content.Percent < d.minPercent {
+		return nil, nil
+	}
+
+	return content, nil
+}
+
+// Measure returns the untranslated content of the language content
+// compared with the EN content, or nil if the language content has no prose.
+func Measure(enContent, langContent string) *Content {
+	enParagraphs := make(map[string]struct{})
+	for _, paragraph := range paragraphs(enContent) {
+		enParagraphs[paragraph] = struct{}{}
+	}
+
+	langParagraphs := paragraphs(langContent)
+	if len(langParagraphs) == 0 {
+		return nil
+	}
+
+	content := Content{
+		Paragraphs: len(langParagraphs),
+		Copy:       pagecontent.Split(enContent).Body == pagecontent.Split(langContent).Body,
+	}
+
+	var identicalBytes, totalBytes int
+
+	for _, paragraph := range langParagraphs {
+		totalBytes += len(paragraph)
+
+		if _, ok := enParagraphs[paragraph]; ok {
+			identicalBytes += len(paragraph)
+			content.IdenticalParagraphs++
+		}
+	}
+
+	content.Percent = identicalBytes * 100 / totalBytes
+
+	return &content
+}
+
+// paragraphs returns the paragraphs of the body of the content with trimmed lines.
+// Code blocks and paragraphs without prose, such as lone shortcodes, are skipped.
+func paragraphs(content string) []string {
+	var (
+		result  []string
+		current []string
+		fence   string
+	)
+
+	flush := func() {
+		if len(current) > 0 && hasProse(current) {
+			result = append(result, strings.Join(current, "\n"))
+		}
+
+		current = nil
+	}
+
+	for line := range strings.SplitSeq(pagecontent.Split(content).Body, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			flush()
+
+			fence = trimmed[:3]
+
+			continue
+		}
+
+		if trimmed == "" {
+			flush()
+
+			continue
+		}
+
+		current = append(current, trimmed)
+	}
+
+	flush()
+
+	return result
+}
+
+// hasProse reports whether the paragraph has letters outside shortcodes.
+func hasProse(lines []string) bool {
+	for _, line := range lines {
+		if strings.HasPrefix(line, "{{") && strings.HasSuffix(line, "}}") {
+			continue
+		}
+
+		if strings.IndexFunc(line, unicode.IsLetter) >= 0 {
+			return true
+		}
+	}
+
+	return false
+}
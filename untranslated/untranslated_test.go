@@ -0,0 +1,209 @@
+package untranslated_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+	"github.com/dkarczmarski/go-kweb-lang/untranslated"
+)
+
+var errFileNotFound = errors.New("file not found")
+
+type fakeGitRepo map[string]string
+
+func (f fakeGitRepo) ReadFile(path string) (string, error) {
+	content, ok := f[path]
+	if !ok {
+		return "", errFileNotFound
+	}
+
+	return content, nil
+}
+
+var pagePair = gitseek.Pair{
+	EnPath:   "content/en/docs/a.md",
+	LangPath: "content/pl/docs/a.md",
+}
+
+const enPage = `---
+title: Pods
+weight: 10
+---
+
+## Overview
+
+Pods are the smallest deployable units of computing
+that you can create and manage in Kubernetes.
+
+` + "```yaml" + `
+kind: Pod
+` + "```" + `
+
+{{< note >}}
+A Pod can contain init containers.
+{{< /note >}}
+`
+
+func TestMeasure(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name string
+		lang string
+		want *untranslated.Content
+	}{
+		{
+			name: "translated",
+			lang: `---
+title: Pody
+weight: 10
+---
+
+## Przegląd
+
+Pody to najmniejsze jednostki obliczeniowe,
+które można tworzyć i zarządzać nimi w Kubernetesie.
+
+` + "```yaml" + `
+kind: Pod
+` + "```" + `
+
+{{< note >}}
+Pod może zawierać kontenery inicjujące.
+{{< /note >}}
+`,
+			want: &untranslated.Content{Percent: 0, IdenticalParagraphs: 0, Paragraphs: 3},
+		},
+		{
+			name: "untranslated section",
+			lang: `---
+title: Pody
+---
+
+## Przegląd
+
+Pods are the smallest deployable units of computing
+that you can create and manage in Kubernetes.
+
+{{< note >}}
+Pod może zawierać kontenery inicjujące.
+{{< /note >}}
+`,
+			want: &untranslated.Content{Percent: 54, IdenticalParagraphs: 1, Paragraphs: 3},
+		},
+		{
+			name: "copy with a translated title",
+			lang: `---
+title: Pody
+weight: 10
+---
+
+## Overview
+
+Pods are the smallest deployable units of computing
+that you can create and manage in Kubernetes.
+
+` + "```yaml" + `
+kind: Pod
+` + "```" + `
+
+{{< note >}}
+A Pod can contain init containers.
+{{< /note >}}
+`,
+			want: &untranslated.Content{Percent: 100, IdenticalParagraphs: 3, Paragraphs: 3, Copy: true},
+		},
+		{
+			name: "no prose",
+			lang: "---\ntitle: Pody\n---\n\n{{< glossary >}}\n\n```\nkind: Pod\n```\n",
+			want: nil,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := untranslated.Measure(enPage, tc.lang)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("unexpected content:\n got:  %#v\nwant: %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDetector_CheckPair(t *testing.T) {
+	t.Parallel()
+
+	gitRepo := fakeGitRepo{
+		"content/en/docs/a.md": enPage,
+		"content/pl/docs/a.md": "## Przegląd\n\nPods are the smallest deployable units of computing\n" +
+			"that you can create and manage in Kubernetes.\n\nPod może zawierać kontenery inicjujące.\n",
+	}
+
+	fileInfo := gitseek.FileInfo{FileStatus: gitseek.StatusLangFileUpToDate}
+
+	got, err := untranslated.New(gitRepo).CheckPair(t.Context(), pagePair, fileInfo)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := &untranslated.Content{Percent: 64, IdenticalParagraphs: 1, Paragraphs: 3}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected content:\n got:  %#v\nwant: %#v", got, want)
+	}
+
+	detector := untranslated.New(gitRepo, func(config *untranslated.NewConfig) {
+		config.MinPercent = 70
+	})
+
+	got, err = detector.CheckPair(t.Context(), pagePair, fileInfo)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got != nil {
+		t.Fatalf("expected nil below the minimum percent, got %#v", got)
+	}
+}
+
+func TestDetector_CheckPair_Skipped(t *testing.T) {
+	t.Parallel()
+
+	gitRepo := fakeGitRepo{
+		"content/en/docs/a.md": enPage,
+		"content/pl/docs/a.md": enPage,
+		"data/en/a.yaml":       "name: Pods\n",
+		"data/pl/a.yaml":       "name: Pods\n",
+	}
+
+	for _, tc := range []struct {
+		name     string
+		pair     gitseek.Pair
+		fileInfo gitseek.FileInfo
+	}{
+		{
+			name:     "not a page",
+			pair:     gitseek.Pair{EnPath: "data/en/a.yaml", LangPath: "data/pl/a.yaml"},
+			fileInfo: gitseek.FileInfo{FileStatus: gitseek.StatusLangFileUpToDate},
+		},
+		{
+			name:     "missing lang file",
+			pair:     pagePair,
+			fileInfo: gitseek.FileInfo{FileStatus: gitseek.StatusLangFileMissing},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := untranslated.New(gitRepo).CheckPair(t.Context(), tc.pair, tc.fileInfo)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != nil {
+				t.Fatalf("expected nil, got %#v", got)
+			}
+		})
+	}
+}
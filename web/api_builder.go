@@ -0,0 +1,263 @@
+package web
+
+import (
+	"github.com/dkarczmarski/go-kweb-lang/alignment"
+	"github.com/dkarczmarski/go-kweb-lang/dashboard"
+	"github.com/dkarczmarski/go-kweb-lang/frontmatter"
+	"github.com/dkarczmarski/go-kweb-lang/git"
+	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+	"github.com/dkarczmarski/go-kweb-lang/i18nkeys"
+	"github.com/dkarczmarski/go-kweb-lang/untranslated"
+)
+
+const apiV1Prefix = "/api/v1"
+
+func BuildAPIRepoIndexResponse(repos []Repository) APIRepoIndexResponse {
+	apiRepos := make([]APIRepo, 0, len(repos))
+	for _, repo := range repos {
+		apiRepos = append(apiRepos, APIRepo{
+			Name:     repo.Name,
+			URL:      repo.Links.Repository(),
+			LangsURL: apiRepoPath(repo.Name) + "/langs",
+		})
+	}
+
+	return APIRepoIndexResponse{
+		Repos: apiRepos,
+	}
+}
+
+func BuildAPILangIndexResponse(repoName string, index dashboard.LangIndex) APILangIndexResponse {
+	langs := make([]APILang, 0, len(index.Items))
+	for _, item := range index.Items {
+		langs = append(langs, APILang{
+			LangCode:     item.LangCode,
+			LangName:     item.LangName,
+			DashboardURL: apiRepoPath(repoName) + "/langs/" + item.LangCode + "/dashboard",
+		})
+	}
+
+	return APILangIndexResponse{
+		Langs: langs,
+	}
+}
+
+func apiRepoPath(repoName string) string {
+	return apiV1Prefix + "/repos/" + repoName
+}
+
+func BuildAPIDashboardResponse(
+	dashboardData dashboard.Dashboard,
+	params LangDashboardParams,
+	links GitHubLinks,
+) APIDashboardResponse {
+	visibleItems := FilterAndSortItems(dashboardData.Items, params)
+
+	items := make([]APIDashboardItem, 0, len(visibleItems))
+	for _, item := range visibleItems {
+		items = append(items, buildAPIDashboardItem(item, links))
+	}
+
+	return APIDashboardResponse{
+		LangCode: dashboardData.LangCode,
+		Params:   buildAPIDashboardParams(params),
+		Total:    len(dashboardData.Items),
+		Items:    items,
+
+		ExcludedCounts: buildAPIExcludedCounts(dashboardData.ExcludedCounts),
+	}
+}
+
+func buildAPIExcludedCounts(excludedCounts map[string]int) map[string]int {
+	counts := make(map[string]int, len(excludedCounts))
+	for name, count := range excludedCounts {
+		counts[name] = count
+	}
+
+	return counts
+}
+
+func buildAPIDashboardParams(params LangDashboardParams) APIDashboardParams {
+	return APIDashboardParams{
+		ItemsTypes: params.ItemsTypes,
+		Filename:   params.Filename,
+		Filepath:   params.Filepath,
+		Sort:       params.SortBy,
+		Order:      params.SortOrder,
+
+		SubstantiveOnly: params.SubstantiveOnly,
+		DefiniteOnly:    params.DefiniteOnly,
+	}
+}
+
+func buildAPIDashboardItem(item dashboard.Item, links GitHubLinks) APIDashboardItem {
+	pullRequests := make([]APIPullRequest, 0, len(item.PRs))
+	for _, pullRequestNumber := range item.PRs {
+		//nolint:exhaustruct
+		pullRequest := APIPullRequest{
+			Number: pullRequestNumber,
+			URL:    links.PR(pullRequestNumber),
+		}
+
+		if preview, ok := findPRPreview(item.PRPreviews, pullRequestNumber); ok {
+			pullRequest.Preview = &APIPRPreview{
+				HeadCommit: toAPICommit(preview.HeadCommit, links),
+				Outdated:   preview.IsOutdated(),
+				Deleted:    preview.IsDeleted(),
+				EnUpdates:  buildAPIEnUpdates(preview.FileInfo.EnUpdates, links),
+			}
+		}
+
+		if prInfo, ok := findPRInfo(item.PRInfos, pullRequestNumber); ok {
+			pullRequest.Title = prInfo.Title
+			pullRequest.Author = prInfo.Author
+			pullRequest.CreatedAt = prInfo.CreatedAt
+			pullRequest.UpdatedAt = prInfo.UpdatedAt
+			pullRequest.Labels = prInfo.Labels
+			pullRequest.RequestedReviewers = prInfo.RequestedReviewers
+			pullRequest.Draft = prInfo.Draft
+			pullRequest.LGTM = prInfo.LGTM
+			pullRequest.Approved = prInfo.Approved
+			pullRequest.ChangesRequested = prInfo.ChangesRequested
+		}
+
+		pullRequests = append(pullRequests, pullRequest)
+	}
+
+	return APIDashboardItem{
+		LangPath:        item.LangPath,
+		EnPath:          item.EnPath,
+		Status:          item.FileStatus,
+		LangLastCommit:  buildAPICommitFromValue(item.LangLastCommit, links),
+		LangMergeCommit: buildAPICommit(item.LangMergeCommit, links),
+		LangForkCommit:  buildAPICommit(item.LangForkCommit, links),
+
+		StartPointSource: item.StartPointSource,
+		SyncedWithCommit: buildAPICommit(item.SyncedWithCommit, links),
+
+		MovedToEnPath:     item.MovedToEnPath,
+		SuggestedLangPath: item.SuggestedLangPath,
+
+		EnUpdates:      buildAPIEnUpdates(item.EnUpdates, links),
+		AckedEnUpdates: buildAPIEnUpdates(item.AckedEnUpdates, links),
+		PullRequests:   pullRequests,
+
+		I18NKeys:              buildAPII18NKeyChanges(item.I18NKeyChanges),
+		FrontMatterMismatches: buildAPIFrontMatterMismatches(item.FrontMatterMismatches),
+		Misalignment:          buildAPIMisalignment(item.Misalignment),
+		UntranslatedContent:   buildAPIUntranslatedContent(item.UntranslatedContent),
+	}
+}
+
+func buildAPIUntranslatedContent(content *untranslated.Content) *APIUntranslatedContent {
+	if content == nil {
+		return nil
+	}
+
+	return &APIUntranslatedContent{
+		Percent:             content.Percent,
+		IdenticalParagraphs: content.IdenticalParagraphs,
+		Paragraphs:          content.Paragraphs,
+		Copy:                content.Copy,
+	}
+}
+
+func buildAPIMisalignment(misalignment *alignment.Misalignment) *APIMisalignment {
+	if misalignment == nil {
+		return nil
+	}
+
+	return &APIMisalignment{
+		Reason:        misalignment.Reason,
+		Line:          misalignment.Line,
+		EnLineCount:   misalignment.EnLineCount,
+		LangLineCount: misalignment.LangLineCount,
+	}
+}
+
+func buildAPIFrontMatterMismatches(mismatches []frontmatter.Mismatch) []APIFrontMatterMismatch {
+	if len(mismatches) == 0 {
+		return nil
+	}
+
+	out := make([]APIFrontMatterMismatch, 0, len(mismatches))
+	for _, mismatch := range mismatches {
+		out = append(out, APIFrontMatterMismatch{
+			Field:     mismatch.Field,
+			Kind:      mismatch.Kind,
+			EnValue:   mismatch.EnValue,
+			LangValue: mismatch.LangValue,
+		})
+	}
+
+	return out
+}
+
+func buildAPII18NKeyChanges(keyChanges []i18nkeys.KeyChange) []APII18NKeyChange {
+	if len(keyChanges) == 0 {
+		return nil
+	}
+
+	changes := make([]APII18NKeyChange, 0, len(keyChanges))
+	for _, keyChange := range keyChanges {
+		changes = append(changes, APII18NKeyChange{
+			Key:        keyChange.Key,
+			Status:     keyChange.Status,
+			EnValue:    keyChange.EnValue,
+			OldEnValue: keyChange.OldEnValue,
+		})
+	}
+
+	return changes
+}
+
+func buildAPIEnUpdates(enUpdates []gitseek.EnUpdate, links GitHubLinks) []APIEnUpdate {
+	updates := make([]APIEnUpdate, 0, len(enUpdates))
+	for _, enUpdate := range enUpdates {
+		//nolint:exhaustruct
+		update := APIEnUpdate{
+			Commit:      toAPICommit(enUpdate.Commit, links),
+			ChangeKind:  enUpdate.ChangeKind,
+			Substantive: enUpdate.IsSubstantive(),
+			Overlap:     enUpdate.Overlap,
+			Definite:    enUpdate.IsDefinite(),
+		}
+
+		if enUpdate.MergePoint != nil {
+			update.MergeCommit = buildAPICommit(enUpdate.MergePoint, links)
+		}
+
+		updates = append(updates, update)
+	}
+
+	return updates
+}
+
+func buildAPICommitFromValue(commit git.CommitInfo, links GitHubLinks) *APICommit {
+	if commit.CommitID == "" {
+		return nil
+	}
+
+	apiCommit := toAPICommit(commit, links)
+
+	return &apiCommit
+}
+
+func buildAPICommit(commit *git.CommitInfo, links GitHubLinks) *APICommit {
+	if commit == nil {
+		return nil
+	}
+
+	apiCommit := toAPICommit(*commit, links)
+
+	return &apiCommit
+}
+
+func toAPICommit(commit git.CommitInfo, links GitHubLinks) APICommit {
+	return APICommit{
+		ID:      commit.CommitID,
+		Date:    commit.DateTime,
+		Message: commit.Comment,
+		URL:     links.Commit(commit.CommitID),
+	}
+}
@@ -0,0 +1,363 @@
+//nolint:testpackage,goconst
+package web
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dkarczmarski/go-kweb-lang/alignment"
+	"github.com/dkarczmarski/go-kweb-lang/dashboard"
+	"github.com/dkarczmarski/go-kweb-lang/git"
+	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+	"github.com/dkarczmarski/go-kweb-lang/i18nkeys"
+	"github.com/dkarczmarski/go-kweb-lang/prpreview"
+	"github.com/dkarczmarski/go-kweb-lang/pullreq"
+	"github.com/dkarczmarski/go-kweb-lang/untranslated"
+)
+
+func TestBuildAPILangIndexResponse(t *testing.T) {
+	t.Parallel()
+
+	index := dashboard.LangIndex{
+		Items: []dashboard.LangIndexItem{
+			{LangCode: "pl", LangName: "Polski (Polish)"},
+			{LangCode: "de"},
+		},
+	}
+
+	got := BuildAPILangIndexResponse("website", index)
+
+	want := APILangIndexResponse{
+		Langs: []APILang{
+			{LangCode: "pl", LangName: "Polski (Polish)", DashboardURL: "/api/v1/repos/website/langs/pl/dashboard"},
+			{LangCode: "de", DashboardURL: "/api/v1/repos/website/langs/de/dashboard"},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected response:\n got:  %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestBuildAPIRepoIndexResponse(t *testing.T) {
+	t.Parallel()
+
+	got := BuildAPIRepoIndexResponse([]Repository{
+		{Name: "website", Links: DefaultGitHubLinks()},
+		{Name: "docs", Links: NewGitHubLinks("https://github.com/example/docs", "develop")},
+	})
+
+	want := APIRepoIndexResponse{
+		Repos: []APIRepo{
+			{Name: "website", URL: "https://github.com/kubernetes/website", LangsURL: "/api/v1/repos/website/langs"},
+			{Name: "docs", URL: "https://github.com/example/docs", LangsURL: "/api/v1/repos/docs/langs"},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected response:\n got:  %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestBuildAPIDashboardResponse(t *testing.T) {
+	t.Parallel()
+
+	dash := dashboard.Dashboard{
+		LangCode: "pl",
+		Items: []dashboard.Item{
+			{
+				FileInfo: gitseek.FileInfo{
+					LangPath:   "content/pl/b.md",
+					FileStatus: gitseek.StatusEnFileUpdated,
+					LangLastCommit: git.CommitInfo{
+						CommitID: "lang1",
+						DateTime: "2023-01-01T10:00:00+00:00",
+						Comment:  "update pl",
+					},
+					LangForkCommit: &git.CommitInfo{
+						CommitID: "fork1",
+						DateTime: "2022-12-30T10:00:00+00:00",
+						Comment:  "fork",
+					},
+					EnUpdates: []gitseek.EnUpdate{
+						{
+							Commit: git.CommitInfo{
+								CommitID: "en1",
+								DateTime: "2023-01-02T10:00:00+00:00",
+								Comment:  "update en",
+							},
+							MergePoint: &git.CommitInfo{
+								CommitID: "merge1",
+								DateTime: "2023-01-03T10:00:00+00:00",
+								Comment:  "merge en",
+							},
+							ChangeKind: gitseek.ChangeKindProse,
+							Overlap:    gitseek.OverlapAfterMerge,
+						},
+					},
+				},
+				PRs: []int{456},
+			},
+			{
+				FileInfo: gitseek.FileInfo{
+					LangPath:   "content/pl/a.md",
+					FileStatus: gitseek.StatusLangFileUpToDate,
+				},
+			},
+			{
+				FileInfo: gitseek.FileInfo{
+					LangPath:   "content/pl/c.md",
+					FileStatus: dashboard.StatusWaitingForReview,
+				},
+				PRs: []int{789},
+			},
+		},
+		ExcludedCounts: map[string]int{"content": 4},
+	}
+
+	params := LangDashboardParams{
+		LangCode:   "pl",
+		ItemsTypes: defaultItemsTypes(),
+		SortBy:     SortByFilename,
+		SortOrder:  SortOrderDesc,
+	}
+
+	got := BuildAPIDashboardResponse(dash, params, DefaultGitHubLinks())
+
+	if got.LangCode != "pl" {
+		t.Fatalf("expected lang code pl, got %q", got.LangCode)
+	}
+
+	if got.Total != 3 {
+		t.Fatalf("expected total 3, got %d", got.Total)
+	}
+
+	if !reflect.DeepEqual(got.ExcludedCounts, map[string]int{"content": 4}) {
+		t.Fatalf("unexpected excluded counts: %#v", got.ExcludedCounts)
+	}
+
+	if got.Params.Sort != SortByFilename || got.Params.Order != SortOrderDesc {
+		t.Fatalf("unexpected params: %#v", got.Params)
+	}
+
+	if len(got.Items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(got.Items))
+	}
+
+	if got.Items[0].LangPath != "content/pl/c.md" {
+		t.Fatalf("expected first item content/pl/c.md, got %q", got.Items[0].LangPath)
+	}
+
+	if got.Items[0].LangLastCommit != nil {
+		t.Fatalf("expected nil last commit for waiting-for-review item, got %#v", got.Items[0].LangLastCommit)
+	}
+
+	wantUpdated := APIDashboardItem{
+		LangPath: "content/pl/b.md",
+		Status:   gitseek.StatusEnFileUpdated,
+		LangLastCommit: &APICommit{
+			ID:      "lang1",
+			Date:    "2023-01-01T10:00:00+00:00",
+			Message: "update pl",
+			URL:     "https://github.com/kubernetes/website/commit/lang1",
+		},
+		LangMergeCommit: nil,
+		LangForkCommit: &APICommit{
+			ID:      "fork1",
+			Date:    "2022-12-30T10:00:00+00:00",
+			Message: "fork",
+			URL:     "https://github.com/kubernetes/website/commit/fork1",
+		},
+		EnUpdates: []APIEnUpdate{
+			{
+				Commit: APICommit{
+					ID:      "en1",
+					Date:    "2023-01-02T10:00:00+00:00",
+					Message: "update en",
+					URL:     "https://github.com/kubernetes/website/commit/en1",
+				},
+				MergeCommit: &APICommit{
+					ID:      "merge1",
+					Date:    "2023-01-03T10:00:00+00:00",
+					Message: "merge en",
+					URL:     "https://github.com/kubernetes/website/commit/merge1",
+				},
+				ChangeKind:  gitseek.ChangeKindProse,
+				Substantive: true,
+				Overlap:     gitseek.OverlapAfterMerge,
+				Definite:    true,
+			},
+		},
+		AckedEnUpdates: []APIEnUpdate{},
+		PullRequests: []APIPullRequest{
+			{Number: 456, URL: "https://github.com/kubernetes/website/pull/456"},
+		},
+	}
+
+	if !reflect.DeepEqual(got.Items[1], wantUpdated) {
+		t.Fatalf("unexpected item:\n got:  %#v\nwant: %#v", got.Items[1], wantUpdated)
+	}
+}
+
+func TestBuildAPIDashboardItem_PRPreview(t *testing.T) {
+	t.Parallel()
+
+	item := dashboard.Item{
+		FileInfo: gitseek.FileInfo{
+			LangPath:   "content/pl/b.md",
+			FileStatus: gitseek.StatusEnFileUpdated,
+		},
+		PRs: []int{456, 789},
+		PRInfos: []pullreq.PRInfo{
+			{
+				Number:             789,
+				Title:              "[pl] Translate b",
+				Author:             "author",
+				Draft:              true,
+				CreatedAt:          "2023-01-03T10:00:00Z",
+				UpdatedAt:          "2023-01-05T10:00:00Z",
+				Labels:             []string{"language/pl"},
+				RequestedReviewers: []string{"reviewer"},
+			},
+		},
+		PRPreviews: []prpreview.FilePreview{
+			{
+				PRNumber: 456,
+				HeadCommit: git.CommitInfo{
+					CommitID: "head1",
+					DateTime: "2023-01-04T10:00:00+00:00",
+					Comment:  "translate b",
+				},
+				FileInfo: gitseek.FileInfo{
+					FileStatus: gitseek.StatusEnFileUpdated,
+					EnUpdates: []gitseek.EnUpdate{
+						{
+							Commit: git.CommitInfo{
+								CommitID: "en1",
+								DateTime: "2023-01-02T10:00:00+00:00",
+								Comment:  "update en",
+							},
+							Overlap: gitseek.OverlapDuringBranch,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	got := buildAPIDashboardItem(item, DefaultGitHubLinks()).PullRequests
+
+	want := []APIPullRequest{
+		{
+			Number: 456,
+			URL:    "https://github.com/kubernetes/website/pull/456",
+			Preview: &APIPRPreview{
+				HeadCommit: APICommit{
+					ID:      "head1",
+					Date:    "2023-01-04T10:00:00+00:00",
+					Message: "translate b",
+					URL:     "https://github.com/kubernetes/website/commit/head1",
+				},
+				Outdated: true,
+				EnUpdates: []APIEnUpdate{
+					{
+						Commit: APICommit{
+							ID:      "en1",
+							Date:    "2023-01-02T10:00:00+00:00",
+							Message: "update en",
+							URL:     "https://github.com/kubernetes/website/commit/en1",
+						},
+						Substantive: true,
+						Overlap:     gitseek.OverlapDuringBranch,
+					},
+				},
+			},
+		},
+		{
+			Number:             789,
+			URL:                "https://github.com/kubernetes/website/pull/789",
+			Title:              "[pl] Translate b",
+			Author:             "author",
+			CreatedAt:          "2023-01-03T10:00:00Z",
+			UpdatedAt:          "2023-01-05T10:00:00Z",
+			Labels:             []string{"language/pl"},
+			RequestedReviewers: []string{"reviewer"},
+			Draft:              true,
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected pull requests:\n got:  %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestBuildAPIDashboardItem_I18NKeys(t *testing.T) {
+	t.Parallel()
+
+	item := dashboard.Item{
+		FileInfo: gitseek.FileInfo{
+			LangPath:   "i18n/pl/pl.toml",
+			FileStatus: gitseek.StatusEnFileUpdated,
+		},
+		I18NKeyChanges: []i18nkeys.KeyChange{
+			{Key: "caution", Status: i18nkeys.KeyStatusEnValueChanged, EnValue: "Caution!", OldEnValue: "Caution:"},
+			{Key: "old", Status: i18nkeys.KeyStatusObsolete},
+		},
+	}
+
+	got := buildAPIDashboardItem(item, DefaultGitHubLinks()).I18NKeys
+
+	want := []APII18NKeyChange{
+		{Key: "caution", Status: "en-value-changed", EnValue: "Caution!", OldEnValue: "Caution:"},
+		{Key: "old", Status: "key-obsolete"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected i18n keys:\n got:  %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestBuildAPIDashboardItem_Misalignment(t *testing.T) {
+	t.Parallel()
+
+	item := dashboard.Item{
+		FileInfo: gitseek.FileInfo{
+			LangPath:   "content/pl/docs/a.md",
+			FileStatus: gitseek.StatusEnFileUpdated,
+		},
+		Misalignment: &alignment.Misalignment{
+			Reason:        alignment.ReasonCodeBlock,
+			Line:          7,
+			EnLineCount:   20,
+			LangLineCount: 21,
+		},
+	}
+
+	got := buildAPIDashboardItem(item, DefaultGitHubLinks()).Misalignment
+
+	want := &APIMisalignment{Reason: "code-block", Line: 7, EnLineCount: 20, LangLineCount: 21}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected misalignment:\n got:  %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestBuildAPIDashboardItem_UntranslatedContent(t *testing.T) {
+	t.Parallel()
+
+	item := dashboard.Item{
+		FileInfo: gitseek.FileInfo{
+			LangPath:   "content/pl/docs/a.md",
+			FileStatus: gitseek.StatusLangFileUpToDate,
+		},
+		UntranslatedContent: &untranslated.Content{Percent: 80, IdenticalParagraphs: 8, Paragraphs: 10},
+	}
+
+	got := buildAPIDashboardItem(item, DefaultGitHubLinks()).UntranslatedContent
+
+	want := &APIUntranslatedContent{Percent: 80, IdenticalParagraphs: 8, Paragraphs: 10}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected untranslated content:\n got:  %#v\nwant: %#v", got, want)
+	}
+}
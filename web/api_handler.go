@@ -0,0 +1,96 @@
+package web
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+type APIHandler struct {
+	repositories repositories
+}
+
+func NewAPIHandler(repos []Repository) *APIHandler {
+	return &APIHandler{
+		repositories: newRepositories(repos),
+	}
+}
+
+func (handler *APIHandler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+apiV1Prefix+"/repos", handler.ListRepos)
+	mux.HandleFunc("GET "+apiV1Prefix+"/repos/{repo}/langs", handler.ListLangs)
+	mux.HandleFunc("GET "+apiV1Prefix+"/repos/{repo}/langs/{code}/dashboard", handler.ShowLangDashboard)
+
+	// the endpoints from before repositories were introduced refer to the first repository
+	mux.HandleFunc("GET "+apiV1Prefix+"/langs", handler.ListLangs)
+	mux.HandleFunc("GET "+apiV1Prefix+"/langs/{code}/dashboard", handler.ShowLangDashboard)
+}
+
+func (handler *APIHandler) ListRepos(responseWriter http.ResponseWriter, _ *http.Request) {
+	writeAPIResponse(responseWriter, http.StatusOK, BuildAPIRepoIndexResponse(handler.repositories.items))
+}
+
+func (handler *APIHandler) ListLangs(responseWriter http.ResponseWriter, request *http.Request) {
+	repo, ok := handler.repositories.fromRequest(request)
+	if !ok {
+		writeAPIError(responseWriter, http.StatusNotFound)
+
+		return
+	}
+
+	index, err := repo.DashboardStore.ReadDashboardIndex()
+	if err != nil {
+		log.Printf("api list langs of %s: %v", repo.Name, err)
+		writeAPIError(responseWriter, http.StatusInternalServerError)
+
+		return
+	}
+
+	writeAPIResponse(responseWriter, http.StatusOK, BuildAPILangIndexResponse(repo.Name, index))
+}
+
+func (handler *APIHandler) ShowLangDashboard(
+	responseWriter http.ResponseWriter,
+	request *http.Request,
+) {
+	repo, ok := handler.repositories.fromRequest(request)
+	if !ok {
+		writeAPIError(responseWriter, http.StatusNotFound)
+
+		return
+	}
+
+	langCode := request.PathValue("code")
+	params := ParseLangDashboardParams(langCode, request.URL.Query())
+
+	dashboardData, err := readLangDashboard(repo, params.LangCode)
+	if err != nil {
+		log.Printf("api read dashboard of %s: %v", repo.Name, err)
+		writeAPIError(responseWriter, http.StatusInternalServerError)
+
+		return
+	}
+
+	if dashboardData.LangCode == "" {
+		writeAPIError(responseWriter, http.StatusNotFound)
+
+		return
+	}
+
+	writeAPIResponse(responseWriter, http.StatusOK, BuildAPIDashboardResponse(dashboardData, params, repo.Links))
+}
+
+func writeAPIError(responseWriter http.ResponseWriter, statusCode int) {
+	writeAPIResponse(responseWriter, statusCode, APIErrorResponse{
+		Error: http.StatusText(statusCode),
+	})
+}
+
+func writeAPIResponse(responseWriter http.ResponseWriter, statusCode int, body any) {
+	responseWriter.Header().Set("Content-Type", "application/json")
+	responseWriter.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(responseWriter).Encode(body); err != nil {
+		log.Printf("api write response: %v", err)
+	}
+}
@@ -0,0 +1,217 @@
+package web_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dkarczmarski/go-kweb-lang/dashboard"
+	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+	"github.com/dkarczmarski/go-kweb-lang/web"
+)
+
+type memoryCacheStorage struct {
+	data map[string][]byte
+}
+
+func newMemoryCacheStorage() *memoryCacheStorage {
+	return &memoryCacheStorage{
+		data: map[string][]byte{},
+	}
+}
+
+func (s *memoryCacheStorage) Read(bucket, key string, buff any) (bool, error) {
+	value, ok := s.data[bucket+"::"+key]
+	if !ok {
+		return false, nil
+	}
+
+	return true, json.Unmarshal(value, buff)
+}
+
+func (s *memoryCacheStorage) Write(bucket, key string, data any) error {
+	value, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	s.data[bucket+"::"+key] = value
+
+	return nil
+}
+
+func newAPITestMux(t *testing.T) *http.ServeMux {
+	t.Helper()
+
+	dashboardStore := dashboard.NewStore(newMemoryCacheStorage())
+
+	if err := dashboardStore.WriteDashboardIndex(dashboard.LangIndex{
+		Items: []dashboard.LangIndexItem{{LangCode: "pl"}},
+	}); err != nil {
+		t.Fatalf("WriteDashboardIndex returned error: %v", err)
+	}
+
+	if err := dashboardStore.WriteDashboard(dashboard.Dashboard{
+		LangCode: "pl",
+		Items: []dashboard.Item{
+			{FileInfo: gitseek.FileInfo{LangPath: "content/pl/a.md", FileStatus: gitseek.StatusLangFileUpToDate}},
+			{FileInfo: gitseek.FileInfo{LangPath: "content/pl/b.md", FileStatus: gitseek.StatusEnFileNoLongerExists}},
+		},
+	}); err != nil {
+		t.Fatalf("WriteDashboard returned error: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	web.NewAPIHandler([]web.Repository{
+		{Name: "website", DashboardStore: dashboardStore, Links: web.DefaultGitHubLinks()},
+		{
+			Name:           "docs",
+			DashboardStore: dashboard.NewStore(newMemoryCacheStorage()),
+			Links:          web.NewGitHubLinks("https://github.com/example/docs", "main"),
+		},
+	}).Register(mux)
+
+	return mux
+}
+
+func TestAPIHandler_ListRepos(t *testing.T) {
+	t.Parallel()
+
+	mux := newAPITestMux(t)
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/repos", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", recorder.Code)
+	}
+
+	var got web.APIRepoIndexResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+
+	if len(got.Repos) != 2 || got.Repos[0].Name != "website" || got.Repos[1].Name != "docs" {
+		t.Fatalf("unexpected repos: %#v", got.Repos)
+	}
+}
+
+func TestAPIHandler_ListLangs(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name      string
+		path      string
+		wantCode  int
+		wantLangs int
+	}{
+		{name: "repository", path: "/api/v1/repos/website/langs", wantCode: http.StatusOK, wantLangs: 1},
+		{name: "first repository", path: "/api/v1/langs", wantCode: http.StatusOK, wantLangs: 1},
+		{name: "repository without dashboards", path: "/api/v1/repos/docs/langs", wantCode: http.StatusOK},
+		{name: "unknown repository", path: "/api/v1/repos/unknown/langs", wantCode: http.StatusNotFound},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mux := newAPITestMux(t)
+
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tc.path, nil))
+
+			if recorder.Code != tc.wantCode {
+				t.Fatalf("expected status %d, got %d", tc.wantCode, recorder.Code)
+			}
+
+			if contentType := recorder.Header().Get("Content-Type"); contentType != "application/json" {
+				t.Fatalf("expected JSON content type, got %q", contentType)
+			}
+
+			if tc.wantCode != http.StatusOK {
+				return
+			}
+
+			var got web.APILangIndexResponse
+			if err := json.Unmarshal(recorder.Body.Bytes(), &got); err != nil {
+				t.Fatalf("unmarshal response: %v", err)
+			}
+
+			if len(got.Langs) != tc.wantLangs || (tc.wantLangs > 0 && got.Langs[0].LangCode != "pl") {
+				t.Fatalf("unexpected langs: %#v", got.Langs)
+			}
+		})
+	}
+}
+
+func TestAPIHandler_ShowLangDashboard(t *testing.T) {
+	t.Parallel()
+
+	t.Run("applies filter parameters", func(t *testing.T) {
+		t.Parallel()
+
+		mux := newAPITestMux(t)
+
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(
+			http.MethodGet,
+			"/api/v1/repos/website/langs/pl/dashboard?itemsType="+web.ItemsTypeLangFileUpToDate,
+			nil,
+		))
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", recorder.Code)
+		}
+
+		var got web.APIDashboardResponse
+		if err := json.Unmarshal(recorder.Body.Bytes(), &got); err != nil {
+			t.Fatalf("unmarshal response: %v", err)
+		}
+
+		if got.Total != 2 {
+			t.Fatalf("expected total 2, got %d", got.Total)
+		}
+
+		if len(got.Items) != 1 || got.Items[0].LangPath != "content/pl/a.md" {
+			t.Fatalf("unexpected items: %#v", got.Items)
+		}
+	})
+
+	t.Run("serves the first repository without a repository in the path", func(t *testing.T) {
+		t.Parallel()
+
+		mux := newAPITestMux(t)
+
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/langs/pl/dashboard", nil))
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", recorder.Code)
+		}
+	})
+
+	t.Run("returns not found for a language of another repository", func(t *testing.T) {
+		t.Parallel()
+
+		mux := newAPITestMux(t)
+
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/repos/docs/langs/pl/dashboard", nil))
+
+		if recorder.Code != http.StatusNotFound {
+			t.Fatalf("expected status 404, got %d", recorder.Code)
+		}
+	})
+
+	t.Run("returns not found for unknown language", func(t *testing.T) {
+		t.Parallel()
+
+		mux := newAPITestMux(t)
+
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/langs/xx/dashboard", nil))
+
+		if recorder.Code != http.StatusNotFound {
+			t.Fatalf("expected status 404, got %d", recorder.Code)
+		}
+	})
+}
@@ -0,0 +1,148 @@
+package web
+
+// The API models below define the JSON contract of the /api/v1/ endpoints.
+// They are intentionally decoupled from the dashboard and gitseek structs so
+// that internal refactorings do not change the published field names.
+
+type APIErrorResponse struct {
+	Error string `json:"error"`
+}
+
+type APIRepoIndexResponse struct {
+	Repos []APIRepo `json:"repos"`
+}
+
+type APIRepo struct {
+	Name     string `json:"name"`
+	URL      string `json:"url"`
+	LangsURL string `json:"langsUrl"`
+}
+
+type APILangIndexResponse struct {
+	Langs []APILang `json:"langs"`
+}
+
+type APILang struct {
+	LangCode     string `json:"langCode"`
+	LangName     string `json:"langName,omitempty"`
+	DashboardURL string `json:"dashboardUrl"`
+}
+
+type APIDashboardResponse struct {
+	LangCode string             `json:"langCode"`
+	Params   APIDashboardParams `json:"params"`
+	Total    int                `json:"total"`
+	Items    []APIDashboardItem `json:"items"`
+
+	// ExcludedCounts holds the number of files excluded from the dashboard, by pair rule name.
+	ExcludedCounts map[string]int `json:"excludedCounts"`
+}
+
+type APIDashboardParams struct {
+	ItemsTypes []string `json:"itemsTypes"`
+	Filename   string   `json:"filename,omitempty"`
+	Filepath   string   `json:"filepath,omitempty"`
+	Sort       string   `json:"sort"`
+	Order      string   `json:"order"`
+
+	SubstantiveOnly bool `json:"substantiveOnly"`
+	DefiniteOnly    bool `json:"definiteOnly"`
+}
+
+type APIDashboardItem struct {
+	LangPath        string     `json:"langPath"`
+	EnPath          string     `json:"enPath,omitempty"`
+	Status          string     `json:"status"`
+	LangLastCommit  *APICommit `json:"langLastCommit,omitempty"`
+	LangMergeCommit *APICommit `json:"langMergeCommit,omitempty"`
+	LangForkCommit  *APICommit `json:"langForkCommit,omitempty"`
+
+	StartPointSource string     `json:"startPointSource,omitempty"`
+	SyncedWithCommit *APICommit `json:"syncedWithCommit,omitempty"`
+
+	MovedToEnPath     string `json:"movedToEnPath,omitempty"`
+	SuggestedLangPath string `json:"suggestedLangPath,omitempty"`
+
+	EnUpdates      []APIEnUpdate    `json:"enUpdates"`
+	AckedEnUpdates []APIEnUpdate    `json:"ackedEnUpdates"`
+	PullRequests   []APIPullRequest `json:"pullRequests"`
+
+	I18NKeys              []APII18NKeyChange       `json:"i18nKeys,omitempty"`
+	FrontMatterMismatches []APIFrontMatterMismatch `json:"frontMatterMismatches,omitempty"`
+	Misalignment          *APIMisalignment         `json:"misalignment,omitempty"`
+	UntranslatedContent   *APIUntranslatedContent  `json:"untranslatedContent,omitempty"`
+}
+
+// APIUntranslatedContent is the share of the file that is identical to the EN file.
+type APIUntranslatedContent struct {
+	Percent             int  `json:"percent"`
+	IdenticalParagraphs int  `json:"identicalParagraphs"`
+	Paragraphs          int  `json:"paragraphs"`
+	Copy                bool `json:"copy"`
+}
+
+// APIMisalignment is the first line where the file is not line-aligned with the EN file.
+type APIMisalignment struct {
+	Reason        string `json:"reason"`
+	Line          int    `json:"line"`
+	EnLineCount   int    `json:"enLineCount"`
+	LangLineCount int    `json:"langLineCount"`
+}
+
+// APIFrontMatterMismatch is a non-translatable front matter field that differs from the EN file.
+type APIFrontMatterMismatch struct {
+	Field     string `json:"field"`
+	Kind      string `json:"kind"`
+	EnValue   string `json:"enValue,omitempty"`
+	LangValue string `json:"langValue,omitempty"`
+}
+
+// APII18NKeyChange is a key of an i18n file that needs attention.
+type APII18NKeyChange struct {
+	Key        string `json:"key"`
+	Status     string `json:"status"`
+	EnValue    string `json:"enValue,omitempty"`
+	OldEnValue string `json:"oldEnValue,omitempty"`
+}
+
+type APICommit struct {
+	ID      string `json:"id"`
+	Date    string `json:"date"`
+	Message string `json:"message"`
+	URL     string `json:"url"`
+}
+
+type APIEnUpdate struct {
+	Commit      APICommit  `json:"commit"`
+	MergeCommit *APICommit `json:"mergeCommit,omitempty"`
+	ChangeKind  string     `json:"changeKind,omitempty"`
+	Substantive bool       `json:"substantive"`
+	Overlap     string     `json:"overlap,omitempty"`
+	Definite    bool       `json:"definite"`
+}
+
+type APIPullRequest struct {
+	Number  int           `json:"number"`
+	URL     string        `json:"url"`
+	Preview *APIPRPreview `json:"preview,omitempty"`
+
+	// The details are omitted when they are not known.
+	Title              string   `json:"title,omitempty"`
+	Author             string   `json:"author,omitempty"`
+	CreatedAt          string   `json:"createdAt,omitempty"`
+	UpdatedAt          string   `json:"updatedAt,omitempty"`
+	Labels             []string `json:"labels,omitempty"`
+	RequestedReviewers []string `json:"requestedReviewers,omitempty"`
+	Draft              bool     `json:"draft,omitempty"`
+	LGTM               bool     `json:"lgtm,omitempty"`
+	Approved           bool     `json:"approved,omitempty"`
+	ChangesRequested   bool     `json:"changesRequested,omitempty"`
+}
+
+// APIPRPreview is the status of the file as if the pull request was merged now.
+type APIPRPreview struct {
+	HeadCommit APICommit     `json:"headCommit"`
+	Outdated   bool          `json:"outdated"`
+	Deleted    bool          `json:"deleted"`
+	EnUpdates  []APIEnUpdate `json:"enUpdates"`
+}
@@ -1,30 +1,57 @@
 package web
 
 import (
+	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 
+	"github.com/dkarczmarski/go-kweb-lang/alignment"
 	"github.com/dkarczmarski/go-kweb-lang/dashboard"
+	"github.com/dkarczmarski/go-kweb-lang/frontmatter"
 	"github.com/dkarczmarski/go-kweb-lang/git"
+	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+	"github.com/dkarczmarski/go-kweb-lang/prpreview"
+	"github.com/dkarczmarski/go-kweb-lang/pullreq"
+	"github.com/dkarczmarski/go-kweb-lang/untranslated"
 )
 
 const shortDateLength = 10
 
 type LangDashboardBuildInput struct {
+	RepoName  string
 	PagePath  string
 	Dashboard dashboard.Dashboard
 	Params    LangDashboardParams
+	Links     GitHubLinks
 }
 
-func BuildLangCodesPageVM(index dashboard.LangIndex) LangCodesPageVM {
-	items := make([]LinkVM, 0, len(index.Items))
-	for _, item := range index.Items {
+func BuildReposPageVM(repos []Repository) ReposPageVM {
+	items := make([]LinkVM, 0, len(repos))
+	for _, repo := range repos {
 		items = append(items, LinkVM{
-			Text: item.LangCode,
-			URL:  "/lang/" + item.LangCode,
+			Text: repo.Name,
+			URL:  repoPath(repo.Name),
+		})
+	}
+
+	return ReposPageVM{
+		Repos: items,
+	}
+}
+
+func BuildLangCodesPageVM(repoName string, index dashboard.LangIndex) LangCodesPageVM {
+	items := make([]LangLinkVM, 0, len(index.Items))
+	for _, item := range index.Items {
+		items = append(items, LangLinkVM{
+			Text:     item.LangCode,
+			URL:      langDashboardPath(repoName, item.LangCode),
+			LangName: item.LangName,
 		})
 	}
 
 	return LangCodesPageVM{
+		RepoName:  repoName,
 		LangCodes: items,
 	}
 }
@@ -32,15 +59,51 @@ func BuildLangCodesPageVM(index dashboard.LangIndex) LangCodesPageVM {
 func BuildLangDashboardPageVM(input LangDashboardBuildInput) LangDashboardPageVM {
 	urlBuilder := NewDashboardURLBuilder(input.PagePath, input.Params)
 	visibleItems := FilterAndSortItems(input.Dashboard.Items, input.Params)
-	rows := buildRows(urlBuilder, visibleItems)
+	rows := buildRows(urlBuilder, input.Links, visibleItems)
 
 	return LangDashboardPageVM{
 		PageURL:   urlBuilder.Current(),
+		PagePath:  input.PagePath,
+		RepoName:  input.RepoName,
+		RepoURL:   repoPath(input.RepoName),
 		LangCode:  input.Dashboard.LangCode,
 		ShowPanel: shouldShowPanel(input.Params),
 		Filters:   buildFiltersVM(input.Params),
 		Table:     buildTableVM(urlBuilder, input.Params, rows),
+
+		ExcludedText: buildExcludedText(input.Dashboard.ExcludedCounts),
+	}
+}
+
+// buildExcludedText builds a text like "3 files excluded (content: 2, i18n: 1)".
+func buildExcludedText(excludedCounts map[string]int) string {
+	names := make([]string, 0, len(excludedCounts))
+	total := 0
+
+	for name, count := range excludedCounts {
+		if count > 0 {
+			names = append(names, name)
+			total += count
+		}
+	}
+
+	if total == 0 {
+		return ""
+	}
+
+	slices.Sort(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, name+": "+strconv.Itoa(excludedCounts[name]))
 	}
+
+	noun := "files"
+	if total == 1 {
+		noun = "file"
+	}
+
+	return fmt.Sprintf("%d %s excluded (%s)", total, noun, strings.Join(parts, ", "))
 }
 
 func shouldShowPanel(params LangDashboardParams) bool {
@@ -70,6 +133,11 @@ func buildFiltersVM(params LangDashboardParams) DashboardFiltersVM {
 			Value:  ItemsTypeEnFileNoLongerExists,
 			Active: hasItemsType(params.ItemsTypes, ItemsTypeEnFileNoLongerExists),
 		},
+		ItemsEnFileMoved: FilterLinkVM{
+			Label:  "en file moved",
+			Value:  ItemsTypeEnFileMoved,
+			Active: hasItemsType(params.ItemsTypes, ItemsTypeEnFileMoved),
+		},
 		ItemsLangFileMissing: FilterLinkVM{
 			Label:  "lang file missing",
 			Value:  ItemsTypeLangFileMissing,
@@ -85,6 +153,31 @@ func buildFiltersVM(params LangDashboardParams) DashboardFiltersVM {
 			Value:  ItemsTypeLangFileUpToDate,
 			Active: hasItemsType(params.ItemsTypes, ItemsTypeLangFileUpToDate),
 		},
+		ItemsWithFindings: FilterLinkVM{
+			Label:  "with findings",
+			Value:  ItemsTypeWithFindings,
+			Active: hasItemsType(params.ItemsTypes, ItemsTypeWithFindings),
+		},
+		ItemsWithApprovedPR: FilterLinkVM{
+			Label:  "with approved pr",
+			Value:  ItemsTypeWithApprovedPR,
+			Active: hasItemsType(params.ItemsTypes, ItemsTypeWithApprovedPR),
+		},
+		ItemsWithDraftPR: FilterLinkVM{
+			Label:  "with draft pr",
+			Value:  ItemsTypeWithDraftPR,
+			Active: hasItemsType(params.ItemsTypes, ItemsTypeWithDraftPR),
+		},
+		SubstantiveOnly: FilterLinkVM{
+			Label:  "only substantive en updates",
+			Value:  substantiveOnlyValue,
+			Active: params.SubstantiveOnly,
+		},
+		DefiniteOnly: FilterLinkVM{
+			Label:  "only definite en updates",
+			Value:  definiteOnlyValue,
+			Active: params.DefiniteOnly,
+		},
 	}
 }
 
@@ -125,22 +218,36 @@ func buildSortHeaderVM(
 	}
 }
 
-func buildRows(urlBuilder DashboardURLBuilder, items []dashboard.Item) []DashboardRowVM {
+func buildRows(urlBuilder DashboardURLBuilder, links GitHubLinks, items []dashboard.Item) []DashboardRowVM {
 	rows := make([]DashboardRowVM, 0, len(items))
 	for _, item := range items {
 		rows = append(rows, DashboardRowVM{
-			Filename: buildFilenameCellVM(urlBuilder, item),
+			Filename: buildFilenameCellVM(urlBuilder, links, item),
 			Status:   buildStatusCellVM(item),
-			Updates:  buildUpdatesCellVM(item),
-			PRs:      buildPRsCellVM(item),
+			Updates:  buildUpdatesCellVM(urlBuilder, links, item),
+			PRs:      buildPRsCellVM(item, links),
+			KeyRows:  buildKeyRowsVM(item),
 		})
 	}
 
 	return rows
 }
 
-func buildFilenameCellVM(urlBuilder DashboardURLBuilder, item dashboard.Item) FilenameCellVM {
-	links := GitHubLinks{}
+func buildKeyRowsVM(item dashboard.Item) []KeyRowVM {
+	keyRows := make([]KeyRowVM, 0, len(item.I18NKeyChanges))
+	for _, keyChange := range item.I18NKeyChanges {
+		keyRows = append(keyRows, KeyRowVM{
+			Key:        keyChange.Key,
+			Status:     keyChange.Status,
+			EnValue:    keyChange.EnValue,
+			OldEnValue: keyChange.OldEnValue,
+		})
+	}
+
+	return keyRows
+}
+
+func buildFilenameCellVM(urlBuilder DashboardURLBuilder, links GitHubLinks, item dashboard.Item) FilenameCellVM {
 	displayPath := item.LangPath
 
 	return FilenameCellVM{
@@ -150,27 +257,92 @@ func buildFilenameCellVM(urlBuilder DashboardURLBuilder, item dashboard.Item) Fi
 		LastCommitText:  buildCommitLabel("Last Commit", item.LangLastCommit.DateTime),
 		MergeCommitText: buildOptionalCommitLabel("Merge Commit", item.LangMergeCommit),
 		ForkCommitText:  buildOptionalCommitLabel("Fork Commit", item.LangForkCommit),
+		SyncedWithText:  buildSyncedWithLabel(item),
+		ViewerURL:       buildViewerURL(urlBuilder, item),
 	}
 }
 
 func buildStatusCellVM(item dashboard.Item) StatusCellVM {
 	return StatusCellVM{
-		Text: item.FileStatus,
+		Text:              item.FileStatus,
+		MovedToEnPath:     item.MovedToEnPath,
+		SuggestedLangPath: item.SuggestedLangPath,
+
+		FrontMatterMismatches: buildFrontMatterMismatchTexts(item.FrontMatterMismatches),
+		MisalignmentText:      buildMisalignmentText(item.Misalignment),
+		UntranslatedText:      buildUntranslatedText(item.UntranslatedContent),
+	}
+}
+
+// buildUntranslatedText builds a text like "untranslated content: 85%".
+func buildUntranslatedText(content *untranslated.Content) string {
+	if content == nil {
+		return ""
+	}
+
+	if content.Copy {
+		return "untranslated copy of en"
+	}
+
+	return fmt.Sprintf("untranslated content: %d%%", content.Percent)
+}
+
+// buildMisalignmentText builds a text like "not line-aligned: heading at line 12".
+func buildMisalignmentText(misalignment *alignment.Misalignment) string {
+	if misalignment == nil {
+		return ""
+	}
+
+	if misalignment.Reason == alignment.ReasonLineCount {
+		return fmt.Sprintf(
+			"not line-aligned: %d lines, en %d lines",
+			misalignment.LangLineCount,
+			misalignment.EnLineCount,
+		)
+	}
+
+	return fmt.Sprintf("not line-aligned: %s at line %d", misalignment.Reason, misalignment.Line)
+}
+
+// buildFrontMatterMismatchTexts builds texts like "weight: en 10, lang 20".
+func buildFrontMatterMismatchTexts(mismatches []frontmatter.Mismatch) []string {
+	texts := make([]string, 0, len(mismatches))
+
+	for _, mismatch := range mismatches {
+		var text string
+
+		switch mismatch.Kind {
+		case frontmatter.MismatchKindMissing:
+			text = mismatch.Field + ": missing, en " + mismatch.EnValue
+		case frontmatter.MismatchKindUnexpected:
+			text = mismatch.Field + ": not in en, lang " + mismatch.LangValue
+		default:
+			text = mismatch.Field + ": en " + mismatch.EnValue + ", lang " + mismatch.LangValue
+		}
+
+		texts = append(texts, text)
 	}
+
+	return texts
 }
 
-func buildUpdatesCellVM(item dashboard.Item) UpdatesCellVM {
+func buildUpdatesCellVM(urlBuilder DashboardURLBuilder, links GitHubLinks, item dashboard.Item) UpdatesCellVM {
 	updates := make([]UpdateItemVM, 0, len(item.EnUpdates))
 	for _, update := range item.EnUpdates {
-		updates = append(
-			updates,
-			buildUpdateItemVM(
-				update.Commit.Comment,
-				update.Commit.CommitID,
-				update.Commit.DateTime,
-				update.MergePoint,
-			),
-		)
+		viewModel := buildEnUpdateItemVM(update, links)
+		viewModel.AckURL = urlBuilder.Ack(item.LangPath, update.Commit.CommitID)
+		viewModel.PatchURL = buildPatchURL(urlBuilder, item, update.Commit.CommitID, false)
+
+		updates = append(updates, viewModel)
+	}
+
+	ackedUpdates := make([]UpdateItemVM, 0, len(item.AckedEnUpdates))
+	for _, update := range item.AckedEnUpdates {
+		viewModel := buildEnUpdateItemVM(update, links)
+		viewModel.UnackURL = urlBuilder.Unack(item.LangPath, update.Commit.CommitID)
+		viewModel.PatchURL = buildPatchURL(urlBuilder, item, update.Commit.CommitID, false)
+
+		ackedUpdates = append(ackedUpdates, viewModel)
 	}
 
 	lastUpdateText := ""
@@ -178,25 +350,71 @@ func buildUpdatesCellVM(item dashboard.Item) UpdatesCellVM {
 		lastUpdateText = trimDate(latestEnUpdateDate(item))
 	}
 
+	var patchURL, patchDownloadURL string
+	if len(updates) > 0 {
+		patchURL = buildPatchURL(urlBuilder, item, "", false)
+		patchDownloadURL = buildPatchURL(urlBuilder, item, "", true)
+	}
+
 	return UpdatesCellVM{
-		HasUpdates:     len(updates) > 0,
-		LastUpdateText: lastUpdateText,
-		Items:          updates,
+		HasUpdates:       len(updates) > 0,
+		LastUpdateText:   lastUpdateText,
+		Items:            updates,
+		PatchURL:         patchURL,
+		PatchDownloadURL: patchDownloadURL,
+		HasAckedUpdates:  len(ackedUpdates) > 0,
+		AckedItems:       ackedUpdates,
 	}
 }
 
+// buildViewerURL returns the URL of the file viewer or an empty string if there is
+// no EN file at the start point to compare with.
+func buildViewerURL(urlBuilder DashboardURLBuilder, item dashboard.Item) string {
+	if item.EnPath == "" || item.StartPoint().CommitID == "" {
+		return ""
+	}
+
+	return urlBuilder.Viewer(item.LangPath)
+}
+
+// buildPatchURL returns the URL of the EN patch or an empty string for dashboards
+// built before the EN paths of the items were stored.
+func buildPatchURL(urlBuilder DashboardURLBuilder, item dashboard.Item, commitID string, download bool) string {
+	if item.EnPath == "" {
+		return ""
+	}
+
+	return urlBuilder.Patch(item.LangPath, commitID, download)
+}
+
+func buildEnUpdateItemVM(update gitseek.EnUpdate, links GitHubLinks) UpdateItemVM {
+	viewModel := buildUpdateItemVM(
+		links,
+		update.Commit.Comment,
+		update.Commit.CommitID,
+		update.Commit.DateTime,
+		update.ChangeKind,
+		update.MergePoint,
+	)
+	viewModel.Overlap = update.Overlap
+
+	return viewModel
+}
+
 func buildUpdateItemVM(
+	links GitHubLinks,
 	commitText string,
 	commitID string,
 	commitDate string,
+	changeKind string,
 	mergeCommit *git.CommitInfo,
 ) UpdateItemVM {
-	links := GitHubLinks{}
 	//nolint:exhaustruct
 	viewModel := UpdateItemVM{
 		CommitText: commitText,
 		CommitURL:  links.Commit(commitID),
 		CommitDate: trimDate(commitDate),
+		ChangeKind: changeKind,
 	}
 
 	if mergeCommit != nil {
@@ -209,15 +427,37 @@ func buildUpdateItemVM(
 	return viewModel
 }
 
-func buildPRsCellVM(item dashboard.Item) PRsCellVM {
-	linksBuilder := GitHubLinks{}
-	links := make([]LinkVM, 0, len(item.PRs))
+func buildPRsCellVM(item dashboard.Item, linksBuilder GitHubLinks) PRsCellVM {
+	links := make([]PRLinkVM, 0, len(item.PRs))
 
 	for _, pullRequestNumber := range item.PRs {
-		links = append(links, LinkVM{
+		//nolint:exhaustruct
+		link := PRLinkVM{
 			Text: "#" + strconv.Itoa(pullRequestNumber),
 			URL:  linksBuilder.PR(pullRequestNumber),
-		})
+		}
+
+		if preview, ok := findPRPreview(item.PRPreviews, pullRequestNumber); ok {
+			link.HasPreview = true
+			link.Outdated = preview.IsOutdated()
+			link.Deleted = preview.IsDeleted()
+			link.PreviewText = buildPRPreviewText(preview)
+		}
+
+		if prInfo, ok := findPRInfo(item.PRInfos, pullRequestNumber); ok {
+			link.Title = prInfo.Title
+			link.Author = prInfo.Author
+			link.CreatedDate = trimDate(prInfo.CreatedAt)
+			link.UpdatedDate = trimDate(prInfo.UpdatedAt)
+			link.LabelsText = strings.Join(prInfo.Labels, ", ")
+			link.ReviewersText = strings.Join(prInfo.RequestedReviewers, ", ")
+			link.Draft = prInfo.Draft
+			link.LGTM = prInfo.LGTM
+			link.Approved = prInfo.Approved
+			link.ChangesRequested = prInfo.ChangesRequested
+		}
+
+		links = append(links, link)
 	}
 
 	return PRsCellVM{
@@ -226,6 +466,41 @@ func buildPRsCellVM(item dashboard.Item) PRsCellVM {
 	}
 }
 
+func findPRInfo(prInfos []pullreq.PRInfo, prNumber int) (pullreq.PRInfo, bool) {
+	for _, prInfo := range prInfos {
+		if prInfo.Number == prNumber {
+			return prInfo, true
+		}
+	}
+
+	return pullreq.PRInfo{}, false
+}
+
+func findPRPreview(previews []prpreview.FilePreview, prNumber int) (prpreview.FilePreview, bool) {
+	for _, preview := range previews {
+		if preview.PRNumber == prNumber {
+			return preview, true
+		}
+	}
+
+	return prpreview.FilePreview{}, false
+}
+
+func buildPRPreviewText(preview prpreview.FilePreview) string {
+	count := len(preview.FileInfo.EnUpdates)
+
+	switch {
+	case preview.IsDeleted():
+		return "deletes the file"
+	case count == 0:
+		return "up-to-date"
+	case count == 1:
+		return "misses 1 en update"
+	default:
+		return "misses " + strconv.Itoa(count) + " en updates"
+	}
+}
+
 func buildCommitLabel(prefix string, date string) string {
 	if date == "" {
 		return ""
@@ -247,6 +522,15 @@ func buildOptionalCommitLabel(prefix string, commit *git.CommitInfo) string {
 	return prefix + ": " + trimDate(date)
 }
 
+func buildSyncedWithLabel(item dashboard.Item) string {
+	label := buildOptionalCommitLabel("Synced With", item.SyncedWithCommit)
+	if label == "" {
+		return ""
+	}
+
+	return label + " (" + item.StartPointSource + ")"
+}
+
 func trimDate(value string) string {
 	if len(value) >= shortDateLength {
 		return value[:shortDateLength]
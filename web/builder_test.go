@@ -2,11 +2,18 @@
 package web
 
 import (
+	"slices"
 	"testing"
 
+	"github.com/dkarczmarski/go-kweb-lang/alignment"
 	"github.com/dkarczmarski/go-kweb-lang/dashboard"
+	"github.com/dkarczmarski/go-kweb-lang/frontmatter"
 	"github.com/dkarczmarski/go-kweb-lang/git"
 	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+	"github.com/dkarczmarski/go-kweb-lang/i18nkeys"
+	"github.com/dkarczmarski/go-kweb-lang/prpreview"
+	"github.com/dkarczmarski/go-kweb-lang/pullreq"
+	"github.com/dkarczmarski/go-kweb-lang/untranslated"
 )
 
 func TestBuildLangCodesPageVM(t *testing.T) {
@@ -14,26 +21,46 @@ func TestBuildLangCodesPageVM(t *testing.T) {
 
 	index := dashboard.LangIndex{
 		Items: []dashboard.LangIndexItem{
-			{LangCode: "pl"},
+			{LangCode: "pl", LangName: "Polski (Polish)"},
 			{LangCode: "de"},
 		},
 	}
 
-	viewModel := BuildLangCodesPageVM(index)
+	viewModel := BuildLangCodesPageVM("website", index)
+
+	if viewModel.RepoName != "website" {
+		t.Fatalf("unexpected repo name: %q", viewModel.RepoName)
+	}
 
 	if len(viewModel.LangCodes) != 2 {
 		t.Fatalf("expected 2 lang codes, got %d", len(viewModel.LangCodes))
 	}
 
-	if viewModel.LangCodes[0].Text != "pl" || viewModel.LangCodes[0].URL != "/lang/pl" {
+	if viewModel.LangCodes[0].Text != "pl" || viewModel.LangCodes[0].URL != "/repos/website/lang/pl" ||
+		viewModel.LangCodes[0].LangName != "Polski (Polish)" {
 		t.Fatalf("unexpected first lang code vm: %#v", viewModel.LangCodes[0])
 	}
 
-	if viewModel.LangCodes[1].Text != "de" || viewModel.LangCodes[1].URL != "/lang/de" {
+	if viewModel.LangCodes[1].Text != "de" || viewModel.LangCodes[1].URL != "/repos/website/lang/de" {
 		t.Fatalf("unexpected second lang code vm: %#v", viewModel.LangCodes[1])
 	}
 }
 
+func TestBuildReposPageVM(t *testing.T) {
+	t.Parallel()
+
+	viewModel := BuildReposPageVM([]Repository{{Name: "website"}, {Name: "docs"}})
+
+	want := []LinkVM{
+		{Text: "website", URL: "/repos/website"},
+		{Text: "docs", URL: "/repos/docs"},
+	}
+
+	if len(viewModel.Repos) != len(want) || viewModel.Repos[0] != want[0] || viewModel.Repos[1] != want[1] {
+		t.Fatalf("unexpected repos vm: %#v", viewModel.Repos)
+	}
+}
+
 func TestBuildLangDashboardPageVM(t *testing.T) {
 	t.Parallel()
 
@@ -58,6 +85,15 @@ func TestBuildLangDashboardPageVM(t *testing.T) {
 					},
 				},
 				PRs: []int{456},
+				AckedEnUpdates: []gitseek.EnUpdate{
+					{
+						Commit: git.CommitInfo{
+							Comment:  "fix en typo",
+							CommitID: "def456",
+							DateTime: "2023-01-01T12:00:00Z",
+						},
+					},
+				},
 			},
 		},
 	}
@@ -70,9 +106,11 @@ func TestBuildLangDashboardPageVM(t *testing.T) {
 	}
 
 	input := LangDashboardBuildInput{
-		PagePath:  "/lang/pl",
+		RepoName:  "website",
+		PagePath:  "/repos/website/lang/pl",
 		Dashboard: dash,
 		Params:    params,
+		Links:     DefaultGitHubLinks(),
 	}
 
 	viewModel := BuildLangDashboardPageVM(input)
@@ -85,8 +123,12 @@ func TestBuildLangDashboardPageVM(t *testing.T) {
 		t.Fatal("expected ShowPanel to be true")
 	}
 
-	if viewModel.PageURL != "/lang/pl" {
-		t.Fatalf("expected PageURL /lang/pl, got %q", viewModel.PageURL)
+	if viewModel.PageURL != "/repos/website/lang/pl" {
+		t.Fatalf("expected PageURL /repos/website/lang/pl, got %q", viewModel.PageURL)
+	}
+
+	if viewModel.RepoURL != "/repos/website" {
+		t.Fatalf("expected RepoURL /repos/website, got %q", viewModel.RepoURL)
 	}
 
 	if !viewModel.Filters.ItemsWithEnUpdates.Active {
@@ -122,7 +164,7 @@ func TestBuildLangDashboardPageVM(t *testing.T) {
 		t.Fatalf("unexpected GithubURL: %q", row.Filename.GithubURL)
 	}
 
-	if row.Filename.DetailsURL != "/lang/pl?filename=content%2Fpl%2Ftest.md" {
+	if row.Filename.DetailsURL != "/repos/website/lang/pl?filename=content%2Fpl%2Ftest.md" {
 		t.Fatalf("unexpected DetailsURL: %q", row.Filename.DetailsURL)
 	}
 
@@ -150,6 +192,20 @@ func TestBuildLangDashboardPageVM(t *testing.T) {
 		t.Fatalf("unexpected CommitURL: %q", row.Updates.Items[0].CommitURL)
 	}
 
+	wantAckURL := "/repos/website/lang/pl/ack?commitId=abc123&langPath=content%2Fpl%2Ftest.md"
+	if row.Updates.Items[0].AckURL != wantAckURL {
+		t.Fatalf("expected AckURL %q, got %q", wantAckURL, row.Updates.Items[0].AckURL)
+	}
+
+	if !row.Updates.HasAckedUpdates || len(row.Updates.AckedItems) != 1 {
+		t.Fatalf("expected 1 acked update item, got %#v", row.Updates.AckedItems)
+	}
+
+	wantUnackURL := "/repos/website/lang/pl/unack?commitId=def456&langPath=content%2Fpl%2Ftest.md"
+	if row.Updates.AckedItems[0].UnackURL != wantUnackURL {
+		t.Fatalf("expected UnackURL %q, got %q", wantUnackURL, row.Updates.AckedItems[0].UnackURL)
+	}
+
 	if len(row.PRs.Links) != 1 {
 		t.Fatalf("expected 1 PR link, got %d", len(row.PRs.Links))
 	}
@@ -163,6 +219,35 @@ func TestBuildLangDashboardPageVM(t *testing.T) {
 	}
 }
 
+func TestBuildExcludedText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		counts map[string]int
+		want   string
+	}{
+		{name: "no counts", counts: nil, want: ""},
+		{name: "zero counts", counts: map[string]int{"content": 0}, want: ""},
+		{name: "single file", counts: map[string]int{"content": 1}, want: "1 file excluded (content: 1)"},
+		{
+			name:   "many providers",
+			counts: map[string]int{"i18n": 1, "content": 2, "data": 0},
+			want:   "3 files excluded (content: 2, i18n: 1)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := buildExcludedText(tt.counts); got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
 func TestShouldShowPanel(t *testing.T) {
 	t.Parallel()
 
@@ -174,3 +259,259 @@ func TestShouldShowPanel(t *testing.T) {
 		t.Fatal("expected shouldShowPanel to return false for non-empty filename")
 	}
 }
+
+func TestBuildSyncedWithLabel(t *testing.T) {
+	t.Parallel()
+
+	item := dashboard.Item{
+		FileInfo: gitseek.FileInfo{
+			StartPointSource: gitseek.StartPointSourceSyncTrailer,
+			SyncedWithCommit: &git.CommitInfo{
+				CommitID: "abc123",
+				DateTime: "2023-01-02T10:00:00Z",
+			},
+		},
+	}
+
+	if got := buildSyncedWithLabel(item); got != "Synced With: 2023-01-02 (sync-trailer)" {
+		t.Fatalf("unexpected label: %q", got)
+	}
+
+	if got := buildSyncedWithLabel(dashboard.Item{}); got != "" {
+		t.Fatalf("expected empty label, got %q", got)
+	}
+}
+
+func TestBuildPRsCellVM_Previews(t *testing.T) {
+	t.Parallel()
+
+	item := dashboard.Item{
+		PRs: []int{1, 2, 3, 4},
+		PRPreviews: []prpreview.FilePreview{
+			{
+				PRNumber: 1,
+				FileInfo: gitseek.FileInfo{FileStatus: gitseek.StatusLangFileUpToDate},
+			},
+			{
+				PRNumber: 2,
+				FileInfo: gitseek.FileInfo{
+					FileStatus: gitseek.StatusEnFileUpdated,
+					EnUpdates: []gitseek.EnUpdate{
+						{Commit: git.CommitInfo{CommitID: "en1"}},
+						{Commit: git.CommitInfo{CommitID: "en2"}},
+					},
+				},
+			},
+			{
+				PRNumber: 4,
+				FileInfo: gitseek.FileInfo{FileStatus: gitseek.StatusLangFileMissing},
+			},
+		},
+	}
+
+	got := buildPRsCellVM(item, DefaultGitHubLinks())
+
+	want := []PRLinkVM{
+		{
+			Text:        "#1",
+			URL:         "https://github.com/kubernetes/website/pull/1",
+			PreviewText: "up-to-date",
+			HasPreview:  true,
+			Outdated:    false,
+		},
+		{
+			Text:        "#2",
+			URL:         "https://github.com/kubernetes/website/pull/2",
+			PreviewText: "misses 2 en updates",
+			HasPreview:  true,
+			Outdated:    true,
+		},
+		{
+			Text:        "#3",
+			URL:         "https://github.com/kubernetes/website/pull/3",
+			PreviewText: "",
+			HasPreview:  false,
+			Outdated:    false,
+		},
+		{
+			Text:        "#4",
+			URL:         "https://github.com/kubernetes/website/pull/4",
+			PreviewText: "deletes the file",
+			HasPreview:  true,
+			Outdated:    false,
+			Deleted:     true,
+		},
+	}
+
+	if len(got.Links) != len(want) {
+		t.Fatalf("expected %d links, got %d", len(want), len(got.Links))
+	}
+
+	for i := range want {
+		if got.Links[i] != want[i] {
+			t.Fatalf("unexpected link %d:\n got:  %#v\nwant: %#v", i, got.Links[i], want[i])
+		}
+	}
+}
+
+func TestBuildPRsCellVM_Details(t *testing.T) {
+	t.Parallel()
+
+	item := dashboard.Item{
+		PRs: []int{1, 2},
+		PRInfos: []pullreq.PRInfo{
+			{
+				Number:             1,
+				Title:              "[pl] Translate pods",
+				Author:             "author",
+				CreatedAt:          "2025-02-01T10:00:00Z",
+				UpdatedAt:          "2025-02-04T14:53:37Z",
+				Labels:             []string{"language/pl", "lgtm", "approved"},
+				RequestedReviewers: []string{"reviewer", "kubernetes/sig-docs-pl-reviews"},
+				LGTM:               true,
+				Approved:           true,
+			},
+		},
+	}
+
+	got := buildPRsCellVM(item, DefaultGitHubLinks())
+
+	want := []PRLinkVM{
+		{
+			Text:          "#1",
+			URL:           "https://github.com/kubernetes/website/pull/1",
+			Title:         "[pl] Translate pods",
+			Author:        "author",
+			CreatedDate:   "2025-02-01",
+			UpdatedDate:   "2025-02-04",
+			LabelsText:    "language/pl, lgtm, approved",
+			ReviewersText: "reviewer, kubernetes/sig-docs-pl-reviews",
+			LGTM:          true,
+			Approved:      true,
+		},
+		{
+			Text: "#2",
+			URL:  "https://github.com/kubernetes/website/pull/2",
+		},
+	}
+
+	if !slices.Equal(got.Links, want) {
+		t.Fatalf("unexpected links:\n got:  %#v\nwant: %#v", got.Links, want)
+	}
+}
+
+func TestBuildKeyRowsVM(t *testing.T) {
+	t.Parallel()
+
+	item := dashboard.Item{
+		I18NKeyChanges: []i18nkeys.KeyChange{
+			{Key: "caution", Status: i18nkeys.KeyStatusEnValueChanged, EnValue: "Caution!", OldEnValue: "Caution:"},
+			{Key: "note", Status: i18nkeys.KeyStatusMissing, EnValue: "Note:"},
+			{Key: "old", Status: i18nkeys.KeyStatusObsolete},
+		},
+	}
+
+	got := buildKeyRowsVM(item)
+
+	want := []KeyRowVM{
+		{Key: "caution", Status: "en-value-changed", EnValue: "Caution!", OldEnValue: "Caution:"},
+		{Key: "note", Status: "key-missing", EnValue: "Note:"},
+		{Key: "old", Status: "key-obsolete"},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("expected %d key rows, got %d", len(want), len(got))
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("unexpected key row %d:\n got:  %#v\nwant: %#v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestBuildFrontMatterMismatchTexts(t *testing.T) {
+	t.Parallel()
+
+	got := buildFrontMatterMismatchTexts([]frontmatter.Mismatch{
+		{Field: "weight", Kind: frontmatter.MismatchKindDifferent, EnValue: "10", LangValue: "20"},
+		{Field: "content_type", Kind: frontmatter.MismatchKindMissing, EnValue: "concept"},
+		{Field: "aliases", Kind: frontmatter.MismatchKindUnexpected, LangValue: `["/pl/a/"]`},
+	})
+
+	want := []string{
+		"weight: en 10, lang 20",
+		"content_type: missing, en concept",
+		`aliases: not in en, lang ["/pl/a/"]`,
+	}
+
+	if !slices.Equal(got, want) {
+		t.Fatalf("unexpected texts:\n got:  %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestBuildMisalignmentText(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name         string
+		misalignment *alignment.Misalignment
+		want         string
+	}{
+		{
+			name: "aligned",
+			want: "",
+		},
+		{
+			name:         "heading",
+			misalignment: &alignment.Misalignment{Reason: alignment.ReasonHeading, Line: 12, EnLineCount: 40, LangLineCount: 41},
+			want:         "not line-aligned: heading at line 12",
+		},
+		{
+			name:         "line count",
+			misalignment: &alignment.Misalignment{Reason: alignment.ReasonLineCount, Line: 41, EnLineCount: 40, LangLineCount: 38},
+			want:         "not line-aligned: 38 lines, en 40 lines",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := buildMisalignmentText(tc.misalignment); got != tc.want {
+				t.Fatalf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBuildUntranslatedText(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name    string
+		content *untranslated.Content
+		want    string
+	}{
+		{
+			name: "translated",
+			want: "",
+		},
+		{
+			name:    "untranslated sections",
+			content: &untranslated.Content{Percent: 45, IdenticalParagraphs: 4, Paragraphs: 10},
+			want:    "untranslated content: 45%",
+		},
+		{
+			name:    "copy",
+			content: &untranslated.Content{Percent: 100, IdenticalParagraphs: 10, Paragraphs: 10, Copy: true},
+			want:    "untranslated copy of en",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := buildUntranslatedText(tc.content); got != tc.want {
+				t.Fatalf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -1,23 +1,48 @@
 package web
 
-import "strconv"
+import (
+	"strconv"
+	"strings"
+)
 
 const (
-	githubWebsiteBlobBaseURL   = "https://github.com/kubernetes/website/blob/main/"
-	githubWebsiteCommitBaseURL = "https://github.com/kubernetes/website/commit/"
-	githubWebsitePullBaseURL   = "https://github.com/kubernetes/website/pull/"
+	defaultGitHubRepoURL = "https://github.com/kubernetes/website"
+	defaultGitHubBranch  = "main"
 )
 
-type GitHubLinks struct{}
+// GitHubLinks builds links to the files, commits and pull requests of a GitHub repository.
+type GitHubLinks struct {
+	repoURL string
+	branch  string
+}
+
+// NewGitHubLinks returns links to the repository at repoURL (for example
+// https://github.com/kubernetes/website) whose files are shown at the given branch.
+func NewGitHubLinks(repoURL string, branch string) GitHubLinks {
+	return GitHubLinks{
+		repoURL: strings.TrimSuffix(strings.TrimSuffix(repoURL, "/"), ".git"),
+		branch:  branch,
+	}
+}
+
+// DefaultGitHubLinks returns links to the main branch of kubernetes/website.
+func DefaultGitHubLinks() GitHubLinks {
+	return NewGitHubLinks(defaultGitHubRepoURL, defaultGitHubBranch)
+}
+
+// Repository returns the URL of the repository.
+func (g GitHubLinks) Repository() string {
+	return g.repoURL
+}
 
 func (g GitHubLinks) File(path string) string {
-	return githubWebsiteBlobBaseURL + path
+	return g.repoURL + "/blob/" + g.branch + "/" + path
 }
 
 func (g GitHubLinks) Commit(id string) string {
-	return githubWebsiteCommitBaseURL + id
+	return g.repoURL + "/commit/" + id
 }
 
 func (g GitHubLinks) PR(number int) string {
-	return githubWebsitePullBaseURL + strconv.Itoa(number)
+	return g.repoURL + "/pull/" + strconv.Itoa(number)
 }
@@ -0,0 +1,47 @@
+//nolint:testpackage
+package web
+
+import "testing"
+
+func TestGitHubLinks(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name       string
+		links      GitHubLinks
+		wantFile   string
+		wantCommit string
+		wantPR     string
+	}{
+		{
+			name:       "default repository",
+			links:      DefaultGitHubLinks(),
+			wantFile:   "https://github.com/kubernetes/website/blob/main/content/pl/a.md",
+			wantCommit: "https://github.com/kubernetes/website/commit/abc123",
+			wantPR:     "https://github.com/kubernetes/website/pull/42",
+		},
+		{
+			name:       "custom repository and branch",
+			links:      NewGitHubLinks("https://github.com/example/docs.git", "develop"),
+			wantFile:   "https://github.com/example/docs/blob/develop/content/pl/a.md",
+			wantCommit: "https://github.com/example/docs/commit/abc123",
+			wantPR:     "https://github.com/example/docs/pull/42",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tc.links.File("content/pl/a.md"); got != tc.wantFile {
+				t.Fatalf("File() = %q, want %q", got, tc.wantFile)
+			}
+
+			if got := tc.links.Commit("abc123"); got != tc.wantCommit {
+				t.Fatalf("Commit() = %q, want %q", got, tc.wantCommit)
+			}
+
+			if got := tc.links.PR(42); got != tc.wantPR {
+				t.Fatalf("PR() = %q, want %q", got, tc.wantPR)
+			}
+		})
+	}
+}
@@ -10,6 +10,14 @@ import (
 )
 
 func FilterAndSortItems(items []dashboard.Item, params LangDashboardParams) []dashboard.Item {
+	if params.SubstantiveOnly {
+		items = filterEnUpdates(items, gitseek.EnUpdate.IsSubstantive)
+	}
+
+	if params.DefiniteOnly {
+		items = filterEnUpdates(items, gitseek.EnUpdate.IsDefinite)
+	}
+
 	filteredItems := filterItems(items, params)
 	sortItems(filteredItems, params)
 
@@ -38,6 +46,27 @@ func filterItems(items []dashboard.Item, params LangDashboardParams) []dashboard
 	return result
 }
 
+// filterEnUpdates returns copies of items with only those EN updates
+// for which keep returns true.
+func filterEnUpdates(items []dashboard.Item, keep func(gitseek.EnUpdate) bool) []dashboard.Item {
+	result := make([]dashboard.Item, 0, len(items))
+
+	for _, item := range items {
+		var enUpdates []gitseek.EnUpdate
+
+		for _, enUpdate := range item.EnUpdates {
+			if keep(enUpdate) {
+				enUpdates = append(enUpdates, enUpdate)
+			}
+		}
+
+		item.EnUpdates = enUpdates
+		result = append(result, item)
+	}
+
+	return result
+}
+
 func filterItemsByFilename(items []dashboard.Item, filename string) []dashboard.Item {
 	result := make([]dashboard.Item, 0, 1)
 
@@ -82,12 +111,20 @@ func matchesSingleItemsType(item dashboard.Item, itemsType string) bool {
 		return item.FileStatus == gitseek.StatusEnFileDoesNotExist
 	case ItemsTypeEnFileNoLongerExists:
 		return item.FileStatus == gitseek.StatusEnFileNoLongerExists
+	case ItemsTypeEnFileMoved:
+		return item.FileStatus == gitseek.StatusEnFileMoved
 	case ItemsTypeLangFileMissing:
 		return item.FileStatus == gitseek.StatusLangFileMissing
 	case ItemsTypeWaitingForReview:
 		return item.FileStatus == dashboard.StatusWaitingForReview
 	case ItemsTypeLangFileUpToDate:
 		return item.FileStatus == gitseek.StatusLangFileUpToDate
+	case ItemsTypeWithFindings:
+		return item.HasFindings()
+	case ItemsTypeWithApprovedPR:
+		return item.HasApprovedPR()
+	case ItemsTypeWithDraftPR:
+		return item.HasDraftPR()
 	default:
 		return false
 	}
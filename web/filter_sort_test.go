@@ -5,8 +5,10 @@ import (
 	"testing"
 
 	"github.com/dkarczmarski/go-kweb-lang/dashboard"
+	"github.com/dkarczmarski/go-kweb-lang/frontmatter"
 	"github.com/dkarczmarski/go-kweb-lang/git"
 	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+	"github.com/dkarczmarski/go-kweb-lang/pullreq"
 )
 
 func TestFilterAndSortItems(t *testing.T) {
@@ -34,6 +36,9 @@ func TestFilterAndSortItems(t *testing.T) {
 				},
 			},
 			PRs: []int{123},
+			PRInfos: []pullreq.PRInfo{
+				{Number: 123, Draft: true, LGTM: true},
+			},
 		},
 		{
 			FileInfo: gitseek.FileInfo{
@@ -43,6 +48,9 @@ func TestFilterAndSortItems(t *testing.T) {
 					DateTime: "2023-01-03T12:00:00Z",
 				},
 			},
+			FrontMatterMismatches: []frontmatter.Mismatch{
+				{Field: "weight", Kind: frontmatter.MismatchKindDifferent, EnValue: "10", LangValue: "20"},
+			},
 		},
 		{
 			FileInfo: gitseek.FileInfo{
@@ -68,6 +76,13 @@ func TestFilterAndSortItems(t *testing.T) {
 				FileStatus: gitseek.StatusLangFileMissing,
 			},
 		},
+		{
+			FileInfo: gitseek.FileInfo{
+				LangPath:      "content/pl/g.md",
+				FileStatus:    gitseek.StatusEnFileMoved,
+				MovedToEnPath: "content/en/docs/g.md",
+			},
+		},
 	}
 
 	t.Run("Filter by ItemsTypeWithEnUpdates", func(t *testing.T) {
@@ -130,6 +145,21 @@ func TestFilterAndSortItems(t *testing.T) {
 		}
 	})
 
+	t.Run("Filter by ItemsTypeEnFileMoved", func(t *testing.T) {
+		t.Parallel()
+
+		params := LangDashboardParams{ItemsTypes: []string{ItemsTypeEnFileMoved}}
+		filtered := FilterAndSortItems(items, params)
+
+		if len(filtered) != 1 {
+			t.Fatalf("expected 1 item, got %d", len(filtered))
+		}
+
+		if filtered[0].LangPath != "content/pl/g.md" {
+			t.Fatalf("expected content/pl/g.md, got %q", filtered[0].LangPath)
+		}
+	})
+
 	t.Run("Filter by ItemsTypeLangFileMissing", func(t *testing.T) {
 		t.Parallel()
 
@@ -175,6 +205,47 @@ func TestFilterAndSortItems(t *testing.T) {
 		}
 	})
 
+	t.Run("Filter by ItemsTypeWithFindings", func(t *testing.T) {
+		t.Parallel()
+
+		params := LangDashboardParams{ItemsTypes: []string{ItemsTypeWithFindings}}
+		filtered := FilterAndSortItems(items, params)
+
+		if len(filtered) != 1 {
+			t.Fatalf("expected 1 item, got %d", len(filtered))
+		}
+
+		if filtered[0].LangPath != "content/pl/c.md" {
+			t.Fatalf("expected content/pl/c.md, got %q", filtered[0].LangPath)
+		}
+	})
+
+	t.Run("Filter by ItemsTypeWithDraftPR", func(t *testing.T) {
+		t.Parallel()
+
+		params := LangDashboardParams{ItemsTypes: []string{ItemsTypeWithDraftPR}}
+		filtered := FilterAndSortItems(items, params)
+
+		if len(filtered) != 1 {
+			t.Fatalf("expected 1 item, got %d", len(filtered))
+		}
+
+		if filtered[0].LangPath != "content/pl/b.md" {
+			t.Fatalf("expected content/pl/b.md, got %q", filtered[0].LangPath)
+		}
+	})
+
+	t.Run("Filter by ItemsTypeWithApprovedPR skips pull requests with lgtm only", func(t *testing.T) {
+		t.Parallel()
+
+		params := LangDashboardParams{ItemsTypes: []string{ItemsTypeWithApprovedPR}}
+		filtered := FilterAndSortItems(items, params)
+
+		if len(filtered) != 0 {
+			t.Fatalf("expected no items, got %d", len(filtered))
+		}
+	})
+
 	t.Run("Filter by multiple items types uses or semantics", func(t *testing.T) {
 		t.Parallel()
 
@@ -232,8 +303,8 @@ func TestFilterAndSortItems(t *testing.T) {
 		}
 		filtered := FilterAndSortItems(items, params)
 
-		if len(filtered) != 4 {
-			t.Fatalf("expected 4 items, got %d", len(filtered))
+		if len(filtered) != 5 {
+			t.Fatalf("expected 5 items, got %d", len(filtered))
 		}
 
 		if filtered[0].LangPath != "content/pl/a.md" {
@@ -251,6 +322,10 @@ func TestFilterAndSortItems(t *testing.T) {
 		if filtered[3].LangPath != "content/pl/e.md" {
 			t.Fatalf("expected fourth path content/pl/e.md, got %q", filtered[3].LangPath)
 		}
+
+		if filtered[4].LangPath != "content/pl/g.md" {
+			t.Fatalf("expected fifth path content/pl/g.md, got %q", filtered[4].LangPath)
+		}
 	})
 
 	t.Run("Sort by Filename desc", func(t *testing.T) {
@@ -442,6 +517,94 @@ func TestFilterAndSortItems(t *testing.T) {
 			t.Fatalf("expected third path content/pl/c.md, got %q", sorted[2].LangPath)
 		}
 	})
+
+	t.Run("Hide non-substantive EN updates", func(t *testing.T) {
+		t.Parallel()
+
+		itemsWithChangeKinds := []dashboard.Item{
+			{
+				FileInfo: gitseek.FileInfo{
+					LangPath:   "content/pl/a.md",
+					FileStatus: gitseek.StatusEnFileUpdated,
+					EnUpdates: []gitseek.EnUpdate{
+						{Commit: git.CommitInfo{CommitID: "a1"}, ChangeKind: gitseek.ChangeKindWhitespaceOnly},
+						{Commit: git.CommitInfo{CommitID: "a2"}, ChangeKind: gitseek.ChangeKindProse},
+					},
+				},
+			},
+			{
+				FileInfo: gitseek.FileInfo{
+					LangPath:   "content/pl/b.md",
+					FileStatus: gitseek.StatusEnFileUpdated,
+					EnUpdates: []gitseek.EnUpdate{
+						{Commit: git.CommitInfo{CommitID: "b1"}, ChangeKind: gitseek.ChangeKindLinkOnly},
+					},
+				},
+			},
+		}
+
+		params := LangDashboardParams{
+			ItemsTypes:      []string{ItemsTypeWithEnUpdates},
+			SubstantiveOnly: true,
+		}
+		filtered := FilterAndSortItems(itemsWithChangeKinds, params)
+
+		if len(filtered) != 1 {
+			t.Fatalf("expected 1 item, got %d", len(filtered))
+		}
+
+		if filtered[0].LangPath != "content/pl/a.md" {
+			t.Fatalf("expected path content/pl/a.md, got %q", filtered[0].LangPath)
+		}
+
+		if len(filtered[0].EnUpdates) != 1 || filtered[0].EnUpdates[0].Commit.CommitID != "a2" {
+			t.Fatalf("expected only substantive update a2, got %#v", filtered[0].EnUpdates)
+		}
+
+		if len(itemsWithChangeKinds[0].EnUpdates) != 2 {
+			t.Fatalf("expected input items to be left unchanged, got %#v", itemsWithChangeKinds[0].EnUpdates)
+		}
+	})
+
+	t.Run("Hide indeterminate EN updates", func(t *testing.T) {
+		t.Parallel()
+
+		itemsWithOverlaps := []dashboard.Item{
+			{
+				FileInfo: gitseek.FileInfo{
+					LangPath:   "content/pl/a.md",
+					FileStatus: gitseek.StatusEnFileUpdated,
+					EnUpdates: []gitseek.EnUpdate{
+						{Commit: git.CommitInfo{CommitID: "a1"}, Overlap: gitseek.OverlapAfterMerge},
+						{Commit: git.CommitInfo{CommitID: "a2"}, Overlap: gitseek.OverlapDuringBranch},
+					},
+				},
+			},
+			{
+				FileInfo: gitseek.FileInfo{
+					LangPath:   "content/pl/b.md",
+					FileStatus: gitseek.StatusEnFileUpdated,
+					EnUpdates: []gitseek.EnUpdate{
+						{Commit: git.CommitInfo{CommitID: "b1"}, Overlap: gitseek.OverlapDuringBranch},
+					},
+				},
+			},
+		}
+
+		params := LangDashboardParams{
+			ItemsTypes:   []string{ItemsTypeWithEnUpdates},
+			DefiniteOnly: true,
+		}
+		filtered := FilterAndSortItems(itemsWithOverlaps, params)
+
+		if len(filtered) != 1 {
+			t.Fatalf("expected 1 item, got %d", len(filtered))
+		}
+
+		if len(filtered[0].EnUpdates) != 1 || filtered[0].EnUpdates[0].Commit.CommitID != "a1" {
+			t.Fatalf("expected only definite update a1, got %#v", filtered[0].EnUpdates)
+		}
+	})
 }
 
 func TestLatestEnUpdateDate(t *testing.T) {
@@ -2,45 +2,103 @@ package web
 
 import (
 	_ "embed"
+	"errors"
 	"fmt"
 	"html/template"
+	"io"
 	"log"
+	"mime"
 	"net/http"
+	"slices"
 
 	"github.com/dkarczmarski/go-kweb-lang/dashboard"
+	"github.com/dkarczmarski/go-kweb-lang/gitseek"
 )
 
+//go:embed repos.html
+var reposHTML string
+
 //go:embed lang_codes.html
 var langCodesHTML string
 
 //go:embed lang_dashboard.html
 var langDashboardHTML string
 
+//go:embed file_viewer.html
+var fileViewerHTML string
+
+var (
+	errRepositoryNotFound = errors.New("repository not found")
+	errItemNotFound       = errors.New("dashboard item not found")
+)
+
 type Handler struct {
-	dashboardStore *dashboard.Store
-	langCodesTmpl  *template.Template
-	dashboardTmpl  *template.Template
+	repositories  repositories
+	reposTmpl     *template.Template
+	langCodesTmpl *template.Template
+	dashboardTmpl *template.Template
+
+	fileViewerTmpl *template.Template
+
+	// crossOriginProtection rejects cross-origin requests changing the acked updates.
+	crossOriginProtection *http.CrossOriginProtection
 }
 
-func NewHandler(dashboardStore *dashboard.Store) *Handler {
+func NewHandler(repos []Repository) *Handler {
+	reposTemplate := template.Must(template.New("repos.html").Parse(reposHTML))
 	langCodesTemplate := template.Must(template.New("lang_codes.html").Parse(langCodesHTML))
 	dashboardTemplate := template.Must(template.New("lang_dashboard.html").Parse(langDashboardHTML))
+	fileViewerTemplate := template.Must(template.New("file_viewer.html").Parse(fileViewerHTML))
 
 	return &Handler{
-		dashboardStore: dashboardStore,
-		langCodesTmpl:  langCodesTemplate,
-		dashboardTmpl:  dashboardTemplate,
+		repositories:  newRepositories(repos),
+		reposTmpl:     reposTemplate,
+		langCodesTmpl: langCodesTemplate,
+		dashboardTmpl: dashboardTemplate,
+
+		fileViewerTmpl: fileViewerTemplate,
+
+		crossOriginProtection: http.NewCrossOriginProtection(),
 	}
 }
 
 func (handler *Handler) Register(mux *http.ServeMux) {
-	mux.HandleFunc("GET /", handler.ListLangCodes)
+	mux.HandleFunc("GET /", handler.ListRepos)
+	mux.HandleFunc("GET /repos/{repo}", handler.ListLangCodes)
+	mux.HandleFunc("GET /repos/{repo}/lang/{code}", handler.ShowLangDashboard)
+	mux.HandleFunc("POST /repos/{repo}/lang/{code}", handler.ShowLangDashboardTable)
+	mux.HandleFunc("POST /repos/{repo}/lang/{code}/ack", handler.AckEnUpdate)
+	mux.HandleFunc("POST /repos/{repo}/lang/{code}/unack", handler.UnackEnUpdate)
+	mux.HandleFunc("GET /repos/{repo}/lang/{code}/patch", handler.ShowEnPatch)
+	mux.HandleFunc("GET /repos/{repo}/lang/{code}/view", handler.ShowFileViewer)
+
+	// dashboard links from before repositories were introduced refer to the first repository
 	mux.HandleFunc("GET /lang/{code}", handler.ShowLangDashboard)
-	mux.HandleFunc("POST /lang/{code}", handler.ShowLangDashboardTable)
 }
 
-func (handler *Handler) ListLangCodes(responseWriter http.ResponseWriter, _ *http.Request) {
-	index, err := handler.dashboardStore.ReadDashboardIndex()
+func (handler *Handler) ListRepos(responseWriter http.ResponseWriter, _ *http.Request) {
+	pageViewModel := BuildReposPageVM(handler.repositories.items)
+	if err := handler.reposTmpl.Execute(responseWriter, pageViewModel); err != nil {
+		log.Printf("render repos: %v", err)
+		http.Error(
+			responseWriter,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+
+		return
+	}
+}
+
+func (handler *Handler) ListLangCodes(responseWriter http.ResponseWriter, request *http.Request) {
+	repo, ok := handler.repositories.fromRequest(request)
+	if !ok {
+		http.NotFound(responseWriter, request)
+
+		return
+	}
+
+	index, err := repo.DashboardStore.ReadDashboardIndex()
 	if err != nil {
 		log.Printf("list lang codes: %v", err)
 		http.Error(
@@ -52,7 +110,7 @@ func (handler *Handler) ListLangCodes(responseWriter http.ResponseWriter, _ *htt
 		return
 	}
 
-	pageViewModel := BuildLangCodesPageVM(index)
+	pageViewModel := BuildLangCodesPageVM(repo.Name, index)
 	if err := handler.langCodesTmpl.Execute(responseWriter, pageViewModel); err != nil {
 		log.Printf("render lang codes: %v", err)
 		http.Error(
@@ -70,6 +128,12 @@ func (handler *Handler) ShowLangDashboard(
 	request *http.Request,
 ) {
 	pageViewModel, err := handler.prepareLangDashboardVM(request)
+	if errors.Is(err, errRepositoryNotFound) {
+		http.NotFound(responseWriter, request)
+
+		return
+	}
+
 	if err != nil {
 		log.Printf("prepare dashboard model: %v", err)
 		http.Error(
@@ -100,6 +164,12 @@ func (handler *Handler) ShowLangDashboardTable(
 	request *http.Request,
 ) {
 	pageViewModel, err := handler.prepareLangDashboardVM(request)
+	if errors.Is(err, errRepositoryNotFound) {
+		http.NotFound(responseWriter, request)
+
+		return
+	}
+
 	if err != nil {
 		log.Printf("prepare dashboard table model: %v", err)
 		http.Error(
@@ -125,7 +195,287 @@ func (handler *Handler) ShowLangDashboardTable(
 	}
 }
 
+// AckEnUpdate marks an EN update of a lang file as not requiring a translation
+// update and renders the dashboard table.
+func (handler *Handler) AckEnUpdate(
+	responseWriter http.ResponseWriter,
+	request *http.Request,
+) {
+	handler.changeAckedUpdates(responseWriter, request, (*dashboard.AckStore).Ack)
+}
+
+// UnackEnUpdate reverts AckEnUpdate.
+func (handler *Handler) UnackEnUpdate(
+	responseWriter http.ResponseWriter,
+	request *http.Request,
+) {
+	handler.changeAckedUpdates(responseWriter, request, (*dashboard.AckStore).Unack)
+}
+
+// ShowEnPatch writes the unified diff of the EN file of a lang file from the start point
+// of the lang file to HEAD or, with the commitId parameter, the patch of a single EN update.
+// With the download parameter the patch is sent as an attachment.
+func (handler *Handler) ShowEnPatch(
+	responseWriter http.ResponseWriter,
+	request *http.Request,
+) {
+	repo, item, err := handler.findRequestedItem(request)
+	if errors.Is(err, errRepositoryNotFound) || errors.Is(err, errItemNotFound) {
+		http.NotFound(responseWriter, request)
+
+		return
+	}
+
+	if err != nil {
+		log.Printf("find requested item: %v", err)
+		http.Error(
+			responseWriter,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+
+		return
+	}
+
+	query := request.URL.Query()
+	langPath := item.LangPath
+	commitID := query.Get("commitId")
+
+	patch, err := buildEnPatch(request.Context(), repo.GitRepo, item, commitID)
+	if errors.Is(err, errEnFileNotAvailable) {
+		http.NotFound(responseWriter, request)
+
+		return
+	}
+
+	if err != nil {
+		log.Printf("build EN patch of %s: %v", langPath, err)
+		http.Error(
+			responseWriter,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+
+		return
+	}
+
+	responseWriter.Header().Set("Content-Type", "text/plain; charset=utf-8")
+
+	if query.Has("download") {
+		responseWriter.Header().Set(
+			"Content-Disposition",
+			mime.FormatMediaType("attachment", map[string]string{"filename": enPatchFileName(item, commitID)}),
+		)
+	}
+
+	if _, err := io.WriteString(responseWriter, patch); err != nil {
+		log.Printf("write EN patch of %s: %v", langPath, err)
+	}
+}
+
+// ShowFileViewer renders the EN file at the start point of a lang file, the EN file
+// at HEAD and the lang file side by side.
+func (handler *Handler) ShowFileViewer(
+	responseWriter http.ResponseWriter,
+	request *http.Request,
+) {
+	repo, item, err := handler.findRequestedItem(request)
+	if errors.Is(err, errRepositoryNotFound) || errors.Is(err, errItemNotFound) {
+		http.NotFound(responseWriter, request)
+
+		return
+	}
+
+	if err != nil {
+		log.Printf("find requested item: %v", err)
+		http.Error(
+			responseWriter,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+
+		return
+	}
+
+	input, err := readFileViewerInput(request.Context(), repo.GitRepo, item)
+	if errors.Is(err, errEnFileNotAvailable) {
+		http.NotFound(responseWriter, request)
+
+		return
+	}
+
+	if err != nil {
+		log.Printf("read files of %s: %v", item.LangPath, err)
+		http.Error(
+			responseWriter,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+
+		return
+	}
+
+	langCode := request.PathValue("code")
+	input.RepoName = repo.Name
+	input.LangCode = langCode
+	input.DashboardURL = langDashboardPath(repo.Name, langCode)
+
+	if err := handler.fileViewerTmpl.Execute(responseWriter, BuildFileViewerPageVM(input)); err != nil {
+		log.Printf("render file viewer: %v", err)
+		http.Error(
+			responseWriter,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+
+		return
+	}
+}
+
+// findRequestedItem returns the repository and the dashboard item of the lang file
+// named by the langPath parameter, for the handlers reading the local clone.
+func (handler *Handler) findRequestedItem(request *http.Request) (Repository, dashboard.Item, error) {
+	repo, ok := handler.repositories.fromRequest(request)
+	if !ok || repo.GitRepo == nil {
+		return Repository{}, dashboard.Item{}, errRepositoryNotFound
+	}
+
+	langCode := request.PathValue("code")
+
+	dashboardData, err := readLangDashboard(repo, langCode)
+	if err != nil {
+		return Repository{}, dashboard.Item{}, err
+	}
+
+	item, ok := findDashboardItem(dashboardData, request.URL.Query().Get("langPath"))
+	if !ok {
+		return Repository{}, dashboard.Item{}, errItemNotFound
+	}
+
+	return repo, item, nil
+}
+
+// changeAckedUpdates applies the change to the ack store only. The stored dashboard
+// is left to the refresh task and the acks are applied to it when it is read.
+func (handler *Handler) changeAckedUpdates(
+	responseWriter http.ResponseWriter,
+	request *http.Request,
+	change func(ackStore *dashboard.AckStore, langCode, langPath, commitID string) error,
+) {
+	if err := handler.crossOriginProtection.Check(request); err != nil {
+		http.Error(responseWriter, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+
+		return
+	}
+
+	repo, ok := handler.repositories.fromRequest(request)
+	if !ok || repo.AckStore == nil {
+		http.NotFound(responseWriter, request)
+
+		return
+	}
+
+	if err := request.ParseForm(); err != nil {
+		http.Error(responseWriter, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+
+		return
+	}
+
+	langCode := request.PathValue("code")
+	langPath := request.Form.Get("langPath")
+	commitID := request.Form.Get("commitId")
+
+	if langPath == "" || commitID == "" {
+		http.Error(responseWriter, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+
+		return
+	}
+
+	err := checkAckableUpdate(repo, langCode, langPath, commitID)
+	if errors.Is(err, errItemNotFound) {
+		http.NotFound(responseWriter, request)
+
+		return
+	}
+
+	if err == nil {
+		err = change(repo.AckStore, langCode, langPath, commitID)
+	}
+
+	if err != nil {
+		log.Printf("change acked update %s of %s: %v", commitID, langPath, err)
+		http.Error(
+			responseWriter,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+
+		return
+	}
+
+	handler.ShowLangDashboardTable(responseWriter, request)
+}
+
+// checkAckableUpdate returns errItemNotFound unless the lang code is one of the
+// detected languages and commitID is an EN update of its dashboard item of langPath.
+func checkAckableUpdate(repo Repository, langCode, langPath, commitID string) error {
+	index, err := repo.DashboardStore.ReadDashboardIndex()
+	if err != nil {
+		return fmt.Errorf("read dashboard index: %w", err)
+	}
+
+	if !slices.ContainsFunc(index.Items, func(indexItem dashboard.LangIndexItem) bool {
+		return indexItem.LangCode == langCode
+	}) {
+		return errItemNotFound
+	}
+
+	dashboardData, err := readLangDashboard(repo, langCode)
+	if err != nil {
+		return err
+	}
+
+	item, ok := findDashboardItem(dashboardData, langPath)
+	if !ok {
+		return errItemNotFound
+	}
+
+	isCommit := func(enUpdate gitseek.EnUpdate) bool {
+		return enUpdate.Commit.CommitID == commitID
+	}
+	if !slices.ContainsFunc(item.EnUpdates, isCommit) && !slices.ContainsFunc(item.AckedEnUpdates, isCommit) {
+		return errItemNotFound
+	}
+
+	return nil
+}
+
+// readLangDashboard reads the stored dashboard and applies the current acked updates,
+// which may have changed since the dashboard was built.
+func readLangDashboard(repo Repository, langCode string) (dashboard.Dashboard, error) {
+	dashboardData, err := repo.DashboardStore.ReadDashboard(langCode)
+	if err != nil {
+		return dashboard.Dashboard{}, fmt.Errorf("read dashboard for lang code %s: %w", langCode, err)
+	}
+
+	if repo.AckStore == nil || dashboardData.LangCode == "" {
+		return dashboardData, nil
+	}
+
+	ackedUpdates, err := repo.AckStore.ReadAckedUpdates(langCode)
+	if err != nil {
+		return dashboard.Dashboard{}, fmt.Errorf("read acked updates for lang code %s: %w", langCode, err)
+	}
+
+	return dashboard.ApplyAckedUpdates(dashboardData, ackedUpdates), nil
+}
+
 func (handler *Handler) prepareLangDashboardVM(request *http.Request) (LangDashboardPageVM, error) {
+	repo, ok := handler.repositories.fromRequest(request)
+	if !ok {
+		return LangDashboardPageVM{}, errRepositoryNotFound
+	}
+
 	if err := request.ParseForm(); err != nil {
 		return LangDashboardPageVM{}, fmt.Errorf("parse lang dashboard form: %w", err)
 	}
@@ -133,14 +483,16 @@ func (handler *Handler) prepareLangDashboardVM(request *http.Request) (LangDashb
 	langCode := request.PathValue("code")
 	params := ParseLangDashboardParams(langCode, request.Form)
 
-	dashboardData, err := handler.dashboardStore.ReadDashboard(langCode)
+	dashboardData, err := readLangDashboard(repo, langCode)
 	if err != nil {
-		return LangDashboardPageVM{}, fmt.Errorf("read dashboard for lang code %s: %w", langCode, err)
+		return LangDashboardPageVM{}, err
 	}
 
 	return BuildLangDashboardPageVM(LangDashboardBuildInput{
-		PagePath:  request.URL.Path,
+		RepoName:  repo.Name,
+		PagePath:  langDashboardPath(repo.Name, langCode),
 		Dashboard: dashboardData,
 		Params:    params,
+		Links:     repo.Links,
 	}), nil
 }
@@ -0,0 +1,153 @@
+package web_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/dkarczmarski/go-kweb-lang/dashboard"
+	"github.com/dkarczmarski/go-kweb-lang/git"
+	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+	"github.com/dkarczmarski/go-kweb-lang/web"
+)
+
+func newAckTestMux(t *testing.T) (*http.ServeMux, *dashboard.Store, *dashboard.AckStore) {
+	t.Helper()
+
+	cacheStorage := newMemoryCacheStorage()
+	dashboardStore := dashboard.NewStore(cacheStorage)
+	ackStore := dashboard.NewAckStore(cacheStorage)
+
+	if err := dashboardStore.WriteDashboardIndex(dashboard.LangIndex{
+		Items: []dashboard.LangIndexItem{{LangCode: "pl"}},
+	}); err != nil {
+		t.Fatalf("WriteDashboardIndex returned error: %v", err)
+	}
+
+	if err := dashboardStore.WriteDashboard(dashboard.Dashboard{
+		LangCode: "pl",
+		Items: []dashboard.Item{
+			{
+				FileInfo: gitseek.FileInfo{
+					LangPath:   "content/pl/a.md",
+					FileStatus: gitseek.StatusEnFileUpdated,
+					EnUpdates: []gitseek.EnUpdate{
+						{Commit: git.CommitInfo{CommitID: "c2", DateTime: "2026-02-01T00:00:00Z"}},
+						{Commit: git.CommitInfo{CommitID: "c1", DateTime: "2026-01-01T00:00:00Z"}},
+					},
+				},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("WriteDashboard returned error: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	web.NewHandler([]web.Repository{
+		{
+			Name:           "website",
+			DashboardStore: dashboardStore,
+			AckStore:       ackStore,
+			Links:          web.DefaultGitHubLinks(),
+		},
+	}).Register(mux)
+
+	return mux, dashboardStore, ackStore
+}
+
+func TestHandler_AckEnUpdate(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name       string
+		path       string
+		langPath   string
+		commitID   string
+		header     http.Header
+		wantStatus int
+		wantAcked  bool
+	}{
+		{
+			name:       "acks EN update",
+			path:       "/repos/website/lang/pl/ack",
+			langPath:   "content/pl/a.md",
+			commitID:   "c1",
+			wantStatus: http.StatusOK,
+			wantAcked:  true,
+		},
+		{
+			name:       "unknown lang code",
+			path:       "/repos/website/lang/..%2F..%2Fdashboard/ack",
+			langPath:   "content/pl/a.md",
+			commitID:   "c1",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "unknown lang path",
+			path:       "/repos/website/lang/pl/ack",
+			langPath:   "content/pl/b.md",
+			commitID:   "c1",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "unknown commit",
+			path:       "/repos/website/lang/pl/ack",
+			langPath:   "content/pl/a.md",
+			commitID:   "c3",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "cross-origin request",
+			path:       "/repos/website/lang/pl/ack",
+			langPath:   "content/pl/a.md",
+			commitID:   "c1",
+			header:     http.Header{"Sec-Fetch-Site": {"cross-site"}},
+			wantStatus: http.StatusForbidden,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mux, dashboardStore, ackStore := newAckTestMux(t)
+
+			form := url.Values{"langPath": {tc.langPath}, "commitId": {tc.commitID}}
+			request := httptest.NewRequest(http.MethodPost, tc.path, strings.NewReader(form.Encode()))
+			request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			for key, values := range tc.header {
+				request.Header[key] = values
+			}
+
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, request)
+
+			if recorder.Code != tc.wantStatus {
+				t.Fatalf("unexpected status: got %d, want %d", recorder.Code, tc.wantStatus)
+			}
+
+			ackedUpdates, err := ackStore.ReadAckedUpdates("pl")
+			if err != nil {
+				t.Fatalf("ReadAckedUpdates returned error: %v", err)
+			}
+
+			if got := ackedUpdates.IsAcked(tc.langPath, tc.commitID); got != tc.wantAcked {
+				t.Fatalf("unexpected acked state: got %v, want %v", got, tc.wantAcked)
+			}
+
+			storedDashboard, err := dashboardStore.ReadDashboard("pl")
+			if err != nil {
+				t.Fatalf("ReadDashboard returned error: %v", err)
+			}
+
+			if len(storedDashboard.Items[0].EnUpdates) != 2 || len(storedDashboard.Items[0].AckedEnUpdates) != 0 {
+				t.Fatalf("stored dashboard was modified: %#v", storedDashboard.Items[0])
+			}
+
+			if tc.wantAcked && !strings.Contains(recorder.Body.String(), "Acknowledged: 1") {
+				t.Fatalf("rendered table does not show the acked update: %s", recorder.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+
+	"github.com/dkarczmarski/go-kweb-lang/dashboard"
+	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+)
+
+const shortCommitIDLength = 7
+
+var errEnFileNotAvailable = errors.New("EN file not available")
+
+// buildEnPatch returns the unified diff of the EN file of the item from the start point
+// of the language file to HEAD or, if commitID is set, the patch of that EN update.
+// Only the EN updates of the item are accepted as commitID.
+func buildEnPatch(ctx context.Context, gitRepo GitRepo, item dashboard.Item, commitID string) (string, error) {
+	if item.EnPath == "" {
+		return "", errEnFileNotAvailable
+	}
+
+	paths := enPatchPaths(item)
+
+	if commitID == "" {
+		startPoint := item.StartPoint().CommitID
+		if startPoint == "" {
+			return "", errEnFileNotAvailable
+		}
+
+		diff, err := gitRepo.FileRangeDiff(ctx, startPoint, "HEAD", paths...)
+		if err != nil {
+			return "", fmt.Errorf("diff %s from %s: %w", item.EnPath, startPoint, err)
+		}
+
+		return diff, nil
+	}
+
+	if !hasEnUpdate(item, commitID) {
+		return "", errEnFileNotAvailable
+	}
+
+	patch, err := gitRepo.FilePatch(ctx, commitID, paths...)
+	if err != nil {
+		return "", fmt.Errorf("patch %s at %s: %w", item.EnPath, commitID, err)
+	}
+
+	return patch, nil
+}
+
+// enPatchPaths returns the EN path of the item and, if the EN file was moved,
+// its current path, so that the patch follows the rename.
+func enPatchPaths(item dashboard.Item) []string {
+	if item.MovedToEnPath == "" || item.MovedToEnPath == item.EnPath {
+		return []string{item.EnPath}
+	}
+
+	return []string{item.EnPath, item.MovedToEnPath}
+}
+
+func hasEnUpdate(item dashboard.Item, commitID string) bool {
+	isCommit := func(update gitseek.EnUpdate) bool {
+		return update.Commit.CommitID == commitID
+	}
+
+	return slices.ContainsFunc(item.EnUpdates, isCommit) || slices.ContainsFunc(item.AckedEnUpdates, isCommit)
+}
+
+// enPatchFileName returns the name of the downloaded patch, like "pods.md.patch"
+// or "pods.md-1a2b3c4.patch" for a single EN update.
+func enPatchFileName(item dashboard.Item, commitID string) string {
+	name := path.Base(item.EnPath)
+	if commitID == "" {
+		return name + ".patch"
+	}
+
+	return name + "-" + commitID[:min(len(commitID), shortCommitIDLength)] + ".patch"
+}
+
+func findDashboardItem(dashboardData dashboard.Dashboard, langPath string) (dashboard.Item, bool) {
+	for _, item := range dashboardData.Items {
+		if item.LangPath == langPath {
+			return item, true
+		}
+	}
+
+	return dashboard.Item{}, false
+}
@@ -0,0 +1,143 @@
+//nolint:testpackage
+package web
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dkarczmarski/go-kweb-lang/dashboard"
+	"github.com/dkarczmarski/go-kweb-lang/git"
+	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+)
+
+// fakeGitRepo returns descriptions of the requested diffs and patches
+// and the files at HEAD from the map.
+type fakeGitRepo map[string]string
+
+func (f fakeGitRepo) FileExistsAt(_ context.Context, _ string, path string) (bool, error) {
+	_, ok := f[path]
+
+	return ok, nil
+}
+
+func (f fakeGitRepo) ReadFileAt(_ context.Context, _ string, path string) (string, error) {
+	return f[path], nil
+}
+
+func (fakeGitRepo) FileRangeDiff(_ context.Context, fromRevision string, toRevision string, paths ...string) (string, error) {
+	return "diff " + fromRevision + ".." + toRevision + " " + strings.Join(paths, " "), nil
+}
+
+func (f fakeGitRepo) FullFileRangeDiff(_ context.Context, _ string, _ string, paths ...string) (string, error) {
+	return f["diff:"+paths[0]], nil
+}
+
+func (fakeGitRepo) FilePatch(_ context.Context, commitID string, paths ...string) (string, error) {
+	return "patch " + commitID + " " + strings.Join(paths, " "), nil
+}
+
+func TestBuildEnPatch(t *testing.T) {
+	t.Parallel()
+
+	item := dashboard.Item{
+		EnPath: "content/en/docs/a.md",
+		FileInfo: gitseek.FileInfo{
+			LangPath:         "content/pl/docs/a.md",
+			FileStatus:       gitseek.StatusEnFileUpdated,
+			LangLastCommit:   git.CommitInfo{CommitID: "last"},
+			SyncedWithCommit: &git.CommitInfo{CommitID: "synced"},
+			EnUpdates:        []gitseek.EnUpdate{{Commit: git.CommitInfo{CommitID: "c1"}}},
+		},
+		AckedEnUpdates: []gitseek.EnUpdate{{Commit: git.CommitInfo{CommitID: "c2"}}},
+	}
+
+	moved := item
+	moved.MovedToEnPath = "content/en/docs/b.md"
+
+	withoutEnPath := item
+	withoutEnPath.EnPath = ""
+
+	missing := dashboard.Item{
+		EnPath:   "content/en/docs/a.md",
+		FileInfo: gitseek.FileInfo{FileStatus: gitseek.StatusLangFileMissing},
+	}
+
+	for _, tc := range []struct {
+		name     string
+		item     dashboard.Item
+		commitID string
+		want     string
+		wantErr  error
+	}{
+		{
+			name: "since the start point",
+			item: item,
+			want: "diff synced..HEAD content/en/docs/a.md",
+		},
+		{
+			name: "moved EN file",
+			item: moved,
+			want: "diff synced..HEAD content/en/docs/a.md content/en/docs/b.md",
+		},
+		{
+			name:     "EN update",
+			item:     item,
+			commitID: "c1",
+			want:     "patch c1 content/en/docs/a.md",
+		},
+		{
+			name:     "acked EN update",
+			item:     item,
+			commitID: "c2",
+			want:     "patch c2 content/en/docs/a.md",
+		},
+		{
+			name:     "commit that is not an EN update",
+			item:     item,
+			commitID: "--output=/tmp/x",
+			wantErr:  errEnFileNotAvailable,
+		},
+		{
+			name:    "dashboard without EN paths",
+			item:    withoutEnPath,
+			wantErr: errEnFileNotAvailable,
+		},
+		{
+			name:    "no start point",
+			item:    missing,
+			wantErr: errEnFileNotAvailable,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := buildEnPatch(t.Context(), fakeGitRepo{}, tc.item, tc.commitID)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != tc.want {
+				t.Fatalf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEnPatchFileName(t *testing.T) {
+	t.Parallel()
+
+	item := dashboard.Item{EnPath: "content/en/docs/pods.md"}
+
+	got := []string{
+		enPatchFileName(item, ""),
+		enPatchFileName(item, "1a2b3c4d5e6f"),
+	}
+	want := []string{"pods.md.patch", "pods.md-1a2b3c4.patch"}
+
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
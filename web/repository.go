@@ -0,0 +1,73 @@
+package web
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/dkarczmarski/go-kweb-lang/dashboard"
+)
+
+// Repository is a tracked repository whose dashboards are served.
+type Repository struct {
+	Name           string
+	DashboardStore *dashboard.Store
+	AckStore       *dashboard.AckStore
+	Links          GitHubLinks
+
+	// GitRepo reads the files of the local clone for the EN patches and the file viewer,
+	// which are not served if it is nil.
+	GitRepo GitRepo
+}
+
+// GitRepo is an interface used to decouple this package from the concrete git implementation.
+type GitRepo interface {
+	FileExistsAt(ctx context.Context, revision string, path string) (bool, error)
+	ReadFileAt(ctx context.Context, revision string, path string) (string, error)
+	FileRangeDiff(ctx context.Context, fromRevision string, toRevision string, paths ...string) (string, error)
+	FullFileRangeDiff(ctx context.Context, fromRevision string, toRevision string, paths ...string) (string, error)
+	FilePatch(ctx context.Context, commitID string, paths ...string) (string, error)
+}
+
+// repositories keeps the served repositories in the configured order.
+type repositories struct {
+	items  []Repository
+	byName map[string]Repository
+}
+
+func newRepositories(repos []Repository) repositories {
+	byName := make(map[string]Repository, len(repos))
+	for _, repo := range repos {
+		byName[repo.Name] = repo
+	}
+
+	return repositories{
+		items:  repos,
+		byName: byName,
+	}
+}
+
+// fromRequest returns the repository named by the {repo} path value.
+// Routes without that path value, kept for links from before repositories
+// were introduced, refer to the first repository.
+func (r repositories) fromRequest(request *http.Request) (Repository, bool) {
+	name := request.PathValue("repo")
+	if name == "" {
+		if len(r.items) == 0 {
+			return Repository{}, false
+		}
+
+		return r.items[0], true
+	}
+
+	repo, ok := r.byName[name]
+
+	return repo, ok
+}
+
+func repoPath(repoName string) string {
+	return "/repos/" + repoName
+}
+
+func langDashboardPath(repoName string, langCode string) string {
+	return repoPath(repoName) + "/lang/" + langCode
+}
@@ -11,9 +11,13 @@ const (
 	ItemsTypeWithPR               = "with-pr"
 	ItemsTypeEnFileDoesNotExist   = "en-file-does-not-exist"
 	ItemsTypeEnFileNoLongerExists = "en-file-no-longer-exists"
+	ItemsTypeEnFileMoved          = "moved"
 	ItemsTypeLangFileMissing      = "lang-file-missing"
 	ItemsTypeWaitingForReview     = "waiting-for-review"
 	ItemsTypeLangFileUpToDate     = "up-to-date"
+	ItemsTypeWithFindings         = "with-findings"
+	ItemsTypeWithApprovedPR       = "with-approved-pr"
+	ItemsTypeWithDraftPR          = "with-draft-pr"
 )
 
 const (
@@ -27,13 +31,20 @@ const (
 	SortOrderDesc = "desc"
 )
 
+const (
+	substantiveOnlyValue = "true"
+	definiteOnlyValue    = "true"
+)
+
 type LangDashboardParams struct {
-	LangCode   string
-	ItemsTypes []string
-	Filename   string
-	Filepath   string
-	SortBy     string
-	SortOrder  string
+	LangCode        string
+	ItemsTypes      []string
+	Filename        string
+	Filepath        string
+	SortBy          string
+	SortOrder       string
+	SubstantiveOnly bool
+	DefiniteOnly    bool
 }
 
 func ParseLangDashboardParams(langCode string, values url.Values) LangDashboardParams {
@@ -44,6 +55,9 @@ func ParseLangDashboardParams(langCode string, values url.Values) LangDashboardP
 		Filepath:   strings.TrimSpace(values.Get("filepath")),
 		SortBy:     normalizeSortBy(values.Get("sort")),
 		SortOrder:  normalizeSortOrder(values.Get("order")),
+
+		SubstantiveOnly: strings.TrimSpace(values.Get("substantiveOnly")) == substantiveOnlyValue,
+		DefiniteOnly:    strings.TrimSpace(values.Get("definiteOnly")) == definiteOnlyValue,
 	}
 
 	return params
@@ -62,12 +76,20 @@ func normalizeItemsTypes(values []string) []string {
 			normalized = appendIfMissing(normalized, ItemsTypeEnFileDoesNotExist)
 		case ItemsTypeEnFileNoLongerExists:
 			normalized = appendIfMissing(normalized, ItemsTypeEnFileNoLongerExists)
+		case ItemsTypeEnFileMoved:
+			normalized = appendIfMissing(normalized, ItemsTypeEnFileMoved)
 		case ItemsTypeLangFileMissing:
 			normalized = appendIfMissing(normalized, ItemsTypeLangFileMissing)
 		case ItemsTypeWaitingForReview:
 			normalized = appendIfMissing(normalized, ItemsTypeWaitingForReview)
 		case ItemsTypeLangFileUpToDate:
 			normalized = appendIfMissing(normalized, ItemsTypeLangFileUpToDate)
+		case ItemsTypeWithFindings:
+			normalized = appendIfMissing(normalized, ItemsTypeWithFindings)
+		case ItemsTypeWithApprovedPR:
+			normalized = appendIfMissing(normalized, ItemsTypeWithApprovedPR)
+		case ItemsTypeWithDraftPR:
+			normalized = appendIfMissing(normalized, ItemsTypeWithDraftPR)
 		}
 	}
 
@@ -84,6 +106,7 @@ func defaultItemsTypes() []string {
 		ItemsTypeWithPR,
 		ItemsTypeEnFileDoesNotExist,
 		ItemsTypeEnFileNoLongerExists,
+		ItemsTypeEnFileMoved,
 		ItemsTypeWaitingForReview,
 	}
 }
@@ -17,11 +17,16 @@ func TestParseLangDashboardParams(t *testing.T) {
 		values.Add("itemsType", ItemsTypeWithEnUpdates)
 		values.Add("itemsType", ItemsTypeWithPR)
 		values.Add("itemsType", ItemsTypeEnFileNoLongerExists)
+		values.Add("itemsType", ItemsTypeEnFileMoved)
 		values.Add("itemsType", ItemsTypeLangFileMissing)
+		values.Add("itemsType", ItemsTypeWithApprovedPR)
+		values.Add("itemsType", ItemsTypeWithDraftPR)
 		values.Set("filename", "content/pl/test.md")
 		values.Set("filepath", "content/pl")
 		values.Set("sort", SortByStatus)
 		values.Set("order", SortOrderDesc)
+		values.Set("substantiveOnly", "true")
+		values.Set("definiteOnly", "true")
 
 		got := ParseLangDashboardParams("pl", values)
 
@@ -33,7 +38,10 @@ func TestParseLangDashboardParams(t *testing.T) {
 			ItemsTypeWithEnUpdates,
 			ItemsTypeWithPR,
 			ItemsTypeEnFileNoLongerExists,
+			ItemsTypeEnFileMoved,
 			ItemsTypeLangFileMissing,
+			ItemsTypeWithApprovedPR,
+			ItemsTypeWithDraftPR,
 		}
 		if !reflect.DeepEqual(got.ItemsTypes, wantItemsTypes) {
 			t.Fatalf("expected ItemsTypes %#v, got %#v", wantItemsTypes, got.ItemsTypes)
@@ -54,6 +62,14 @@ func TestParseLangDashboardParams(t *testing.T) {
 		if got.SortOrder != SortOrderDesc {
 			t.Fatalf("expected SortOrder %q, got %q", SortOrderDesc, got.SortOrder)
 		}
+
+		if !got.SubstantiveOnly {
+			t.Fatalf("expected SubstantiveOnly true, got false")
+		}
+
+		if !got.DefiniteOnly {
+			t.Fatalf("expected DefiniteOnly true, got false")
+		}
 	})
 
 	t.Run("uses defaults for invalid values", func(t *testing.T) {
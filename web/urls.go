@@ -51,7 +51,57 @@ func (builder DashboardURLBuilder) Sort(sortBy string) string {
 	return builder.build(params)
 }
 
+// Ack returns the URL which acknowledges the EN update of the given lang file
+// and re-renders the table with the current parameters.
+func (builder DashboardURLBuilder) Ack(langPath string, commitID string) string {
+	return builder.buildAckAction("ack", langPath, commitID)
+}
+
+// Unack returns the URL which reverts Ack.
+func (builder DashboardURLBuilder) Unack(langPath string, commitID string) string {
+	return builder.buildAckAction("unack", langPath, commitID)
+}
+
+// Patch returns the URL of the EN patch of the given lang file since its start point
+// or, if commitID is set, of that EN update.
+func (builder DashboardURLBuilder) Patch(langPath string, commitID string, download bool) string {
+	queryValues := url.Values{}
+	queryValues.Set("langPath", langPath)
+
+	if commitID != "" {
+		queryValues.Set("commitId", commitID)
+	}
+
+	if download {
+		queryValues.Set("download", "1")
+	}
+
+	return builder.Path + "/patch?" + queryValues.Encode()
+}
+
+// Viewer returns the URL of the side-by-side view of the EN file and the given lang file.
+func (builder DashboardURLBuilder) Viewer(langPath string) string {
+	return builder.Path + "/view?" + url.Values{"langPath": []string{langPath}}.Encode()
+}
+
+func (builder DashboardURLBuilder) buildAckAction(action string, langPath string, commitID string) string {
+	queryValues := buildQuery(builder.Params)
+	queryValues.Set("langPath", langPath)
+	queryValues.Set("commitId", commitID)
+
+	return builder.Path + "/" + action + "?" + queryValues.Encode()
+}
+
 func (builder DashboardURLBuilder) build(params LangDashboardParams) string {
+	encodedQuery := buildQuery(params).Encode()
+	if encodedQuery == "" {
+		return builder.Path
+	}
+
+	return builder.Path + "?" + encodedQuery
+}
+
+func buildQuery(params LangDashboardParams) url.Values {
 	queryValues := url.Values{}
 
 	addItemsTypesToQuery(queryValues, params.ItemsTypes)
@@ -59,13 +109,10 @@ func (builder DashboardURLBuilder) build(params LangDashboardParams) string {
 	addFilepathToQuery(queryValues, params.Filepath)
 	addSortByToQuery(queryValues, params.SortBy)
 	addSortOrderToQuery(queryValues, params.SortOrder)
+	addSubstantiveOnlyToQuery(queryValues, params.SubstantiveOnly)
+	addDefiniteOnlyToQuery(queryValues, params.DefiniteOnly)
 
-	encodedQuery := queryValues.Encode()
-	if encodedQuery == "" {
-		return builder.Path
-	}
-
-	return builder.Path + "?" + encodedQuery
+	return queryValues
 }
 
 func addItemsTypesToQuery(queryValues url.Values, itemsTypes []string) {
@@ -110,6 +157,22 @@ func addSortOrderToQuery(queryValues url.Values, sortOrder string) {
 	queryValues.Set("order", sortOrder)
 }
 
+func addSubstantiveOnlyToQuery(queryValues url.Values, substantiveOnly bool) {
+	if !substantiveOnly {
+		return
+	}
+
+	queryValues.Set("substantiveOnly", substantiveOnlyValue)
+}
+
+func addDefiniteOnlyToQuery(queryValues url.Values, definiteOnly bool) {
+	if !definiteOnly {
+		return
+	}
+
+	queryValues.Set("definiteOnly", definiteOnlyValue)
+}
+
 func toggleSortOrder(order string) string {
 	if order == SortOrderAsc {
 		return SortOrderDesc
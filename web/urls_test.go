@@ -47,6 +47,36 @@ func TestDashboardURLBuilder(t *testing.T) {
 		}
 	})
 
+	t.Run("Current with substantive only", func(t *testing.T) {
+		t.Parallel()
+
+		params := baseParams
+		params.SubstantiveOnly = true
+		customBuilder := NewDashboardURLBuilder("/lang/pl", params)
+
+		got := customBuilder.Current()
+		want := "/lang/pl?substantiveOnly=true"
+
+		if got != want {
+			t.Fatalf("expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("Current with definite only", func(t *testing.T) {
+		t.Parallel()
+
+		params := baseParams
+		params.DefiniteOnly = true
+		customBuilder := NewDashboardURLBuilder("/lang/pl", params)
+
+		got := customBuilder.Current()
+		want := "/lang/pl?definiteOnly=true"
+
+		if got != want {
+			t.Fatalf("expected %q, got %q", want, got)
+		}
+	})
+
 	t.Run("WithFilename", func(t *testing.T) {
 		t.Parallel()
 
@@ -167,4 +197,34 @@ func TestDashboardURLBuilder(t *testing.T) {
 			t.Fatalf("expected %q, got %q", want, got)
 		}
 	})
+
+	t.Run("Patch", func(t *testing.T) {
+		t.Parallel()
+
+		got := []string{
+			builder.Patch("content/pl/a.md", "", false),
+			builder.Patch("content/pl/a.md", "abc", true),
+		}
+		want := []string{
+			"/lang/pl/patch?langPath=content%2Fpl%2Fa.md",
+			"/lang/pl/patch?commitId=abc&download=1&langPath=content%2Fpl%2Fa.md",
+		}
+
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("expected %q, got %q", want[i], got[i])
+			}
+		}
+	})
+
+	t.Run("Viewer", func(t *testing.T) {
+		t.Parallel()
+
+		got := builder.Viewer("content/pl/a.md")
+		want := "/lang/pl/view?langPath=content%2Fpl%2Fa.md"
+
+		if got != want {
+			t.Fatalf("expected %q, got %q", want, got)
+		}
+	})
 }
@@ -5,17 +5,35 @@ type LinkVM struct {
 	URL  string
 }
 
+type ReposPageVM struct {
+	Repos []LinkVM
+}
+
 type LangCodesPageVM struct {
-	LangCodes []LinkVM
+	RepoName  string
+	LangCodes []LangLinkVM
+}
+
+type LangLinkVM struct {
+	Text string
+	URL  string
+	// LangName is the display name of the language, empty if unknown.
+	LangName string
 }
 
 type LangDashboardPageVM struct {
 	PageURL  string
+	PagePath string
+	RepoName string
+	RepoURL  string
 	LangCode string
 
 	ShowPanel bool
 	Filters   DashboardFiltersVM
 	Table     DashboardTableVM
+
+	// ExcludedText describes the number of files excluded from the dashboard, empty if none.
+	ExcludedText string
 }
 
 type DashboardFiltersVM struct {
@@ -25,9 +43,16 @@ type DashboardFiltersVM struct {
 	ItemsWithPR               FilterLinkVM
 	ItemsEnFileDoesNotExist   FilterLinkVM
 	ItemsEnFileNoLongerExists FilterLinkVM
+	ItemsEnFileMoved          FilterLinkVM
 	ItemsLangFileMissing      FilterLinkVM
 	ItemsWaitingForReview     FilterLinkVM
 	ItemsLangFileUpToDate     FilterLinkVM
+	ItemsWithFindings         FilterLinkVM
+	ItemsWithApprovedPR       FilterLinkVM
+	ItemsWithDraftPR          FilterLinkVM
+
+	SubstantiveOnly FilterLinkVM
+	DefiniteOnly    FilterLinkVM
 }
 
 type FilterLinkVM struct {
@@ -55,6 +80,16 @@ type DashboardRowVM struct {
 	Status   StatusCellVM
 	Updates  UpdatesCellVM
 	PRs      PRsCellVM
+
+	// KeyRows are the rows of the i18n keys of the file that need attention.
+	KeyRows []KeyRowVM
+}
+
+type KeyRowVM struct {
+	Key        string
+	Status     string
+	EnValue    string
+	OldEnValue string
 }
 
 type FilenameCellVM struct {
@@ -65,22 +100,54 @@ type FilenameCellVM struct {
 	LastCommitText  string
 	MergeCommitText string
 	ForkCommitText  string
+	SyncedWithText  string
+
+	// ViewerURL leads to the side-by-side view of the EN file and the lang file.
+	ViewerURL string
 }
 
 type StatusCellVM struct {
 	Text string
+
+	MovedToEnPath     string
+	SuggestedLangPath string
+
+	// FrontMatterMismatches describe the front matter fields that differ from the EN file.
+	FrontMatterMismatches []string
+
+	// MisalignmentText describes the first line where the file is not line-aligned, empty if aligned.
+	MisalignmentText string
+
+	// UntranslatedText describes the share of the file that is identical to the EN file,
+	// empty if the file is translated.
+	UntranslatedText string
 }
 
 type UpdatesCellVM struct {
 	HasUpdates     bool
 	LastUpdateText string
 	Items          []UpdateItemVM
+
+	// PatchURL and PatchDownloadURL lead to the diff of the EN file since the start point.
+	PatchURL         string
+	PatchDownloadURL string
+
+	HasAckedUpdates bool
+	AckedItems      []UpdateItemVM
 }
 
 type UpdateItemVM struct {
 	CommitText string
 	CommitURL  string
 	CommitDate string
+	ChangeKind string
+	Overlap    string
+
+	AckURL   string
+	UnackURL string
+
+	// PatchURL leads to the patch of the EN file introduced by the commit.
+	PatchURL string
 
 	MergeCommitText string
 	MergeCommitURL  string
@@ -89,6 +156,63 @@ type UpdateItemVM struct {
 }
 
 type PRsCellVM struct {
-	Links []LinkVM
+	Links []PRLinkVM
 	Empty bool
 }
+
+type PRLinkVM struct {
+	Text string
+	URL  string
+
+	// PreviewText describes the status of the file as if the pull request was merged now.
+	// It is empty when the head of the pull request has not been fetched yet.
+	PreviewText string
+	HasPreview  bool
+	Outdated    bool
+	Deleted     bool
+
+	// Title and the other details are empty when the details of the pull request are not known.
+	Title         string
+	Author        string
+	CreatedDate   string
+	UpdatedDate   string
+	LabelsText    string
+	ReviewersText string
+
+	Draft            bool
+	LGTM             bool
+	Approved         bool
+	ChangesRequested bool
+}
+
+// FileViewerPageVM shows the EN file at the start point of the lang file, the EN file
+// at HEAD and the lang file side by side.
+type FileViewerPageVM struct {
+	RepoName string
+	LangCode string
+	LangPath string
+	EnPath   string
+
+	// CurrentEnPath differs from EnPath if the EN file was moved.
+	CurrentEnPath string
+
+	DashboardURL   string
+	StartPointText string
+	Rows           []ViewerRowVM
+}
+
+type ViewerRowVM struct {
+	OldLine  ViewerLineVM
+	NewLine  ViewerLineVM
+	LangLine ViewerLineVM
+
+	// Change tells how the EN line changed since the start point (see ViewerChange* constants),
+	// empty if it did not change.
+	Change string
+}
+
+// ViewerLineVM is a line of a file. Number is 0 if the file has no line in the row.
+type ViewerLineVM struct {
+	Number int
+	Text   string
+}
@@ -0,0 +1,343 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dkarczmarski/go-kweb-lang/dashboard"
+)
+
+const (
+	// ViewerChangeModified marks a row whose EN line was changed since the start point.
+	ViewerChangeModified = "modified"
+
+	// ViewerChangeRemoved marks a row whose EN line was removed since the start point.
+	ViewerChangeRemoved = "removed"
+
+	// ViewerChangeAdded marks a row whose EN line was added since the start point.
+	ViewerChangeAdded = "added"
+)
+
+const (
+	diffOpEqual   = ' '
+	diffOpRemoved = '-'
+	diffOpAdded   = '+'
+)
+
+// diffLine is a line of a unified diff with the whole file as context.
+type diffLine struct {
+	op   byte
+	text string
+}
+
+// FileViewerBuildInput holds the data needed to build the file viewer page.
+type FileViewerBuildInput struct {
+	RepoName     string
+	LangCode     string
+	DashboardURL string
+	Item         dashboard.Item
+
+	// EnDiff is the diff of the EN file from the start point to HEAD with the whole file as context.
+	EnDiff string
+
+	// EnContent is the EN file at HEAD, used when EnDiff is empty because the file did not change.
+	EnContent string
+
+	LangContent string
+}
+
+// readFileViewerInput reads the EN file at the start point and at HEAD
+// and the lang file of the item from the local clone.
+func readFileViewerInput(ctx context.Context, gitRepo GitRepo, item dashboard.Item) (FileViewerBuildInput, error) {
+	startPoint := item.StartPoint().CommitID
+	if item.EnPath == "" || startPoint == "" {
+		return FileViewerBuildInput{}, errEnFileNotAvailable
+	}
+
+	enDiff, err := gitRepo.FullFileRangeDiff(ctx, startPoint, "HEAD", enPatchPaths(item)...)
+	if err != nil {
+		return FileViewerBuildInput{}, fmt.Errorf("diff %s from %s: %w", item.EnPath, startPoint, err)
+	}
+
+	var enContent string
+	if enDiff == "" {
+		enContent, err = readFileAtHead(ctx, gitRepo, currentEnPath(item))
+		if err != nil {
+			return FileViewerBuildInput{}, err
+		}
+	}
+
+	langContent, err := readFileAtHead(ctx, gitRepo, item.LangPath)
+	if err != nil {
+		return FileViewerBuildInput{}, err
+	}
+
+	return FileViewerBuildInput{
+		Item:        item,
+		EnDiff:      enDiff,
+		EnContent:   enContent,
+		LangContent: langContent,
+	}, nil
+}
+
+func currentEnPath(item dashboard.Item) string {
+	if item.MovedToEnPath != "" {
+		return item.MovedToEnPath
+	}
+
+	return item.EnPath
+}
+
+// readFileAtHead returns the content of the file at HEAD or an empty string if it does not exist.
+func readFileAtHead(ctx context.Context, gitRepo GitRepo, path string) (string, error) {
+	exists, err := gitRepo.FileExistsAt(ctx, "HEAD", path)
+	if err != nil {
+		return "", fmt.Errorf("check whether %s exists: %w", path, err)
+	}
+
+	if !exists {
+		return "", nil
+	}
+
+	content, err := gitRepo.ReadFileAt(ctx, "HEAD", path)
+	if err != nil {
+		return "", fmt.Errorf("read %s: %w", path, err)
+	}
+
+	return content, nil
+}
+
+func BuildFileViewerPageVM(input FileViewerBuildInput) FileViewerPageVM {
+	enLines := parseFullDiff(input.EnDiff)
+	if input.EnDiff == "" {
+		for _, line := range splitLines(input.EnContent) {
+			enLines = append(enLines, diffLine{op: diffOpEqual, text: line})
+		}
+	}
+
+	item := input.Item
+	startPoint := item.StartPoint()
+
+	return FileViewerPageVM{
+		RepoName:       input.RepoName,
+		LangCode:       input.LangCode,
+		LangPath:       item.LangPath,
+		EnPath:         item.EnPath,
+		CurrentEnPath:  currentEnPath(item),
+		DashboardURL:   input.DashboardURL,
+		StartPointText: buildStartPointText(startPoint.CommitID, startPoint.DateTime, item.StartPointSource),
+		Rows:           buildViewerRows(enLines, splitLines(input.LangContent)),
+	}
+}
+
+// buildStartPointText builds a text like "1a2b3c4 2025-01-02 (fork-commit)".
+func buildStartPointText(commitID string, dateTime string, source string) string {
+	text := commitID[:min(len(commitID), shortCommitIDLength)]
+	if dateTime != "" {
+		text += " " + trimDate(dateTime)
+	}
+
+	if source != "" {
+		text += " (" + source + ")"
+	}
+
+	return text
+}
+
+func splitLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+}
+
+// parseFullDiff returns the lines of a unified diff produced with the whole file
+// as context. A diff of a moved file which git does not detect as a rename has
+// a section for the removed and a section for the added path.
+func parseFullDiff(diff string) []diffLine {
+	var (
+		lines      []diffLine
+		inHunkBody bool
+	)
+
+	for _, line := range strings.Split(diff, "\n") {
+		if strings.HasPrefix(line, "diff --git ") {
+			inHunkBody = false
+
+			continue
+		}
+
+		if strings.HasPrefix(line, "@@") {
+			inHunkBody = true
+
+			continue
+		}
+
+		if !inHunkBody || line == "" {
+			continue
+		}
+
+		switch line[0] {
+		case diffOpEqual, diffOpRemoved, diffOpAdded:
+			lines = append(lines, diffLine{op: line[0], text: line[1:]})
+		}
+	}
+
+	return lines
+}
+
+// buildViewerRows lays out the EN file at the start point, the EN file at HEAD
+// and the lang file side by side. Removed EN lines are paired with the added
+// lines that replace them. The lang file is expected to be line-aligned with
+// the EN file at the start point, except for the front matter, which is aligned
+// separately because its translatable fields may differ.
+func buildViewerRows(enLines []diffLine, langLines []string) []ViewerRowVM {
+	var oldLines []string
+
+	for _, line := range enLines {
+		if line.op != diffOpAdded {
+			oldLines = append(oldLines, line.text)
+		}
+	}
+
+	aligner := langAligner{
+		langLines:            langLines,
+		oldFrontMatterLines:  frontMatterLineCount(oldLines),
+		langFrontMatterLines: frontMatterLineCount(langLines),
+	}
+
+	var oldNumber, newNumber int
+
+	for i := 0; i < len(enLines); {
+		if enLines[i].op == diffOpEqual {
+			oldNumber++
+			newNumber++
+
+			aligner.add(ViewerRowVM{
+				OldLine: ViewerLineVM{Number: oldNumber, Text: enLines[i].text},
+				NewLine: ViewerLineVM{Number: newNumber, Text: enLines[i].text},
+			})
+
+			i++
+
+			continue
+		}
+
+		var removed, added []string
+
+		for ; i < len(enLines) && enLines[i].op == diffOpRemoved; i++ {
+			removed = append(removed, enLines[i].text)
+		}
+
+		for ; i < len(enLines) && enLines[i].op == diffOpAdded; i++ {
+			added = append(added, enLines[i].text)
+		}
+
+		for j := range max(len(removed), len(added)) {
+			var row ViewerRowVM
+
+			if j < len(removed) {
+				oldNumber++
+				row.OldLine = ViewerLineVM{Number: oldNumber, Text: removed[j]}
+			}
+
+			if j < len(added) {
+				newNumber++
+				row.NewLine = ViewerLineVM{Number: newNumber, Text: added[j]}
+			}
+
+			switch {
+			case j >= len(added):
+				row.Change = ViewerChangeRemoved
+			case j >= len(removed):
+				row.Change = ViewerChangeAdded
+			default:
+				row.Change = ViewerChangeModified
+			}
+
+			aligner.add(row)
+		}
+	}
+
+	return aligner.finish()
+}
+
+// langAligner adds the aligned lang lines to the rows.
+type langAligner struct {
+	langLines            []string
+	oldFrontMatterLines  int
+	langFrontMatterLines int
+
+	rows     []ViewerRowVM
+	nextLang int
+}
+
+func (a *langAligner) add(row ViewerRowVM) {
+	if row.OldLine.Number > 0 {
+		if langIndex := a.langIndex(row.OldLine.Number - 1); langIndex >= a.nextLang && langIndex < len(a.langLines) {
+			a.addLangOnlyRows(langIndex)
+
+			row.LangLine = ViewerLineVM{Number: langIndex + 1, Text: a.langLines[langIndex]}
+			a.nextLang = langIndex + 1
+		}
+	}
+
+	a.rows = append(a.rows, row)
+}
+
+func (a *langAligner) finish() []ViewerRowVM {
+	a.addLangOnlyRows(len(a.langLines))
+
+	return a.rows
+}
+
+// addLangOnlyRows adds the lang lines that have no EN counterpart up to the given index.
+func (a *langAligner) addLangOnlyRows(end int) {
+	for ; a.nextLang < end; a.nextLang++ {
+		a.rows = append(a.rows, ViewerRowVM{
+			LangLine: ViewerLineVM{Number: a.nextLang + 1, Text: a.langLines[a.nextLang]},
+		})
+	}
+}
+
+// langIndex returns the index of the lang line aligned with the given line
+// of the EN file at the start point, or -1 if there is none.
+func (a *langAligner) langIndex(oldIndex int) int {
+	if oldIndex >= a.oldFrontMatterLines {
+		return oldIndex - a.oldFrontMatterLines + a.langFrontMatterLines
+	}
+
+	// the closing delimiters of the front matter are aligned with each other
+	if oldIndex == a.oldFrontMatterLines-1 {
+		return a.langFrontMatterLines - 1
+	}
+
+	if oldIndex < a.langFrontMatterLines-1 {
+		return oldIndex
+	}
+
+	return -1
+}
+
+// frontMatterLineCount returns the number of lines of the front matter
+// including its delimiters, or 0 if the lines do not start with a front matter.
+func frontMatterLineCount(lines []string) int {
+	if len(lines) == 0 {
+		return 0
+	}
+
+	delimiter := strings.TrimSpace(lines[0])
+	if delimiter != "---" && delimiter != "+++" {
+		return 0
+	}
+
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == delimiter {
+			return i + 1
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,194 @@
+//nolint:testpackage
+package web
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/dkarczmarski/go-kweb-lang/dashboard"
+	"github.com/dkarczmarski/go-kweb-lang/git"
+	"github.com/dkarczmarski/go-kweb-lang/gitseek"
+)
+
+func TestBuildFileViewerPageVM(t *testing.T) {
+	t.Parallel()
+
+	input := FileViewerBuildInput{
+		RepoName:     "website",
+		LangCode:     "pl",
+		DashboardURL: "/repos/website/lang/pl",
+		Item: dashboard.Item{
+			EnPath: "content/en/docs/a.md",
+			FileInfo: gitseek.FileInfo{
+				LangPath:         "content/pl/docs/a.md",
+				FileStatus:       gitseek.StatusEnFileUpdated,
+				StartPointSource: gitseek.StartPointSourceFrontMatter,
+				SyncedWithCommit: &git.CommitInfo{CommitID: "1a2b3c4d5e", DateTime: "2025-01-02T10:00:00+00:00"},
+			},
+		},
+		EnDiff: `diff --git a/content/en/docs/a.md b/content/en/docs/a.md
+index 1111111..2222222 100644
+--- a/content/en/docs/a.md
++++ b/content/en/docs/a.md
+@@ -1,7 +1,7 @@
+ ---
+ title: Pods
+ ---
+ # Pods
+-Old text.
+-Removed.
++New text.
+ End.
++Added.
+`,
+		LangContent: `---
+title: Pody
+synced_with: 1a2b3c4d5e
+---
+# Pody
+Stary tekst.
+Usunięty.
+Koniec.
+Dodatek.
+`,
+	}
+
+	got := BuildFileViewerPageVM(input)
+
+	line := func(number int, text string) ViewerLineVM {
+		return ViewerLineVM{Number: number, Text: text}
+	}
+
+	want := FileViewerPageVM{
+		RepoName:       "website",
+		LangCode:       "pl",
+		LangPath:       "content/pl/docs/a.md",
+		EnPath:         "content/en/docs/a.md",
+		CurrentEnPath:  "content/en/docs/a.md",
+		DashboardURL:   "/repos/website/lang/pl",
+		StartPointText: "1a2b3c4 2025-01-02 (front-matter)",
+		Rows: []ViewerRowVM{
+			{OldLine: line(1, "---"), NewLine: line(1, "---"), LangLine: line(1, "---")},
+			{OldLine: line(2, "title: Pods"), NewLine: line(2, "title: Pods"), LangLine: line(2, "title: Pody")},
+			{LangLine: line(3, "synced_with: 1a2b3c4d5e")},
+			{OldLine: line(3, "---"), NewLine: line(3, "---"), LangLine: line(4, "---")},
+			{OldLine: line(4, "# Pods"), NewLine: line(4, "# Pods"), LangLine: line(5, "# Pody")},
+			{
+				OldLine:  line(5, "Old text."),
+				NewLine:  line(5, "New text."),
+				LangLine: line(6, "Stary tekst."),
+				Change:   ViewerChangeModified,
+			},
+			{OldLine: line(6, "Removed."), LangLine: line(7, "Usunięty."), Change: ViewerChangeRemoved},
+			{OldLine: line(7, "End."), NewLine: line(6, "End."), LangLine: line(8, "Koniec.")},
+			{NewLine: line(7, "Added."), Change: ViewerChangeAdded},
+			{LangLine: line(9, "Dodatek.")},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected page:\n got:  %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestParseFullDiff(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name string
+		diff string
+		want []diffLine
+	}{
+		{
+			name: "one file",
+			diff: `diff --git a/content/en/a.md b/content/en/a.md
+index 1111111..2222222 100644
+--- a/content/en/a.md
++++ b/content/en/a.md
+@@ -1,2 +1,2 @@
+ Title
+-Old.
++New.
+`,
+			want: []diffLine{
+				{op: diffOpEqual, text: "Title"},
+				{op: diffOpRemoved, text: "Old."},
+				{op: diffOpAdded, text: "New."},
+			},
+		},
+		{
+			name: "moved file not detected as a rename",
+			diff: `diff --git a/content/en/a.md b/content/en/a.md
+deleted file mode 100644
+index 1111111..0000000
+--- a/content/en/a.md
++++ /dev/null
+@@ -1,2 +0,0 @@
+-Title
+-Old.
+diff --git a/content/en/b.md b/content/en/b.md
+new file mode 100644
+index 0000000..2222222
+--- /dev/null
++++ b/content/en/b.md
+@@ -0,0 +1,2 @@
++Title
++New.
+`,
+			want: []diffLine{
+				{op: diffOpRemoved, text: "Title"},
+				{op: diffOpRemoved, text: "Old."},
+				{op: diffOpAdded, text: "Title"},
+				{op: diffOpAdded, text: "New."},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := parseFullDiff(tc.diff); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("unexpected lines:\n got:  %#v\nwant: %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReadFileViewerInput(t *testing.T) {
+	t.Parallel()
+
+	item := dashboard.Item{
+		EnPath: "content/en/docs/a.md",
+		FileInfo: gitseek.FileInfo{
+			LangPath:       "content/pl/docs/a.md",
+			FileStatus:     gitseek.StatusLangFileUpToDate,
+			LangLastCommit: git.CommitInfo{CommitID: "last"},
+		},
+	}
+
+	gitRepo := fakeGitRepo{
+		"content/en/docs/a.md": "# Pods\n",
+		"content/pl/docs/a.md": "# Pody\n",
+	}
+
+	got, err := readFileViewerInput(t.Context(), gitRepo, item)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := FileViewerBuildInput{
+		Item:        item,
+		EnContent:   "# Pods\n",
+		LangContent: "# Pody\n",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected input:\n got:  %#v\nwant: %#v", got, want)
+	}
+
+	item.LangLastCommit = git.CommitInfo{}
+
+	if _, err := readFileViewerInput(t.Context(), gitRepo, item); !errors.Is(err, errEnFileNotAvailable) {
+		t.Fatalf("expected errEnFileNotAvailable without a start point, got %v", err)
+	}
+}
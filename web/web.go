@@ -5,8 +5,6 @@ import (
 	"fmt"
 	"net/http"
 	"time"
-
-	"github.com/dkarczmarski/go-kweb-lang/dashboard"
 )
 
 const (
@@ -21,11 +19,29 @@ type Server struct {
 	httpServer *http.Server
 }
 
-func NewServer(webHTTPAddr string, dashboardStore *dashboard.Store) *Server {
+type NewServerConfig struct {
+	// GitHubWebhook handles the GitHub webhook deliveries; the endpoint is not registered if nil.
+	GitHubWebhook http.Handler
+}
+
+func NewServer(webHTTPAddr string, repos []Repository, opts ...func(config *NewServerConfig)) *Server {
+	//nolint:exhaustruct
+	config := NewServerConfig{}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
 	mux := http.NewServeMux()
-	handler := NewHandler(dashboardStore)
+	handler := NewHandler(repos)
 	handler.Register(mux)
 
+	apiHandler := NewAPIHandler(repos)
+	apiHandler.Register(mux)
+
+	if config.GitHubWebhook != nil {
+		mux.Handle("POST /webhooks/github", config.GitHubWebhook)
+	}
+
 	//nolint:exhaustruct
 	httpServer := &http.Server{
 		Addr:              webHTTPAddr,